@@ -37,13 +37,51 @@ type API interface {
 	GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.TransactionBody, error)
 	GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) (*TransactionResult, error)
 
+	// SimulateTransaction executes the transaction against the latest sealed execution state without
+	// committing any of its changes, returning its events, error, resource usage and fees. Signature and
+	// sequence number checks are skipped if skipSignatureVerification is set.
+	// It is served by the REST API only, as the flow protobuf version this module depends on defines no
+	// Access API messages for it.
+	SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureVerification bool, requiredEventEncodingVersion entities.EventEncodingVersion) (*TransactionSimulationResult, error)
+
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
 
+	// The account balance and key methods below are served by the REST API only. The flow protobuf
+	// version this module depends on defines no Access API messages for them, so they have no gRPC handler.
+	GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error)
+	GetAccountBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error)
+	GetAccountBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error)
+
+	GetAccountAvailableBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error)
+	GetAccountAvailableBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error)
+	GetAccountAvailableBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error)
+
+	GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]flow.AccountPublicKey, error)
+	GetAccountKeysAtBlockHeight(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error)
+	GetAccountKeysAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) ([]flow.AccountPublicKey, error)
+
+	GetAccountKeyByIndexAtLatestBlock(ctx context.Context, address flow.Address, keyIndex uint64) (*flow.AccountPublicKey, error)
+	GetAccountKeyByIndexAtBlockHeight(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error)
+	GetAccountKeyByIndexAtBlockID(ctx context.Context, address flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error)
+
+	// GetTransactionsByAccount returns up to limit transactions the account with the given address was
+	// involved in, most recent first, starting at the given cursor (inclusive). A nil cursor starts at
+	// the most recent indexed transaction. Accounts with flow.TransactionRoleInteraction are associated
+	// with all transactions of the chunk that updated their registers.
+	// It is served by the REST API only, as the flow protobuf version this module depends on defines no
+	// Access API messages for it.
+	GetTransactionsByAccount(ctx context.Context, address flow.Address, limit uint32, cursor *flow.AccountTransactionCursor) (*AccountTransactionsPage, error)
+
 	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error)
+	// ExecuteScriptsAtBlockID executes a batch of scripts at the given block. Results are returned in
+	// the order of the scripts, and failures of individual scripts are returned in their result.
+	// It is served by the REST API only, as the flow protobuf version this module depends on defines no
+	// Access API messages for it.
+	ExecuteScriptsAtBlockID(ctx context.Context, blockID flow.Identifier, scripts []Script) ([]ScriptResult, error)
 
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
@@ -265,3 +303,55 @@ type NodeVersionInfo struct {
 	SporkRootBlockHeight uint64
 	NodeRootBlockHeight  uint64
 }
+
+// AccountTransactionsPage is a page of the transaction history of an account.
+type AccountTransactionsPage struct {
+	Transactions []flow.AccountTransaction
+	// NextCursor is the position of the first transaction of the next page, or nil if this is the last page.
+	NextCursor *flow.AccountTransactionCursor
+}
+
+// TransactionSimulationResult is the result of a transaction executed without committing any of its changes.
+type TransactionSimulationResult struct {
+	// BlockID is the ID of the sealed block whose execution state the transaction was executed against.
+	BlockID flow.Identifier
+	// BlockHeight is the height of the sealed block whose execution state the transaction was executed against.
+	BlockHeight uint64
+	// Events are the events emitted by the transaction. If the transaction failed, this only contains
+	// the fee deduction events.
+	Events []flow.Event
+	// ErrorMessage is the error returned by the transaction, or empty if the transaction succeeded.
+	ErrorMessage string
+	// ComputationUsed is the computation used by the transaction.
+	ComputationUsed uint64
+	// MemoryEstimate is the estimated memory used by the transaction.
+	MemoryEstimate uint64
+	// StorageDelta is the change of the storage used by each account whose storage was updated by
+	// the transaction, in bytes.
+	StorageDelta map[flow.Address]int64
+	// Fees are the fees deducted for the transaction.
+	Fees TransactionFees
+}
+
+// TransactionFees is the breakdown of the fees deducted for a transaction. All values are UFix64 encoded.
+type TransactionFees struct {
+	Amount          uint64
+	InclusionEffort uint64
+	ExecutionEffort uint64
+}
+
+// Script is a Cadence script with its arguments.
+type Script struct {
+	Source    []byte
+	Arguments [][]byte
+}
+
+// ScriptResult is the result of a script executed as part of a batch.
+type ScriptResult struct {
+	// Value is the JSON-CDC encoded result of the script, or nil if the script failed.
+	Value []byte
+	// ComputationUsed is the computation used by the script, if reported by the executor.
+	ComputationUsed uint64
+	// Error is the status error returned while executing the script, or nil if the script succeeded.
+	Error error
+}
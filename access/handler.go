@@ -593,7 +593,7 @@ func (h *Handler) ExecuteScriptAtLatestBlock(
 	script := req.GetScript()
 	arguments := req.GetArguments()
 
-	value, err := h.api.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+	value, err := h.api.ExecuteScriptAtLatestBlock(scriptContext(ctx), script, arguments)
 	if err != nil {
 		return nil, err
 	}
@@ -618,7 +618,7 @@ func (h *Handler) ExecuteScriptAtBlockHeight(
 	arguments := req.GetArguments()
 	blockHeight := req.GetBlockHeight()
 
-	value, err := h.api.ExecuteScriptAtBlockHeight(ctx, blockHeight, script, arguments)
+	value, err := h.api.ExecuteScriptAtBlockHeight(scriptContext(ctx), blockHeight, script, arguments)
 	if err != nil {
 		return nil, err
 	}
@@ -642,7 +642,7 @@ func (h *Handler) ExecuteScriptAtBlockID(
 	arguments := req.GetArguments()
 	blockID := convert.MessageToIdentifier(req.GetBlockId())
 
-	value, err := h.api.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+	value, err := h.api.ExecuteScriptAtBlockID(scriptContext(ctx), blockID, script, arguments)
 	if err != nil {
 		return nil, err
 	}
@@ -830,8 +830,12 @@ func (h *Handler) GetExecutionResultByID(ctx context.Context, req *access.GetExe
 // The handler manages the subscription to block updates and sends the subscribed block information
 // to the client via the provided stream.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if invalid startBlockID provided or unknown block status provided.
+// - codes.InvalidArgument - if invalid resume token provided, if invalid startBlockID provided or unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block to message or could not send response.
 func (h *Handler) SubscribeBlocksFromStartBlockID(request *access.SubscribeBlocksFromStartBlockIDRequest, stream access.AccessAPI_SubscribeBlocksFromStartBlockIDServer) error {
@@ -847,7 +851,17 @@ func (h *Handler) SubscribeBlocksFromStartBlockID(request *access.SubscribeBlock
 		return err
 	}
 
-	sub := h.api.SubscribeBlocksFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeBlocksFromStartHeight(stream.Context(), resumeToken.Height, blockStatus)
+	} else {
+		sub = h.api.SubscribeBlocksFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	}
 	return subscription.HandleSubscription(sub, h.handleBlocksResponse(stream.Send, request.GetFullBlockResponse(), blockStatus))
 }
 
@@ -856,8 +870,12 @@ func (h *Handler) SubscribeBlocksFromStartBlockID(request *access.SubscribeBlock
 // The handler manages the subscription to block updates and sends the subscribed block information
 // to the client via the provided stream.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if unknown block status provided.
+// - codes.InvalidArgument - if invalid resume token provided, if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block to message or could not send response.
 func (h *Handler) SubscribeBlocksFromStartHeight(request *access.SubscribeBlocksFromStartHeightRequest, stream access.AccessAPI_SubscribeBlocksFromStartHeightServer) error {
@@ -874,7 +892,17 @@ func (h *Handler) SubscribeBlocksFromStartHeight(request *access.SubscribeBlocks
 		return err
 	}
 
-	sub := h.api.SubscribeBlocksFromStartHeight(stream.Context(), request.GetStartBlockHeight(), blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	sub := h.api.SubscribeBlocksFromStartHeight(stream.Context(), startHeight, blockStatus)
 	return subscription.HandleSubscription(sub, h.handleBlocksResponse(stream.Send, request.GetFullBlockResponse(), blockStatus))
 }
 
@@ -883,6 +911,9 @@ func (h *Handler) SubscribeBlocksFromStartHeight(request *access.SubscribeBlocks
 // The handler manages the subscription to block updates and sends the subscribed block information
 // to the client via the provided stream.
 //
+// Each response carries the resume token of the position after it, which is read using
+// subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
 // - codes.InvalidArgument - if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
@@ -927,9 +958,12 @@ func (h *Handler) handleBlocksResponse(send sendSubscribeBlocksResponseFunc, ful
 			return rpc.ConvertError(err, "could not convert block to message", codes.Internal)
 		}
 
-		err = send(&access.SubscribeBlocksResponse{
+		msg := &access.SubscribeBlocksResponse{
 			Block: msgBlockResponse.Block,
-		})
+		}
+		subscription.SetMessageResumeToken(msg, subscription.NewResumeToken(block.Header.Height+1, 0).Encode())
+
+		err = send(msg)
 		if err != nil {
 			return rpc.ConvertError(err, "could not send response", codes.Internal)
 		}
@@ -943,8 +977,12 @@ func (h *Handler) handleBlocksResponse(send sendSubscribeBlocksResponseFunc, ful
 // The handler manages the subscription to block updates and sends the subscribed block header information
 // to the client via the provided stream.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if invalid startBlockID provided or unknown block status provided.
+// - codes.InvalidArgument - if invalid resume token provided, if invalid startBlockID provided or unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block header to message or could not send response.
 func (h *Handler) SubscribeBlockHeadersFromStartBlockID(request *access.SubscribeBlockHeadersFromStartBlockIDRequest, stream access.AccessAPI_SubscribeBlockHeadersFromStartBlockIDServer) error {
@@ -960,7 +998,17 @@ func (h *Handler) SubscribeBlockHeadersFromStartBlockID(request *access.Subscrib
 		return err
 	}
 
-	sub := h.api.SubscribeBlockHeadersFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeBlockHeadersFromStartHeight(stream.Context(), resumeToken.Height, blockStatus)
+	} else {
+		sub = h.api.SubscribeBlockHeadersFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	}
 	return subscription.HandleSubscription(sub, h.handleBlockHeadersResponse(stream.Send))
 }
 
@@ -969,8 +1017,12 @@ func (h *Handler) SubscribeBlockHeadersFromStartBlockID(request *access.Subscrib
 // The handler manages the subscription to block updates and sends the subscribed block header information
 // to the client via the provided stream.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if unknown block status provided.
+// - codes.InvalidArgument - if invalid resume token provided, if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block header to message or could not send response.
 func (h *Handler) SubscribeBlockHeadersFromStartHeight(request *access.SubscribeBlockHeadersFromStartHeightRequest, stream access.AccessAPI_SubscribeBlockHeadersFromStartHeightServer) error {
@@ -987,7 +1039,17 @@ func (h *Handler) SubscribeBlockHeadersFromStartHeight(request *access.Subscribe
 		return err
 	}
 
-	sub := h.api.SubscribeBlockHeadersFromStartHeight(stream.Context(), request.GetStartBlockHeight(), blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	sub := h.api.SubscribeBlockHeadersFromStartHeight(stream.Context(), startHeight, blockStatus)
 	return subscription.HandleSubscription(sub, h.handleBlockHeadersResponse(stream.Send))
 }
 
@@ -996,6 +1058,9 @@ func (h *Handler) SubscribeBlockHeadersFromStartHeight(request *access.Subscribe
 // The handler manages the subscription to block updates and sends the subscribed block header information
 // to the client via the provided stream.
 //
+// Each response carries the resume token of the position after it, which is read using
+// subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
 // - codes.InvalidArgument - if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
@@ -1043,9 +1108,12 @@ func (h *Handler) handleBlockHeadersResponse(send sendSubscribeBlockHeadersRespo
 			return rpc.ConvertError(err, "could not convert block header to message", codes.Internal)
 		}
 
-		err = send(&access.SubscribeBlockHeadersResponse{
+		msg := &access.SubscribeBlockHeadersResponse{
 			Header: msgHeader,
-		})
+		}
+		subscription.SetMessageResumeToken(msg, subscription.NewResumeToken(header.Height+1, 0).Encode())
+
+		err = send(msg)
 		if err != nil {
 			return rpc.ConvertError(err, "could not send response", codes.Internal)
 		}
@@ -1057,8 +1125,12 @@ func (h *Handler) handleBlockHeadersResponse(send sendSubscribeBlockHeadersRespo
 // SubscribeBlockDigestsFromStartBlockID streams finalized or sealed lightweight block starting at the requested block id.
 // It takes a SubscribeBlockDigestsFromStartBlockIDRequest and an AccessAPI_SubscribeBlockDigestsFromStartBlockIDServer stream as input.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if invalid startBlockID provided or unknown block status provided,
+// - codes.InvalidArgument - if invalid resume token provided, if invalid startBlockID provided or unknown block status provided,
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block to message or could not send response.
 func (h *Handler) SubscribeBlockDigestsFromStartBlockID(request *access.SubscribeBlockDigestsFromStartBlockIDRequest, stream access.AccessAPI_SubscribeBlockDigestsFromStartBlockIDServer) error {
@@ -1074,7 +1146,17 @@ func (h *Handler) SubscribeBlockDigestsFromStartBlockID(request *access.Subscrib
 		return err
 	}
 
-	sub := h.api.SubscribeBlockDigestsFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeBlockDigestsFromStartHeight(stream.Context(), resumeToken.Height, blockStatus)
+	} else {
+		sub = h.api.SubscribeBlockDigestsFromStartBlockID(stream.Context(), startBlockID, blockStatus)
+	}
 	return subscription.HandleSubscription(sub, h.handleBlockDigestsResponse(stream.Send))
 }
 
@@ -1083,8 +1165,12 @@ func (h *Handler) SubscribeBlockDigestsFromStartBlockID(request *access.Subscrib
 // The handler manages the subscription to block updates and sends the subscribed block information
 // to the client via the provided stream.
 //
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the
+// subscription resumes from the token instead of the requested start block. Each response carries the
+// token of the position after it, which is read using subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument - if unknown block status provided.
+// - codes.InvalidArgument - if invalid resume token provided, if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal - if stream encountered an error, if stream got unexpected response or could not convert block to message or could not send response.
 func (h *Handler) SubscribeBlockDigestsFromStartHeight(request *access.SubscribeBlockDigestsFromStartHeightRequest, stream access.AccessAPI_SubscribeBlockDigestsFromStartHeightServer) error {
@@ -1101,7 +1187,17 @@ func (h *Handler) SubscribeBlockDigestsFromStartHeight(request *access.Subscribe
 		return err
 	}
 
-	sub := h.api.SubscribeBlockDigestsFromStartHeight(stream.Context(), request.GetStartBlockHeight(), blockStatus)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	sub := h.api.SubscribeBlockDigestsFromStartHeight(stream.Context(), startHeight, blockStatus)
 	return subscription.HandleSubscription(sub, h.handleBlockDigestsResponse(stream.Send))
 }
 
@@ -1110,6 +1206,9 @@ func (h *Handler) SubscribeBlockDigestsFromStartHeight(request *access.Subscribe
 // The handler manages the subscription to block updates and sends the subscribed block header information
 // to the client via the provided stream.
 //
+// Each response carries the resume token of the position after it, which is read using
+// subscription.MessageResumeToken.
+//
 // Expected errors during normal operation:
 // - codes.InvalidArgument - if unknown block status provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
@@ -1147,11 +1246,14 @@ func (h *Handler) SubscribeBlockDigestsFromLatest(request *access.SubscribeBlock
 //   - codes.Internal: if the stream cannot send a response.
 func (h *Handler) handleBlockDigestsResponse(send sendSubscribeBlockDigestsResponseFunc) func(*flow.BlockDigest) error {
 	return func(blockDigest *flow.BlockDigest) error {
-		err := send(&access.SubscribeBlockDigestsResponse{
+		msg := &access.SubscribeBlockDigestsResponse{
 			BlockId:        convert.IdentifierToMessage(blockDigest.ID()),
 			BlockHeight:    blockDigest.Height,
 			BlockTimestamp: timestamppb.New(blockDigest.Timestamp),
-		})
+		}
+		subscription.SetMessageResumeToken(msg, subscription.NewResumeToken(blockDigest.Height+1, 0).Encode())
+
+		err := send(msg)
 		if err != nil {
 			return rpc.ConvertError(err, "could not send response", codes.Internal)
 		}
@@ -1189,6 +1291,10 @@ func (h *Handler) getSubscriptionDataFromStartBlockID(msgBlockId []byte, msgBloc
 // SendAndSubscribeTransactionStatuses streams transaction statuses starting from the reference block saved in the
 // transaction itself until the block containing the transaction becomes sealed or expired. When the transaction
 // status becomes TransactionStatusSealed or TransactionStatusExpired, the subscription will automatically shut down.
+//
+// Each response carries the resume token of the position after it, which is read using subscription.MessageResumeToken.
+// If a resume token is provided in the subscription.ResumeTokenMetadataKey metadata of the request, the transaction
+// is not sent again, and statuses delivered before the token was issued are skipped.
 func (h *Handler) SendAndSubscribeTransactionStatuses(
 	request *access.SendAndSubscribeTransactionStatusesRequest,
 	stream access.AccessAPI_SendAndSubscribeTransactionStatusesServer,
@@ -1207,22 +1313,37 @@ func (h *Handler) SendAndSubscribeTransactionStatuses(
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = h.api.SendTransaction(ctx, &tx)
+	resumeToken, err := subscription.ResumeTokenFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
+	// a resumed subscription was created by a request which already sent the transaction
+	if resumeToken == nil {
+		err = h.api.SendTransaction(ctx, &tx)
+		if err != nil {
+			return err
+		}
+	}
+
 	sub := h.api.SubscribeTransactionStatuses(ctx, &tx, request.GetEventEncodingVersion())
 
 	messageIndex := counters.NewMonotonousCounter(0)
 	return subscription.HandleSubscription(sub, func(txResults []*TransactionResult) error {
 		for i := range txResults {
+			if resumeToken.TransactionStatusDelivered(txResults[i].Status) {
+				continue
+			}
+
 			value := messageIndex.Increment()
 
-			err = stream.Send(&access.SendAndSubscribeTransactionStatusesResponse{
+			msg := &access.SendAndSubscribeTransactionStatusesResponse{
 				TransactionResults: TransactionResultToMessage(txResults[i]),
 				MessageIndex:       value,
-			})
+			}
+			subscription.SetMessageResumeToken(msg, subscription.NewTransactionStatusResumeToken(txResults[i].Status).Encode())
+
+			err = stream.Send(msg)
 			if err != nil {
 				return rpc.ConvertError(err, "could not send response", codes.Internal)
 			}
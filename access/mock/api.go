@@ -111,6 +111,36 @@ func (_m *API) ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, ar
 	return r0, r1
 }
 
+// ExecuteScriptsAtBlockID provides a mock function with given fields: ctx, blockID, scripts
+func (_m *API) ExecuteScriptsAtBlockID(ctx context.Context, blockID flow.Identifier, scripts []access.Script) ([]access.ScriptResult, error) {
+	ret := _m.Called(ctx, blockID, scripts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptsAtBlockID")
+	}
+
+	var r0 []access.ScriptResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []access.Script) ([]access.ScriptResult, error)); ok {
+		return rf(ctx, blockID, scripts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []access.Script) []access.ScriptResult); ok {
+		r0 = rf(ctx, blockID, scripts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]access.ScriptResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, []access.Script) error); ok {
+		r1 = rf(ctx, blockID, scripts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccount provides a mock function with given fields: ctx, address
 func (_m *API) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
 	ret := _m.Called(ctx, address)
@@ -201,6 +231,354 @@ func (_m *API) GetAccountAtLatestBlock(ctx context.Context, address flow.Address
 	return r0, r1
 }
 
+// GetAccountAvailableBalanceAtBlockHeight provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountAvailableBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	ret := _m.Called(ctx, address, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAvailableBalanceAtBlockHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (uint64, error)); ok {
+		return rf(ctx, address, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) uint64); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountAvailableBalanceAtBlockID provides a mock function with given fields: ctx, address, blockID
+func (_m *API) GetAccountAvailableBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAvailableBalanceAtBlockID")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) (uint64, error)); ok {
+		return rf(ctx, address, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) uint64); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountAvailableBalanceAtLatestBlock provides a mock function with given fields: ctx, address
+func (_m *API) GetAccountAvailableBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAvailableBalanceAtLatestBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) (uint64, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) uint64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountBalanceAtBlockHeight provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	ret := _m.Called(ctx, address, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalanceAtBlockHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (uint64, error)); ok {
+		return rf(ctx, address, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) uint64); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountBalanceAtBlockID provides a mock function with given fields: ctx, address, blockID
+func (_m *API) GetAccountBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalanceAtBlockID")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) (uint64, error)); ok {
+		return rf(ctx, address, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) uint64); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountBalanceAtLatestBlock provides a mock function with given fields: ctx, address
+func (_m *API) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalanceAtLatestBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) (uint64, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) uint64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyByIndexAtBlockHeight provides a mock function with given fields: ctx, address, keyIndex, height
+func (_m *API) GetAccountKeyByIndexAtBlockHeight(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeyByIndexAtBlockHeight")
+	}
+
+	var r0 *flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64) (*flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, keyIndex, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, keyIndex, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyByIndexAtBlockID provides a mock function with given fields: ctx, address, keyIndex, blockID
+func (_m *API) GetAccountKeyByIndexAtBlockID(ctx context.Context, address flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeyByIndexAtBlockID")
+	}
+
+	var r0 *flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, flow.Identifier) (*flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, keyIndex, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, flow.Identifier) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, keyIndex, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyByIndexAtLatestBlock provides a mock function with given fields: ctx, address, keyIndex
+func (_m *API) GetAccountKeyByIndexAtLatestBlock(ctx context.Context, address flow.Address, keyIndex uint64) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeyByIndexAtLatestBlock")
+	}
+
+	var r0 *flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (*flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, keyIndex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, keyIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeysAtBlockHeight provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountKeysAtBlockHeight(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeysAtBlockHeight")
+	}
+
+	var r0 []flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) ([]flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeysAtBlockID provides a mock function with given fields: ctx, address, blockID
+func (_m *API) GetAccountKeysAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) ([]flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeysAtBlockID")
+	}
+
+	var r0 []flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) ([]flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeysAtLatestBlock provides a mock function with given fields: ctx, address
+func (_m *API) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKeysAtLatestBlock")
+	}
+
+	var r0 []flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) ([]flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.BlockStatus, error) {
 	ret := _m.Called(ctx, height)
@@ -921,6 +1299,36 @@ func (_m *API) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.
 	return r0, r1
 }
 
+// GetTransactionsByAccount provides a mock function with given fields: ctx, address, limit, cursor
+func (_m *API) GetTransactionsByAccount(ctx context.Context, address flow.Address, limit uint32, cursor *flow.AccountTransactionCursor) (*access.AccountTransactionsPage, error) {
+	ret := _m.Called(ctx, address, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByAccount")
+	}
+
+	var r0 *access.AccountTransactionsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint32, *flow.AccountTransactionCursor) (*access.AccountTransactionsPage, error)); ok {
+		return rf(ctx, address, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint32, *flow.AccountTransactionCursor) *access.AccountTransactionsPage); ok {
+		r0 = rf(ctx, address, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.AccountTransactionsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint32, *flow.AccountTransactionCursor) error); ok {
+		r1 = rf(ctx, address, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionsByBlockID provides a mock function with given fields: ctx, blockID
 func (_m *API) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, blockID)
@@ -987,6 +1395,36 @@ func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) er
 	return r0
 }
 
+// SimulateTransaction provides a mock function with given fields: ctx, tx, skipSignatureVerification, requiredEventEncodingVersion
+func (_m *API) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureVerification bool, requiredEventEncodingVersion entities.EventEncodingVersion) (*access.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, skipSignatureVerification, requiredEventEncodingVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransaction")
+	}
+
+	var r0 *access.TransactionSimulationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, bool, entities.EventEncodingVersion) (*access.TransactionSimulationResult, error)); ok {
+		return rf(ctx, tx, skipSignatureVerification, requiredEventEncodingVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, bool, entities.EventEncodingVersion) *access.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, skipSignatureVerification, requiredEventEncodingVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.TransactionSimulationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, bool, entities.EventEncodingVersion) error); ok {
+		r1 = rf(ctx, tx, skipSignatureVerification, requiredEventEncodingVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeBlockDigestsFromLatest provides a mock function with given fields: ctx, blockStatus
 func (_m *API) SubscribeBlockDigestsFromLatest(ctx context.Context, blockStatus flow.BlockStatus) subscription.Subscription {
 	ret := _m.Called(ctx, blockStatus)
@@ -0,0 +1,44 @@
+package access
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ScriptCacheControlHeader is the gRPC metadata key clients use to control caching of script results.
+// Setting it to "no-cache" executes the script even if a cached result is available.
+const ScriptCacheControlHeader = "cache-control"
+
+// scriptCacheBypassKey is the context key marking script executions which must not be served from
+// the script result cache.
+type scriptCacheBypassKey struct{}
+
+// WithScriptCacheBypass returns a copy of the context that instructs the API to execute scripts
+// instead of returning previously cached results.
+func WithScriptCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scriptCacheBypassKey{}, true)
+}
+
+// IsScriptCacheBypassed returns true if the script result cache must not be used for script
+// executions using the given context.
+func IsScriptCacheBypassed(ctx context.Context) bool {
+	bypass, ok := ctx.Value(scriptCacheBypassKey{}).(bool)
+	return ok && bypass
+}
+
+// scriptContext returns the context used to execute scripts for a gRPC request, bypassing the
+// script result cache if requested by the client.
+func scriptContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	for _, value := range md.Get(ScriptCacheControlHeader) {
+		if strings.EqualFold(strings.TrimSpace(value), "no-cache") {
+			return WithScriptCacheBypass(ctx)
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,112 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/quota"
+)
+
+var _ commands.AdminCommand = (*ClientQuotasCommand)(nil)
+
+// ClientQuotasCommand is an admin command which shows and updates the per-client quotas of the access API.
+//
+// Supported requests:
+//   - {"command": "get"} returns the quota tiers and clients
+//   - {"command": "set-tier", "name": "...", "tier": {...}} creates or updates a tier
+//   - {"command": "set-client", "client": {...}} creates or replaces a client
+//   - {"command": "remove-client", "name": "..."} removes a client
+//
+// Tiers and clients use the format of the quota config file.
+type ClientQuotasCommand struct {
+	quotas *quota.Manager
+}
+
+type clientQuotasRequest struct {
+	Command string        `json:"command"`
+	Name    string        `json:"name"`
+	Tier    *quota.Tier   `json:"tier"`
+	Client  *quota.Client `json:"client"`
+}
+
+func NewClientQuotasCommand(quotas *quota.Manager) *ClientQuotasCommand {
+	return &ClientQuotasCommand{
+		quotas: quotas,
+	}
+}
+
+func (c *ClientQuotasCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(clientQuotasRequest)
+
+	var err error
+	switch data.Command {
+	case "get":
+		// the response must consist of basic types, so the config is converted using its json encoding
+		encoded, err := json.Marshal(c.quotas.Config())
+		if err != nil {
+			return nil, fmt.Errorf("could not encode quota config: %w", err)
+		}
+		var res map[string]interface{}
+		if err := json.Unmarshal(encoded, &res); err != nil {
+			return nil, fmt.Errorf("could not decode quota config: %w", err)
+		}
+		return res, nil
+	case "set-tier":
+		err = c.quotas.SetTier(data.Name, *data.Tier)
+	case "set-client":
+		err = c.quotas.SetClient(*data.Client)
+	case "remove-client":
+		err = c.quotas.RemoveClient(data.Name)
+	}
+	if err != nil {
+		return nil, admin.NewInvalidAdminReqErrorf("%s failed: %v", data.Command, err)
+	}
+
+	return "ok", nil
+}
+
+// Validator checks the inputs of the command. It expects a "command" field with one of get, set-tier,
+// set-client or remove-client, and the fields required by the command.
+// The following sentinel errors are expected during normal operations:
+// * `admin.InvalidAdminReqError` if any required field is missing or in a wrong format
+func (c *ClientQuotasCommand) Validator(req *admin.CommandRequest) error {
+	if req.Data == nil {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	encoded, err := json.Marshal(req.Data)
+	if err != nil {
+		return admin.NewInvalidAdminReqFormatError("could not encode request: %v", err)
+	}
+	var data clientQuotasRequest
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return admin.NewInvalidAdminReqFormatError("could not decode request: %v", err)
+	}
+
+	switch data.Command {
+	case "get":
+	case "set-tier":
+		if data.Name == "" {
+			return admin.NewInvalidAdminReqErrorf("missing required field: 'name'")
+		}
+		if data.Tier == nil {
+			return admin.NewInvalidAdminReqErrorf("missing required field: 'tier'")
+		}
+	case "set-client":
+		if data.Client == nil {
+			return admin.NewInvalidAdminReqErrorf("missing required field: 'client'")
+		}
+	case "remove-client":
+		if data.Name == "" {
+			return admin.NewInvalidAdminReqErrorf("missing required field: 'name'")
+		}
+	default:
+		return admin.NewInvalidAdminReqParameterError("command", fmt.Sprintf("must be one of %q", []string{"get", "set-tier", "set-client", "remove-client"}), data.Command)
+	}
+
+	req.ValidatorData = data
+	return nil
+}
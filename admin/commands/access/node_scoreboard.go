@@ -0,0 +1,50 @@
+package access
+
+import (
+	"context"
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+)
+
+var _ commands.AdminCommand = (*GetNodeScoreboardCommand)(nil)
+
+// GetNodeScoreboardCommand is an admin command which lists the health of the upstream nodes tracked
+// by the latency-aware node selection, best first.
+type GetNodeScoreboardCommand struct {
+	scoreboard *backend.NodeScoreboard
+}
+
+func NewGetNodeScoreboardCommand(scoreboard *backend.NodeScoreboard) *GetNodeScoreboardCommand {
+	return &GetNodeScoreboardCommand{
+		scoreboard: scoreboard,
+	}
+}
+
+func (c *GetNodeScoreboardCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	scores := c.scoreboard.Scores()
+
+	res := make([]interface{}, len(scores))
+	for i, score := range scores {
+		entry := map[string]interface{}{
+			"node_id":              score.NodeID.String(),
+			"latency":              score.Latency.String(),
+			"error_rate":           score.ErrorRate,
+			"circuit_breaker_open": score.CircuitBreakerOpen,
+			"requests":             score.Requests,
+			"failures":             score.Failures,
+		}
+		// nodes with an open circuit breaker have an infinite score, which cannot be encoded
+		if !math.IsInf(score.Score, 1) {
+			entry["score"] = score.Score
+		}
+		res[i] = entry
+	}
+	return res, nil
+}
+
+func (c *GetNodeScoreboardCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/onflow/flow-go/admin/commands"
+	accessCommands "github.com/onflow/flow-go/admin/commands/access"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
@@ -40,8 +41,11 @@ import (
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rest"
+	"github.com/onflow/flow-go/engine/access/rest/graphql"
 	"github.com/onflow/flow-go/engine/access/rest/routes"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	rpcConnection "github.com/onflow/flow-go/engine/access/rpc/connection"
@@ -72,6 +76,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/metrics/unstaked"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	edrequester "github.com/onflow/flow-go/module/state_synchronization/requester"
@@ -131,6 +136,8 @@ type AccessNodeConfig struct {
 	nodeInfoFile                      string
 	apiRatelimits                     map[string]int
 	apiBurstlimits                    map[string]int
+	quotaConfigFile                   string
+	quotaClientCAFile                 string
 	rpcConf                           rpc.Config
 	stateStreamConf                   statestreambackend.Config
 	stateStreamFilterConf             map[string]int
@@ -150,14 +157,21 @@ type AccessNodeConfig struct {
 	TxResultCacheSize                 uint
 	TxErrorMessagesCacheSize          uint
 	executionDataIndexingEnabled      bool
+	eventTypeIndexEnabled             bool
+	accountTransactionIndexEnabled    bool
 	registersDBPath                   string
 	checkpointFile                    string
+	registerSnapshotDir               string
 	scriptExecutorConfig              query.QueryConfig
 	scriptExecMinBlock                uint64
 	scriptExecMaxBlock                uint64
 	registerCacheType                 string
 	registerCacheSize                 uint
 	programCacheSize                  uint
+	registersPruningHeightRangeTarget uint64
+	registersPruningThreshold         uint64
+	registersPruningInterval          time.Duration
+	blockDataPrunerConfig             pruner.Config
 }
 
 type PublicNetworkConfig struct {
@@ -196,12 +210,32 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 				ScriptExecutionMode: backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
 				EventQueryMode:      backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
 				TxResultQueryMode:   backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
+
+				EventTypeIndexMaxHeightRange:   backend.DefaultEventTypeIndexMaxHeightRange,
+				EventTypeIndexMaxEvents:        backend.DefaultEventTypeIndexMaxEvents,
+				AccountTransactionsMaxPageSize: backend.DefaultAccountTransactionsMaxPageSize,
+				ScriptResultCacheSize:          0,
+				ScriptResultCacheTTL:           backend.DefaultScriptResultCacheTTL,
+				ScriptBatchMaxSize:             backend.DefaultScriptBatchMaxSize,
+				ScriptBatchComputationLimit:    backend.DefaultScriptBatchComputationLimit,
+				NodeSelectionConfig: backend.NodeScoreboardConfig{
+					Enabled:             false,
+					LatencyDecay:        backend.DefaultNodeLatencyDecay,
+					ErrorRecoveryPeriod: backend.DefaultNodeErrorRecoveryPeriod,
+				},
+				RequestHedgingConfig: backend.RequestHedgingConfig{
+					Enabled:  false,
+					Delay:    backend.DefaultRequestHedgingDelay,
+					MaxRatio: backend.DefaultRequestHedgingMaxRatio,
+				},
 			},
 			RestConfig: rest.Config{
-				ListenAddress: "",
-				WriteTimeout:  rest.DefaultWriteTimeout,
-				ReadTimeout:   rest.DefaultReadTimeout,
-				IdleTimeout:   rest.DefaultIdleTimeout,
+				ListenAddress:   "",
+				WriteTimeout:    rest.DefaultWriteTimeout,
+				ReadTimeout:     rest.DefaultReadTimeout,
+				IdleTimeout:     rest.DefaultIdleTimeout,
+				WebSocketConfig: websockets.NewDefaultWebsocketConfig(),
+				GraphQLConfig:   graphql.NewDefaultConfig(),
 			},
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
@@ -216,6 +250,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			RegisterIDsRequestLimit: state_stream.DefaultRegisterIDsRequestLimit,
 			ResponseLimit:           subscription.DefaultResponseLimit,
 			HeartbeatInterval:       subscription.DefaultHeartbeatInterval,
+			MaxEventsPerResponse:    subscription.DefaultMaxEventsPerResponse,
 		},
 		stateStreamFilterConf:        nil,
 		ExecutionNodeAddress:         "localhost:9000",
@@ -228,6 +263,8 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		nodeInfoFile:                 "",
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
+		quotaConfigFile:              "",
+		quotaClientCAFile:            "",
 		TxResultCacheSize:            0,
 		TxErrorMessagesCacheSize:     1000,
 		PublicNetworkConfig: PublicNetworkConfig{
@@ -246,15 +283,22 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			RetryDelay:         edrequester.DefaultRetryDelay,
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
-		executionDataIndexingEnabled: false,
-		registersDBPath:              filepath.Join(homedir, ".flow", "execution_state"),
-		checkpointFile:               cmd.NotSet,
-		scriptExecutorConfig:         query.NewDefaultConfig(),
-		scriptExecMinBlock:           0,
-		scriptExecMaxBlock:           math.MaxUint64,
-		registerCacheType:            pStorage.CacheTypeTwoQueue.String(),
-		registerCacheSize:            0,
-		programCacheSize:             0,
+		executionDataIndexingEnabled:      false,
+		eventTypeIndexEnabled:             false,
+		accountTransactionIndexEnabled:    false,
+		registersDBPath:                   filepath.Join(homedir, ".flow", "execution_state"),
+		checkpointFile:                    cmd.NotSet,
+		registerSnapshotDir:               "",
+		scriptExecutorConfig:              query.NewDefaultConfig(),
+		scriptExecMinBlock:                0,
+		scriptExecMaxBlock:                math.MaxUint64,
+		registerCacheType:                 pStorage.CacheTypeTwoQueue.String(),
+		registerCacheSize:                 0,
+		programCacheSize:                  0,
+		registersPruningHeightRangeTarget: pStorage.DefaultRegistersPruningHeightRangeTarget,
+		registersPruningThreshold:         pStorage.DefaultRegistersPruningThreshold,
+		registersPruningInterval:          pStorage.DefaultRegistersPruningInterval,
+		blockDataPrunerConfig:             pruner.DefaultConfig(),
 	}
 }
 
@@ -292,10 +336,14 @@ type FlowAccessNodeBuilder struct {
 	ExecutionIndexer           *indexer.Indexer
 	ExecutionIndexerCore       *indexer.IndexerCore
 	ScriptExecutor             *backend.ScriptExecutor
+	NodeScoreboard             *backend.NodeScoreboard
+	ClientQuotas               *quota.Manager
 	RegistersAsyncStore        *execution.RegistersAsyncStore
 	Reporter                   *index.Reporter
 	EventsIndex                *index.EventsIndex
 	TxResultsIndex             *index.TransactionResultsIndex
+	AccountTransactionsIndex   *index.AccountTransactionsIndex
+	BlockDataPruner            *pruner.BlockDataPruner
 	IndexerDependencies        *cmd.DependencyList
 	collectionExecutedMetric   module.CollectionExecutedMetric
 
@@ -721,6 +769,10 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 
 	if builder.executionDataIndexingEnabled {
 		var indexedBlockHeight storage.ConsumerProgress
+		var registers *pStorage.Registers
+
+		// setup dependency chain to ensure the registers pruner starts after the indexer
+		indexerDependable := module.NewProxiedReadyDoneAware()
 
 		builder.
 			AdminCommand("execute-script", func(config *cmd.NodeConfig) commands.AdminCommand {
@@ -754,24 +806,6 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 				}
 
 				if !bootstrapped {
-					checkpointFile := builder.checkpointFile
-					if checkpointFile == cmd.NotSet {
-						checkpointFile = path.Join(builder.BootstrapDir, bootstrap.PathRootCheckpoint)
-					}
-
-					// currently, the checkpoint must be from the root block.
-					// read the root hash from the provided checkpoint and verify it matches the
-					// state commitment from the root snapshot.
-					err := wal.CheckpointHasRootHash(
-						node.Logger,
-						"", // checkpoint file already full path
-						checkpointFile,
-						ledger.RootHash(node.RootSeal.FinalState),
-					)
-					if err != nil {
-						return nil, fmt.Errorf("could not verify checkpoint file: %w", err)
-					}
-
 					checkpointHeight := builder.SealedRootBlock.Header.Height
 
 					if builder.SealedRootBlock.ID() != builder.RootSeal.BlockID {
@@ -780,20 +814,51 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					}
 
 					rootHash := ledger.RootHash(builder.RootSeal.FinalState)
-					bootstrap, err := pStorage.NewRegisterBootstrap(pdb, checkpointFile, checkpointHeight, rootHash, builder.Logger)
-					if err != nil {
-						return nil, fmt.Errorf("could not create registers bootstrap: %w", err)
-					}
 
 					// TODO: find a way to hook a context up to this to allow a graceful shutdown
 					workerCount := 10
-					err = bootstrap.IndexCheckpointFile(context.Background(), workerCount)
-					if err != nil {
-						return nil, fmt.Errorf("could not load checkpoint file: %w", err)
+					if builder.registerSnapshotDir != "" {
+						bootstrap, err := pStorage.NewRegisterSnapshotBootstrap(pdb, builder.registerSnapshotDir, checkpointHeight, rootHash, builder.Logger)
+						if err != nil {
+							return nil, fmt.Errorf("could not create register snapshot bootstrap: %w", err)
+						}
+
+						err = bootstrap.IndexSnapshot(context.Background(), workerCount)
+						if err != nil {
+							return nil, fmt.Errorf("could not import register snapshot: %w", err)
+						}
+					} else {
+						checkpointFile := builder.checkpointFile
+						if checkpointFile == cmd.NotSet {
+							checkpointFile = path.Join(builder.BootstrapDir, bootstrap.PathRootCheckpoint)
+						}
+
+						// currently, the checkpoint must be from the root block.
+						// read the root hash from the provided checkpoint and verify it matches the
+						// state commitment from the root snapshot.
+						err := wal.CheckpointHasRootHash(
+							node.Logger,
+							"", // checkpoint file already full path
+							checkpointFile,
+							ledger.RootHash(node.RootSeal.FinalState),
+						)
+						if err != nil {
+							return nil, fmt.Errorf("could not verify checkpoint file: %w", err)
+						}
+
+						bootstrap, err := pStorage.NewRegisterBootstrap(pdb, checkpointFile, checkpointHeight, rootHash, builder.Logger)
+						if err != nil {
+							return nil, fmt.Errorf("could not create registers bootstrap: %w", err)
+						}
+
+						err = bootstrap.IndexCheckpointFile(context.Background(), workerCount)
+						if err != nil {
+							return nil, fmt.Errorf("could not load checkpoint file: %w", err)
+						}
 					}
 				}
 
-				registers, err := pStorage.NewRegisters(pdb)
+				registers, err = pStorage.NewRegisters(pdb)
 				if err != nil {
 					return nil, fmt.Errorf("could not create registers storage: %w", err)
 				}
@@ -818,6 +883,14 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					return nil, fmt.Errorf("could not create derived chain data: %w", err)
 				}
 
+				var indexerOpts []indexer.IndexerCoreOption
+				if builder.Storage.EventTypeIndex != nil {
+					indexerOpts = append(indexerOpts, indexer.WithEventTypeIndex(builder.Storage.EventTypeIndex))
+				}
+				if builder.Storage.AccountTransactions != nil {
+					indexerOpts = append(indexerOpts, indexer.WithAccountTransactions(builder.Storage.AccountTransactions))
+				}
+
 				indexerCore, err := indexer.New(
 					builder.Logger,
 					metrics.NewExecutionStateIndexerCollector(),
@@ -831,6 +904,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.RootChainID.Chain(),
 					indexerDerivedChainData,
 					builder.collectionExecutedMetric,
+					indexerOpts...,
 				)
 				if err != nil {
 					return nil, err
@@ -866,6 +940,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.scriptExecutorConfig,
 					queryDerivedChainData,
 					builder.programCacheSize > 0,
+					node.FvmOptions,
 				)
 
 				err = builder.ScriptExecutor.Initialize(builder.ExecutionIndexer, scripts)
@@ -883,8 +958,41 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					return nil, err
 				}
 
+				indexerDependable.Init(builder.ExecutionIndexer)
+
 				return builder.ExecutionIndexer, nil
-			}, builder.IndexerDependencies)
+			}, builder.IndexerDependencies).
+			DependableComponent("registers pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				registersPruner := pStorage.NewRegistersPruner(
+					node.Logger,
+					metrics.NewRegistersPrunerCollector(),
+					registers,
+					pStorage.WithPruningHeightRangeTarget(builder.registersPruningHeightRangeTarget),
+					pStorage.WithPruningThreshold(builder.registersPruningThreshold),
+					pStorage.WithPruneInterval(builder.registersPruningInterval),
+				)
+
+				// allow the pruning configuration to be updated at runtime using the set-config admin command
+				err := node.ConfigManager.RegisterUintConfig(
+					"registers-pruning-height-range-target",
+					func() uint { return uint(registersPruner.HeightRangeTarget()) },
+					func(target uint) error { registersPruner.SetHeightRangeTarget(uint64(target)); return nil },
+				)
+				if err != nil {
+					return nil, fmt.Errorf("could not register registers-pruning-height-range-target config: %w", err)
+				}
+
+				err = node.ConfigManager.RegisterUintConfig(
+					"registers-pruning-threshold",
+					func() uint { return uint(registersPruner.Threshold()) },
+					func(threshold uint) error { registersPruner.SetThreshold(uint64(threshold)); return nil },
+				)
+				if err != nil {
+					return nil, fmt.Errorf("could not register registers-pruning-threshold config: %w", err)
+				}
+
+				return registersPruner, nil
+			}, cmd.NewDependencyList(indexerDependable))
 	}
 
 	if builder.stateStreamConf.ListenAddr != "" {
@@ -899,6 +1007,14 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.stateStreamConf.MaxContracts = value
 				case "AccountAddresses":
 					builder.stateStreamConf.MaxAccountAddress = value
+				case "RegisterOwners":
+					builder.stateStreamConf.MaxRegisterOwners = value
+				case "RegisterKeyPrefixes":
+					builder.stateStreamConf.MaxRegisterKeyPrefixes = value
+				case "FieldPredicates":
+					builder.stateStreamConf.MaxFieldPredicates = value
+				case "FieldPredicateTerms":
+					builder.stateStreamConf.MaxFieldPredicateTerms = value
 				}
 			}
 			builder.stateStreamConf.RpcMetricsEnabled = builder.rpcMetricsEnabled
@@ -1059,6 +1175,34 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			defaultConfig.rpcConf.RestConfig.ReadTimeout,
 			"timeout to use when reading REST request headers")
 		flags.DurationVar(&builder.rpcConf.RestConfig.IdleTimeout, "rest-idle-timeout", defaultConfig.rpcConf.RestConfig.IdleTimeout, "idle timeout for REST connections")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"rest-ws-max-connections",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"maximum number of connections to the multiplexed REST WebSocket endpoint served at the same time")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"rest-ws-max-subscriptions-per-connection",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"maximum number of subscriptions per connection to the multiplexed REST WebSocket endpoint")
+		flags.Float64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxResponsesPerSecond,
+			"rest-ws-max-responses-per-second",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxResponsesPerSecond,
+			"maximum number of subscription responses sent per second on a connection to the multiplexed REST WebSocket endpoint. 0 means unlimited")
+		flags.BoolVar(&builder.rpcConf.RestConfig.GraphQLConfig.Enabled,
+			"rest-graphql-enabled",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.Enabled,
+			"whether to serve the GraphQL endpoint on the REST server")
+		flags.IntVar(&builder.rpcConf.RestConfig.GraphQLConfig.MaxQueryDepth,
+			"rest-graphql-max-query-depth",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxQueryDepth,
+			"maximum nesting depth of the fields of a GraphQL query")
+		flags.UintVar(&builder.rpcConf.RestConfig.GraphQLConfig.MaxQueryCost,
+			"rest-graphql-max-query-cost",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxQueryCost,
+			"maximum number of access API calls made to resolve a single GraphQL query")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.GraphQLConfig.MaxHeightRange,
+			"rest-graphql-max-height-range",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxHeightRange,
+			"maximum size of the height range of GraphQL block and event queries")
 		flags.StringVarP(&builder.rpcConf.CollectionAddr,
 			"static-collection-ingress-addr",
 			"",
@@ -1127,6 +1271,8 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"full path to a json file which provides more details about nodes when reporting its reachability metrics")
 		flags.StringToIntVar(&builder.apiRatelimits, "api-rate-limits", defaultConfig.apiRatelimits, "per second rate limits for Access API methods e.g. Ping=300,GetTransaction=500 etc.")
 		flags.StringToIntVar(&builder.apiBurstlimits, "api-burst-limits", defaultConfig.apiBurstlimits, "burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
+		flags.StringVar(&builder.quotaConfigFile, "quota-config", defaultConfig.quotaConfigFile, "path to a JSON file configuring the per-client quota tiers and the API keys and mTLS certificate common names of clients. per-client quotas are disabled if empty")
+		flags.StringVar(&builder.quotaClientCAFile, "quota-client-ca-file", defaultConfig.quotaClientCAFile, "path to a PEM file with the CA certificates of the mTLS client certificates identifying clients of the per-client quotas on the secure gRPC server. client certificates are not verified if empty")
 		flags.BoolVar(&builder.supportsObserver, "supports-observer", defaultConfig.supportsObserver, "true if this staked access node supports observer or follower connections")
 		flags.StringVar(&builder.PublicNetworkConfig.BindAddress, "public-network-address", defaultConfig.PublicNetworkConfig.BindAddress, "staked access node's public network bind address")
 		flags.BoolVar(&builder.rpcConf.BackendConfig.CircuitBreakerConfig.Enabled,
@@ -1145,6 +1291,30 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"circuit-breaker-max-requests",
 			defaultConfig.rpcConf.BackendConfig.CircuitBreakerConfig.MaxRequests,
 			"maximum number of requests to check if connection restored after timeout. Default value is 1")
+		flags.BoolVar(&builder.rpcConf.BackendConfig.NodeSelectionConfig.Enabled,
+			"adaptive-node-selection-enabled",
+			defaultConfig.rpcConf.BackendConfig.NodeSelectionConfig.Enabled,
+			"whether to prefer healthy, low-latency collection and execution nodes based on the latency and error rate of previous requests")
+		flags.Float64Var(&builder.rpcConf.BackendConfig.NodeSelectionConfig.LatencyDecay,
+			"adaptive-node-selection-latency-decay",
+			defaultConfig.rpcConf.BackendConfig.NodeSelectionConfig.LatencyDecay,
+			"weight of the latest request when updating the moving averages of the latency and error rate of a node, in the range (0, 1]")
+		flags.DurationVar(&builder.rpcConf.BackendConfig.NodeSelectionConfig.ErrorRecoveryPeriod,
+			"adaptive-node-selection-error-recovery-period",
+			defaultConfig.rpcConf.BackendConfig.NodeSelectionConfig.ErrorRecoveryPeriod,
+			"duration after which the error rate of a node which received no requests is halved")
+		flags.BoolVar(&builder.rpcConf.BackendConfig.RequestHedgingConfig.Enabled,
+			"request-hedging-enabled",
+			defaultConfig.rpcConf.BackendConfig.RequestHedgingConfig.Enabled,
+			"whether to send script executions, transaction result and event requests to a second execution node if the first node is slow to respond")
+		flags.DurationVar(&builder.rpcConf.BackendConfig.RequestHedgingConfig.Delay,
+			"request-hedging-delay",
+			defaultConfig.rpcConf.BackendConfig.RequestHedgingConfig.Delay,
+			"duration after which a request which has not completed yet is sent to a second execution node")
+		flags.Float64Var(&builder.rpcConf.BackendConfig.RequestHedgingConfig.MaxRatio,
+			"request-hedging-max-ratio",
+			defaultConfig.rpcConf.BackendConfig.RequestHedgingConfig.MaxRatio,
+			"maximum ratio of requests which are sent to a second execution node, in the range (0, 1]")
 		// ExecutionDataRequester config
 		flags.BoolVar(&builder.executionDataSyncEnabled,
 			"execution-data-sync-enabled",
@@ -1207,6 +1377,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"state-stream-heartbeat-interval",
 			defaultConfig.stateStreamConf.HeartbeatInterval,
 			"default interval in blocks at which heartbeat messages should be sent. applied when client did not specify a value.")
+		flags.UintVar(&builder.stateStreamConf.MaxEventsPerResponse,
+			"state-stream-max-events-per-response",
+			defaultConfig.stateStreamConf.MaxEventsPerResponse,
+			"max number of events in a single event or account status response. the events of larger blocks are split across responses at transaction boundaries. 0 means no limit")
 		flags.Uint32Var(&builder.stateStreamConf.RegisterIDsRequestLimit,
 			"state-stream-max-register-values",
 			defaultConfig.stateStreamConf.RegisterIDsRequestLimit,
@@ -1217,8 +1391,29 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.eventTypeIndexEnabled,
+			"event-type-index-enabled",
+			defaultConfig.eventTypeIndexEnabled,
+			"whether to index events by event type. when enabled, locally served event height range queries skip blocks without matching events. requires execution-data-indexing-enabled")
+		flags.UintVar(&builder.rpcConf.BackendConfig.EventTypeIndexMaxHeightRange,
+			"event-type-index-max-height-range",
+			defaultConfig.rpcConf.BackendConfig.EventTypeIndexMaxHeightRange,
+			"maximum size for event height range requests served using the event type index")
+		flags.UintVar(&builder.rpcConf.BackendConfig.EventTypeIndexMaxEvents,
+			"event-type-index-max-events",
+			defaultConfig.rpcConf.BackendConfig.EventTypeIndexMaxEvents,
+			"maximum number of events returned by a GetEventsForHeightRange request served using the event type index")
+		flags.BoolVar(&builder.accountTransactionIndexEnabled,
+			"account-transaction-index-enabled",
+			defaultConfig.accountTransactionIndexEnabled,
+			"whether to index the transactions each account was involved in, which enables GetTransactionsByAccount. requires execution-data-indexing-enabled")
+		flags.Uint32Var(&builder.rpcConf.BackendConfig.AccountTransactionsMaxPageSize,
+			"account-transactions-max-page-size",
+			defaultConfig.rpcConf.BackendConfig.AccountTransactionsMaxPageSize,
+			"maximum number of transactions returned in a single page of the transaction history of an account")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
+		flags.StringVar(&builder.registerSnapshotDir, "execution-state-register-snapshot-dir", defaultConfig.registerSnapshotDir, "directory of a register snapshot of the root state to bootstrap the execution-state database from, instead of the execution-state checkpoint file")
 
 		flags.StringVar(&builder.rpcConf.BackendConfig.EventQueryMode,
 			"event-query-mode",
@@ -1243,6 +1438,22 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"script-execution-max-error-length",
 			defaultConfig.scriptExecutorConfig.MaxErrorMessageSize,
 			"maximum number characters to include in error message strings. additional characters are truncated. default: 1000")
+		flags.UintVar(&builder.rpcConf.BackendConfig.ScriptResultCacheSize,
+			"script-result-cache-size",
+			defaultConfig.rpcConf.BackendConfig.ScriptResultCacheSize,
+			"maximum number of locally executed script results to cache. (Disabled by default i.e 0)")
+		flags.DurationVar(&builder.rpcConf.BackendConfig.ScriptResultCacheTTL,
+			"script-result-cache-ttl",
+			defaultConfig.rpcConf.BackendConfig.ScriptResultCacheTTL,
+			"duration locally executed script results are cached for. 0 caches results until they are evicted by newer results")
+		flags.UintVar(&builder.rpcConf.BackendConfig.ScriptBatchMaxSize,
+			"script-batch-max-size",
+			defaultConfig.rpcConf.BackendConfig.ScriptBatchMaxSize,
+			"maximum number of scripts in a single batch script execution request")
+		flags.Uint64Var(&builder.rpcConf.BackendConfig.ScriptBatchComputationLimit,
+			"script-batch-computation-limit",
+			defaultConfig.rpcConf.BackendConfig.ScriptBatchComputationLimit,
+			"maximum computation used by all scripts of a batch script execution request. 0 means no limit")
 		flags.DurationVar(&builder.scriptExecutorConfig.LogTimeThreshold,
 			"script-execution-log-time-threshold",
 			defaultConfig.scriptExecutorConfig.LogTimeThreshold,
@@ -1267,11 +1478,57 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"register-cache-size",
 			defaultConfig.registerCacheSize,
 			"number of registers to cache for script execution. default: 0 (no cache)")
+		flags.Uint64Var(&builder.registersPruningHeightRangeTarget,
+			"registers-pruning-height-range-target",
+			defaultConfig.registersPruningHeightRangeTarget,
+			"number of most recent heights to keep in the registers db. older register values are pruned. default: 0 (no pruning)")
+		flags.Uint64Var(&builder.registersPruningThreshold,
+			"registers-pruning-threshold",
+			defaultConfig.registersPruningThreshold,
+			"number of heights the registers db may exceed the pruning height range target by before pruning is triggered")
+		flags.DurationVar(&builder.registersPruningInterval,
+			"registers-pruning-interval",
+			defaultConfig.registersPruningInterval,
+			"interval at which the registers db is checked for pruning. default: 10m")
 		flags.UintVar(&builder.programCacheSize,
 			"program-cache-size",
 			defaultConfig.programCacheSize,
 			"[experimental] number of blocks to cache for cadence programs. use 0 to disable cache. default: 0. Note: this is an experimental feature and may cause nodes to become unstable under certain workloads. Use with caution.")
+
+		// Block data pruning
+		flags.BoolVar(&builder.blockDataPrunerConfig.Enabled,
+			"block-data-pruning-enabled",
+			defaultConfig.blockDataPrunerConfig.Enabled,
+			"whether to prune block payloads, collections, transaction results and events below the retained height range")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.HeightRangeTarget,
+			"block-data-pruning-height-range-target",
+			defaultConfig.blockDataPrunerConfig.HeightRangeTarget,
+			"number of most recent sealed heights to keep block data for")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.Threshold,
+			"block-data-pruning-threshold",
+			defaultConfig.blockDataPrunerConfig.Threshold,
+			"number of heights the retained range may exceed the height range target by before pruning is triggered")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.RetainedEpochs,
+			"block-data-pruning-retained-epochs",
+			defaultConfig.blockDataPrunerConfig.RetainedEpochs,
+			"number of most recent epochs, including the current epoch, whose block data is never pruned. 0 disables this bound")
+		flags.DurationVar(&builder.blockDataPrunerConfig.PruneInterval,
+			"block-data-pruning-interval",
+			defaultConfig.blockDataPrunerConfig.PruneInterval,
+			"interval at which block data is checked for pruning")
 	}).ValidateFlags(func() error {
+		if builder.rpcConf.RestConfig.GraphQLConfig.Enabled {
+			graphQLConfig := builder.rpcConf.RestConfig.GraphQLConfig
+			if graphQLConfig.MaxQueryDepth <= 0 {
+				return errors.New("rest-graphql-max-query-depth must be greater than 0")
+			}
+			if graphQLConfig.MaxQueryCost == 0 {
+				return errors.New("rest-graphql-max-query-cost must be greater than 0")
+			}
+			if graphQLConfig.MaxHeightRange == 0 {
+				return errors.New("rest-graphql-max-height-range must be greater than 0")
+			}
+		}
 		if builder.supportsObserver && (builder.PublicNetworkConfig.BindAddress == cmd.NotSet || builder.PublicNetworkConfig.BindAddress == "") {
 			return errors.New("public-network-address must be set if supports-observer is true")
 		}
@@ -1299,17 +1556,17 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			if builder.stateStreamConf.ClientSendBufferSize == 0 {
 				return errors.New("state-stream-send-buffer-size must be greater than 0")
 			}
-			if len(builder.stateStreamFilterConf) > 4 {
-				return errors.New("state-stream-event-filter-limits must have at most 3 keys (EventTypes, Addresses, Contracts, AccountAddresses)")
+			if len(builder.stateStreamFilterConf) > 8 {
+				return errors.New("state-stream-event-filter-limits must have at most 8 keys (EventTypes, Addresses, Contracts, AccountAddresses, RegisterOwners, RegisterKeyPrefixes, FieldPredicates, FieldPredicateTerms)")
 			}
 			for key, value := range builder.stateStreamFilterConf {
 				switch key {
-				case "EventTypes", "Addresses", "Contracts", "AccountAddresses":
+				case "EventTypes", "Addresses", "Contracts", "AccountAddresses", "RegisterOwners", "RegisterKeyPrefixes", "FieldPredicates", "FieldPredicateTerms":
 					if value <= 0 {
 						return fmt.Errorf("state-stream-event-filter-limits %s must be greater than 0", key)
 					}
 				default:
-					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses")
+					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses, RegisterOwners, RegisterKeyPrefixes, FieldPredicates, FieldPredicateTerms")
 				}
 			}
 			if builder.stateStreamConf.ResponseLimit < 0 {
@@ -1330,14 +1587,62 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 				return errors.New("circuit-breaker-restore-timeout must be greater than 0")
 			}
 		}
+		if builder.rpcConf.BackendConfig.NodeSelectionConfig.Enabled {
+			latencyDecay := builder.rpcConf.BackendConfig.NodeSelectionConfig.LatencyDecay
+			if latencyDecay <= 0 || latencyDecay > 1 {
+				return errors.New("adaptive-node-selection-latency-decay must be in the range (0, 1]")
+			}
+			if builder.rpcConf.BackendConfig.NodeSelectionConfig.ErrorRecoveryPeriod <= 0 {
+				return errors.New("adaptive-node-selection-error-recovery-period must be greater than 0")
+			}
+		}
+		if builder.rpcConf.BackendConfig.RequestHedgingConfig.Enabled {
+			if builder.rpcConf.BackendConfig.RequestHedgingConfig.Delay <= 0 {
+				return errors.New("request-hedging-delay must be greater than 0")
+			}
+			maxRatio := builder.rpcConf.BackendConfig.RequestHedgingConfig.MaxRatio
+			if maxRatio <= 0 || maxRatio > 1 {
+				return errors.New("request-hedging-max-ratio must be in the range (0, 1]")
+			}
+		}
 		if builder.TxErrorMessagesCacheSize == 0 {
 			return errors.New("transaction-error-messages-cache-size must be greater than 0")
 		}
+		if builder.eventTypeIndexEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if event-type-index-enabled is true")
+		}
+		if builder.eventTypeIndexEnabled && builder.rpcConf.BackendConfig.EventTypeIndexMaxEvents == 0 {
+			return errors.New("event-type-index-max-events must be greater than 0")
+		}
+		if builder.accountTransactionIndexEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if account-transaction-index-enabled is true")
+		}
+		if builder.rpcConf.BackendConfig.AccountTransactionsMaxPageSize == 0 {
+			return errors.New("account-transactions-max-page-size must be greater than 0")
+		}
+		if builder.rpcConf.BackendConfig.ScriptBatchMaxSize == 0 {
+			return errors.New("script-batch-max-size must be greater than 0")
+		}
+		if builder.executionDataIndexingEnabled && builder.registersPruningInterval <= 0 {
+			return errors.New("registers-pruning-interval must be greater than 0")
+		}
+		if builder.blockDataPrunerConfig.Enabled && builder.blockDataPrunerConfig.PruneInterval <= 0 {
+			return errors.New("block-data-pruning-interval must be greater than 0")
+		}
 
 		return nil
 	})
 }
 
+// prunedHeightReporter returns the reporter for the lowest height with available block data,
+// or nil if block data pruning is disabled.
+func (builder *FlowAccessNodeBuilder) prunedHeightReporter() pruner.LowestHeightReporter {
+	if builder.BlockDataPruner == nil {
+		return nil
+	}
+	return builder.BlockDataPruner
+}
+
 func publicNetworkMsgValidators(log zerolog.Logger, idProvider module.IdentityProvider, selfID flow.Identifier) []network.MessageValidator {
 	return []network.MessageValidator{
 		// filter out messages sent by this node itself
@@ -1404,6 +1709,18 @@ func (builder *FlowAccessNodeBuilder) Initialize() error {
 		return storageCommands.NewGetTransactionsCommand(conf.State, conf.Storage.Payloads, conf.Storage.Collections)
 	})
 
+	if builder.rpcConf.BackendConfig.NodeSelectionConfig.Enabled {
+		builder.AdminCommand("get-node-scoreboard", func(conf *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewGetNodeScoreboardCommand(builder.NodeScoreboard)
+		})
+	}
+
+	if builder.quotaConfigFile != "" {
+		builder.AdminCommand("client-quotas", func(conf *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewClientQuotasCommand(builder.ClientQuotas)
+		})
+	}
+
 	// if this is an access node that supports public followers, enqueue the public network
 	if builder.supportsObserver {
 		builder.enqueuePublicNetworkInit()
@@ -1562,6 +1879,16 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.PingMetrics = metrics.NewPingCollector()
 			return nil
 		}).
+		Module("node scoreboard", func(node *cmd.NodeConfig) error {
+			nodeSelectionConfig := builder.rpcConf.BackendConfig.NodeSelectionConfig
+			if !nodeSelectionConfig.Enabled {
+				return nil
+			}
+
+			nodeSelectionConfig.CircuitBreakerRestoreTimeout = builder.rpcConf.BackendConfig.CircuitBreakerConfig.RestoreTimeout
+			builder.NodeScoreboard = backend.NewNodeScoreboard(nodeSelectionConfig, metrics.NewNodeSelectionCollector())
+			return nil
+		}).
 		Module("server certificate", func(node *cmd.NodeConfig) error {
 			// generate the server certificate that will be served by the GRPC server
 			x509Certificate, err := grpcutils.X509Certificate(node.NetworkKey)
@@ -1569,10 +1896,39 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				return err
 			}
 			tlsConfig := grpcutils.DefaultServerTLSConfig(x509Certificate)
+			if builder.quotaClientCAFile != "" {
+				// client certificates identify the clients of the per-client quotas
+				err = quota.ClientCertificates(tlsConfig, builder.quotaClientCAFile)
+				if err != nil {
+					return err
+				}
+			}
 			builder.rpcConf.TransportCredentials = credentials.NewTLS(tlsConfig)
 			return nil
 		}).
 		Module("creating grpc servers", func(node *cmd.NodeConfig) error {
+			if builder.quotaConfigFile != "" {
+				quotaConfig, err := quota.LoadConfig(builder.quotaConfigFile)
+				if err != nil {
+					return err
+				}
+				builder.ClientQuotas, err = quota.NewManager(node.Logger, quotaConfig, metrics.NewClientQuotaCollector())
+				if err != nil {
+					return fmt.Errorf("could not create client quotas: %w", err)
+				}
+			}
+
+			// grpcServerOptions adds the interceptors enforcing the per-client quotas to the given options, if enabled
+			grpcServerOptions := func(opts ...grpcserver.Option) []grpcserver.Option {
+				if builder.ClientQuotas != nil {
+					opts = append(opts, grpcserver.WithServerInterceptors(
+						builder.ClientQuotas.UnaryServerInterceptor(),
+						builder.ClientQuotas.StreamServerInterceptor(),
+					))
+				}
+				return opts
+			}
+
 			builder.secureGrpcServer = grpcserver.NewGrpcServerBuilder(
 				node.Logger,
 				builder.rpcConf.SecureGRPCListenAddr,
@@ -1580,7 +1936,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
 				builder.apiBurstlimits,
-				grpcserver.WithTransportCredentials(builder.rpcConf.TransportCredentials)).Build()
+				grpcServerOptions(grpcserver.WithTransportCredentials(builder.rpcConf.TransportCredentials))...).Build()
 
 			builder.stateStreamGrpcServer = grpcserver.NewGrpcServerBuilder(
 				node.Logger,
@@ -1589,7 +1945,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
 				builder.apiBurstlimits,
-				grpcserver.WithStreamInterceptor()).Build()
+				grpcServerOptions(grpcserver.WithStreamInterceptor())...).Build()
 
 			if builder.rpcConf.UnsecureGRPCListenAddr != builder.stateStreamConf.ListenAddr {
 				builder.unsecureGrpcServer = grpcserver.NewGrpcServerBuilder(node.Logger,
@@ -1597,13 +1953,49 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 					builder.rpcConf.MaxMsgSize,
 					builder.rpcMetricsEnabled,
 					builder.apiRatelimits,
-					builder.apiBurstlimits).Build()
+					builder.apiBurstlimits,
+					grpcServerOptions()...).Build()
 			} else {
 				builder.unsecureGrpcServer = builder.stateStreamGrpcServer
 			}
 
 			return nil
 		}).
+		Module("block data pruner", func(node *cmd.NodeConfig) error {
+			if !builder.blockDataPrunerConfig.Enabled {
+				return nil
+			}
+
+			// collections are synced up to the last full block height, and events and transaction results are
+			// indexed up to the highest indexed height. block data above these heights must not be pruned,
+			// since it would be written again once synced or indexed.
+			opts := append(builder.blockDataPrunerConfig.Options(),
+				pruner.WithProcessedHeight("last full block height", func() (uint64, error) {
+					if lastFullBlockHeight == nil {
+						return 0, fmt.Errorf("last full block height not initialized")
+					}
+					return lastFullBlockHeight.Value(), nil
+				}),
+			)
+			if builder.executionDataIndexingEnabled {
+				opts = append(opts, pruner.WithProcessedHeight("execution data indexer", func() (uint64, error) {
+					return builder.Reporter.HighestIndexedHeight()
+				}))
+			}
+
+			var err error
+			builder.BlockDataPruner, err = pruner.NewBlockDataPruner(
+				node.Logger,
+				metrics.NewBlockDataPrunerCollector(),
+				node.DB,
+				node.State,
+				opts...,
+			)
+			if err != nil {
+				return fmt.Errorf("could not create block data pruner: %w", err)
+			}
+			return nil
+		}).
 		Module("backend script executor", func(node *cmd.NodeConfig) error {
 			builder.ScriptExecutor = backend.NewScriptExecutor(builder.Logger, builder.scriptExecMinBlock, builder.scriptExecMaxBlock)
 			return nil
@@ -1614,20 +2006,42 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 		}).
 		Module("events storage", func(node *cmd.NodeConfig) error {
 			builder.Storage.Events = bstorage.NewEvents(node.Metrics.Cache, node.DB)
+			if builder.eventTypeIndexEnabled {
+				eventTypeIndex, err := bstorage.NewEventTypeIndex(node.DB)
+				if err != nil {
+					return fmt.Errorf("could not create event type index: %w", err)
+				}
+				builder.Storage.EventTypeIndex = eventTypeIndex
+			}
 			return nil
 		}).
 		Module("reporter", func(node *cmd.NodeConfig) error {
-			builder.Reporter = index.NewReporter()
+			var opts []index.ReporterOption
+			if builder.BlockDataPruner != nil {
+				opts = append(opts, index.WithPrunedHeightReporter(builder.BlockDataPruner))
+			}
+			builder.Reporter = index.NewReporter(opts...)
 			return nil
 		}).
 		Module("events index", func(node *cmd.NodeConfig) error {
-			builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events)
+			var opts []index.EventsIndexOption
+			if builder.Storage.EventTypeIndex != nil {
+				opts = append(opts, index.WithEventTypeIndex(builder.Storage.EventTypeIndex))
+			}
+			builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events, opts...)
 			return nil
 		}).
 		Module("transaction result index", func(node *cmd.NodeConfig) error {
 			builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 			return nil
 		}).
+		Module("account transactions index", func(node *cmd.NodeConfig) error {
+			if builder.accountTransactionIndexEnabled {
+				builder.Storage.AccountTransactions = bstorage.NewAccountTransactions(node.DB)
+				builder.AccountTransactionsIndex = index.NewAccountTransactionsIndex(builder.Reporter, builder.Storage.AccountTransactions)
+			}
+			return nil
+		}).
 		Module("processed block height consumer progress", func(node *cmd.NodeConfig) error {
 			processedBlockHeight = bstorage.NewConsumerProgress(builder.DB, module.ConsumeProgressIngestionEngineBlockHeight)
 			return nil
@@ -1711,6 +2125,14 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				return nil, fmt.Errorf("transaction result query mode 'compare' is not supported")
 			}
 
+			nodeCommunicator := backend.NewNodeCommunicator(backendConfig.CircuitBreakerConfig.Enabled)
+			if builder.NodeScoreboard != nil {
+				nodeCommunicator = backend.NewAdaptiveNodeCommunicator(backendConfig.CircuitBreakerConfig.Enabled, builder.NodeScoreboard)
+			}
+			if backendConfig.RequestHedgingConfig.Enabled {
+				nodeCommunicator = nodeCommunicator.WithRequestHedging(backendConfig.RequestHedgingConfig, metrics.NewRequestHedgingCollector())
+			}
+
 			nodeBackend, err := backend.New(backend.Params{
 				State:                     node.State,
 				CollectionRPC:             builder.CollectionRPC,
@@ -1730,11 +2152,13 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				FixedExecutionNodeIDs:     backendConfig.FixedExecutionNodeIDs,
 				Log:                       node.Logger,
 				SnapshotHistoryLimit:      backend.DefaultSnapshotHistoryLimit,
-				Communicator:              backend.NewNodeCommunicator(backendConfig.CircuitBreakerConfig.Enabled),
+				Communicator:              nodeCommunicator,
 				TxResultCacheSize:         builder.TxResultCacheSize,
 				TxErrorMessagesCacheSize:  builder.TxErrorMessagesCacheSize,
 				ScriptExecutor:            builder.ScriptExecutor,
 				ScriptExecutionMode:       scriptExecMode,
+				ScriptResultCacheSize:     backendConfig.ScriptResultCacheSize,
+				ScriptResultCacheTTL:      backendConfig.ScriptResultCacheTTL,
 				EventQueryMode:            eventQueryMode,
 				BlockTracker:              blockTracker,
 				SubscriptionHandler: subscription.NewSubscriptionHandler(
@@ -1744,10 +2168,19 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 					builder.stateStreamConf.ResponseLimit,
 					builder.stateStreamConf.ClientSendBufferSize,
 				),
-				EventsIndex:         builder.EventsIndex,
-				TxResultQueryMode:   txResultQueryMode,
-				TxResultsIndex:      builder.TxResultsIndex,
-				LastFullBlockHeight: lastFullBlockHeight,
+				EventsIndex:                  builder.EventsIndex,
+				EventTypeIndexMaxHeightRange: backendConfig.EventTypeIndexMaxHeightRange,
+				EventTypeIndexMaxEvents:      backendConfig.EventTypeIndexMaxEvents,
+				TxResultQueryMode:            txResultQueryMode,
+				TxResultsIndex:               builder.TxResultsIndex,
+				LastFullBlockHeight:          lastFullBlockHeight,
+				PrunedHeightReporter:         builder.prunedHeightReporter(),
+
+				AccountTransactionsIndex:       builder.AccountTransactionsIndex,
+				AccountTransactionsMaxPageSize: backendConfig.AccountTransactionsMaxPageSize,
+
+				ScriptBatchMaxSize:          backendConfig.ScriptBatchMaxSize,
+				ScriptBatchComputationLimit: backendConfig.ScriptBatchComputationLimit,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize backend: %w", err)
@@ -1782,6 +2215,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.RpcEng, err = engineBuilder.
 				WithLegacy().
 				WithBlockSignerDecoder(signature.NewBlockSignerDecoder(builder.Committee)).
+				WithClientQuotas(builder.ClientQuotas).
 				Build()
 			if err != nil {
 				return nil, err
@@ -1790,6 +2224,12 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 
 			return builder.RpcEng, nil
 		}).
+		Component("block data pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if builder.BlockDataPruner == nil {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return builder.BlockDataPruner, nil
+		}).
 		Component("ingestion engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			var err error
 
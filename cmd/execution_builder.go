@@ -852,7 +852,6 @@ func (exeNode *ExecutionNode) LoadRegisterStore(
 	node.Logger.Info().Msgf("register store bootstrapped: %v", bootstrapped)
 
 	if !bootstrapped {
-		checkpointFile := path.Join(exeNode.exeConf.triedir, modelbootstrap.FilenameWALRootCheckpoint)
 		sealedRoot := node.State.Params().SealedRoot()
 
 		rootSeal := node.State.Params().Seal()
@@ -864,9 +863,17 @@ func (exeNode *ExecutionNode) LoadRegisterStore(
 		checkpointHeight := sealedRoot.Height
 		rootHash := ledgerpkg.RootHash(rootSeal.FinalState)
 
-		err = bootstrap.ImportRegistersFromCheckpoint(node.Logger, checkpointFile, checkpointHeight, rootHash, pebbledb, exeNode.exeConf.importCheckpointWorkerCount)
-		if err != nil {
-			return fmt.Errorf("could not import registers from checkpoint: %w", err)
+		if exeNode.exeConf.registerSnapshotDir != "" {
+			err = bootstrap.ImportRegistersFromSnapshot(node.Logger, exeNode.exeConf.registerSnapshotDir, checkpointHeight, rootHash, pebbledb, exeNode.exeConf.importCheckpointWorkerCount)
+			if err != nil {
+				return fmt.Errorf("could not import registers from register snapshot: %w", err)
+			}
+		} else {
+			checkpointFile := path.Join(exeNode.exeConf.triedir, modelbootstrap.FilenameWALRootCheckpoint)
+			err = bootstrap.ImportRegistersFromCheckpoint(node.Logger, checkpointFile, checkpointHeight, rootHash, pebbledb, exeNode.exeConf.importCheckpointWorkerCount)
+			if err != nil {
+				return fmt.Errorf("could not import registers from checkpoint: %w", err)
+			}
 		}
 	}
 	diskStore, err := storagepebble.NewRegisters(pebbledb)
@@ -906,13 +913,21 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedger(
 	// that all WAL updates are completed before closing opened WAL segment.
 	var err error
 	exeNode.diskWAL, err = wal.NewDiskWAL(node.Logger.With().Str("subcomponent", "wal").Logger(),
-		node.MetricsRegisterer, exeNode.collector, exeNode.exeConf.triedir, int(exeNode.exeConf.mTrieCacheSize), pathfinder.PathByteSize, wal.SegmentSize)
+		node.MetricsRegisterer, exeNode.collector, exeNode.exeConf.triedir, int(exeNode.exeConf.mTrieCacheSize), pathfinder.PathByteSize, wal.SegmentSize,
+		wal.WithReadWorkers(exeNode.exeConf.checkpointReadWorkers),
+		wal.WithMemoryMappedRead(exeNode.exeConf.checkpointMemoryMappedRead),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize wal: %w", err)
 	}
 
+	var ledgerOpts []ledger.LedgerOption
+	if exeNode.exeConf.ledgerEarlyReads {
+		ledgerOpts = append(ledgerOpts, ledger.WithEarlyReads())
+	}
+
 	exeNode.ledgerStorage, err = ledger.NewLedger(exeNode.diskWAL, int(exeNode.exeConf.mTrieCacheSize), exeNode.collector, node.Logger.With().Str("subcomponent",
-		"ledger").Logger(), ledger.DefaultPathFinderVersion)
+		"ledger").Logger(), ledger.DefaultPathFinderVersion, ledgerOpts...)
 	return exeNode.ledgerStorage, err
 }
 
@@ -931,6 +946,7 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 		exeNode.exeConf.checkpointsToKeep,
 		exeNode.toTriggerCheckpoint, // compactor will listen to the signal from admin tool for force triggering checkpointing
 		exeNode.collector,
+		ledger.WithIncrementalCheckpoints(exeNode.exeConf.checkpointConsolidationInterval),
 	)
 }
 
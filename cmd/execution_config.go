@@ -33,6 +33,10 @@ type ExecutionConfig struct {
 	transactionResultsCacheSize          uint
 	checkpointDistance                   uint
 	checkpointsToKeep                    uint
+	checkpointConsolidationInterval      uint
+	checkpointReadWorkers                int
+	checkpointMemoryMappedRead           bool
+	ledgerEarlyReads                     bool
 	chunkDataPackDir                     string
 	chunkDataPackCacheSize               uint
 	chunkDataPackRequestsCacheSize       uint32
@@ -54,6 +58,7 @@ type ExecutionConfig struct {
 	chunkDataPackRequestWorkers          uint
 	maxGracefulStopDuration              time.Duration
 	importCheckpointWorkerCount          int
+	registerSnapshotDir                  string
 
 	// evm tracing configuration
 	evmTracingEnabled  bool
@@ -86,6 +91,10 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.Uint32Var(&exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 	flags.UintVar(&exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 	flags.UintVar(&exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+	flags.UintVar(&exeConf.checkpointConsolidationInterval, "checkpoint-consolidation-interval", 0, "number of incremental checkpoints between full checkpoints (0 to only create full checkpoints)")
+	flags.IntVar(&exeConf.checkpointReadWorkers, "checkpoint-read-workers", 16, "max number of checkpoint part files read concurrently when loading a checkpoint")
+	flags.BoolVar(&exeConf.checkpointMemoryMappedRead, "checkpoint-mmap-read", false, "whether to read checkpoint part files through memory mapping when loading a checkpoint")
+	flags.BoolVar(&exeConf.ledgerEarlyReads, "ledger-early-reads", false, "whether to serve register reads of the latest checkpointed trie, and of other tries once loaded, while the ledger loads the forest on startup")
 	flags.UintVar(&exeConf.computationConfig.DerivedDataCacheSize, "cadence-execution-cache", derived.DefaultDerivedDataCacheSize,
 		"cache size for Cadence execution")
 	flags.BoolVar(&exeConf.computationConfig.ExtensiveTracing, "extensive-tracing", false, "adds high-overhead tracing to execution")
@@ -122,6 +131,7 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.IntVar(&exeConf.blobstoreBurstLimit, "blobstore-burst-limit", 0, "outgoing burst limit for Execution Data blobstore")
 	flags.DurationVar(&exeConf.maxGracefulStopDuration, "max-graceful-stop-duration", stop.DefaultMaxGracefulStopDuration, "the maximum amount of time stop control will wait for ingestion engine to gracefully shutdown before crashing")
 	flags.IntVar(&exeConf.importCheckpointWorkerCount, "import-checkpoint-worker-count", 10, "number of workers to import checkpoint file during bootstrap")
+	flags.StringVar(&exeConf.registerSnapshotDir, "register-snapshot-dir", "", "directory of a register snapshot of the root state to bootstrap the register store from, instead of the root checkpoint file")
 	flags.BoolVar(&exeConf.evmTracingEnabled, "evm-tracing-enabled", false, "enable EVM tracing, when set it will generate traces and upload them to the GCP bucket provided by the --evm-traces-gcp-bucket. Warning: this might affect speed of execution")
 	flags.StringVar(&exeConf.evmTracesGCPBucket, "evm-traces-gcp-bucket", "", "define GCP bucket name used for uploading EVM traces, must be used in combination with --evm-tracing-enabled.")
 
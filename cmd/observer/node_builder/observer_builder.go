@@ -24,7 +24,9 @@ import (
 	"github.com/spf13/pflag"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/admin/commands"
+	accessCommands "github.com/onflow/flow-go/admin/commands/access"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
@@ -39,9 +41,12 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/apiproxy"
 	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rest"
 	restapiproxy "github.com/onflow/flow-go/engine/access/rest/apiproxy"
+	"github.com/onflow/flow-go/engine/access/rest/graphql"
 	"github.com/onflow/flow-go/engine/access/rest/routes"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	rpcConnection "github.com/onflow/flow-go/engine/access/rpc/connection"
@@ -71,6 +76,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/herocache"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	edrequester "github.com/onflow/flow-go/module/state_synchronization/requester"
@@ -133,10 +139,13 @@ type ObserverServiceConfig struct {
 	bootstrapIdentities          flow.IdentitySkeletonList // the identity list of bootstrap peers the node uses to discover other nodes
 	apiRatelimits                map[string]int
 	apiBurstlimits               map[string]int
+	quotaConfigFile              string
+	quotaClientCAFile            string
 	rpcConf                      rpc.Config
 	rpcMetricsEnabled            bool
 	registersDBPath              string
 	checkpointFile               string
+	registerSnapshotDir          string
 	apiTimeout                   time.Duration
 	stateStreamConf              statestreambackend.Config
 	stateStreamFilterConf        map[string]int
@@ -149,7 +158,13 @@ type ObserverServiceConfig struct {
 	logTxTimeToFinalizedExecuted bool
 	executionDataSyncEnabled     bool
 	executionDataIndexingEnabled bool
+	eventTypeIndexEnabled        bool
+	accountTxIndexEnabled        bool
 	localServiceAPIEnabled       bool
+	standaloneModeEnabled        bool
+	txRelayEnabled               bool
+	txRelayConfig                apiproxy.TransactionRelayConfig
+	txRelayCollectionIngressPort uint
 	executionDataDir             string
 	executionDataStartHeight     uint64
 	executionDataConfig          edrequester.ExecutionDataConfig
@@ -158,6 +173,7 @@ type ObserverServiceConfig struct {
 	registerCacheType            string
 	registerCacheSize            uint
 	programCacheSize             uint
+	blockDataPrunerConfig        pruner.Config
 }
 
 // DefaultObserverServiceConfig defines all the default values for the ObserverServiceConfig
@@ -180,12 +196,22 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 				ScriptExecutionMode:       backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
 				EventQueryMode:            backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
 				TxResultQueryMode:         backend.IndexQueryModeExecutionNodesOnly.String(), // default to ENs only for now
+
+				EventTypeIndexMaxHeightRange:   backend.DefaultEventTypeIndexMaxHeightRange,
+				EventTypeIndexMaxEvents:        backend.DefaultEventTypeIndexMaxEvents,
+				AccountTransactionsMaxPageSize: backend.DefaultAccountTransactionsMaxPageSize,
+				ScriptResultCacheSize:          0,
+				ScriptResultCacheTTL:           backend.DefaultScriptResultCacheTTL,
+				ScriptBatchMaxSize:             backend.DefaultScriptBatchMaxSize,
+				ScriptBatchComputationLimit:    backend.DefaultScriptBatchComputationLimit,
 			},
 			RestConfig: rest.Config{
-				ListenAddress: "",
-				WriteTimeout:  rest.DefaultWriteTimeout,
-				ReadTimeout:   rest.DefaultReadTimeout,
-				IdleTimeout:   rest.DefaultIdleTimeout,
+				ListenAddress:   "",
+				WriteTimeout:    rest.DefaultWriteTimeout,
+				ReadTimeout:     rest.DefaultReadTimeout,
+				IdleTimeout:     rest.DefaultIdleTimeout,
+				WebSocketConfig: websockets.NewDefaultWebsocketConfig(),
+				GraphQLConfig:   graphql.NewDefaultConfig(),
 			},
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
@@ -199,12 +225,15 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			EventFilterConfig:       state_stream.DefaultEventFilterConfig,
 			ResponseLimit:           subscription.DefaultResponseLimit,
 			HeartbeatInterval:       subscription.DefaultHeartbeatInterval,
+			MaxEventsPerResponse:    subscription.DefaultMaxEventsPerResponse,
 			RegisterIDsRequestLimit: state_stream.DefaultRegisterIDsRequestLimit,
 		},
 		stateStreamFilterConf:        nil,
 		rpcMetricsEnabled:            false,
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
+		quotaConfigFile:              "",
+		quotaClientCAFile:            "",
 		bootstrapNodeAddresses:       []string{},
 		bootstrapNodePublicKeys:      []string{},
 		observerNetworkingKeyPath:    cmd.NotSet,
@@ -213,13 +242,18 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 		upstreamNodePublicKeys:       []string{},
 		registersDBPath:              filepath.Join(homedir, ".flow", "execution_state"),
 		checkpointFile:               cmd.NotSet,
+		registerSnapshotDir:          "",
 		scriptExecutorConfig:         query.NewDefaultConfig(),
 		logTxTimeToFinalized:         false,
 		logTxTimeToExecuted:          false,
 		logTxTimeToFinalizedExecuted: false,
 		executionDataSyncEnabled:     false,
 		executionDataIndexingEnabled: false,
+		eventTypeIndexEnabled:        false,
+		accountTxIndexEnabled:        false,
 		localServiceAPIEnabled:       false,
+		standaloneModeEnabled:        false,
+		txRelayEnabled:               false,
 		executionDataDir:             filepath.Join(homedir, ".flow", "execution_data"),
 		executionDataStartHeight:     0,
 		executionDataConfig: edrequester.ExecutionDataConfig{
@@ -230,11 +264,18 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			RetryDelay:         edrequester.DefaultRetryDelay,
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
-		scriptExecMinBlock: 0,
-		scriptExecMaxBlock: math.MaxUint64,
-		registerCacheType:  pStorage.CacheTypeTwoQueue.String(),
-		registerCacheSize:  0,
-		programCacheSize:   0,
+		scriptExecMinBlock:    0,
+		scriptExecMaxBlock:    math.MaxUint64,
+		registerCacheType:     pStorage.CacheTypeTwoQueue.String(),
+		registerCacheSize:     0,
+		programCacheSize:      0,
+		blockDataPrunerConfig: pruner.DefaultConfig(),
+		txRelayConfig: apiproxy.TransactionRelayConfig{
+			ResubmitBlocks:  apiproxy.DefaultTransactionRelayResubmitBlocks,
+			MaxPending:      apiproxy.DefaultTransactionRelayMaxPending,
+			ResubmitTimeout: apiproxy.DefaultTransactionRelayResubmitTimeout,
+		},
+		txRelayCollectionIngressPort: 0,
 	}
 }
 
@@ -250,6 +291,7 @@ type ObserverServiceBuilder struct {
 	FollowerState        stateprotocol.FollowerState
 	SyncCore             *chainsync.Core
 	RpcEng               *rpc.Engine
+	ClientQuotas         *quota.Manager
 	TransactionTimings   *stdmap.TransactionTimings
 	FollowerDistributor  *pubsub.FollowerDistributor
 	Committee            hotstuff.DynamicCommittee
@@ -259,6 +301,7 @@ type ObserverServiceBuilder struct {
 	ExecutionIndexer     *indexer.Indexer
 	ExecutionIndexerCore *indexer.IndexerCore
 	TxResultsIndex       *index.TransactionResultsIndex
+	AccountTxsIndex      *index.AccountTransactionsIndex
 	IndexerDependencies  *cmd.DependencyList
 
 	ExecutionDataDownloader execution_data.Downloader
@@ -269,6 +312,8 @@ type ObserverServiceBuilder struct {
 	Reporter            *index.Reporter
 	EventsIndex         *index.EventsIndex
 	ScriptExecutor      *backend.ScriptExecutor
+	BlockDataPruner     *pruner.BlockDataPruner
+	TxRelay             *apiproxy.TransactionRelay
 
 	// available until after the network has started. Hence, a factory function that needs to be called just before
 	// creating the sync engine
@@ -600,6 +645,34 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			defaultConfig.rpcConf.RestConfig.ReadTimeout,
 			"timeout to use when reading REST request headers")
 		flags.DurationVar(&builder.rpcConf.RestConfig.IdleTimeout, "rest-idle-timeout", defaultConfig.rpcConf.RestConfig.IdleTimeout, "idle timeout for REST connections")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"rest-ws-max-connections",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"maximum number of connections to the multiplexed REST WebSocket endpoint served at the same time")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"rest-ws-max-subscriptions-per-connection",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"maximum number of subscriptions per connection to the multiplexed REST WebSocket endpoint")
+		flags.Float64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxResponsesPerSecond,
+			"rest-ws-max-responses-per-second",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxResponsesPerSecond,
+			"maximum number of subscription responses sent per second on a connection to the multiplexed REST WebSocket endpoint. 0 means unlimited")
+		flags.BoolVar(&builder.rpcConf.RestConfig.GraphQLConfig.Enabled,
+			"rest-graphql-enabled",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.Enabled,
+			"whether to serve the GraphQL endpoint on the REST server")
+		flags.IntVar(&builder.rpcConf.RestConfig.GraphQLConfig.MaxQueryDepth,
+			"rest-graphql-max-query-depth",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxQueryDepth,
+			"maximum nesting depth of the fields of a GraphQL query")
+		flags.UintVar(&builder.rpcConf.RestConfig.GraphQLConfig.MaxQueryCost,
+			"rest-graphql-max-query-cost",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxQueryCost,
+			"maximum number of access API calls made to resolve a single GraphQL query")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.GraphQLConfig.MaxHeightRange,
+			"rest-graphql-max-height-range",
+			defaultConfig.rpcConf.RestConfig.GraphQLConfig.MaxHeightRange,
+			"maximum size of the height range of GraphQL block and event queries")
 		flags.UintVar(&builder.rpcConf.MaxMsgSize,
 			"rpc-max-message-size",
 			defaultConfig.rpcConf.MaxMsgSize,
@@ -620,6 +693,14 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"api-burst-limits",
 			defaultConfig.apiBurstlimits,
 			"burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
+		flags.StringVar(&builder.quotaConfigFile,
+			"quota-config",
+			defaultConfig.quotaConfigFile,
+			"path to a JSON file configuring the per-client quota tiers and the API keys and mTLS certificate common names of clients. per-client quotas are disabled if empty")
+		flags.StringVar(&builder.quotaClientCAFile,
+			"quota-client-ca-file",
+			defaultConfig.quotaClientCAFile,
+			"path to a PEM file with the CA certificates of the mTLS client certificates identifying clients of the per-client quotas on the secure gRPC server. client certificates are not verified if empty")
 		flags.StringVar(&builder.observerNetworkingKeyPath,
 			"observer-networking-key-path",
 			defaultConfig.observerNetworkingKeyPath,
@@ -653,9 +734,54 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.eventTypeIndexEnabled,
+			"event-type-index-enabled",
+			defaultConfig.eventTypeIndexEnabled,
+			"whether to index events by event type. when enabled, locally served event height range queries skip blocks without matching events. requires execution-data-indexing-enabled")
+		flags.UintVar(&builder.rpcConf.BackendConfig.EventTypeIndexMaxHeightRange,
+			"event-type-index-max-height-range",
+			defaultConfig.rpcConf.BackendConfig.EventTypeIndexMaxHeightRange,
+			"maximum size for event height range requests served using the event type index")
+		flags.UintVar(&builder.rpcConf.BackendConfig.EventTypeIndexMaxEvents,
+			"event-type-index-max-events",
+			defaultConfig.rpcConf.BackendConfig.EventTypeIndexMaxEvents,
+			"maximum number of events returned by a GetEventsForHeightRange request served using the event type index")
+		flags.BoolVar(&builder.accountTxIndexEnabled,
+			"account-transaction-index-enabled",
+			defaultConfig.accountTxIndexEnabled,
+			"whether to index the transactions each account was involved in, which enables GetTransactionsByAccount. requires execution-data-indexing-enabled")
+		flags.Uint32Var(&builder.rpcConf.BackendConfig.AccountTransactionsMaxPageSize,
+			"account-transactions-max-page-size",
+			defaultConfig.rpcConf.BackendConfig.AccountTransactionsMaxPageSize,
+			"maximum number of transactions returned in a single page of the transaction history of an account")
 		flags.BoolVar(&builder.localServiceAPIEnabled, "local-service-api-enabled", defaultConfig.localServiceAPIEnabled, "whether to use local indexed data for api queries")
+		flags.BoolVar(&builder.standaloneModeEnabled,
+			"standalone-mode-enabled",
+			defaultConfig.standaloneModeEnabled,
+			"whether to serve all api queries except transaction submissions from local indexed data, without forwarding them to upstream access nodes. requires execution-data-indexing-enabled")
+		flags.BoolVar(&builder.txRelayEnabled,
+			"tx-relay-enabled",
+			defaultConfig.txRelayEnabled,
+			"whether to keep track of submitted transactions, and resubmit them to upstream access nodes if they are not included in a finalized collection in time")
+		flags.Uint64Var(&builder.txRelayConfig.ResubmitBlocks,
+			"tx-relay-resubmit-blocks",
+			defaultConfig.txRelayConfig.ResubmitBlocks,
+			"number of finalized blocks after which a transaction which was not included in a finalized collection is resubmitted")
+		flags.UintVar(&builder.txRelayConfig.MaxPending,
+			"tx-relay-max-pending",
+			defaultConfig.txRelayConfig.MaxPending,
+			"maximum number of pending transactions tracked for resubmission")
+		flags.DurationVar(&builder.txRelayConfig.ResubmitTimeout,
+			"tx-relay-resubmit-timeout",
+			defaultConfig.txRelayConfig.ResubmitTimeout,
+			"timeout of each request made to resubmit a pending transaction")
+		flags.UintVar(&builder.txRelayCollectionIngressPort,
+			"tx-relay-collection-ingress-port",
+			defaultConfig.txRelayCollectionIngressPort,
+			"the grpc ingress port of the collection nodes. if set, pending transactions are also resubmitted directly to the collection nodes responsible for them")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
+		flags.StringVar(&builder.registerSnapshotDir, "execution-state-register-snapshot-dir", defaultConfig.registerSnapshotDir, "directory of a register snapshot of the root state to bootstrap the execution-state database from, instead of the execution-state checkpoint file")
 
 		// ExecutionDataRequester config
 		flags.BoolVar(&builder.executionDataSyncEnabled,
@@ -727,6 +853,10 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"state-stream-heartbeat-interval",
 			defaultConfig.stateStreamConf.HeartbeatInterval,
 			"default interval in blocks at which heartbeat messages should be sent. applied when client did not specify a value.")
+		flags.UintVar(&builder.stateStreamConf.MaxEventsPerResponse,
+			"state-stream-max-events-per-response",
+			defaultConfig.stateStreamConf.MaxEventsPerResponse,
+			"max number of events in a single event or account status response. the events of larger blocks are split across responses at transaction boundaries. 0 means no limit")
 		flags.Uint32Var(&builder.stateStreamConf.RegisterIDsRequestLimit,
 			"state-stream-max-register-values",
 			defaultConfig.stateStreamConf.RegisterIDsRequestLimit,
@@ -743,6 +873,22 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"script-execution-max-height",
 			defaultConfig.scriptExecMaxBlock,
 			"highest block height to allow for script execution. default: no limit")
+		flags.UintVar(&builder.rpcConf.BackendConfig.ScriptResultCacheSize,
+			"script-result-cache-size",
+			defaultConfig.rpcConf.BackendConfig.ScriptResultCacheSize,
+			"maximum number of locally executed script results to cache. (Disabled by default i.e 0)")
+		flags.DurationVar(&builder.rpcConf.BackendConfig.ScriptResultCacheTTL,
+			"script-result-cache-ttl",
+			defaultConfig.rpcConf.BackendConfig.ScriptResultCacheTTL,
+			"duration locally executed script results are cached for. 0 caches results until they are evicted by newer results")
+		flags.UintVar(&builder.rpcConf.BackendConfig.ScriptBatchMaxSize,
+			"script-batch-max-size",
+			defaultConfig.rpcConf.BackendConfig.ScriptBatchMaxSize,
+			"maximum number of scripts in a single batch script execution request")
+		flags.Uint64Var(&builder.rpcConf.BackendConfig.ScriptBatchComputationLimit,
+			"script-batch-computation-limit",
+			defaultConfig.rpcConf.BackendConfig.ScriptBatchComputationLimit,
+			"maximum computation used by all scripts of a batch script execution request. 0 means no limit")
 
 		flags.StringVar(&builder.registerCacheType,
 			"register-cache-type",
@@ -756,7 +902,41 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"program-cache-size",
 			defaultConfig.programCacheSize,
 			"[experimental] number of blocks to cache for cadence programs. use 0 to disable cache. default: 0. Note: this is an experimental feature and may cause nodes to become unstable under certain workloads. Use with caution.")
+
+		// Block data pruning
+		flags.BoolVar(&builder.blockDataPrunerConfig.Enabled,
+			"block-data-pruning-enabled",
+			defaultConfig.blockDataPrunerConfig.Enabled,
+			"whether to prune block payloads, collections, transaction results and events below the retained height range")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.HeightRangeTarget,
+			"block-data-pruning-height-range-target",
+			defaultConfig.blockDataPrunerConfig.HeightRangeTarget,
+			"number of most recent sealed heights to keep block data for")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.Threshold,
+			"block-data-pruning-threshold",
+			defaultConfig.blockDataPrunerConfig.Threshold,
+			"number of heights the retained range may exceed the height range target by before pruning is triggered")
+		flags.Uint64Var(&builder.blockDataPrunerConfig.RetainedEpochs,
+			"block-data-pruning-retained-epochs",
+			defaultConfig.blockDataPrunerConfig.RetainedEpochs,
+			"number of most recent epochs, including the current epoch, whose block data is never pruned. 0 disables this bound")
+		flags.DurationVar(&builder.blockDataPrunerConfig.PruneInterval,
+			"block-data-pruning-interval",
+			defaultConfig.blockDataPrunerConfig.PruneInterval,
+			"interval at which block data is checked for pruning")
 	}).ValidateFlags(func() error {
+		if builder.rpcConf.RestConfig.GraphQLConfig.Enabled {
+			graphQLConfig := builder.rpcConf.RestConfig.GraphQLConfig
+			if graphQLConfig.MaxQueryDepth <= 0 {
+				return errors.New("rest-graphql-max-query-depth must be greater than 0")
+			}
+			if graphQLConfig.MaxQueryCost == 0 {
+				return errors.New("rest-graphql-max-query-cost must be greater than 0")
+			}
+			if graphQLConfig.MaxHeightRange == 0 {
+				return errors.New("rest-graphql-max-height-range must be greater than 0")
+			}
+		}
 		if builder.executionDataSyncEnabled {
 			if builder.executionDataConfig.FetchTimeout <= 0 {
 				return errors.New("execution-data-fetch-timeout must be greater than 0")
@@ -781,17 +961,17 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			if builder.stateStreamConf.ClientSendBufferSize == 0 {
 				return errors.New("state-stream-send-buffer-size must be greater than 0")
 			}
-			if len(builder.stateStreamFilterConf) > 4 {
-				return errors.New("state-stream-event-filter-limits must have at most 4 keys (EventTypes, Addresses, Contracts, AccountAddresses)")
+			if len(builder.stateStreamFilterConf) > 8 {
+				return errors.New("state-stream-event-filter-limits must have at most 8 keys (EventTypes, Addresses, Contracts, AccountAddresses, RegisterOwners, RegisterKeyPrefixes, FieldPredicates, FieldPredicateTerms)")
 			}
 			for key, value := range builder.stateStreamFilterConf {
 				switch key {
-				case "EventTypes", "Addresses", "Contracts", "AccountAddresses":
+				case "EventTypes", "Addresses", "Contracts", "AccountAddresses", "RegisterOwners", "RegisterKeyPrefixes", "FieldPredicates", "FieldPredicateTerms":
 					if value <= 0 {
 						return fmt.Errorf("state-stream-event-filter-limits %s must be greater than 0", key)
 					}
 				default:
-					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses")
+					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses, RegisterOwners, RegisterKeyPrefixes, FieldPredicates, FieldPredicateTerms")
 				}
 			}
 			if builder.stateStreamConf.ResponseLimit < 0 {
@@ -801,6 +981,38 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 				return errors.New("state-stream-max-register-values must be greater than 0")
 			}
 		}
+		if builder.eventTypeIndexEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if event-type-index-enabled is true")
+		}
+		if builder.eventTypeIndexEnabled && builder.rpcConf.BackendConfig.EventTypeIndexMaxEvents == 0 {
+			return errors.New("event-type-index-max-events must be greater than 0")
+		}
+		if builder.accountTxIndexEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if account-transaction-index-enabled is true")
+		}
+		if builder.standaloneModeEnabled && !(builder.executionDataSyncEnabled && builder.executionDataIndexingEnabled) {
+			return errors.New("execution-data-sync-enabled and execution-data-indexing-enabled must be set if standalone-mode-enabled is true")
+		}
+		if builder.txRelayEnabled {
+			if builder.txRelayConfig.ResubmitBlocks == 0 || builder.txRelayConfig.ResubmitBlocks >= flow.DefaultTransactionExpiry {
+				return fmt.Errorf("tx-relay-resubmit-blocks must be greater than 0 and less than %d", flow.DefaultTransactionExpiry)
+			}
+			if builder.txRelayConfig.MaxPending == 0 {
+				return errors.New("tx-relay-max-pending must be greater than 0")
+			}
+			if builder.txRelayConfig.ResubmitTimeout <= 0 {
+				return errors.New("tx-relay-resubmit-timeout must be greater than 0")
+			}
+		}
+		if builder.rpcConf.BackendConfig.AccountTransactionsMaxPageSize == 0 {
+			return errors.New("account-transactions-max-page-size must be greater than 0")
+		}
+		if builder.rpcConf.BackendConfig.ScriptBatchMaxSize == 0 {
+			return errors.New("script-batch-max-size must be greater than 0")
+		}
+		if builder.blockDataPrunerConfig.Enabled && builder.blockDataPrunerConfig.PruneInterval <= 0 {
+			return errors.New("block-data-pruning-interval must be greater than 0")
+		}
 
 		return nil
 	})
@@ -1263,24 +1475,6 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 			}
 
 			if !bootstrapped {
-				checkpointFile := builder.checkpointFile
-				if checkpointFile == cmd.NotSet {
-					checkpointFile = path.Join(builder.BootstrapDir, bootstrap.PathRootCheckpoint)
-				}
-
-				// currently, the checkpoint must be from the root block.
-				// read the root hash from the provided checkpoint and verify it matches the
-				// state commitment from the root snapshot.
-				err := wal.CheckpointHasRootHash(
-					node.Logger,
-					"", // checkpoint file already full path
-					checkpointFile,
-					ledger.RootHash(node.RootSeal.FinalState),
-				)
-				if err != nil {
-					return nil, fmt.Errorf("could not verify checkpoint file: %w", err)
-				}
-
 				checkpointHeight := builder.SealedRootBlock.Header.Height
 
 				if builder.SealedRootBlock.ID() != builder.RootSeal.BlockID {
@@ -1289,16 +1483,47 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				}
 
 				rootHash := ledger.RootHash(builder.RootSeal.FinalState)
-				bootstrap, err := pStorage.NewRegisterBootstrap(pdb, checkpointFile, checkpointHeight, rootHash, builder.Logger)
-				if err != nil {
-					return nil, fmt.Errorf("could not create registers bootstrap: %w", err)
-				}
 
 				// TODO: find a way to hook a context up to this to allow a graceful shutdown
 				workerCount := 10
-				err = bootstrap.IndexCheckpointFile(context.Background(), workerCount)
-				if err != nil {
-					return nil, fmt.Errorf("could not load checkpoint file: %w", err)
+				if builder.registerSnapshotDir != "" {
+					bootstrap, err := pStorage.NewRegisterSnapshotBootstrap(pdb, builder.registerSnapshotDir, checkpointHeight, rootHash, builder.Logger)
+					if err != nil {
+						return nil, fmt.Errorf("could not create register snapshot bootstrap: %w", err)
+					}
+
+					err = bootstrap.IndexSnapshot(context.Background(), workerCount)
+					if err != nil {
+						return nil, fmt.Errorf("could not import register snapshot: %w", err)
+					}
+				} else {
+					checkpointFile := builder.checkpointFile
+					if checkpointFile == cmd.NotSet {
+						checkpointFile = path.Join(builder.BootstrapDir, bootstrap.PathRootCheckpoint)
+					}
+
+					// currently, the checkpoint must be from the root block.
+					// read the root hash from the provided checkpoint and verify it matches the
+					// state commitment from the root snapshot.
+					err := wal.CheckpointHasRootHash(
+						node.Logger,
+						"", // checkpoint file already full path
+						checkpointFile,
+						ledger.RootHash(node.RootSeal.FinalState),
+					)
+					if err != nil {
+						return nil, fmt.Errorf("could not verify checkpoint file: %w", err)
+					}
+
+					bootstrap, err := pStorage.NewRegisterBootstrap(pdb, checkpointFile, checkpointHeight, rootHash, builder.Logger)
+					if err != nil {
+						return nil, fmt.Errorf("could not create registers bootstrap: %w", err)
+					}
+
+					err = bootstrap.IndexCheckpointFile(context.Background(), workerCount)
+					if err != nil {
+						return nil, fmt.Errorf("could not load checkpoint file: %w", err)
+					}
 				}
 			}
 
@@ -1328,6 +1553,15 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 			}
 
 			var collectionExecutedMetric module.CollectionExecutedMetric = metrics.NewNoopCollector()
+
+			var indexerOpts []indexer.IndexerCoreOption
+			if builder.Storage.EventTypeIndex != nil {
+				indexerOpts = append(indexerOpts, indexer.WithEventTypeIndex(builder.Storage.EventTypeIndex))
+			}
+			if builder.Storage.AccountTransactions != nil {
+				indexerOpts = append(indexerOpts, indexer.WithAccountTransactions(builder.Storage.AccountTransactions))
+			}
+
 			indexerCore, err := indexer.New(
 				builder.Logger,
 				metrics.NewExecutionStateIndexerCollector(),
@@ -1341,6 +1575,7 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				builder.RootChainID.Chain(),
 				indexerDerivedChainData,
 				collectionExecutedMetric,
+				indexerOpts...,
 			)
 			if err != nil {
 				return nil, err
@@ -1381,6 +1616,7 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				builder.scriptExecutorConfig,
 				queryDerivedChainData,
 				builder.programCacheSize > 0,
+				node.FvmOptions,
 			)
 
 			err = builder.ScriptExecutor.Initialize(builder.ExecutionIndexer, scripts)
@@ -1409,6 +1645,14 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 					builder.stateStreamConf.MaxContracts = value
 				case "AccountAddresses":
 					builder.stateStreamConf.MaxAccountAddress = value
+				case "RegisterOwners":
+					builder.stateStreamConf.MaxRegisterOwners = value
+				case "RegisterKeyPrefixes":
+					builder.stateStreamConf.MaxRegisterKeyPrefixes = value
+				case "FieldPredicates":
+					builder.stateStreamConf.MaxFieldPredicates = value
+				case "FieldPredicateTerms":
+					builder.stateStreamConf.MaxFieldPredicateTerms = value
 				}
 			}
 			builder.stateStreamConf.RpcMetricsEnabled = builder.rpcMetricsEnabled
@@ -1425,9 +1669,9 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 			}
 
 			// use the events index for events if enabled and the node is configured to use it for
-			// regular event queries
+			// regular event queries, or serves all queries from local indexed data
 			useIndex := builder.executionDataIndexingEnabled &&
-				eventQueryMode != backend.IndexQueryModeExecutionNodesOnly
+				(eventQueryMode != backend.IndexQueryModeExecutionNodesOnly || builder.standaloneModeEnabled)
 
 			executionDataTracker := subscription.NewExecutionDataTracker(
 				builder.Logger,
@@ -1636,17 +1880,51 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			return err
 		}
 		tlsConfig := grpcutils.DefaultServerTLSConfig(x509Certificate)
+		if builder.quotaClientCAFile != "" {
+			// client certificates identify the clients of the per-client quotas
+			err = quota.ClientCertificates(tlsConfig, builder.quotaClientCAFile)
+			if err != nil {
+				return err
+			}
+		}
 		builder.rpcConf.TransportCredentials = credentials.NewTLS(tlsConfig)
 		return nil
 	})
+	if builder.quotaConfigFile != "" {
+		builder.AdminCommand("client-quotas", func(conf *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewClientQuotasCommand(builder.ClientQuotas)
+		})
+	}
 	builder.Module("creating grpc servers", func(node *cmd.NodeConfig) error {
+		if builder.quotaConfigFile != "" {
+			quotaConfig, err := quota.LoadConfig(builder.quotaConfigFile)
+			if err != nil {
+				return err
+			}
+			builder.ClientQuotas, err = quota.NewManager(node.Logger, quotaConfig, metrics.NewClientQuotaCollector())
+			if err != nil {
+				return fmt.Errorf("could not create client quotas: %w", err)
+			}
+		}
+
+		// grpcServerOptions adds the interceptors enforcing the per-client quotas to the given options, if enabled
+		grpcServerOptions := func(opts ...grpcserver.Option) []grpcserver.Option {
+			if builder.ClientQuotas != nil {
+				opts = append(opts, grpcserver.WithServerInterceptors(
+					builder.ClientQuotas.UnaryServerInterceptor(),
+					builder.ClientQuotas.StreamServerInterceptor(),
+				))
+			}
+			return opts
+		}
+
 		builder.secureGrpcServer = grpcserver.NewGrpcServerBuilder(node.Logger,
 			builder.rpcConf.SecureGRPCListenAddr,
 			builder.rpcConf.MaxMsgSize,
 			builder.rpcMetricsEnabled,
 			builder.apiRatelimits,
 			builder.apiBurstlimits,
-			grpcserver.WithTransportCredentials(builder.rpcConf.TransportCredentials)).Build()
+			grpcServerOptions(grpcserver.WithTransportCredentials(builder.rpcConf.TransportCredentials))...).Build()
 
 		builder.stateStreamGrpcServer = grpcserver.NewGrpcServerBuilder(
 			node.Logger,
@@ -1655,7 +1933,7 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			builder.rpcMetricsEnabled,
 			builder.apiRatelimits,
 			builder.apiBurstlimits,
-			grpcserver.WithStreamInterceptor()).Build()
+			grpcServerOptions(grpcserver.WithStreamInterceptor())...).Build()
 
 		if builder.rpcConf.UnsecureGRPCListenAddr != builder.stateStreamConf.ListenAddr {
 			builder.unsecureGrpcServer = grpcserver.NewGrpcServerBuilder(node.Logger,
@@ -1663,7 +1941,8 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 				builder.rpcConf.MaxMsgSize,
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
-				builder.apiBurstlimits).Build()
+				builder.apiBurstlimits,
+				grpcServerOptions()...).Build()
 		} else {
 			builder.unsecureGrpcServer = builder.stateStreamGrpcServer
 		}
@@ -1676,20 +1955,69 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 	})
 	builder.Module("events storage", func(node *cmd.NodeConfig) error {
 		builder.Storage.Events = bstorage.NewEvents(node.Metrics.Cache, node.DB)
+		if builder.eventTypeIndexEnabled {
+			eventTypeIndex, err := bstorage.NewEventTypeIndex(node.DB)
+			if err != nil {
+				return fmt.Errorf("could not create event type index: %w", err)
+			}
+			builder.Storage.EventTypeIndex = eventTypeIndex
+		}
+		return nil
+	})
+	builder.Module("block data pruner", func(node *cmd.NodeConfig) error {
+		if !builder.blockDataPrunerConfig.Enabled {
+			return nil
+		}
+
+		// events and transaction results are indexed up to the highest indexed height. block data above
+		// this height must not be pruned, since it would be written again once indexed.
+		opts := builder.blockDataPrunerConfig.Options()
+		if builder.executionDataIndexingEnabled {
+			opts = append(opts, pruner.WithProcessedHeight("execution data indexer", func() (uint64, error) {
+				return builder.Reporter.HighestIndexedHeight()
+			}))
+		}
+
+		var err error
+		builder.BlockDataPruner, err = pruner.NewBlockDataPruner(
+			node.Logger,
+			metrics.NewBlockDataPrunerCollector(),
+			node.DB,
+			node.State,
+			opts...,
+		)
+		if err != nil {
+			return fmt.Errorf("could not create block data pruner: %w", err)
+		}
 		return nil
 	})
 	builder.Module("reporter", func(node *cmd.NodeConfig) error {
-		builder.Reporter = index.NewReporter()
+		var opts []index.ReporterOption
+		if builder.BlockDataPruner != nil {
+			opts = append(opts, index.WithPrunedHeightReporter(builder.BlockDataPruner))
+		}
+		builder.Reporter = index.NewReporter(opts...)
 		return nil
 	})
 	builder.Module("events index", func(node *cmd.NodeConfig) error {
-		builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events)
+		var opts []index.EventsIndexOption
+		if builder.Storage.EventTypeIndex != nil {
+			opts = append(opts, index.WithEventTypeIndex(builder.Storage.EventTypeIndex))
+		}
+		builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events, opts...)
 		return nil
 	})
 	builder.Module("transaction result index", func(node *cmd.NodeConfig) error {
 		builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 		return nil
 	})
+	builder.Module("account transactions index", func(node *cmd.NodeConfig) error {
+		if builder.accountTxIndexEnabled {
+			builder.Storage.AccountTransactions = bstorage.NewAccountTransactions(node.DB)
+			builder.AccountTxsIndex = index.NewAccountTransactionsIndex(builder.Reporter, builder.Storage.AccountTransactions)
+		}
+		return nil
+	})
 	builder.Module("script executor", func(node *cmd.NodeConfig) error {
 		builder.ScriptExecutor = backend.NewScriptExecutor(builder.Logger, builder.scriptExecMinBlock, builder.scriptExecMaxBlock)
 		return nil
@@ -1739,6 +2067,37 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			return nil, fmt.Errorf("failed to initialize block tracker: %w", err)
 		}
 
+		// upstream access node forwarder
+		forwarder, err := apiproxy.NewFlowAccessAPIForwarder(builder.upstreamIdentities, connFactory)
+		if err != nil {
+			return nil, err
+		}
+
+		if builder.txRelayEnabled {
+			var collectors apiproxy.CollectionUpstream
+			if builder.txRelayCollectionIngressPort > 0 {
+				collectionConnFactory := *connFactory
+				collectionConnFactory.CollectionGRPCPort = builder.txRelayCollectionIngressPort
+				collectors = apiproxy.NewCollectionForwarder(node.State, &collectionConnFactory)
+			}
+
+			builder.TxRelay, err = apiproxy.NewTransactionRelay(
+				node.Logger,
+				node.DB,
+				forwarder,
+				collectors,
+				node.RootChainID.Chain(),
+				node.State,
+				node.Storage.Headers,
+				node.Storage.Collections,
+				builder.txRelayConfig,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create transaction relay: %w", err)
+			}
+			builder.FollowerDistributor.AddOnBlockFinalizedConsumer(builder.TxRelay.OnFinalizedBlock)
+		}
+
 		backendParams := backend.Params{
 			State:                     node.State,
 			Blocks:                    node.Storage.Blocks,
@@ -1767,12 +2126,36 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			),
 		}
 
-		if builder.localServiceAPIEnabled {
+		if builder.BlockDataPruner != nil {
+			backendParams.PrunedHeightReporter = builder.BlockDataPruner
+		}
+
+		backendParams.ScriptBatchMaxSize = backendConfig.ScriptBatchMaxSize
+		backendParams.ScriptBatchComputationLimit = backendConfig.ScriptBatchComputationLimit
+
+		if builder.TxRelay != nil {
+			backendParams.TxResubmissions = builder.TxRelay
+		}
+
+		if builder.localServiceAPIEnabled || builder.standaloneModeEnabled {
 			backendParams.ScriptExecutionMode = backend.IndexQueryModeLocalOnly
 			backendParams.EventQueryMode = backend.IndexQueryModeLocalOnly
 			backendParams.TxResultsIndex = builder.TxResultsIndex
 			backendParams.EventsIndex = builder.EventsIndex
+			backendParams.EventTypeIndexMaxHeightRange = backendConfig.EventTypeIndexMaxHeightRange
+			backendParams.EventTypeIndexMaxEvents = backendConfig.EventTypeIndexMaxEvents
+			backendParams.AccountTransactionsIndex = builder.AccountTxsIndex
+			backendParams.AccountTransactionsMaxPageSize = backendConfig.AccountTransactionsMaxPageSize
 			backendParams.ScriptExecutor = builder.ScriptExecutor
+			backendParams.ScriptResultCacheSize = backendConfig.ScriptResultCacheSize
+			backendParams.ScriptResultCacheTTL = backendConfig.ScriptResultCacheTTL
+		}
+
+		if builder.standaloneModeEnabled {
+			// the error messages of failed transactions are not part of the execution data, and observers
+			// have no access to execution nodes to look them up
+			backendParams.TxResultQueryMode = backend.IndexQueryModeLocalOnly
+			backendParams.TxErrorMessages = backend.NewGenericTransactionErrorMessages(builder.TxResultsIndex)
 		}
 
 		accessBackend, err := backend.New(backendParams)
@@ -1781,13 +2164,26 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 		}
 
 		observerCollector := metrics.NewObserverCollector()
-		restHandler, err := restapiproxy.NewRestProxyHandler(
-			accessBackend,
-			builder.upstreamIdentities,
-			connFactory,
-			builder.Logger,
-			observerCollector,
-			node.RootChainID.Chain())
+		var restHandler access.API
+		if builder.standaloneModeEnabled {
+			restHandler, err = restapiproxy.NewStandaloneRestProxyHandler(
+				accessBackend,
+				builder.upstreamIdentities,
+				connFactory,
+				builder.Logger,
+				observerCollector,
+				node.RootChainID.Chain(),
+				builder.TxRelay)
+		} else {
+			restHandler, err = restapiproxy.NewRestProxyHandler(
+				accessBackend,
+				builder.upstreamIdentities,
+				connFactory,
+				builder.Logger,
+				observerCollector,
+				node.RootChainID.Chain(),
+				builder.TxRelay)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1818,24 +2214,21 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			return nil, err
 		}
 
-		// upstream access node forwarder
-		forwarder, err := apiproxy.NewFlowAccessAPIForwarder(builder.upstreamIdentities, connFactory)
-		if err != nil {
-			return nil, err
-		}
-
 		rpcHandler := apiproxy.NewFlowAccessAPIRouter(apiproxy.Params{
-			Log:      builder.Logger,
-			Metrics:  observerCollector,
-			Upstream: forwarder,
-			Local:    engineBuilder.DefaultHandler(hotsignature.NewBlockSignerDecoder(builder.Committee)),
-			UseIndex: builder.localServiceAPIEnabled,
+			Log:        builder.Logger,
+			Metrics:    observerCollector,
+			Upstream:   forwarder,
+			Local:      engineBuilder.DefaultHandler(hotsignature.NewBlockSignerDecoder(builder.Committee)),
+			UseIndex:   builder.localServiceAPIEnabled,
+			Standalone: builder.standaloneModeEnabled,
+			TxRelay:    builder.TxRelay,
 		})
 
 		// build the rpc engine
 		builder.RpcEng, err = engineBuilder.
 			WithRpcHandler(rpcHandler).
 			WithLegacy().
+			WithClientQuotas(builder.ClientQuotas).
 			Build()
 		if err != nil {
 			return nil, err
@@ -1844,6 +2237,20 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 		return builder.RpcEng, nil
 	})
 
+	builder.Component("transaction relay", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+		if builder.TxRelay == nil {
+			return &module.NoopReadyDoneAware{}, nil
+		}
+		return builder.TxRelay, nil
+	})
+
+	builder.Component("block data pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+		if builder.BlockDataPruner == nil {
+			return &module.NoopReadyDoneAware{}, nil
+		}
+		return builder.BlockDataPruner, nil
+	})
+
 	// build secure grpc server
 	builder.Component("secure grpc server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 		return builder.secureGrpcServer, nil
@@ -0,0 +1,82 @@
+package export_register_snapshot
+
+import (
+	"encoding/hex"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/registersnapshot"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var (
+	flagCheckpoint      string
+	flagStateCommitment string
+	flagOutputDir       string
+	flagChunkSize       int
+)
+
+// export the registers of a trie stored in a checkpoint as a register snapshot,
+// which can be imported to bootstrap the register db of an execution node.
+var Cmd = &cobra.Command{
+	Use:   "export-register-snapshot",
+	Short: "Exports the registers of a trie stored in a checkpoint as a register snapshot",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpoint, "checkpoint", "",
+		"checkpoint file to read")
+	_ = Cmd.MarkFlagRequired("checkpoint")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"state commitment (hex-encoded, 64 characters) of the trie to export")
+	_ = Cmd.MarkFlagRequired("state-commitment")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to write the register snapshot to, must not exist or be empty")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().IntVar(&flagChunkSize, "chunk-size", registersnapshot.DefaultChunkSize,
+		"number of registers per chunk of the snapshot")
+}
+
+func run(*cobra.Command, []string) {
+	commitmentBytes, err := hex.DecodeString(flagStateCommitment)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot decode state commitment")
+	}
+	rootHash, err := ledger.ToRootHash(commitmentBytes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid state commitment")
+	}
+
+	log.Info().Msgf("loading checkpoint %v", flagCheckpoint)
+	tries, err := wal.LoadCheckpoint(flagCheckpoint, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while loading checkpoint")
+	}
+	log.Info().Msgf("checkpoint loaded, total tries: %v", len(tries))
+
+	for _, t := range tries {
+		if t.RootHash() != rootHash {
+			continue
+		}
+
+		log.Info().Msgf("exporting registers of trie %v to %v", rootHash, flagOutputDir)
+		manifest, err := registersnapshot.ExportTrie(t, flagOutputDir, flagChunkSize)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot export register snapshot")
+		}
+
+		log.Info().
+			Uint64("register_count", manifest.RegisterCount).
+			Int("chunk_count", len(manifest.Chunks)).
+			Msgf("register snapshot exported to %v", flagOutputDir)
+		return
+	}
+
+	log.Fatal().Msgf("trie with root hash %v not found in checkpoint %v", rootHash, flagCheckpoint)
+}
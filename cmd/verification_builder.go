@@ -34,6 +34,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
@@ -54,6 +55,8 @@ type VerificationConfig struct {
 	chunkWorkers uint64 // number of chunks processed in parallel.
 
 	stopAtHeight uint64 // height to stop the node on
+
+	blockDataPrunerConfig pruner.Config // configuration of the block data pruner
 }
 
 type VerificationNodeBuilder struct {
@@ -81,6 +84,13 @@ func (v *VerificationNodeBuilder) LoadFlags() {
 			flags.Uint64Var(&v.verConf.blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 			flags.Uint64Var(&v.verConf.chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
 			flags.Uint64Var(&v.verConf.stopAtHeight, "stop-at-height", 0, "height to stop the node at (0 to disable)")
+
+			defaultPrunerConfig := pruner.DefaultConfig()
+			flags.BoolVar(&v.verConf.blockDataPrunerConfig.Enabled, "block-data-pruning-enabled", defaultPrunerConfig.Enabled, "whether to prune block payloads, collections, transaction results and events below the retained height range")
+			flags.Uint64Var(&v.verConf.blockDataPrunerConfig.HeightRangeTarget, "block-data-pruning-height-range-target", defaultPrunerConfig.HeightRangeTarget, "number of most recent sealed heights to keep block data for")
+			flags.Uint64Var(&v.verConf.blockDataPrunerConfig.Threshold, "block-data-pruning-threshold", defaultPrunerConfig.Threshold, "number of heights the retained range may exceed the height range target by before pruning is triggered")
+			flags.Uint64Var(&v.verConf.blockDataPrunerConfig.RetainedEpochs, "block-data-pruning-retained-epochs", defaultPrunerConfig.RetainedEpochs, "number of most recent epochs, including the current epoch, whose block data is never pruned. 0 disables this bound")
+			flags.DurationVar(&v.verConf.blockDataPrunerConfig.PruneInterval, "block-data-pruning-interval", defaultPrunerConfig.PruneInterval, "interval at which block data is checked for pruning")
 		})
 }
 
@@ -187,6 +197,18 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			syncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector(node.RootChainID), node.RootChainID)
 			return err
 		}).
+		Component("block data pruner", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if !v.verConf.blockDataPrunerConfig.Enabled {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return pruner.NewBlockDataPruner(
+				node.Logger,
+				metrics.NewBlockDataPrunerCollector(),
+				node.DB,
+				node.State,
+				v.verConf.blockDataPrunerConfig.Options()...,
+			)
+		}).
 		Component("verifier engine", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 			var err error
 
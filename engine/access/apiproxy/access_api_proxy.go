@@ -22,30 +22,37 @@ const (
 
 // FlowAccessAPIRouter is a structure that represents the routing proxy algorithm.
 // It splits requests between a local and a remote API service.
+// In standalone mode, all requests except transaction submissions are served by the local API service.
 type FlowAccessAPIRouter struct {
-	logger   zerolog.Logger
-	metrics  *metrics.ObserverCollector
-	upstream *FlowAccessAPIForwarder
-	local    *accessflow.Handler
-	useIndex bool
+	logger     zerolog.Logger
+	metrics    *metrics.ObserverCollector
+	upstream   *FlowAccessAPIForwarder
+	local      *accessflow.Handler
+	useIndex   bool
+	standalone bool
+	txRelay    *TransactionRelay // nil if transactions are forwarded without resubmission
 }
 
 type Params struct {
-	Log      zerolog.Logger
-	Metrics  *metrics.ObserverCollector
-	Upstream *FlowAccessAPIForwarder
-	Local    *accessflow.Handler
-	UseIndex bool
+	Log        zerolog.Logger
+	Metrics    *metrics.ObserverCollector
+	Upstream   *FlowAccessAPIForwarder
+	Local      *accessflow.Handler
+	UseIndex   bool
+	Standalone bool
+	TxRelay    *TransactionRelay
 }
 
 // NewFlowAccessAPIRouter creates FlowAccessAPIRouter instance
 func NewFlowAccessAPIRouter(params Params) *FlowAccessAPIRouter {
 	h := &FlowAccessAPIRouter{
-		logger:   params.Log,
-		metrics:  params.Metrics,
-		upstream: params.Upstream,
-		local:    params.Local,
-		useIndex: params.UseIndex,
+		logger:     params.Log,
+		metrics:    params.Metrics,
+		upstream:   params.Upstream,
+		local:      params.Local,
+		useIndex:   params.UseIndex || params.Standalone,
+		standalone: params.Standalone,
+		txRelay:    params.TxRelay,
 	}
 
 	return h
@@ -143,6 +150,12 @@ func (h *FlowAccessAPIRouter) GetFullCollectionByID(context context.Context, req
 }
 
 func (h *FlowAccessAPIRouter) SendTransaction(context context.Context, req *access.SendTransactionRequest) (*access.SendTransactionResponse, error) {
+	if h.txRelay != nil {
+		res, err := h.txRelay.SendTransaction(context, req)
+		h.log(UpstreamApiService, "SendTransaction", err)
+		return res, err
+	}
+
 	res, err := h.upstream.SendTransaction(context, req)
 	h.log(UpstreamApiService, "SendTransaction", err)
 	return res, err
@@ -162,6 +175,11 @@ func (h *FlowAccessAPIRouter) GetTransaction(context context.Context, req *acces
 
 func (h *FlowAccessAPIRouter) GetTransactionResult(context context.Context, req *access.GetTransactionRequest) (*access.TransactionResultResponse, error) {
 	//TODO: add implementation for transaction error message before adding local impl
+	if h.standalone {
+		res, err := h.local.GetTransactionResult(context, req)
+		h.log(LocalApiService, "GetTransactionResult", err)
+		return res, err
+	}
 
 	res, err := h.upstream.GetTransactionResult(context, req)
 	h.log(UpstreamApiService, "GetTransactionResult", err)
@@ -170,6 +188,11 @@ func (h *FlowAccessAPIRouter) GetTransactionResult(context context.Context, req
 
 func (h *FlowAccessAPIRouter) GetTransactionResultsByBlockID(context context.Context, req *access.GetTransactionsByBlockIDRequest) (*access.TransactionResultsResponse, error) {
 	//TODO: add implementation for transaction error message before adding local impl
+	if h.standalone {
+		res, err := h.local.GetTransactionResultsByBlockID(context, req)
+		h.log(LocalApiService, "GetTransactionResultsByBlockID", err)
+		return res, err
+	}
 
 	res, err := h.upstream.GetTransactionResultsByBlockID(context, req)
 	h.log(UpstreamApiService, "GetTransactionResultsByBlockID", err)
@@ -190,6 +213,11 @@ func (h *FlowAccessAPIRouter) GetTransactionsByBlockID(context context.Context,
 
 func (h *FlowAccessAPIRouter) GetTransactionResultByIndex(context context.Context, req *access.GetTransactionByIndexRequest) (*access.TransactionResultResponse, error) {
 	//TODO: add implementation for transaction error message before adding local impl
+	if h.standalone {
+		res, err := h.local.GetTransactionResultByIndex(context, req)
+		h.log(LocalApiService, "GetTransactionResultByIndex", err)
+		return res, err
+	}
 
 	res, err := h.upstream.GetTransactionResultByIndex(context, req)
 	h.log(UpstreamApiService, "GetTransactionResultByIndex", err)
@@ -209,6 +237,12 @@ func (h *FlowAccessAPIRouter) GetSystemTransaction(context context.Context, req
 }
 
 func (h *FlowAccessAPIRouter) GetSystemTransactionResult(context context.Context, req *access.GetSystemTransactionResultRequest) (*access.TransactionResultResponse, error) {
+	if h.standalone {
+		res, err := h.local.GetSystemTransactionResult(context, req)
+		h.log(LocalApiService, "GetSystemTransactionResult", err)
+		return res, err
+	}
+
 	res, err := h.upstream.GetSystemTransactionResult(context, req)
 	h.log(UpstreamApiService, "GetSystemTransactionResult", err)
 	return res, err
@@ -335,6 +369,12 @@ func (h *FlowAccessAPIRouter) GetProtocolStateSnapshotByHeight(context context.C
 }
 
 func (h *FlowAccessAPIRouter) GetExecutionResultForBlockID(context context.Context, req *access.GetExecutionResultForBlockIDRequest) (*access.ExecutionResultForBlockIDResponse, error) {
+	if h.standalone {
+		res, err := h.local.GetExecutionResultForBlockID(context, req)
+		h.log(LocalApiService, "GetExecutionResultForBlockID", err)
+		return res, err
+	}
+
 	res, err := h.upstream.GetExecutionResultForBlockID(context, req)
 	h.log(UpstreamApiService, "GetExecutionResultForBlockID", err)
 	return res, err
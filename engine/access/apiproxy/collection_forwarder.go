@@ -0,0 +1,75 @@
+package apiproxy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+
+	"github.com/onflow/flow-go/engine/access/rpc/connection"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// CollectionForwarder submits transactions directly to the collection nodes of the cluster responsible
+// for them in the current epoch. The collection nodes are reached via their public ingress API.
+type CollectionForwarder struct {
+	state       protocol.State
+	connFactory connection.ConnectionFactory
+}
+
+// NewCollectionForwarder creates a new CollectionForwarder. The connection factory must be configured
+// with the ingress port of the collection nodes.
+func NewCollectionForwarder(state protocol.State, connFactory connection.ConnectionFactory) *CollectionForwarder {
+	return &CollectionForwarder{
+		state:       state,
+		connFactory: connFactory,
+	}
+}
+
+// SendTransaction sends the transaction to the collection nodes of the cluster responsible for it, in
+// random order, until one of them accepts it.
+// Expected errors during normal operations:
+//   - the error returned by each collection node, if none of them accepted the transaction
+func (f *CollectionForwarder) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
+	clusters, err := f.state.Final().Epochs().Current().Clustering()
+	if err != nil {
+		return fmt.Errorf("could not cluster collection nodes: %w", err)
+	}
+
+	txID := tx.ID()
+	nodes, ok := clusters.ByTxID(txID)
+	if !ok {
+		return fmt.Errorf("could not get cluster of transaction %v", txID)
+	}
+
+	req := &access.SendTransactionRequest{Transaction: convert.TransactionToMessage(*tx)}
+
+	var errs *multierror.Error
+	for _, i := range rand.Perm(len(nodes)) {
+		err = f.send(ctx, nodes[i].Address, req)
+		if err == nil {
+			return nil
+		}
+		errs = multierror.Append(errs, err)
+	}
+	return errs.ErrorOrNil()
+}
+
+// send sends the transaction to the collection node at the given address.
+func (f *CollectionForwarder) send(ctx context.Context, address string, req *access.SendTransactionRequest) error {
+	client, closer, err := f.connFactory.GetAccessAPIClient(address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to collection node at %s: %w", address, err)
+	}
+	defer closer.Close()
+
+	_, err = client.SendTransaction(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to send transaction to collection node at %s: %w", address, err)
+	}
+	return nil
+}
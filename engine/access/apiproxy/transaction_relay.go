@@ -0,0 +1,496 @@
+package apiproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/events"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+const (
+	// DefaultTransactionRelayResubmitBlocks is the default number of finalized blocks after which a
+	// transaction which was not included in a finalized collection is resubmitted.
+	DefaultTransactionRelayResubmitBlocks = 30
+
+	// DefaultTransactionRelayMaxPending is the default maximum number of pending transactions tracked
+	// by the relay.
+	DefaultTransactionRelayMaxPending = 10_000
+
+	// DefaultTransactionRelayResubmitTimeout is the default timeout of each request made to resubmit a
+	// transaction.
+	DefaultTransactionRelayResubmitTimeout = 5 * time.Second
+
+	// transactionRelayResubmitWorkers is the number of transactions resubmitted concurrently.
+	transactionRelayResubmitWorkers = 16
+)
+
+// TransactionRelayConfig is the configuration of the TransactionRelay.
+type TransactionRelayConfig struct {
+	// ResubmitBlocks is the number of finalized blocks after which a transaction which was not included
+	// in a finalized collection is resubmitted.
+	ResubmitBlocks uint64
+	// MaxPending is the maximum number of pending transactions tracked. Transactions submitted while
+	// the limit is reached are forwarded upstream, but not resubmitted.
+	MaxPending uint
+	// ResubmitTimeout is the timeout of each request made to resubmit a transaction.
+	ResubmitTimeout time.Duration
+}
+
+// TransactionUpstream is the part of the access API used by the TransactionRelay to submit transactions
+// and to look up their status.
+type TransactionUpstream interface {
+	SendTransaction(context.Context, *access.SendTransactionRequest) (*access.SendTransactionResponse, error)
+	GetTransactionResult(context.Context, *access.GetTransactionRequest) (*access.TransactionResultResponse, error)
+}
+
+// CollectionUpstream submits transactions directly to collection nodes.
+type CollectionUpstream interface {
+	// SendTransaction sends the transaction to a collection node of the cluster responsible for it.
+	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
+}
+
+// pendingTransaction is a transaction tracked by the TransactionRelay until it is included in a
+// finalized collection or expires.
+type pendingTransaction struct {
+	tx              *entities.Transaction
+	expiryHeight    uint64 // the last height at which the transaction can be included
+	submittedHeight uint64 // the finalized height at the last successful submission
+	resubmissions   uint
+}
+
+// TransactionRelay forwards transactions to the upstream access nodes, and keeps track of them until
+// they are included in a finalized collection. Transactions which were not included within the
+// configured number of finalized blocks are resubmitted, until they expire. Since the upstream
+// forwarder selects upstream nodes in round-robin order, each resubmission is sent to a different
+// upstream node than the previous one, if more than one upstream node is configured. If collection
+// nodes are configured, transactions are also resubmitted directly to the collection nodes of the
+// cluster responsible for them, so they are included even if the upstream nodes dropped them.
+//
+// The relay can only detect the inclusion of transactions in the local storage if the collections
+// are indexed by the node. Before resubmitting a transaction, the relay asks an upstream node for
+// its status, so transactions are not resubmitted once they are known to be finalized.
+//
+// Resubmissions are done by a separate worker, so slow upstream nodes don't delay the processing of
+// finalized blocks. The pending transactions are persisted, so they are still resubmitted after a
+// restart of the node.
+//
+// TransactionRelay is safe for concurrent use.
+type TransactionRelay struct {
+	component.Component
+
+	log               zerolog.Logger
+	db                *badger.DB
+	upstream          TransactionUpstream
+	collectors        CollectionUpstream // nil if transactions are not resubmitted to collection nodes
+	chain             flow.Chain
+	state             protocol.State
+	headers           storage.Headers
+	collections       storage.Collections
+	config            TransactionRelayConfig
+	finalizationActor *events.FinalizationActor
+	resubmitNotifier  engine.Notifier
+	finalizedHeight   *atomic.Uint64
+
+	mu      sync.Mutex
+	pending map[flow.Identifier]*pendingTransaction
+}
+
+// NewTransactionRelay creates a new TransactionRelay which forwards transactions to the provided upstream,
+// and loads the pending transactions persisted in the database. If collectors is nil, transactions are
+// only resubmitted to the upstream.
+// The relay must be subscribed to block finalization events using OnFinalizedBlock.
+// No errors are expected during normal operations.
+func NewTransactionRelay(
+	log zerolog.Logger,
+	db *badger.DB,
+	upstream TransactionUpstream,
+	collectors CollectionUpstream,
+	chain flow.Chain,
+	state protocol.State,
+	headers storage.Headers,
+	collections storage.Collections,
+	config TransactionRelayConfig,
+) (*TransactionRelay, error) {
+	if config.ResubmitBlocks == 0 {
+		config.ResubmitBlocks = DefaultTransactionRelayResubmitBlocks
+	}
+	if config.MaxPending == 0 {
+		config.MaxPending = DefaultTransactionRelayMaxPending
+	}
+	if config.ResubmitTimeout == 0 {
+		config.ResubmitTimeout = DefaultTransactionRelayResubmitTimeout
+	}
+
+	r := &TransactionRelay{
+		log:              log.With().Str("component", "transaction_relay").Logger(),
+		db:               db,
+		upstream:         upstream,
+		collectors:       collectors,
+		chain:            chain,
+		state:            state,
+		headers:          headers,
+		collections:      collections,
+		config:           config,
+		resubmitNotifier: engine.NewNotifier(),
+		finalizedHeight:  atomic.NewUint64(0),
+		pending:          make(map[flow.Identifier]*pendingTransaction),
+	}
+
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	finalizationActor, finalizationWorker := events.NewFinalizationActor(r.processFinalizedBlock)
+	r.finalizationActor = finalizationActor
+	r.Component = component.NewComponentManagerBuilder().
+		AddWorker(finalizationWorker).
+		AddWorker(r.resubmitLoop).
+		Build()
+
+	return r, nil
+}
+
+// OnFinalizedBlock is called when a new block is finalized.
+func (r *TransactionRelay) OnFinalizedBlock(block *model.Block) {
+	r.finalizationActor.OnFinalizedBlock(block)
+}
+
+// SendTransaction forwards the transaction to an upstream node, and tracks it for resubmission once
+// it was accepted.
+func (r *TransactionRelay) SendTransaction(ctx context.Context, req *access.SendTransactionRequest) (*access.SendTransactionResponse, error) {
+	res, err := r.upstream.SendTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the transaction was accepted upstream, so failing to track it must not fail the request
+	err = r.track(req.GetTransaction())
+	if err != nil {
+		r.log.Warn().Err(err).Msg("failed to track transaction for resubmission")
+	}
+
+	return res, nil
+}
+
+// Resubmissions returns the number of times the transaction was resubmitted by the relay, or 0 if the
+// transaction is not pending.
+func (r *TransactionRelay) Resubmissions(txID flow.Identifier) uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pendingTx, ok := r.pending[txID]; ok {
+		return pendingTx.resubmissions
+	}
+	return 0
+}
+
+// load tracks the pending transactions persisted in the database. Persisted transactions which expired
+// are removed. The resubmission interval of the loaded transactions starts at the finalized block.
+// No errors are expected during normal operations.
+func (r *TransactionRelay) load() error {
+	var txs []flow.TransactionBody
+	err := r.db.View(operation.RetrieveRelayTransactions(&txs))
+	if err != nil {
+		return fmt.Errorf("could not retrieve pending transactions: %w", err)
+	}
+
+	for i := range txs {
+		tracked, err := r.add(convert.TransactionToMessage(txs[i]), &txs[i])
+		if err != nil {
+			return fmt.Errorf("could not track pending transaction %v: %w", txs[i].ID(), err)
+		}
+		if !tracked {
+			r.unpersist([]flow.Identifier{txs[i].ID()})
+		}
+	}
+
+	r.log.Info().Int("pending", len(r.pending)).Msg("loaded pending transactions")
+	return nil
+}
+
+// track adds the transaction to the pending transactions, and persists it.
+// No errors are expected for transactions accepted by the upstream node.
+func (r *TransactionRelay) track(txMsg *entities.Transaction) error {
+	tx, err := convert.MessageToTransaction(txMsg, r.chain)
+	if err != nil {
+		return fmt.Errorf("could not convert transaction: %w", err)
+	}
+
+	tracked, err := r.add(txMsg, &tx)
+	if err != nil || !tracked {
+		return err
+	}
+
+	err = operation.RetryOnConflict(r.db.Update, operation.InsertRelayTransaction(&tx))
+	if err != nil {
+		return fmt.Errorf("could not persist pending transaction: %w", err)
+	}
+	return nil
+}
+
+// add adds the transaction to the pending transactions, and returns true if it was added. Transactions
+// which are already pending or expired are not added.
+// No errors are expected during normal operations.
+func (r *TransactionRelay) add(txMsg *entities.Transaction, tx *flow.TransactionBody) (bool, error) {
+	finalized, err := r.state.Final().Head()
+	if err != nil {
+		return false, fmt.Errorf("could not get finalized header: %w", err)
+	}
+
+	// if the reference block is not known yet, the transaction expires at the latest relative to the
+	// finalized block
+	referenceHeight := finalized.Height
+	referenceHeader, err := r.headers.ByBlockID(tx.ReferenceBlockID)
+	if err == nil {
+		referenceHeight = referenceHeader.Height
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return false, fmt.Errorf("could not get reference block %v: %w", tx.ReferenceBlockID, err)
+	}
+
+	expiryHeight := referenceHeight + flow.DefaultTransactionExpiry
+	if finalized.Height > expiryHeight {
+		return false, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	txID := tx.ID()
+	if _, ok := r.pending[txID]; ok {
+		return false, nil
+	}
+	if uint(len(r.pending)) >= r.config.MaxPending {
+		return false, fmt.Errorf("limit of %d pending transactions reached", r.config.MaxPending)
+	}
+
+	r.pending[txID] = &pendingTransaction{
+		tx:              txMsg,
+		expiryHeight:    expiryHeight,
+		submittedHeight: finalized.Height,
+	}
+	return true, nil
+}
+
+// processFinalizedBlock is invoked by the FinalizationActor when a new block is finalized. It removes
+// the transactions which were included in a collection or expired, and notifies the resubmission worker.
+// No errors are expected during normal operations.
+func (r *TransactionRelay) processFinalizedBlock(block *model.Block) error {
+	header, err := r.headers.ByBlockID(block.BlockID)
+	if err != nil {
+		return fmt.Errorf("could not get finalized block %v: %w", block.BlockID, err)
+	}
+
+	err = r.removeCompleted(header.Height)
+	if err != nil {
+		return err
+	}
+
+	r.finalizedHeight.Store(header.Height)
+	r.resubmitNotifier.Notify()
+	return nil
+}
+
+// removeCompleted removes the pending transactions which were included in a collection or expired at
+// the given height. The collections are looked up without holding the lock, so transactions can be
+// submitted concurrently.
+// No errors are expected during normal operations.
+func (r *TransactionRelay) removeCompleted(height uint64) error {
+	var completed, candidates []flow.Identifier
+	r.mu.Lock()
+	for txID, pendingTx := range r.pending {
+		if height > pendingTx.expiryHeight {
+			r.log.Debug().Str("tx_id", txID.String()).Uint("resubmissions", pendingTx.resubmissions).Msg("pending transaction expired")
+			completed = append(completed, txID)
+			continue
+		}
+		candidates = append(candidates, txID)
+	}
+	r.mu.Unlock()
+
+	for _, txID := range candidates {
+		_, err := r.collections.LightByTransactionID(txID)
+		if err == nil {
+			completed = append(completed, txID)
+			continue
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not look up collection of transaction %v: %w", txID, err)
+		}
+	}
+
+	r.remove(completed)
+	return nil
+}
+
+// remove stops tracking the given transactions.
+func (r *TransactionRelay) remove(txIDs []flow.Identifier) {
+	if len(txIDs) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	for _, txID := range txIDs {
+		delete(r.pending, txID)
+	}
+	r.mu.Unlock()
+
+	r.unpersist(txIDs)
+}
+
+// unpersist removes the given transactions from the database. Failures are only logged, since
+// transactions left in the database are removed when they are loaded after a restart.
+func (r *TransactionRelay) unpersist(txIDs []flow.Identifier) {
+	err := operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		for _, txID := range txIDs {
+			err := operation.RemoveRelayTransaction(txID)(tx)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Warn().Err(err).Int("transactions", len(txIDs)).Msg("failed to remove completed transactions from the database")
+	}
+}
+
+// resubmitLoop is the worker resubmitting the pending transactions which are due, each time a block
+// is finalized.
+func (r *TransactionRelay) resubmitLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.resubmitNotifier.Channel():
+			r.resubmitDue(ctx, r.finalizedHeight.Load())
+		}
+	}
+}
+
+// resubmitDue resubmits the pending transactions which were not included within the configured number
+// of blocks before the given height, and returns once all of them were processed.
+func (r *TransactionRelay) resubmitDue(ctx context.Context, height uint64) {
+	due := make(map[flow.Identifier]*entities.Transaction)
+	r.mu.Lock()
+	for txID, pendingTx := range r.pending {
+		if height >= pendingTx.submittedHeight+r.config.ResubmitBlocks {
+			due[txID] = pendingTx.tx
+		}
+	}
+	r.mu.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+
+	txIDs := make(chan flow.Identifier)
+	var wg sync.WaitGroup
+	for i := 0; i < transactionRelayResubmitWorkers && i < len(due); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for txID := range txIDs {
+				r.resubmit(ctx, txID, due[txID], height)
+			}
+		}()
+	}
+
+send:
+	for txID := range due {
+		select {
+		case <-ctx.Done():
+			break send
+		case txIDs <- txID:
+		}
+	}
+	close(txIDs)
+	wg.Wait()
+}
+
+// resubmit checks the status of the transaction on an upstream node, and resubmits it if it is still
+// pending. Each request is limited by the configured timeout. Failed resubmissions are retried at the
+// next finalized block.
+func (r *TransactionRelay) resubmit(ctx context.Context, txID flow.Identifier, txMsg *entities.Transaction, height uint64) {
+	lg := r.log.With().Str("tx_id", txID.String()).Uint64("height", height).Logger()
+
+	statusCtx, cancel := context.WithTimeout(ctx, r.config.ResubmitTimeout)
+	res, err := r.upstream.GetTransactionResult(statusCtx, &access.GetTransactionRequest{Id: txID[:]})
+	cancel()
+	if err == nil && !isPending(res.GetStatus()) {
+		r.remove([]flow.Identifier{txID})
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, r.config.ResubmitTimeout)
+	_, err = r.upstream.SendTransaction(sendCtx, &access.SendTransactionRequest{Transaction: txMsg})
+	cancel()
+	if err != nil {
+		lg.Warn().Err(err).Msg("failed to resubmit pending transaction upstream")
+	}
+	resubmitted := err == nil
+
+	if r.collectors != nil {
+		err = r.sendToCollectors(ctx, txMsg)
+		if err != nil {
+			lg.Warn().Err(err).Msg("failed to resubmit pending transaction to collection nodes")
+		}
+		resubmitted = resubmitted || err == nil
+	}
+
+	if !resubmitted {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pendingTx, ok := r.pending[txID]
+	if !ok {
+		return
+	}
+	pendingTx.submittedHeight = height
+	pendingTx.resubmissions++
+
+	lg.Info().Uint("resubmissions", pendingTx.resubmissions).Msg("resubmitted pending transaction")
+}
+
+// sendToCollectors sends the transaction to a collection node responsible for it.
+// No errors are expected during normal operations.
+func (r *TransactionRelay) sendToCollectors(ctx context.Context, txMsg *entities.Transaction) error {
+	tx, err := convert.MessageToTransaction(txMsg, r.chain)
+	if err != nil {
+		return fmt.Errorf("could not convert transaction: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.ResubmitTimeout)
+	defer cancel()
+
+	return r.collectors.SendTransaction(ctx, &tx)
+}
+
+// isPending returns true if the transaction status indicates that the transaction was neither included
+// in a finalized block nor expired.
+func isPending(txStatus entities.TransactionStatus) bool {
+	return txStatus == entities.TransactionStatus_UNKNOWN || txStatus == entities.TransactionStatus_PENDING
+}
@@ -0,0 +1,256 @@
+package apiproxy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const testResubmitBlocks = 10
+
+// relayTest holds the dependencies of a TransactionRelay under test
+type relayTest struct {
+	relay       *TransactionRelay
+	db          *badger.DB
+	upstream    *accessmock.AccessAPIServer
+	collectors  *collectionUpstream
+	state       *protocol.State
+	headers     *storagemock.Headers
+	collections *storagemock.Collections
+	tx          flow.TransactionBody
+	refHeight   uint64
+}
+
+// collectionUpstream is a CollectionUpstream recording the transactions sent to it
+type collectionUpstream struct {
+	mu  sync.Mutex
+	txs []flow.Identifier
+	err error
+}
+
+func (c *collectionUpstream) SendTransaction(_ context.Context, tx *flow.TransactionBody) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.txs = append(c.txs, tx.ID())
+	return c.err
+}
+
+// newRelayTest creates a TransactionRelay and submits a transaction referencing the finalized block
+func newRelayTest(t *testing.T, db *badger.DB) *relayTest {
+	upstream := accessmock.NewAccessAPIServer(t)
+	headers := storagemock.NewHeaders(t)
+	collections := storagemock.NewCollections(t)
+	state := protocol.NewState(t)
+	snapshot := protocol.NewSnapshot(t)
+
+	refHeader := unittest.BlockHeaderFixture()
+	state.On("Final").Return(snapshot)
+	snapshot.On("Head").Return(refHeader, nil)
+	headers.On("ByBlockID", refHeader.ID()).Return(refHeader, nil)
+
+	r := &relayTest{
+		db:          db,
+		upstream:    upstream,
+		collectors:  &collectionUpstream{},
+		state:       state,
+		headers:     headers,
+		collections: collections,
+		refHeight:   refHeader.Height,
+	}
+	r.relay = r.newRelay(t)
+
+	r.tx = unittest.TransactionBodyFixture(func(tb *flow.TransactionBody) {
+		tb.ReferenceBlockID = refHeader.ID()
+	})
+
+	upstream.On("SendTransaction", mock.Anything, mock.Anything).Return(&access.SendTransactionResponse{}, nil).Once()
+	_, err := r.relay.SendTransaction(context.Background(), &access.SendTransactionRequest{
+		Transaction: convert.TransactionToMessage(r.tx),
+	})
+	require.NoError(t, err)
+
+	return r
+}
+
+// newRelay creates a TransactionRelay using the dependencies of the test
+func (r *relayTest) newRelay(t *testing.T) *TransactionRelay {
+	relay, err := NewTransactionRelay(
+		unittest.Logger(),
+		r.db,
+		r.upstream,
+		r.collectors,
+		flow.Testnet.Chain(),
+		r.state,
+		r.headers,
+		r.collections,
+		TransactionRelayConfig{ResubmitBlocks: testResubmitBlocks},
+	)
+	require.NoError(t, err)
+	return relay
+}
+
+// finalize notifies the relay of a finalized block at the given height, and resubmits the transactions
+// which are due
+func (r *relayTest) finalize(t *testing.T, height uint64) {
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+	r.headers.On("ByBlockID", header.ID()).Return(header, nil).Once()
+
+	err := r.relay.processFinalizedBlock(model.BlockFromFlow(header))
+	require.NoError(t, err)
+
+	r.relay.resubmitDue(context.Background(), height)
+}
+
+// persisted returns the IDs of the transactions persisted by the relay
+func (r *relayTest) persisted(t *testing.T) []flow.Identifier {
+	var txs []flow.TransactionBody
+	require.NoError(t, r.db.View(operation.RetrieveRelayTransactions(&txs)))
+
+	txIDs := make([]flow.Identifier, 0, len(txs))
+	for _, tx := range txs {
+		txIDs = append(txIDs, tx.ID())
+	}
+	return txIDs
+}
+
+// TestTransactionRelay_Resubmit tests that transactions which are not included in a collection are resubmitted
+// after the configured number of blocks, until they are included
+func TestTransactionRelay_Resubmit(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		r := newRelayTest(t, db)
+		txID := r.tx.ID()
+		r.collections.On("LightByTransactionID", txID).Return(nil, storage.ErrNotFound)
+
+		// the transaction is not resubmitted before the configured number of blocks
+		r.finalize(t, r.refHeight+testResubmitBlocks-1)
+		assert.Zero(t, r.relay.Resubmissions(txID))
+
+		// the transaction is resubmitted while the upstream still reports it as pending
+		r.upstream.On("GetTransactionResult", mock.Anything, &access.GetTransactionRequest{Id: txID[:]}).
+			Return(&access.TransactionResultResponse{Status: entities.TransactionStatus_PENDING}, nil).Twice()
+		r.upstream.On("SendTransaction", mock.Anything, mock.Anything).Return(&access.SendTransactionResponse{}, nil).Twice()
+
+		r.finalize(t, r.refHeight+testResubmitBlocks)
+		assert.Equal(t, uint(1), r.relay.Resubmissions(txID))
+
+		// the interval restarts with each resubmission
+		r.finalize(t, r.refHeight+2*testResubmitBlocks-1)
+		assert.Equal(t, uint(1), r.relay.Resubmissions(txID))
+		r.finalize(t, r.refHeight+2*testResubmitBlocks)
+		assert.Equal(t, uint(2), r.relay.Resubmissions(txID))
+
+		// the transaction is also resubmitted to the collection nodes
+		assert.Equal(t, []flow.Identifier{txID, txID}, r.collectors.txs)
+
+		// once the transaction is included in a collection, it is no longer pending
+		r.collections.On("LightByTransactionID", txID).Unset()
+		r.collections.On("LightByTransactionID", txID).Return(&flow.LightCollection{}, nil).Once()
+		r.finalize(t, r.refHeight+3*testResubmitBlocks)
+		assert.Zero(t, r.relay.Resubmissions(txID))
+		assert.Empty(t, r.persisted(t))
+	})
+}
+
+// TestTransactionRelay_FinalizedUpstream tests that transactions are not resubmitted if the upstream reports them
+// as finalized
+func TestTransactionRelay_FinalizedUpstream(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		r := newRelayTest(t, db)
+		txID := r.tx.ID()
+		r.collections.On("LightByTransactionID", txID).Return(nil, storage.ErrNotFound).Once()
+
+		r.upstream.On("GetTransactionResult", mock.Anything, &access.GetTransactionRequest{Id: txID[:]}).
+			Return(&access.TransactionResultResponse{Status: entities.TransactionStatus_FINALIZED}, nil).Once()
+
+		r.finalize(t, r.refHeight+testResubmitBlocks)
+		assert.Zero(t, r.relay.Resubmissions(txID))
+
+		// the transaction is no longer tracked, so it is not looked up again
+		r.finalize(t, r.refHeight+2*testResubmitBlocks)
+	})
+}
+
+// TestTransactionRelay_Expired tests that transactions are no longer tracked once they expired
+func TestTransactionRelay_Expired(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		r := newRelayTest(t, db)
+		txID := r.tx.ID()
+
+		// the expiry is checked before the collection is looked up, or the upstream is called
+		r.finalize(t, r.refHeight+flow.DefaultTransactionExpiry+1)
+		assert.Zero(t, r.relay.Resubmissions(txID))
+
+		r.finalize(t, r.refHeight+flow.DefaultTransactionExpiry+2)
+	})
+}
+
+// TestTransactionRelay_Persisted tests that pending transactions are loaded after a restart, and that
+// expired transactions are removed when they are loaded
+func TestTransactionRelay_Persisted(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		r := newRelayTest(t, db)
+		txID := r.tx.ID()
+		assert.Equal(t, []flow.Identifier{txID}, r.persisted(t))
+
+		// the pending transaction is resubmitted by the restarted relay
+		r.relay = r.newRelay(t)
+		r.collections.On("LightByTransactionID", txID).Return(nil, storage.ErrNotFound).Once()
+		r.upstream.On("GetTransactionResult", mock.Anything, &access.GetTransactionRequest{Id: txID[:]}).
+			Return(nil, fmt.Errorf("unavailable")).Once()
+		r.upstream.On("SendTransaction", mock.Anything, mock.Anything).Return(&access.SendTransactionResponse{}, nil).Once()
+		r.finalize(t, r.refHeight+testResubmitBlocks)
+		assert.Equal(t, uint(1), r.relay.Resubmissions(txID))
+
+		// expired transactions are not loaded
+		expired := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(r.refHeight + flow.DefaultTransactionExpiry + 1))
+		snapshot := protocol.NewSnapshot(t)
+		snapshot.On("Head").Return(expired, nil)
+		r.state.On("Final").Unset()
+		r.state.On("Final").Return(snapshot)
+
+		r.relay = r.newRelay(t)
+		assert.Empty(t, r.persisted(t))
+	})
+}
+
+// TestTransactionRelay_UpstreamFailure tests that transactions are resubmitted to the collection nodes if the
+// upstream fails, and that resubmissions are retried if all of them fail
+func TestTransactionRelay_UpstreamFailure(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		r := newRelayTest(t, db)
+		txID := r.tx.ID()
+		r.collections.On("LightByTransactionID", txID).Return(nil, storage.ErrNotFound)
+		r.upstream.On("GetTransactionResult", mock.Anything, &access.GetTransactionRequest{Id: txID[:]}).
+			Return(nil, fmt.Errorf("unavailable"))
+		r.upstream.On("SendTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("unavailable"))
+
+		r.finalize(t, r.refHeight+testResubmitBlocks)
+		assert.Equal(t, uint(1), r.relay.Resubmissions(txID))
+
+		// failed resubmissions are retried at the next finalized block
+		r.collectors.err = fmt.Errorf("unavailable")
+		r.finalize(t, r.refHeight+2*testResubmitBlocks)
+		assert.Equal(t, uint(1), r.relay.Resubmissions(txID))
+		r.collectors.err = nil
+		r.finalize(t, r.refHeight+2*testResubmitBlocks+1)
+		assert.Equal(t, uint(2), r.relay.Resubmissions(txID))
+	})
+}
@@ -0,0 +1,51 @@
+package index
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// AccountTransactionsIndex implements a wrapper around `storage.AccountTransactions` ensuring that needed data has been synced and is available to the client.
+// Note: read detail how `Reporter` is working
+type AccountTransactionsIndex struct {
+	*Reporter
+	accountTxs storage.AccountTransactions
+}
+
+func NewAccountTransactionsIndex(reporter *Reporter, accountTxs storage.AccountTransactions) *AccountTransactionsIndex {
+	return &AccountTransactionsIndex{
+		Reporter:   reporter,
+		accountTxs: accountTxs,
+	}
+}
+
+// ByAddress checks data availability and returns up to limit transactions of the given address, most
+// recent first. The cursor is inclusive. A nil cursor starts at the most recent transaction within the
+// indexed height range.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the `AccountTransactionsIndex` has not been initialized
+//   - storage.ErrHeightNotIndexed if the cursor height is not indexed
+func (a *AccountTransactionsIndex) ByAddress(address flow.Address, cursor *flow.AccountTransactionCursor, limit uint32) ([]flow.AccountTransaction, error) {
+	if cursor == nil {
+		highestHeight, err := a.HighestIndexedHeight()
+		if err != nil {
+			return nil, err
+		}
+		cursor = &flow.AccountTransactionCursor{
+			BlockHeight:      highestHeight,
+			TransactionIndex: math.MaxUint32,
+		}
+	} else if err := a.checkDataAvailability(cursor.BlockHeight); err != nil {
+		return nil, err
+	}
+
+	txs, err := a.accountTxs.ByAddress(address, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transactions of account %v: %w", address, err)
+	}
+
+	return txs, nil
+}
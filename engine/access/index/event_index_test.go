@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -58,6 +59,63 @@ func TestGetEvents(t *testing.T) {
 	}
 }
 
+// TestGetEventsByEventType tests that ByEventType uses the event type index if it is enabled
+func TestGetEventsByEventType(t *testing.T) {
+	eventType := unittest.EventTypeFixture(flow.Localnet)
+	header := unittest.BlockHeaderFixture()
+	expected := []flow.BlockEvents{
+		{
+			BlockID:     header.ID(),
+			BlockHeight: header.Height,
+			Events:      generateTxEvents(unittest.IdentifierFixture(), 0, 2),
+		},
+	}
+
+	t.Run("event type index disabled", func(t *testing.T) {
+		eventsIndex := NewEventsIndex(NewReporter(), storagemock.NewEvents(t))
+		err := eventsIndex.Initialize(&mockIndexReporter{})
+		require.NoError(t, err)
+
+		assert.False(t, eventsIndex.EventTypeIndexEnabled())
+
+		_, err = eventsIndex.ByEventType(eventType, header.Height, header.Height+10, 100)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+	})
+
+	t.Run("event type index enabled", func(t *testing.T) {
+		eventTypeIndex := storagemock.NewEventTypeIndex(t)
+		eventTypeIndex.On("ByEventType", eventType, header.Height, header.Height+10, uint(100)).Return(expected, nil)
+
+		eventsIndex := NewEventsIndex(NewReporter(), storagemock.NewEvents(t), WithEventTypeIndex(eventTypeIndex))
+		err := eventsIndex.Initialize(&mockIndexReporter{})
+		require.NoError(t, err)
+
+		assert.True(t, eventsIndex.EventTypeIndexEnabled())
+
+		actual, err := eventsIndex.ByEventType(eventType, header.Height, header.Height+10, 100)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+// TestGetEventsPruned tests that events of heights whose block data was pruned are reported as not indexed,
+// rather than returned empty
+func TestGetEventsPruned(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	prunedHeight := prunedHeightReporter(header.Height + 1)
+
+	eventsIndex := NewEventsIndex(NewReporter(WithPrunedHeightReporter(prunedHeight)), storagemock.NewEvents(t))
+	err := eventsIndex.Initialize(&mockIndexReporter{})
+	require.NoError(t, err)
+
+	_, err = eventsIndex.ByBlockID(header.ID(), header.Height)
+	require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+	lowestHeight, err := eventsIndex.LowestIndexedHeight()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(prunedHeight), lowestHeight)
+}
+
 func generateTxEvents(txID flow.Identifier, txIndex uint32, count int) flow.EventsList {
 	events := make(flow.EventsList, count)
 	for i := 0; i < count; i++ {
@@ -80,3 +138,9 @@ func (r *mockIndexReporter) LowestIndexedHeight() (uint64, error) {
 func (r *mockIndexReporter) HighestIndexedHeight() (uint64, error) {
 	return math.MaxUint64, nil
 }
+
+type prunedHeightReporter uint64
+
+func (r prunedHeightReporter) LowestAvailableHeight() uint64 {
+	return uint64(r)
+}
@@ -1,6 +1,7 @@
 package index
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -12,13 +13,36 @@ import (
 type EventsIndex struct {
 	*Reporter
 	events storage.Events
+	// eventTypeIndex is nil if the event type index is disabled
+	eventTypeIndex storage.EventTypeIndex
 }
 
-func NewEventsIndex(reporter *Reporter, events storage.Events) *EventsIndex {
-	return &EventsIndex{
+type EventsIndexOption func(*EventsIndex)
+
+// WithEventTypeIndex is used to configure the index to look up events by event type using the
+// given event type index.
+func WithEventTypeIndex(eventTypeIndex storage.EventTypeIndex) EventsIndexOption {
+	return func(e *EventsIndex) {
+		e.eventTypeIndex = eventTypeIndex
+	}
+}
+
+func NewEventsIndex(reporter *Reporter, events storage.Events, opts ...EventsIndexOption) *EventsIndex {
+	e := &EventsIndex{
 		Reporter: reporter,
 		events:   events,
 	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// EventTypeIndexEnabled returns true if events can be looked up by event type using ByEventType.
+func (e *EventsIndex) EventTypeIndexEnabled() bool {
+	return e.eventTypeIndex != nil
 }
 
 // ByBlockID checks data availability and returns events for a block
@@ -73,3 +97,28 @@ func (e *EventsIndex) ByBlockIDTransactionIndex(blockID flow.Identifier, height
 
 	return e.events.ByBlockIDTransactionIndex(blockID, txIndex)
 }
+
+// ByEventType checks data availability and returns all events of the given type emitted in blocks within
+// the given height range (inclusive), grouped by block in ascending height order.
+// Blocks without events of the given type are omitted, and the BlockTimestamp of the returned block
+// events is not set. At most limit events are loaded.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the `EventsIndex` has not been initialized
+//   - storage.ErrHeightNotIndexed when data for any height within the range is unavailable, or the
+//     event type index is disabled
+//   - storage.ErrLimitExceeded if more than limit events of the given type are in the height range
+func (e *EventsIndex) ByEventType(eventType flow.EventType, startHeight uint64, endHeight uint64, limit uint) ([]flow.BlockEvents, error) {
+	if e.eventTypeIndex == nil {
+		return nil, fmt.Errorf("%w: event type index is disabled", storage.ErrHeightNotIndexed)
+	}
+
+	// the indexed height range is contiguous, so it's enough to check both ends of the range
+	if err := e.checkDataAvailability(startHeight); err != nil {
+		return nil, err
+	}
+	if err := e.checkDataAvailability(endHeight); err != nil {
+		return nil, err
+	}
+
+	return e.eventTypeIndex.ByEventType(eventType, startHeight, endHeight, limit)
+}
@@ -5,6 +5,7 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	"github.com/onflow/flow-go/storage"
 
@@ -22,12 +23,30 @@ var _ state_synchronization.IndexReporter = (*Reporter)(nil)
 // The caller is responsible for handling this error appropriately for the method.
 type Reporter struct {
 	reporter *atomic.Pointer[state_synchronization.IndexReporter]
+
+	// prunedHeight reports the lowest height whose block data was not pruned. nil if block data
+	// pruning is disabled.
+	prunedHeight pruner.LowestHeightReporter
 }
 
-func NewReporter() *Reporter {
-	return &Reporter{
+type ReporterOption func(*Reporter)
+
+// WithPrunedHeightReporter configures the reporter to treat heights whose block data was removed by the
+// block data pruner as not indexed, since the events and transaction results of these heights are pruned.
+func WithPrunedHeightReporter(prunedHeight pruner.LowestHeightReporter) ReporterOption {
+	return func(s *Reporter) {
+		s.prunedHeight = prunedHeight
+	}
+}
+
+func NewReporter(opts ...ReporterOption) *Reporter {
+	s := &Reporter{
 		reporter: atomic.NewPointer[state_synchronization.IndexReporter](nil),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Initialize replaces a previously non-initialized reporter. Can be called once.
@@ -39,7 +58,8 @@ func (s *Reporter) Initialize(indexReporter state_synchronization.IndexReporter)
 	return fmt.Errorf("index reporter already initialized")
 }
 
-// LowestIndexedHeight returns the lowest height indexed by the execution state indexer.
+// LowestIndexedHeight returns the lowest height indexed by the execution state indexer whose block
+// data was not pruned.
 // Expected errors:
 // - indexer.ErrIndexNotInitialized if the IndexReporter has not been initialized
 func (s *Reporter) LowestIndexedHeight() (uint64, error) {
@@ -48,7 +68,17 @@ func (s *Reporter) LowestIndexedHeight() (uint64, error) {
 		return 0, err
 	}
 
-	return reporter.LowestIndexedHeight()
+	lowestHeight, err := reporter.LowestIndexedHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.prunedHeight != nil {
+		if lowestAvailable := s.prunedHeight.LowestAvailableHeight(); lowestAvailable > lowestHeight {
+			return lowestAvailable, nil
+		}
+	}
+	return lowestHeight, nil
 }
 
 // HighestIndexedHeight returns the highest height indexed by the execution state indexer.
@@ -80,7 +110,14 @@ func (s *Reporter) checkDataAvailability(height uint64) error {
 		return fmt.Errorf("could not get highest indexed height: %w", err)
 	}
 	if height > highestHeight {
-		return fmt.Errorf("%w: block not indexed yet", storage.ErrHeightNotIndexed)
+		return fmt.Errorf("%w: block %d not indexed yet, highest indexed height is %d", storage.ErrHeightNotIndexed, height, highestHeight)
+	}
+
+	if s.prunedHeight != nil {
+		if lowestAvailable := s.prunedHeight.LowestAvailableHeight(); height < lowestAvailable {
+			return fmt.Errorf("%w: block data for height %d has been pruned, lowest available height is %d",
+				storage.ErrHeightNotIndexed, height, lowestAvailable)
+		}
 	}
 
 	lowestHeight, err := reporter.LowestIndexedHeight()
@@ -88,7 +125,7 @@ func (s *Reporter) checkDataAvailability(height uint64) error {
 		return fmt.Errorf("could not get lowest indexed height: %w", err)
 	}
 	if height < lowestHeight {
-		return fmt.Errorf("%w: block is before lowest indexed height", storage.ErrHeightNotIndexed)
+		return fmt.Errorf("%w: block %d is before lowest indexed height %d", storage.ErrHeightNotIndexed, height, lowestHeight)
 	}
 
 	return nil
@@ -0,0 +1,182 @@
+package quota
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Tier is a set of quotas shared by the clients assigned to it. Each client of a tier has its own
+// quotas, they are not shared among the clients of the tier. A value of 0 means unlimited.
+type Tier struct {
+	// RequestsPerSecond is the number of requests per second a client may send.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+
+	// Burst is the number of requests a client may send at once. If 0, it defaults to RequestsPerSecond
+	// rounded up.
+	Burst int `json:"burst"`
+
+	// MaxStreams is the number of streams a client may have open at the same time, including gRPC
+	// streams, WebSocket connections and the subscriptions of multiplexed WebSocket connections.
+	MaxStreams int `json:"max_streams"`
+
+	// ScriptComputePerMinute is the computation a client's scripts may use per minute.
+	ScriptComputePerMinute uint64 `json:"script_compute_per_minute"`
+}
+
+// Client is a client of the access API identified by API keys or the common names of its mTLS certificates.
+type Client struct {
+	// Name is the unique name of the client, used for logging and administration.
+	Name string `json:"name"`
+
+	// Tier is the name of the tier of the client.
+	Tier string `json:"tier"`
+
+	// APIKeys are the API keys of the client, sent in the x-api-key gRPC metadata or HTTP header.
+	APIKeys []string `json:"api_keys"`
+
+	// CertCommonNames are the common names of the mTLS client certificates of the client.
+	CertCommonNames []string `json:"cert_common_names"`
+}
+
+// Config is the configuration of the per-client quotas.
+type Config struct {
+	// DefaultTier is the name of the tier of anonymous clients, which are identified by their IP address.
+	DefaultTier string `json:"default_tier"`
+
+	// Tiers are the quota tiers by name.
+	Tiers map[string]Tier `json:"tiers"`
+
+	// Clients are the known clients.
+	Clients []Client `json:"clients"`
+}
+
+// LoadConfig reads the quota configuration from the JSON file at the given path.
+//
+// No errors are expected during normal operation.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read quota config: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("could not decode quota config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid quota config: %w", err)
+	}
+
+	return config, nil
+}
+
+// ClientCertificates configures the TLS config of a server to verify the certificates of clients signed
+// by the CAs in the PEM file at the given path, so that the common names of their certificates identify
+// clients. Clients without a certificate are still accepted, as anonymous clients or using API keys.
+//
+// No errors are expected during normal operation.
+func ClientCertificates(tlsConfig *tls.Config, caFile string) error {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return fmt.Errorf("could not read client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return fmt.Errorf("client CA file %s does not contain any PEM encoded certificate", caFile)
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return nil
+}
+
+// Validate checks that the tiers are valid, that the default tier and the tiers of all clients exist,
+// and that client names, API keys and certificate common names are unique.
+//
+// Expected errors during normal operation:
+//   - if the configuration is invalid
+func (c Config) Validate() error {
+	for name, tier := range c.Tiers {
+		if err := tier.Validate(); err != nil {
+			return fmt.Errorf("invalid tier %s: %w", name, err)
+		}
+	}
+
+	if _, ok := c.Tiers[c.DefaultTier]; !ok {
+		return fmt.Errorf("default tier %q does not exist", c.DefaultTier)
+	}
+
+	names := make(map[string]struct{}, len(c.Clients))
+	apiKeys := make(map[string]struct{})
+	commonNames := make(map[string]struct{})
+	for _, client := range c.Clients {
+		if err := client.validate(c.Tiers); err != nil {
+			return err
+		}
+		if _, ok := names[client.Name]; ok {
+			return fmt.Errorf("duplicate client %s", client.Name)
+		}
+		names[client.Name] = struct{}{}
+
+		for _, key := range client.APIKeys {
+			if _, ok := apiKeys[key]; ok {
+				return fmt.Errorf("API key of client %s is used by another client", client.Name)
+			}
+			apiKeys[key] = struct{}{}
+		}
+		for _, cn := range client.CertCommonNames {
+			if _, ok := commonNames[cn]; ok {
+				return fmt.Errorf("certificate common name %s of client %s is used by another client", cn, client.Name)
+			}
+			commonNames[cn] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the quotas of the tier are valid.
+//
+// Expected errors during normal operation:
+//   - if any quota is negative
+func (t Tier) Validate() error {
+	if t.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests_per_second must not be negative")
+	}
+	if t.Burst < 0 {
+		return fmt.Errorf("burst must not be negative")
+	}
+	if t.MaxStreams < 0 {
+		return fmt.Errorf("max_streams must not be negative")
+	}
+	return nil
+}
+
+// validate checks that the client is named, has a valid tier and can be identified.
+func (c Client) validate(tiers map[string]Tier) error {
+	if c.Name == "" {
+		return fmt.Errorf("client name must not be empty")
+	}
+	if _, ok := tiers[c.Tier]; !ok {
+		return fmt.Errorf("tier %q of client %s does not exist", c.Tier, c.Name)
+	}
+	if len(c.APIKeys) == 0 && len(c.CertCommonNames) == 0 {
+		return fmt.Errorf("client %s must have an API key or certificate common name", c.Name)
+	}
+	for _, key := range c.APIKeys {
+		if key == "" {
+			return fmt.Errorf("API key of client %s must not be empty", c.Name)
+		}
+	}
+	for _, cn := range c.CertCommonNames {
+		if cn == "" {
+			return fmt.Errorf("certificate common name of client %s must not be empty", c.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor enforcing the request rate quota of clients, and the
+// script computation quota for methods executing scripts.
+func (m *Manager) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		state, tier, err := m.admit(identityFromContext(ctx), isScriptMethod(info.FullMethod))
+		if err != nil {
+			return nil, convertError(err)
+		}
+
+		return handler(m.scriptContext(ctx, state, tier), req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor enforcing the request rate and stream quotas of
+// clients. Streams count against the stream quota until the handler returns.
+func (m *Manager) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		state, tier, err := m.admit(identityFromContext(stream.Context()), false)
+		if err != nil {
+			return convertError(err)
+		}
+
+		release, err := m.openStream(state, tier)
+		if err != nil {
+			return convertError(err)
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+}
+
+// identityFromContext returns the identity of the client of a gRPC request.
+func identityFromContext(ctx context.Context) Identity {
+	var id Identity
+	if values := metadata.ValueFromIncomingContext(ctx, APIKeyHeader); len(values) > 0 {
+		id.APIKey = values[0]
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return id
+	}
+	if p.Addr != nil {
+		id.Address = hostOf(p.Addr.String())
+	}
+	// only certificates verified by the server identify a client
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+		id.CommonName = tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	}
+	return id
+}
+
+// isScriptMethod returns true if the gRPC method executes scripts.
+func isScriptMethod(fullMethod string) bool {
+	return strings.Contains(fullMethod, "ExecuteScript")
+}
+
+// hostOf returns the host of the address, or the address if it has no port.
+func hostOf(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// convertError converts errors returned by the manager to gRPC status errors.
+func convertError(err error) error {
+	if errors.Is(err, ErrUnknownAPIKey) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if IsExceededError(err) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,77 @@
+package quota
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/module/execution"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// TestServerInterceptors tests that the gRPC interceptors identify clients using the request metadata and
+// peer, and reject requests exceeding the quotas of the client with the corresponding status codes.
+func TestServerInterceptors(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	require.NoError(t, m.SetTier("anonymous", Tier{MaxStreams: 1, ScriptComputePerMinute: 100}))
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	scriptInfo := &grpc.UnaryServerInfo{FullMethod: "/flow.access.AccessAPI/ExecuteScriptAtLatestBlock"}
+	unary := m.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		execution.ReportComputation(ctx, 100)
+		return req, nil
+	}
+
+	t.Run("unknown API key", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyHeader, "unknown"))
+		_, err := unary(ctx, nil, scriptInfo, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("script computation", func(t *testing.T) {
+		_, err := unary(ctx, nil, scriptInfo, handler)
+		require.NoError(t, err)
+		_, err = unary(ctx, nil, scriptInfo, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// clients with an API key have their own quotas
+		keyCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyHeader, "acme-key"))
+		_, err = unary(keyCtx, nil, scriptInfo, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("streams", func(t *testing.T) {
+		stream := m.StreamServerInterceptor()
+		info := &grpc.StreamServerInfo{FullMethod: "/flow.executiondata.ExecutionDataAPI/SubscribeEvents"}
+
+		err := stream(nil, &testServerStream{ctx: ctx}, info, func(srv any, s grpc.ServerStream) error {
+			// a second stream of the client is rejected while the first one is open
+			err := stream(nil, s, info, func(any, grpc.ServerStream) error { return nil })
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = stream(nil, &testServerStream{ctx: ctx}, info, func(any, grpc.ServerStream) error { return nil })
+		assert.NoError(t, err)
+	})
+}
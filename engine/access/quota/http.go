@@ -0,0 +1,86 @@
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+)
+
+// Middleware returns an HTTP middleware enforcing the request rate quota of clients, the script
+// computation quota for script requests, and the stream quota for WebSocket connections. WebSocket
+// connections count against the stream quota until the handler returns, except for multiplexed
+// WebSocket connections, whose subscriptions each count against the stream quota using OpenStream.
+func (m *Manager) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state, tier, err := m.admit(identityFromRequest(r), isScriptRequest(r))
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+
+			ctx := m.scriptContext(r.Context(), state, tier)
+			if isMultiplexedWebSocketRequest(r) {
+				ctx = m.withStreamQuota(ctx, state)
+			} else if isWebSocketRequest(r) {
+				release, err := m.openStream(state, tier)
+				if err != nil {
+					writeError(w, err)
+					return
+				}
+				defer release()
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// identityFromRequest returns the identity of the client of an HTTP request.
+func identityFromRequest(r *http.Request) Identity {
+	id := Identity{
+		APIKey:  r.Header.Get(APIKeyHeader),
+		Address: hostOf(r.RemoteAddr),
+	}
+	// only certificates verified by the server identify a client
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		id.CommonName = r.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+	return id
+}
+
+// isScriptRequest returns true if the request executes scripts.
+func isScriptRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/v1/scripts")
+}
+
+// isWebSocketRequest returns true if the request upgrades the connection to a WebSocket connection.
+func isWebSocketRequest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// isMultiplexedWebSocketRequest returns true if the request upgrades the connection to a multiplexed
+// WebSocket connection, which can serve multiple subscriptions.
+func isMultiplexedWebSocketRequest(r *http.Request) bool {
+	return isWebSocketRequest(r) && strings.TrimSuffix(r.URL.Path, "/") == "/v1/ws"
+}
+
+// writeError writes the error returned by the manager as a REST API error response.
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, ErrUnknownAPIKey) {
+		code = http.StatusUnauthorized
+	} else if IsExceededError(err) {
+		code = http.StatusTooManyRequests
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(models.ModelError{
+		Code:    int32(code),
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,85 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/execution"
+)
+
+// TestMiddleware tests that the HTTP middleware rejects requests exceeding the quotas of the client with
+// the corresponding status codes, and accounts for the computation of scripts.
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	require.NoError(t, m.SetTier("anonymous", Tier{MaxStreams: 1, ScriptComputePerMinute: 100}))
+
+	blockStream := make(chan struct{})
+	streamStarted := make(chan struct{})
+	handler := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isMultiplexedWebSocketRequest(r) {
+			// each subscription of a multiplexed connection counts as a stream
+			release, err := OpenStream(r.Context())
+			require.NoError(t, err)
+			_, err = OpenStream(r.Context())
+			assert.True(t, IsExceededError(err))
+			release()
+		} else if isWebSocketRequest(r) {
+			close(streamStarted)
+			<-blockStream
+		}
+		execution.ReportComputation(r.Context(), 100)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(req *http.Request) int {
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("unknown API key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/blocks", nil)
+		req.Header.Set(APIKeyHeader, "unknown")
+		assert.Equal(t, http.StatusUnauthorized, serve(req))
+	})
+
+	t.Run("script computation", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodPost, "/v1/scripts", nil)))
+		assert.Equal(t, http.StatusTooManyRequests, serve(httptest.NewRequest(http.MethodPost, "/v1/scripts", nil)))
+		assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, "/v1/blocks", nil)))
+	})
+
+	t.Run("websocket streams", func(t *testing.T) {
+		wsRequest := func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/v1/subscribe_events", nil)
+			req.Header.Set("Upgrade", "websocket")
+			return req
+		}
+
+		done := make(chan int)
+		go func() {
+			done <- serve(wsRequest())
+		}()
+		<-streamStarted
+
+		assert.Equal(t, http.StatusTooManyRequests, serve(wsRequest()))
+
+		close(blockStream)
+		assert.Equal(t, http.StatusOK, <-done)
+	})
+	t.Run("multiplexed websocket subscriptions", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+		req.Header.Set("Upgrade", "websocket")
+		req.RemoteAddr = "10.0.0.2:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,577 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/execution"
+)
+
+const (
+	// APIKeyHeader is the gRPC metadata key and HTTP header used by clients to send their API key.
+	APIKeyHeader = "x-api-key"
+
+	// maxAnonymousClients is the number of anonymous clients for which quota usage is tracked separately.
+	// Anonymous clients are only removed once they are idle, so that removing them doesn't reset quota
+	// usage. While the maximum number of clients are active, new anonymous clients share their quotas.
+	maxAnonymousClients = 10_000
+
+	// anonymousSweepInterval is the minimum interval between sweeps for idle anonymous clients.
+	anonymousSweepInterval = time.Second
+
+	// computeWindow is the window over which the script computation of a client is limited.
+	computeWindow = time.Minute
+)
+
+// Names of the quotas, used in errors and metrics.
+const (
+	QuotaRequests      = "requests"
+	QuotaStreams       = "streams"
+	QuotaScriptCompute = "script_compute"
+)
+
+// ErrUnknownAPIKey is returned for requests with an API key which does not belong to any client.
+var ErrUnknownAPIKey = errors.New("unknown API key")
+
+// ExceededError is returned for requests rejected because a quota of the client was exceeded.
+type ExceededError struct {
+	Client string
+	Tier   string
+	Quota  string
+}
+
+func (e ExceededError) Error() string {
+	return fmt.Sprintf("%s quota of client %s (tier %s) exceeded", e.Quota, e.Client, e.Tier)
+}
+
+// IsExceededError returns true if the error is an ExceededError.
+func IsExceededError(err error) bool {
+	var exceededErr ExceededError
+	return errors.As(err, &exceededErr)
+}
+
+// Identity is the information identifying the client sending a request.
+type Identity struct {
+	// APIKey is the API key sent by the client, if any.
+	APIKey string
+
+	// CommonName is the common name of the verified mTLS certificate of the client, if any.
+	CommonName string
+
+	// Address is the IP address of the client, used to identify anonymous clients.
+	Address string
+}
+
+// Manager enforces the per-client quotas of the access API.
+//
+// Clients are identified by their API key, or by the common name of their mTLS certificate. Requests
+// with an unknown API key are rejected, while other unidentified clients are anonymous clients of the
+// default tier, identified by their IP address. Certificates only identify clients if the server
+// verifies client certificates, see ClientCertificates.
+//
+// Quotas of a client are tracked for the client, not per API key or certificate. Quotas can be updated
+// at runtime, which applies them to new requests and streams.
+//
+// Safe for concurrent use.
+type Manager struct {
+	log     zerolog.Logger
+	metrics module.ClientQuotaMetrics
+	now     func() time.Time
+
+	mu          sync.RWMutex
+	defaultTier string
+	tiers       map[string]Tier
+	clients     map[string]*clientState // by name
+	apiKeys     map[string]*clientState
+	commonNames map[string]*clientState
+
+	// anonymous clients are tracked separately from the configured clients, so that adding anonymous
+	// clients doesn't block requests of other clients. The manager's lock must be acquired first.
+	anonMu    sync.RWMutex
+	anonymous map[string]*clientState // by address
+	overflow  *clientState            // shared by new anonymous clients while tracking the maximum number
+	lastSweep time.Time
+}
+
+// clientState is the quota usage of a client. The name, tier and configuration of a client are immutable,
+// updating a client replaces its state.
+type clientState struct {
+	config  Client
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	streams     int
+	windowStart time.Time
+	computeUsed uint64
+}
+
+// NewManager creates a new quota manager for the given configuration.
+//
+// No errors are expected during normal operation.
+func NewManager(log zerolog.Logger, config Config, metrics module.ClientQuotaMetrics) (*Manager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quota config: %w", err)
+	}
+
+	m := &Manager{
+		log:         log.With().Str("component", "client_quotas").Logger(),
+		metrics:     metrics,
+		now:         time.Now,
+		defaultTier: config.DefaultTier,
+		tiers:       make(map[string]Tier, len(config.Tiers)),
+		clients:     make(map[string]*clientState, len(config.Clients)),
+		apiKeys:     make(map[string]*clientState),
+		commonNames: make(map[string]*clientState),
+		anonymous:   make(map[string]*clientState),
+		overflow: newClientState(
+			Client{Name: "anonymous:overflow", Tier: config.DefaultTier},
+			config.Tiers[config.DefaultTier],
+		),
+	}
+	for name, tier := range config.Tiers {
+		m.tiers[name] = tier
+	}
+	for _, client := range config.Clients {
+		m.addClient(client)
+	}
+
+	return m, nil
+}
+
+// Config returns the current configuration of the quotas, with clients sorted by name.
+func (m *Manager) Config() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	config := Config{
+		DefaultTier: m.defaultTier,
+		Tiers:       make(map[string]Tier, len(m.tiers)),
+		Clients:     make([]Client, 0, len(m.clients)),
+	}
+	for name, tier := range m.tiers {
+		config.Tiers[name] = tier
+	}
+	for _, state := range m.clients {
+		config.Clients = append(config.Clients, state.config)
+	}
+	sort.Slice(config.Clients, func(i, j int) bool {
+		return config.Clients[i].Name < config.Clients[j].Name
+	})
+
+	return config
+}
+
+// SetTier creates or updates the tier with the given name. The request rate of clients of the tier is
+// updated immediately, other quotas apply to new requests and streams.
+//
+// Expected errors during normal operation:
+//   - if the tier is invalid
+func (m *Manager) SetTier(name string, tier Tier) error {
+	if name == "" {
+		return fmt.Errorf("tier name must not be empty")
+	}
+	if err := tier.Validate(); err != nil {
+		return fmt.Errorf("invalid tier %s: %w", name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tiers[name] = tier
+
+	limit, burst := tierRate(tier)
+	for _, state := range m.clients {
+		if state.config.Tier == name {
+			state.limiter.SetLimit(limit)
+			state.limiter.SetBurst(burst)
+		}
+	}
+	if name == m.defaultTier {
+		m.anonMu.RLock()
+		for _, state := range m.anonymous {
+			state.limiter.SetLimit(limit)
+			state.limiter.SetBurst(burst)
+		}
+		m.anonMu.RUnlock()
+		m.overflow.limiter.SetLimit(limit)
+		m.overflow.limiter.SetBurst(burst)
+	}
+
+	m.log.Info().Str("tier", name).Interface("quotas", tier).Msg("quota tier updated")
+
+	return nil
+}
+
+// SetClient creates or replaces the client with the given name. The quota usage of a replaced client is
+// reset, but its open streams are still released.
+//
+// Expected errors during normal operation:
+//   - if the client is invalid, or any of its API keys or certificate common names belongs to another client
+func (m *Manager) SetClient(client Client) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := client.validate(m.tiers); err != nil {
+		return err
+	}
+	for _, key := range client.APIKeys {
+		if state, ok := m.apiKeys[key]; ok && state.config.Name != client.Name {
+			return fmt.Errorf("API key of client %s is used by client %s", client.Name, state.config.Name)
+		}
+	}
+	for _, cn := range client.CertCommonNames {
+		if state, ok := m.commonNames[cn]; ok && state.config.Name != client.Name {
+			return fmt.Errorf("certificate common name %s of client %s is used by client %s", cn, client.Name, state.config.Name)
+		}
+	}
+
+	m.removeClient(client.Name)
+	m.addClient(client)
+
+	m.log.Info().Str("client", client.Name).Str("tier", client.Tier).Msg("quota client updated")
+
+	return nil
+}
+
+// RemoveClient removes the client with the given name. Requests using its API keys are rejected, and
+// requests using its certificates are treated as anonymous afterwards.
+//
+// Expected errors during normal operation:
+//   - if the client does not exist
+func (m *Manager) RemoveClient(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.clients[name]; !ok {
+		return fmt.Errorf("client %s does not exist", name)
+	}
+	m.removeClient(name)
+
+	m.log.Info().Str("client", name).Msg("quota client removed")
+
+	return nil
+}
+
+// addClient adds the client, which must not exist.
+// Caller must hold the write lock.
+func (m *Manager) addClient(client Client) {
+	state := newClientState(client, m.tiers[client.Tier])
+	m.clients[client.Name] = state
+	for _, key := range client.APIKeys {
+		m.apiKeys[key] = state
+	}
+	for _, cn := range client.CertCommonNames {
+		m.commonNames[cn] = state
+	}
+}
+
+// removeClient removes the client with the given name, if it exists.
+// Caller must hold the write lock.
+func (m *Manager) removeClient(name string) {
+	state, ok := m.clients[name]
+	if !ok {
+		return
+	}
+	delete(m.clients, name)
+	for _, key := range state.config.APIKeys {
+		delete(m.apiKeys, key)
+	}
+	for _, cn := range state.config.CertCommonNames {
+		delete(m.commonNames, cn)
+	}
+}
+
+// client returns the state and tier of the client with the given identity.
+//
+// Expected errors during normal operation:
+//   - ErrUnknownAPIKey if the identity has an API key which does not belong to any client
+func (m *Manager) client(id Identity) (*clientState, Tier, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, err := m.knownClient(id)
+	if err != nil {
+		return nil, Tier{}, err
+	}
+	if state != nil {
+		return state, m.tiers[state.tierName()], nil
+	}
+
+	tier := m.tiers[m.defaultTier]
+	return m.anonymousClient(id.Address, tier), tier, nil
+}
+
+// anonymousClient returns the state of the anonymous client with the given address, which is added if
+// it isn't tracked yet. If the maximum number of anonymous clients are tracked and none of them is idle,
+// the state shared by new anonymous clients is returned.
+// Caller must hold the read lock.
+func (m *Manager) anonymousClient(address string, tier Tier) *clientState {
+	m.anonMu.RLock()
+	state, ok := m.anonymous[address]
+	m.anonMu.RUnlock()
+	if ok {
+		return state
+	}
+
+	m.anonMu.Lock()
+	defer m.anonMu.Unlock()
+
+	if state, ok := m.anonymous[address]; ok {
+		return state
+	}
+	if len(m.anonymous) >= maxAnonymousClients {
+		m.removeIdleAnonymous(tier)
+		if len(m.anonymous) >= maxAnonymousClients {
+			return m.overflow
+		}
+	}
+
+	state = newClientState(Client{Name: "anonymous:" + address, Tier: m.defaultTier}, tier)
+	m.anonymous[address] = state
+	return state
+}
+
+// removeIdleAnonymous removes the anonymous clients which are idle, unless a sweep was done recently.
+// Caller must hold the anonymous clients write lock.
+func (m *Manager) removeIdleAnonymous(tier Tier) {
+	now := m.now()
+	if now.Sub(m.lastSweep) < anonymousSweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for address, state := range m.anonymous {
+		if state.idle(tier, now) {
+			delete(m.anonymous, address)
+		}
+	}
+}
+
+// knownClient returns the state of the configured client with the given identity, or nil if the client
+// is anonymous.
+// Caller must hold the read lock.
+//
+// Expected errors during normal operation:
+//   - ErrUnknownAPIKey if the identity has an API key which does not belong to any client
+func (m *Manager) knownClient(id Identity) (*clientState, error) {
+	if id.APIKey != "" {
+		state, ok := m.apiKeys[id.APIKey]
+		if !ok {
+			return nil, ErrUnknownAPIKey
+		}
+		return state, nil
+	}
+	if id.CommonName != "" {
+		if state, ok := m.commonNames[id.CommonName]; ok {
+			return state, nil
+		}
+	}
+	return nil, nil
+}
+
+// admit checks the request rate quota of the client with the given identity, and if script is set, its
+// script computation quota. It returns the state of the client, which is used to account for streams and
+// script computation of the request.
+//
+// Expected errors during normal operation:
+//   - ErrUnknownAPIKey if the identity has an API key which does not belong to any client
+//   - ExceededError if a quota of the client is exceeded
+func (m *Manager) admit(id Identity, script bool) (*clientState, Tier, error) {
+	state, tier, err := m.client(id)
+	if err != nil {
+		return nil, Tier{}, err
+	}
+
+	if !state.limiter.Allow() {
+		return nil, Tier{}, m.rejected(state, QuotaRequests)
+	}
+	if script && !state.computeAvailable(tier, m.now()) {
+		return nil, Tier{}, m.rejected(state, QuotaScriptCompute)
+	}
+
+	m.metrics.QuotaRequestAllowed(state.tierName())
+	return state, tier, nil
+}
+
+// openStream counts a stream opened by the client against its stream quota. The returned function must
+// be called once the stream is closed.
+//
+// Expected errors during normal operation:
+//   - ExceededError if the client has the maximum number of streams open
+func (m *Manager) openStream(state *clientState, tier Tier) (func(), error) {
+	state.mu.Lock()
+	if tier.MaxStreams > 0 && state.streams >= tier.MaxStreams {
+		state.mu.Unlock()
+		return nil, m.rejected(state, QuotaStreams)
+	}
+	state.streams++
+	state.mu.Unlock()
+
+	m.metrics.QuotaStreamsChanged(state.tierName(), 1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			state.mu.Lock()
+			state.streams--
+			state.mu.Unlock()
+
+			m.metrics.QuotaStreamsChanged(state.tierName(), -1)
+		})
+	}, nil
+}
+
+type streamQuotaKey struct{}
+
+// streamQuota is the client whose stream quota streams opened with OpenStream count against.
+type streamQuota struct {
+	manager *Manager
+	state   *clientState
+}
+
+// withStreamQuota returns a copy of the context in which streams opened with OpenStream count against the
+// stream quota of the client.
+func (m *Manager) withStreamQuota(ctx context.Context, state *clientState) context.Context {
+	return context.WithValue(ctx, streamQuotaKey{}, streamQuota{manager: m, state: state})
+}
+
+// OpenStream counts a stream opened while serving the request of the context against the stream quota
+// of the client of the request. This allows handlers serving multiple streams for a single request, like
+// the subscriptions of a multiplexed WebSocket connection, to count each of them. If the quotas are not
+// enforced for the request, the stream isn't counted. The returned function must be called once the
+// stream is closed.
+//
+// Expected errors during normal operation:
+//   - ExceededError if the client has the maximum number of streams open
+func OpenStream(ctx context.Context) (func(), error) {
+	quota, ok := ctx.Value(streamQuotaKey{}).(streamQuota)
+	if !ok {
+		return func() {}, nil
+	}
+
+	quota.manager.mu.RLock()
+	tier := quota.manager.tiers[quota.state.tierName()]
+	quota.manager.mu.RUnlock()
+
+	return quota.manager.openStream(quota.state, tier)
+}
+
+// computationReporter returns a reporter which accounts the computation used by scripts of a request of
+// the client against its script computation quota.
+func (m *Manager) computationReporter(state *clientState) func(uint64) {
+	return func(computationUsed uint64) {
+		state.addCompute(computationUsed, m.now())
+		m.metrics.QuotaScriptComputationUsed(state.tierName(), computationUsed)
+	}
+}
+
+// scriptContext returns a copy of the context of a request of the client, which accounts the computation
+// used by the scripts of the request against the script computation quota of the client, and limits the
+// computation of scripts executed locally to the computation remaining in the current window.
+// Scripts executed on execution nodes can't be limited, so they are only accounted for once executed.
+func (m *Manager) scriptContext(ctx context.Context, state *clientState, tier Tier) context.Context {
+	ctx = execution.WithComputationReporter(ctx, m.computationReporter(state))
+	if tier.ScriptComputePerMinute > 0 {
+		if remaining := state.computeRemaining(tier, m.now()); remaining > 0 {
+			ctx = query.WithComputationLimit(ctx, remaining)
+		}
+	}
+	return ctx
+}
+
+// rejected records the rejection of a request of the client and returns the corresponding error.
+func (m *Manager) rejected(state *clientState, quota string) error {
+	m.metrics.QuotaRequestRejected(state.tierName(), quota)
+	return ExceededError{
+		Client: state.config.Name,
+		Tier:   state.tierName(),
+		Quota:  quota,
+	}
+}
+
+func newClientState(client Client, tier Tier) *clientState {
+	limit, burst := tierRate(tier)
+	return &clientState{
+		config:  client,
+		limiter: rate.NewLimiter(limit, burst),
+	}
+}
+
+func (s *clientState) tierName() string {
+	return s.config.Tier
+}
+
+// computeAvailable returns true if the client has not used its script computation of the current window.
+func (s *clientState) computeAvailable(tier Tier, now time.Time) bool {
+	if tier.ScriptComputePerMinute == 0 {
+		return true
+	}
+	return s.computeRemaining(tier, now) > 0
+}
+
+// computeRemaining returns the script computation the client may still use in the current window.
+// Must only be called for tiers with a script computation quota.
+func (s *clientState) computeRemaining(tier Tier, now time.Time) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advanceWindow(now)
+	if s.computeUsed >= tier.ScriptComputePerMinute {
+		return 0
+	}
+	return tier.ScriptComputePerMinute - s.computeUsed
+}
+
+// idle returns true if the client has no open streams and its quota usage is fully replenished, so that
+// removing its state doesn't reset any quota usage.
+func (s *clientState) idle(tier Tier, now time.Time) bool {
+	_, burst := tierRate(tier)
+	if s.limiter.TokensAt(now) < float64(burst) {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.streams == 0 && (s.computeUsed == 0 || now.Sub(s.windowStart) >= computeWindow)
+}
+
+// addCompute adds the computation to the computation used in the current window.
+func (s *clientState) addCompute(computationUsed uint64, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advanceWindow(now)
+	s.computeUsed += computationUsed
+}
+
+// advanceWindow starts a new computation window if the current window has ended.
+// Caller must hold the lock.
+func (s *clientState) advanceWindow(now time.Time) {
+	if now.Sub(s.windowStart) >= computeWindow {
+		s.windowStart = now
+		s.computeUsed = 0
+	}
+}
+
+// tierRate returns the rate limit and burst of the tier.
+func tierRate(tier Tier) (rate.Limit, int) {
+	if tier.RequestsPerSecond == 0 {
+		return rate.Inf, 0
+	}
+	burst := tier.Burst
+	if burst == 0 {
+		burst = int(math.Max(1, math.Ceil(tier.RequestsPerSecond)))
+	}
+	return rate.Limit(tier.RequestsPerSecond), burst
+}
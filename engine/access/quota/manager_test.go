@@ -0,0 +1,387 @@
+package quota
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func testConfig() Config {
+	return Config{
+		DefaultTier: "anonymous",
+		Tiers: map[string]Tier{
+			"anonymous": {RequestsPerSecond: 1, Burst: 2, MaxStreams: 1, ScriptComputePerMinute: 100},
+			"partner":   {},
+		},
+		Clients: []Client{
+			{Name: "acme", Tier: "partner", APIKeys: []string{"acme-key"}, CertCommonNames: []string{"acme.example.com"}},
+		},
+	}
+}
+
+func newTestManager(t *testing.T) *Manager {
+	m, err := NewManager(unittest.Logger(), testConfig(), metrics.NewNoopCollector())
+	require.NoError(t, err)
+	return m
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		update func(*Config)
+		err    bool
+	}{
+		{
+			name:   "valid",
+			update: func(*Config) {},
+		},
+		{
+			name:   "missing default tier",
+			update: func(c *Config) { c.DefaultTier = "missing" },
+			err:    true,
+		},
+		{
+			name:   "negative quota",
+			update: func(c *Config) { c.Tiers["partner"] = Tier{MaxStreams: -1} },
+			err:    true,
+		},
+		{
+			name:   "unknown client tier",
+			update: func(c *Config) { c.Clients[0].Tier = "missing" },
+			err:    true,
+		},
+		{
+			name:   "client without identity",
+			update: func(c *Config) { c.Clients[0].APIKeys, c.Clients[0].CertCommonNames = nil, nil },
+			err:    true,
+		},
+		{
+			name: "duplicate API key",
+			update: func(c *Config) {
+				c.Clients = append(c.Clients, Client{Name: "other", Tier: "partner", APIKeys: []string{"acme-key"}})
+			},
+			err: true,
+		},
+		{
+			name: "duplicate client",
+			update: func(c *Config) {
+				c.Clients = append(c.Clients, Client{Name: "acme", Tier: "partner", APIKeys: []string{"other-key"}})
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			test.update(&config)
+			if test.err {
+				assert.Error(t, config.Validate())
+			} else {
+				assert.NoError(t, config.Validate())
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "quotas.json")
+	err := os.WriteFile(path, []byte(`{
+		"default_tier": "anonymous",
+		"tiers": {
+			"anonymous": {"requests_per_second": 10, "max_streams": 2},
+			"partner": {"requests_per_second": 100, "burst": 200, "script_compute_per_minute": 1000000}
+		},
+		"clients": [{"name": "acme", "tier": "partner", "api_keys": ["acme-key"]}]
+	}`), 0600)
+	require.NoError(t, err)
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", config.DefaultTier)
+	assert.Equal(t, Tier{RequestsPerSecond: 100, Burst: 200, ScriptComputePerMinute: 1000000}, config.Tiers["partner"])
+	assert.Equal(t, []Client{{Name: "acme", Tier: "partner", APIKeys: []string{"acme-key"}}}, config.Clients)
+
+	err = os.WriteFile(path, []byte(`{"default_tier": "missing"}`), 0600)
+	require.NoError(t, err)
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+}
+
+// TestClientIdentification tests that clients are identified by API key, then certificate common name,
+// and are otherwise anonymous clients identified by address.
+func TestClientIdentification(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+
+	state, _, err := m.client(Identity{APIKey: "acme-key", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", state.config.Name)
+
+	state, _, err = m.client(Identity{CommonName: "acme.example.com", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", state.config.Name)
+
+	_, _, err = m.client(Identity{APIKey: "unknown", CommonName: "acme.example.com"})
+	assert.ErrorIs(t, err, ErrUnknownAPIKey)
+
+	anonymous, tier, err := m.client(Identity{CommonName: "unknown.example.com", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", anonymous.tierName())
+	assert.Equal(t, 1, tier.MaxStreams)
+
+	// anonymous clients with the same address share their quotas
+	other, _, err := m.client(Identity{Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Same(t, anonymous, other)
+
+	other, _, err = m.client(Identity{Address: "10.0.0.2"})
+	require.NoError(t, err)
+	assert.NotSame(t, anonymous, other)
+}
+
+func TestRequestQuota(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	anonymous := Identity{Address: "10.0.0.1"}
+
+	// the burst of the tier is allowed, then requests are rejected
+	for i := 0; i < 2; i++ {
+		_, _, err := m.admit(anonymous, false)
+		require.NoError(t, err)
+	}
+	_, _, err := m.admit(anonymous, false)
+	assert.True(t, IsExceededError(err))
+	assert.ErrorIs(t, err, ExceededError{Client: "anonymous:10.0.0.1", Tier: "anonymous", Quota: QuotaRequests})
+
+	// other clients have their own quotas
+	_, _, err = m.admit(Identity{Address: "10.0.0.2"}, false)
+	assert.NoError(t, err)
+
+	// clients of unlimited tiers are never rejected
+	for i := 0; i < 100; i++ {
+		_, _, err := m.admit(Identity{APIKey: "acme-key"}, false)
+		require.NoError(t, err)
+	}
+
+	// updating the tier applies to existing clients
+	require.NoError(t, m.SetTier("anonymous", Tier{}))
+	_, _, err = m.admit(anonymous, false)
+	assert.NoError(t, err)
+}
+
+func TestStreamQuota(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+
+	state, tier, err := m.admit(Identity{Address: "10.0.0.1"}, false)
+	require.NoError(t, err)
+
+	release, err := m.openStream(state, tier)
+	require.NoError(t, err)
+
+	_, err = m.openStream(state, tier)
+	assert.ErrorIs(t, err, ExceededError{Client: "anonymous:10.0.0.1", Tier: "anonymous", Quota: QuotaStreams})
+
+	// releasing more than once only releases the stream once
+	release()
+	release()
+
+	release, err = m.openStream(state, tier)
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 0, state.streams)
+}
+
+// TestOpenStream tests that streams opened for a request count against the stream quota of its client,
+// and are not counted for requests without quotas.
+func TestOpenStream(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+
+	release, err := OpenStream(context.Background())
+	require.NoError(t, err)
+	release()
+
+	state, _, err := m.admit(Identity{Address: "10.0.0.1"}, false)
+	require.NoError(t, err)
+	ctx := m.withStreamQuota(context.Background(), state)
+
+	release, err = OpenStream(ctx)
+	require.NoError(t, err)
+	_, err = OpenStream(ctx)
+	assert.ErrorIs(t, err, ExceededError{Client: "anonymous:10.0.0.1", Tier: "anonymous", Quota: QuotaStreams})
+
+	// the current tier of the client applies
+	require.NoError(t, m.SetTier("anonymous", Tier{MaxStreams: 2}))
+	release2, err := OpenStream(ctx)
+	require.NoError(t, err)
+
+	release()
+	release2()
+	assert.Equal(t, 0, state.streams)
+}
+
+// TestAnonymousClients tests that anonymous clients are only removed once idle, so that their quota usage
+// isn't reset, and that new anonymous clients share their quotas while no tracked client is idle.
+func TestAnonymousClients(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	for i := 0; i < maxAnonymousClients; i++ {
+		_, _, err := m.admit(Identity{Address: fmt.Sprintf("address-%d", i)}, false)
+		require.NoError(t, err)
+	}
+
+	// all clients have used their request quota, so none of them is removed
+	state, _, err := m.client(Identity{Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Same(t, m.overflow, state)
+	assert.Len(t, m.anonymous, maxAnonymousClients)
+
+	// clients whose quotas are replenished are removed
+	now = now.Add(time.Minute)
+	state, _, err = m.client(Identity{Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.NotSame(t, m.overflow, state)
+	assert.Len(t, m.anonymous, 1)
+}
+
+// TestScriptContext tests that the computation of scripts is accounted against the script computation
+// quota of the client.
+func TestScriptContext(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	state, tier, err := m.admit(Identity{Address: "10.0.0.1"}, true)
+	require.NoError(t, err)
+
+	execution.ReportComputation(m.scriptContext(context.Background(), state, tier), 40)
+	assert.Equal(t, uint64(60), state.computeRemaining(tier, m.now()))
+}
+
+func TestClientCertificates(t *testing.T) {
+	t.Parallel()
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		tlsConfig := &tls.Config{}
+
+		invalid := filepath.Join(dir, "invalid.pem")
+		require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0644))
+		assert.Error(t, ClientCertificates(tlsConfig, invalid))
+		assert.Error(t, ClientCertificates(tlsConfig, filepath.Join(dir, "missing.pem")))
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "test CA"},
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+
+		caFile := filepath.Join(dir, "ca.pem")
+		require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+		require.NoError(t, ClientCertificates(tlsConfig, caFile))
+		assert.Equal(t, tls.VerifyClientCertIfGiven, tlsConfig.ClientAuth)
+		assert.NotNil(t, tlsConfig.ClientCAs)
+	})
+}
+
+func TestScriptComputeQuota(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	require.NoError(t, m.SetTier("anonymous", Tier{ScriptComputePerMinute: 100}))
+	anonymous := Identity{Address: "10.0.0.1"}
+
+	state, _, err := m.admit(anonymous, true)
+	require.NoError(t, err)
+	report := m.computationReporter(state)
+	report(60)
+
+	// the budget is checked before scripts are executed, so the last script may exceed it
+	_, _, err = m.admit(anonymous, true)
+	require.NoError(t, err)
+	report(60)
+
+	_, _, err = m.admit(anonymous, true)
+	assert.ErrorIs(t, err, ExceededError{Client: "anonymous:10.0.0.1", Tier: "anonymous", Quota: QuotaScriptCompute})
+
+	// requests which do not execute scripts are still allowed
+	_, _, err = m.admit(anonymous, false)
+	assert.NoError(t, err)
+
+	// the budget is reset after the window
+	now = now.Add(computeWindow)
+	_, _, err = m.admit(anonymous, true)
+	assert.NoError(t, err)
+}
+
+func TestUpdateClients(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t)
+
+	err := m.SetClient(Client{Name: "other", Tier: "partner", APIKeys: []string{"acme-key"}})
+	assert.Error(t, err, "API key of another client")
+
+	err = m.SetClient(Client{Name: "other", Tier: "missing", APIKeys: []string{"other-key"}})
+	assert.Error(t, err, "unknown tier")
+
+	// replacing a client replaces its API keys
+	err = m.SetClient(Client{Name: "acme", Tier: "anonymous", APIKeys: []string{"new-key"}})
+	require.NoError(t, err)
+
+	_, _, err = m.client(Identity{APIKey: "acme-key"})
+	assert.ErrorIs(t, err, ErrUnknownAPIKey)
+	state, _, err := m.client(Identity{APIKey: "new-key"})
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", state.tierName())
+
+	err = m.SetTier("enterprise", Tier{RequestsPerSecond: 1000})
+	require.NoError(t, err)
+	err = m.SetClient(Client{Name: "other", Tier: "enterprise", CertCommonNames: []string{"other.example.com"}})
+	require.NoError(t, err)
+
+	require.NoError(t, m.RemoveClient("acme"))
+	assert.Error(t, m.RemoveClient("acme"))
+
+	config := m.Config()
+	assert.Equal(t, []Client{{Name: "other", Tier: "enterprise", CertCommonNames: []string{"other.example.com"}}}, config.Clients)
+	assert.Equal(t, Tier{RequestsPerSecond: 1000}, config.Tiers["enterprise"])
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/access"
+	accessapiproxy "github.com/onflow/flow-go/engine/access/apiproxy"
 	"github.com/onflow/flow-go/engine/access/rpc/connection"
 	"github.com/onflow/flow-go/engine/common/grpc/forwarder"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
@@ -27,9 +28,14 @@ type RestProxyHandler struct {
 	Logger  zerolog.Logger
 	Metrics metrics.ObserverMetrics
 	Chain   flow.Chain
+
+	// TxRelay resubmits the transactions which are not included in a collection in time.
+	// If nil, transactions are forwarded without resubmission.
+	TxRelay *accessapiproxy.TransactionRelay
 }
 
 // NewRestProxyHandler returns a new rest proxy handler for observer node.
+// If txRelay is nil, transactions are forwarded to an upstream without resubmission.
 func NewRestProxyHandler(
 	api access.API,
 	identities flow.IdentitySkeletonList,
@@ -37,6 +43,7 @@ func NewRestProxyHandler(
 	log zerolog.Logger,
 	metrics metrics.ObserverMetrics,
 	chain flow.Chain,
+	txRelay *accessapiproxy.TransactionRelay,
 ) (*RestProxyHandler, error) {
 	forwarder, err := forwarder.NewForwarder(
 		identities,
@@ -50,6 +57,7 @@ func NewRestProxyHandler(
 		Logger:  log,
 		Metrics: metrics,
 		Chain:   chain,
+		TxRelay: txRelay,
 	}
 
 	restProxyHandler.API = api
@@ -105,17 +113,23 @@ func (r *RestProxyHandler) GetCollectionByID(ctx context.Context, id flow.Identi
 
 // SendTransaction sends already created transaction.
 func (r *RestProxyHandler) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
+	transaction := convert.TransactionToMessage(*tx)
+	sendTransactionRequest := &accessproto.SendTransactionRequest{
+		Transaction: transaction,
+	}
+
+	if r.TxRelay != nil {
+		_, err := r.TxRelay.SendTransaction(ctx, sendTransactionRequest)
+		r.log("upstream", "SendTransaction", err)
+		return err
+	}
+
 	upstream, closer, err := r.FaultTolerantClient()
 	if err != nil {
 		return err
 	}
 	defer closer.Close()
 
-	transaction := convert.TransactionToMessage(*tx)
-	sendTransactionRequest := &accessproto.SendTransactionRequest{
-		Transaction: transaction,
-	}
-
 	_, err = upstream.SendTransaction(ctx, sendTransactionRequest)
 	r.log("upstream", "SendTransaction", err)
 
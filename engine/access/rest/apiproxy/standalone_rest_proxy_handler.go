@@ -0,0 +1,47 @@
+package apiproxy
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	accessapiproxy "github.com/onflow/flow-go/engine/access/apiproxy"
+	"github.com/onflow/flow-go/engine/access/rpc/connection"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// StandaloneRestProxyHandler is the REST handler of observer nodes running in standalone mode.
+// It serves all requests using the local backend, and only forwards transactions to an upstream using gRPC API.
+type StandaloneRestProxyHandler struct {
+	access.API
+	proxy *RestProxyHandler
+}
+
+// NewStandaloneRestProxyHandler returns a new rest proxy handler for observer node running in standalone mode.
+// If txRelay is nil, transactions are forwarded to an upstream without resubmission.
+func NewStandaloneRestProxyHandler(
+	api access.API,
+	identities flow.IdentitySkeletonList,
+	connectionFactory connection.ConnectionFactory,
+	log zerolog.Logger,
+	metrics metrics.ObserverMetrics,
+	chain flow.Chain,
+	txRelay *accessapiproxy.TransactionRelay,
+) (*StandaloneRestProxyHandler, error) {
+	proxy, err := NewRestProxyHandler(api, identities, connectionFactory, log, metrics, chain, txRelay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StandaloneRestProxyHandler{
+		API:   api,
+		proxy: proxy,
+	}, nil
+}
+
+// SendTransaction forwards the transaction to an upstream.
+func (r *StandaloneRestProxyHandler) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
+	return r.proxy.SendTransaction(ctx, tx)
+}
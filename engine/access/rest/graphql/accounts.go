@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// accountResolver resolves the fields of an Account.
+type accountResolver struct {
+	account *flow.Account
+}
+
+func (a *accountResolver) Address() string {
+	return a.account.Address.String()
+}
+
+func (a *accountResolver) Balance() Uint64 {
+	return Uint64(a.account.Balance)
+}
+
+func (a *accountResolver) Keys() []*accountKeyResolver {
+	keys := make([]*accountKeyResolver, len(a.account.Keys))
+	for i, key := range a.account.Keys {
+		keys[i] = &accountKeyResolver{key: key}
+	}
+	return keys
+}
+
+// Contracts resolves the contracts of the account, sorted by name.
+func (a *accountResolver) Contracts() []*contractResolver {
+	contracts := make([]*contractResolver, 0, len(a.account.Contracts))
+	for name, code := range a.account.Contracts {
+		contracts = append(contracts, &contractResolver{name: name, code: code})
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].name < contracts[j].name
+	})
+	return contracts
+}
+
+// accountKeyResolver resolves the fields of an AccountKey.
+type accountKeyResolver struct {
+	key flow.AccountPublicKey
+}
+
+func (k *accountKeyResolver) Index() int32 {
+	return int32(k.key.Index)
+}
+
+func (k *accountKeyResolver) PublicKey() string {
+	return k.key.PublicKey.String()
+}
+
+func (k *accountKeyResolver) SigningAlgorithm() string {
+	return k.key.SignAlgo.String()
+}
+
+func (k *accountKeyResolver) HashingAlgorithm() string {
+	return k.key.HashAlgo.String()
+}
+
+func (k *accountKeyResolver) SequenceNumber() Uint64 {
+	return Uint64(k.key.SeqNumber)
+}
+
+func (k *accountKeyResolver) Weight() int32 {
+	return int32(k.key.Weight)
+}
+
+func (k *accountKeyResolver) Revoked() bool {
+	return k.key.Revoked
+}
+
+// contractResolver resolves the fields of a Contract.
+type contractResolver struct {
+	name string
+	code []byte
+}
+
+func (c *contractResolver) Name() string {
+	return c.name
+}
+
+func (c *contractResolver) Code() string {
+	return util.ToBase64(c.code)
+}
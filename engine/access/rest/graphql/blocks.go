@@ -0,0 +1,193 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// blockResolver resolves the fields of a Block.
+type blockResolver struct {
+	r      *resolver
+	block  *flow.Block
+	status flow.BlockStatus
+}
+
+func (b *blockResolver) ID() graphql.ID {
+	return toID(b.block.ID())
+}
+
+func (b *blockResolver) ParentID() graphql.ID {
+	return toID(b.block.Header.ParentID)
+}
+
+func (b *blockResolver) Height() Uint64 {
+	return Uint64(b.block.Header.Height)
+}
+
+func (b *blockResolver) View() Uint64 {
+	return Uint64(b.block.Header.View)
+}
+
+func (b *blockResolver) Timestamp() string {
+	return b.block.Header.Timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+func (b *blockResolver) Status() string {
+	return b.status.String()
+}
+
+func (b *blockResolver) Parent(ctx context.Context) (*blockResolver, error) {
+	return b.r.blockByID(ctx, b.block.Header.ParentID)
+}
+
+func (b *blockResolver) CollectionGuarantees() []*collectionGuaranteeResolver {
+	guarantees := make([]*collectionGuaranteeResolver, len(b.block.Payload.Guarantees))
+	for i, guarantee := range b.block.Payload.Guarantees {
+		guarantees[i] = &collectionGuaranteeResolver{r: b.r, guarantee: guarantee}
+	}
+	return guarantees
+}
+
+// Collections resolves the collections of the block, in the order of their guarantees.
+func (b *blockResolver) Collections(ctx context.Context) ([]*collectionResolver, error) {
+	collections := make([]*collectionResolver, len(b.block.Payload.Guarantees))
+	for i, guarantee := range b.block.Payload.Guarantees {
+		collection, err := b.r.collectionByID(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+		collections[i] = collection
+	}
+	return collections, nil
+}
+
+func (b *blockResolver) Seals() []*blockSealResolver {
+	seals := make([]*blockSealResolver, len(b.block.Payload.Seals))
+	for i, seal := range b.block.Payload.Seals {
+		seals[i] = &blockSealResolver{r: b.r, seal: seal}
+	}
+	return seals
+}
+
+func (b *blockResolver) TransactionResults(ctx context.Context) ([]*transactionResultResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	results, err := b.r.api.GetTransactionResultsByBlockID(ctx, b.block.ID(), eventEncodingVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*transactionResultResolver, len(results))
+	for i, result := range results {
+		resolvers[i] = &transactionResultResolver{r: b.r, result: result}
+	}
+	return resolvers, nil
+}
+
+func (b *blockResolver) Events(ctx context.Context, args struct{ Type string }) ([]*eventResolver, error) {
+	eventType, err := parseEventType(args.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	blockEvents, err := b.r.api.GetEventsForBlockIDs(ctx, eventType, []flow.Identifier{b.block.ID()}, eventEncodingVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*eventResolver, 0)
+	for _, be := range blockEvents {
+		for _, event := range be.Events {
+			events = append(events, &eventResolver{r: b.r, event: event})
+		}
+	}
+	return events, nil
+}
+
+func (b *blockResolver) ExecutionResult(ctx context.Context) (*executionResultResolver, error) {
+	return b.r.executionResultForBlockID(ctx, b.block.ID())
+}
+
+// collectionGuaranteeResolver resolves the fields of a CollectionGuarantee.
+type collectionGuaranteeResolver struct {
+	r         *resolver
+	guarantee *flow.CollectionGuarantee
+}
+
+func (c *collectionGuaranteeResolver) CollectionID() graphql.ID {
+	return toID(c.guarantee.CollectionID)
+}
+
+func (c *collectionGuaranteeResolver) ReferenceBlockID() graphql.ID {
+	return toID(c.guarantee.ReferenceBlockID)
+}
+
+func (c *collectionGuaranteeResolver) Collection(ctx context.Context) (*collectionResolver, error) {
+	return c.r.collectionByID(ctx, c.guarantee.CollectionID)
+}
+
+// blockSealResolver resolves the fields of a BlockSeal.
+type blockSealResolver struct {
+	r    *resolver
+	seal *flow.Seal
+}
+
+func (s *blockSealResolver) BlockID() graphql.ID {
+	return toID(s.seal.BlockID)
+}
+
+func (s *blockSealResolver) ResultID() graphql.ID {
+	return toID(s.seal.ResultID)
+}
+
+func (s *blockSealResolver) FinalState() string {
+	return util.ToBase64(s.seal.FinalState[:])
+}
+
+func (s *blockSealResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return s.r.blockByID(ctx, s.seal.BlockID)
+}
+
+func (s *blockSealResolver) ExecutionResult(ctx context.Context) (*executionResultResolver, error) {
+	return s.r.executionResultByID(ctx, s.seal.ResultID)
+}
+
+// collectionResolver resolves the fields of a Collection.
+type collectionResolver struct {
+	r          *resolver
+	collection *flow.LightCollection
+}
+
+func (c *collectionResolver) ID() graphql.ID {
+	return toID(c.collection.ID())
+}
+
+func (c *collectionResolver) TransactionIDs() []graphql.ID {
+	ids := make([]graphql.ID, len(c.collection.Transactions))
+	for i, txID := range c.collection.Transactions {
+		ids[i] = toID(txID)
+	}
+	return ids
+}
+
+// Transactions resolves the transactions of the collection, in the order of their inclusion.
+func (c *collectionResolver) Transactions(ctx context.Context) ([]*transactionResolver, error) {
+	transactions := make([]*transactionResolver, len(c.collection.Transactions))
+	for i, txID := range c.collection.Transactions {
+		tx, err := c.r.transactionByID(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = tx
+	}
+	return transactions, nil
+}
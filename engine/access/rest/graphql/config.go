@@ -0,0 +1,40 @@
+package graphql
+
+const (
+	// DefaultMaxQueryDepth is the default maximum nesting depth of the fields of a query.
+	DefaultMaxQueryDepth = 8
+
+	// DefaultMaxQueryCost is the default maximum cost of a query, which is the number of access API
+	// calls made to resolve it.
+	DefaultMaxQueryCost = 500
+
+	// DefaultMaxHeightRange is the default maximum size of the height range of block and event queries.
+	DefaultMaxHeightRange = 50
+
+	// maxRequestBodySize is the maximum size of the body of a GraphQL request.
+	maxRequestBodySize = 1 << 20 // 1MB
+)
+
+// Config contains the configuration of the GraphQL endpoint.
+type Config struct {
+	// Enabled specifies whether the GraphQL endpoint is served.
+	Enabled bool
+	// MaxQueryDepth is the maximum nesting depth of the fields of a query.
+	MaxQueryDepth int
+	// MaxQueryCost is the maximum number of access API calls made to resolve a single query. Queries whose
+	// estimated cost exceeds the limit are rejected before they are executed, and fields which would exceed
+	// the limit during execution are resolved with an error.
+	MaxQueryCost uint
+	// MaxHeightRange is the maximum size of the height range of block and event queries.
+	MaxHeightRange uint64
+}
+
+// NewDefaultConfig returns the default GraphQL configuration. The endpoint is disabled by default.
+func NewDefaultConfig() Config {
+	return Config{
+		Enabled:        false,
+		MaxQueryDepth:  DefaultMaxQueryDepth,
+		MaxQueryCost:   DefaultMaxQueryCost,
+		MaxHeightRange: DefaultMaxHeightRange,
+	}
+}
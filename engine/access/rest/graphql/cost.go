@@ -0,0 +1,282 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type costBudgetKey struct{}
+
+// costBudget is the remaining number of access API calls a query may make. Since fields are resolved
+// concurrently, it is safe for concurrent use.
+type costBudget struct {
+	limit     uint
+	remaining atomic.Int64
+}
+
+// withCostBudget returns a context carrying a new cost budget with the given limit.
+func withCostBudget(ctx context.Context, limit uint) context.Context {
+	budget := &costBudget{limit: limit}
+	budget.remaining.Store(int64(limit))
+	return context.WithValue(ctx, costBudgetKey{}, budget)
+}
+
+// charge takes the cost of a single access API call from the budget of the query.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.ResourceExhausted] if the budget of the query is exhausted.
+func charge(ctx context.Context) error {
+	budget, ok := ctx.Value(costBudgetKey{}).(*costBudget)
+	if !ok {
+		return nil
+	}
+	if budget.remaining.Add(-1) < 0 {
+		return status.Errorf(codes.ResourceExhausted, "query exceeds the maximum cost of %d access API calls", budget.limit)
+	}
+	return nil
+}
+
+// estimatedListSize is the number of elements assumed for lists whose size is not known before the query is
+// executed, such as the collections of a block or the transactions of a collection.
+const estimatedListSize = 10
+
+// fieldCall describes the access API calls made to resolve a field.
+type fieldCall int
+
+const (
+	// callPerField fields make a single access API call.
+	callPerField fieldCall = iota + 1
+	// callPerElement list fields make an access API call for each of their elements.
+	callPerElement
+)
+
+// fieldCalls are the fields of the schema which call the access API, keyed by type and field name. Other
+// fields are resolved from the data of their parent.
+var fieldCalls = map[string]fieldCall{
+	"Query.block":                    callPerField,
+	"Query.blocks":                   callPerElement,
+	"Query.collection":               callPerField,
+	"Query.transaction":              callPerField,
+	"Query.transactionResult":        callPerField,
+	"Query.account":                  callPerField,
+	"Query.events":                   callPerField,
+	"Query.executionResult":          callPerField,
+	"Block.parent":                   callPerField,
+	"Block.collections":              callPerElement,
+	"Block.transactionResults":       callPerField,
+	"Block.events":                   callPerField,
+	"Block.executionResult":          callPerField,
+	"CollectionGuarantee.collection": callPerField,
+	"BlockSeal.block":                callPerField,
+	"BlockSeal.executionResult":      callPerField,
+	"Collection.transactions":        callPerElement,
+	"Transaction.result":             callPerField,
+	"TransactionResult.block":        callPerField,
+	"TransactionResult.transaction":  callPerField,
+	"Event.transaction":              callPerField,
+	"BlockEvents.block":              callPerField,
+	"ExecutionResult.block":          callPerField,
+	"ExecutionResult.previousResult": callPerField,
+}
+
+// heightRangeFields are the list fields whose size is given by their height range arguments.
+var heightRangeFields = map[string]bool{
+	"Query.blocks": true,
+	"Query.events": true,
+}
+
+// costEstimator estimates the cost of a query before it is executed, which is the number of access API
+// calls made to resolve it. Lists of unknown size are assumed to contain estimatedListSize elements, so
+// the estimate does not replace the cost budget enforced during execution.
+type costEstimator struct {
+	schema    *types.Schema
+	config    Config
+	fragments map[string]*queryFragment
+	variables map[string]interface{}
+	// visiting are the fragments being estimated, to detect fragment cycles.
+	visiting map[string]bool
+}
+
+// estimateCost estimates the cost of the operation of a query to be executed.
+//
+// Expected errors during normal operation:
+//   - if the query cannot be parsed, or the operation to execute cannot be determined
+func estimateCost(
+	schema *types.Schema,
+	config Config,
+	query string,
+	operationName string,
+	variables map[string]interface{},
+) (uint64, error) {
+	doc, err := parseQuery(query)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse query: %w", err)
+	}
+
+	var op *queryOperation
+	for _, candidate := range doc.operations {
+		if candidate.name == operationName || (operationName == "" && len(doc.operations) == 1) {
+			op = candidate
+			break
+		}
+	}
+	if op == nil {
+		return 0, fmt.Errorf("could not find operation %q", operationName)
+	}
+
+	root, ok := schema.EntryPoints[op.kind].(*types.ObjectTypeDefinition)
+	if !ok {
+		return 0, fmt.Errorf("unsupported operation type %s", op.kind)
+	}
+
+	e := &costEstimator{
+		schema:    schema,
+		config:    config,
+		fragments: doc.fragments,
+		variables: variables,
+		visiting:  make(map[string]bool),
+	}
+	return e.selectionsCost(root, op.selections)
+}
+
+// selectionsCost estimates the cost of the selections of an object of the given type.
+func (e *costEstimator) selectionsCost(object *types.ObjectTypeDefinition, selections []*querySelection) (uint64, error) {
+	var cost uint64
+	for _, selection := range selections {
+		var selectionCost uint64
+		var err error
+		switch {
+		case selection.fragment != "":
+			fragment, ok := e.fragments[selection.fragment]
+			if !ok {
+				return 0, fmt.Errorf("unknown fragment %q", selection.fragment)
+			}
+			if e.visiting[selection.fragment] {
+				return 0, fmt.Errorf("fragment %q spreads itself", selection.fragment)
+			}
+			e.visiting[selection.fragment] = true
+			selectionCost, err = e.fragmentCost(object, fragment.typeCondition, fragment.selections)
+			delete(e.visiting, selection.fragment)
+		case selection.field == "":
+			selectionCost, err = e.fragmentCost(object, selection.typeCondition, selection.selections)
+		default:
+			selectionCost, err = e.fieldCost(object, selection)
+		}
+		if err != nil {
+			return 0, err
+		}
+		cost = addCost(cost, selectionCost)
+	}
+	return cost, nil
+}
+
+// fragmentCost estimates the cost of the selections of a fragment applied to an object of the given type.
+// Since the schema has no interfaces or unions, the type condition is either the type of the object, or
+// the fragment does not apply.
+func (e *costEstimator) fragmentCost(object *types.ObjectTypeDefinition, typeCondition string, selections []*querySelection) (uint64, error) {
+	if typeCondition != "" && typeCondition != object.Name {
+		return 0, nil
+	}
+	return e.selectionsCost(object, selections)
+}
+
+// fieldCost estimates the cost of resolving a field of an object of the given type, and its selections.
+func (e *costEstimator) fieldCost(object *types.ObjectTypeDefinition, selection *querySelection) (uint64, error) {
+	field := object.Fields.Get(selection.field)
+	if field == nil {
+		// introspection fields, and unknown fields which are rejected by the graphql library
+		return 0, nil
+	}
+
+	fieldType, isList := unwrapType(field.Type)
+	var childCost uint64
+	if child, ok := fieldType.(*types.ObjectTypeDefinition); ok {
+		var err error
+		childCost, err = e.selectionsCost(child, selection.selections)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	key := object.Name + "." + field.Name
+	call := fieldCalls[key]
+	if !isList {
+		if call != 0 {
+			return addCost(1, childCost), nil
+		}
+		return childCost, nil
+	}
+
+	size := uint64(estimatedListSize)
+	if heightRangeFields[key] {
+		size = e.heightRangeSize(selection.arguments)
+	}
+	switch call {
+	case callPerElement:
+		return mulCost(size, addCost(1, childCost)), nil
+	case callPerField:
+		return addCost(1, mulCost(size, childCost)), nil
+	default:
+		return mulCost(size, childCost), nil
+	}
+}
+
+// heightRangeSize returns the size of the height range of the given arguments. If the range is not valid,
+// the size is the maximum height range, since larger ranges are rejected when the query is executed.
+func (e *costEstimator) heightRangeSize(arguments map[string]interface{}) uint64 {
+	var startHeight, endHeight Uint64
+	if e.uint64Argument(arguments, "startHeight", &startHeight) != nil ||
+		e.uint64Argument(arguments, "endHeight", &endHeight) != nil ||
+		startHeight > endHeight ||
+		endHeight-startHeight >= Uint64(e.config.MaxHeightRange) {
+		return e.config.MaxHeightRange
+	}
+	return uint64(endHeight-startHeight) + 1
+}
+
+// uint64Argument decodes a Uint64 argument, which may be a literal or a variable.
+func (e *costEstimator) uint64Argument(arguments map[string]interface{}, name string, value *Uint64) error {
+	argument := arguments[name]
+	if variable, ok := argument.(queryVariable); ok {
+		argument = e.variables[string(variable)]
+	}
+	return value.UnmarshalGraphQL(argument)
+}
+
+// unwrapType returns the named type of a field type, and whether it is a list.
+func unwrapType(t types.Type) (types.Type, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *types.NonNull:
+			t = wrapped.OfType
+		case *types.List:
+			isList = true
+			t = wrapped.OfType
+		default:
+			return t, isList
+		}
+	}
+}
+
+// addCost adds two costs, saturating at the maximum cost.
+func addCost(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// mulCost multiplies two costs, saturating at the maximum cost.
+func mulCost(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TestEstimateCost tests the cost of queries estimated before they are executed.
+func TestEstimateCost(t *testing.T) {
+	config := NewDefaultConfig()
+	config.MaxHeightRange = 20
+
+	schema, err := NewSchema(accessmock.NewAPI(t), flow.Testnet.Chain(), config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]interface{}
+		cost      uint64
+	}{
+		{
+			name:  "fields resolved from their parent",
+			query: `{ block { id height collectionGuarantees { collectionId } seals { blockId } } }`,
+			cost:  1,
+		},
+		{
+			name:  "nested fields",
+			query: `{ block { parent { id } executionResult { previousResult { id } } } }`,
+			cost:  4,
+		},
+		{
+			name:  "list with a call per element",
+			query: `{ block { collections { transactions { id result { status } } } } }`,
+			cost:  1 + estimatedListSize*(1+estimatedListSize*2),
+		},
+		{
+			name:  "list with a single call",
+			query: `{ block { transactionResults { transaction { id } } } }`,
+			cost:  1 + 1 + estimatedListSize,
+		},
+		{
+			name:  "height range literals",
+			query: `{ blocks(startHeight: "10", endHeight: 14) { parent { id } } }`,
+			cost:  5 * 2,
+		},
+		{
+			name:      "height range variables",
+			query:     `query ($start: Uint64!, $end: Uint64!) { events(type: "flow.AccountCreated", startHeight: $start, endHeight: $end) { block { id } } }`,
+			variables: map[string]interface{}{"start": "10", "end": float64(12)},
+			cost:      1 + 3,
+		},
+		{
+			name:  "invalid height range",
+			query: `{ blocks(startHeight: "1", endHeight: "100") { id } }`,
+			cost:  20,
+		},
+		{
+			name:  "aliases",
+			query: `{ a: block(height: 1) { id } b: block(height: 2) { id } }`,
+			cost:  2,
+		},
+		{
+			name: "fragments",
+			query: `
+				query Blocks { block { ...BlockFields ... on Block { parent { id } } ... { executionResult { id } } } }
+				fragment BlockFields on Block { parent { ...ParentFields } }
+				fragment ParentFields on Block { id parent { id } }`,
+			cost: 1 + 2 + 1 + 1,
+		},
+		{
+			name: "selected operation",
+			query: `
+				query Single { block { id } }
+				query Nested { block { parent { id } } }`,
+			operation: "Nested",
+			cost:      2,
+		},
+		{
+			name: "comments, strings and directives",
+			query: `
+				# comment with { braces
+				query ($skip: Boolean = false) {
+					transaction(id: "\"}") @include(if: true) { id }
+					events(type: """ "block" } string """, startHeight: 1, endHeight: 1) @skip(if: $skip) { blockId }
+				}`,
+			cost: 2,
+		},
+		{
+			name:  "introspection",
+			query: `{ __typename __schema { types { name } } }`,
+			cost:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cost, err := estimateCost(schema.ASTSchema(), config, test.query, test.operation, test.variables)
+			require.NoError(t, err)
+			assert.Equal(t, test.cost, cost)
+		})
+	}
+
+	t.Run("invalid queries", func(t *testing.T) {
+		for _, query := range []string{
+			`{ block { id }`,
+			`query { block(id: "unterminated) { id } }`,
+			`{ block { ...Unknown } }`,
+			`{ block { ...Cycle } } fragment Cycle on Block { parent { ...Cycle } }`,
+			`query A { block { id } } query B { block { id } }`,
+		} {
+			_, err := estimateCost(schema.ASTSchema(), config, query, "", nil)
+			assert.Error(t, err, query)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventResolver resolves the fields of an Event.
+type eventResolver struct {
+	r     *resolver
+	event flow.Event
+}
+
+func (e *eventResolver) Type() string {
+	return string(e.event.Type)
+}
+
+func (e *eventResolver) TransactionID() graphql.ID {
+	return toID(e.event.TransactionID)
+}
+
+func (e *eventResolver) TransactionIndex() int32 {
+	return int32(e.event.TransactionIndex)
+}
+
+func (e *eventResolver) EventIndex() int32 {
+	return int32(e.event.EventIndex)
+}
+
+func (e *eventResolver) Payload() string {
+	return util.ToBase64(e.event.Payload)
+}
+
+func (e *eventResolver) Transaction(ctx context.Context) (*transactionResolver, error) {
+	return e.r.transactionByID(ctx, e.event.TransactionID)
+}
+
+// blockEventsResolver resolves the fields of BlockEvents.
+type blockEventsResolver struct {
+	r           *resolver
+	blockEvents flow.BlockEvents
+}
+
+func (b *blockEventsResolver) BlockID() graphql.ID {
+	return toID(b.blockEvents.BlockID)
+}
+
+func (b *blockEventsResolver) BlockHeight() Uint64 {
+	return Uint64(b.blockEvents.BlockHeight)
+}
+
+func (b *blockEventsResolver) BlockTimestamp() string {
+	return b.blockEvents.BlockTimestamp.UTC().Format(time.RFC3339Nano)
+}
+
+func (b *blockEventsResolver) Events() []*eventResolver {
+	events := make([]*eventResolver, len(b.blockEvents.Events))
+	for i, event := range b.blockEvents.Events {
+		events[i] = &eventResolver{r: b.r, event: event}
+	}
+	return events
+}
+
+func (b *blockEventsResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return b.r.blockByID(ctx, b.blockEvents.BlockID)
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// executionResultResolver resolves the fields of an ExecutionResult.
+type executionResultResolver struct {
+	r      *resolver
+	result *flow.ExecutionResult
+}
+
+func (e *executionResultResolver) ID() graphql.ID {
+	return toID(e.result.ID())
+}
+
+func (e *executionResultResolver) BlockID() graphql.ID {
+	return toID(e.result.BlockID)
+}
+
+func (e *executionResultResolver) PreviousResultID() graphql.ID {
+	return toID(e.result.PreviousResultID)
+}
+
+func (e *executionResultResolver) Chunks() []*chunkResolver {
+	chunks := make([]*chunkResolver, len(e.result.Chunks))
+	for i, chunk := range e.result.Chunks {
+		chunks[i] = &chunkResolver{chunk: chunk}
+	}
+	return chunks
+}
+
+func (e *executionResultResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return e.r.blockByID(ctx, e.result.BlockID)
+}
+
+func (e *executionResultResolver) PreviousResult(ctx context.Context) (*executionResultResolver, error) {
+	return e.r.executionResultByID(ctx, e.result.PreviousResultID)
+}
+
+// chunkResolver resolves the fields of a Chunk.
+type chunkResolver struct {
+	chunk *flow.Chunk
+}
+
+func (c *chunkResolver) Index() Uint64 {
+	return Uint64(c.chunk.Index)
+}
+
+func (c *chunkResolver) CollectionIndex() int32 {
+	return int32(c.chunk.CollectionIndex)
+}
+
+func (c *chunkResolver) StartState() string {
+	return util.ToBase64(c.chunk.StartState[:])
+}
+
+func (c *chunkResolver) EndState() string {
+	return util.ToBase64(c.chunk.EndState[:])
+}
+
+func (c *chunkResolver) EventCollection() string {
+	return util.ToBase64(c.chunk.EventCollection[:])
+}
+
+func (c *chunkResolver) BlockID() graphql.ID {
+	return toID(c.chunk.BlockID)
+}
+
+func (c *chunkResolver) NumberOfTransactions() Uint64 {
+	return Uint64(c.chunk.NumberOfTransactions)
+}
+
+func (c *chunkResolver) TotalComputationUsed() Uint64 {
+	return Uint64(c.chunk.TotalComputationUsed)
+}
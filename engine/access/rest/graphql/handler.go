@@ -0,0 +1,149 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryRequest is the body of a GraphQL request.
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries over HTTP. Queries are accepted as a JSON body of POST requests, or
+// as the `query` parameter of GET requests.
+type Handler struct {
+	logger zerolog.Logger
+	config Config
+	schema *graphql.Schema
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new Handler serving the given schema.
+func NewHandler(logger zerolog.Logger, config Config, schema *graphql.Schema) *Handler {
+	return &Handler{
+		logger: logger.With().Str("component", "graphql_handler").Logger(),
+		config: config,
+		schema: schema,
+	}
+}
+
+// ServeHTTP executes the query of the request and writes the result. Errors of the query are returned
+// in the `errors` field of the response, with the gRPC status code of resolver errors in the `code`
+// extension. Queries whose estimated cost exceeds the maximum cost, or whose cost cannot be estimated,
+// are rejected before they are executed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := h.parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the query is validated by the graphql library first, so that only queries which the library
+	// would execute are estimated. graphql-go does not export its query parser, so the estimate parses
+	// the query separately, and queries it cannot estimate are rejected instead of being executed
+	// without the cost check.
+	if errs := h.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+		h.writeResponse(w, &graphql.Response{Errors: errs})
+		return
+	}
+
+	cost, err := estimateCost(h.schema.ASTSchema(), h.config, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("rejecting graphql query whose cost could not be estimated")
+		h.writeResponse(w, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{{
+				Message:    fmt.Sprintf("could not estimate the cost of the query: %v", err),
+				Extensions: map[string]interface{}{"code": codes.InvalidArgument.String()},
+			}},
+		})
+		return
+	}
+	if cost > uint64(h.config.MaxQueryCost) {
+		h.writeResponse(w, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{{
+				Message:    fmt.Sprintf("query exceeds the maximum cost of %d access API calls (estimated cost %d)", h.config.MaxQueryCost, cost),
+				Extensions: map[string]interface{}{"code": codes.ResourceExhausted.String()},
+			}},
+		})
+		return
+	}
+
+	ctx := withCostBudget(r.Context(), h.config.MaxQueryCost)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	for _, qErr := range response.Errors {
+		if qErr.ResolverError == nil {
+			continue
+		}
+		if qErr.Extensions == nil {
+			qErr.Extensions = make(map[string]interface{})
+		}
+		qErr.Extensions["code"] = status.Code(qErr.ResolverError).String()
+	}
+
+	h.writeResponse(w, response)
+}
+
+// writeResponse writes the response of a query as JSON.
+func (h *Handler) writeResponse(w http.ResponseWriter, response *graphql.Response) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to encode graphql response")
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		h.logger.Debug().Err(err).Msg("failed to write graphql response")
+	}
+}
+
+// parseRequest reads the GraphQL request from the query parameters of GET requests, or the body of
+// POST requests.
+func (h *Handler) parseRequest(r *http.Request) (*queryRequest, error) {
+	switch r.Method {
+	case http.MethodGet:
+		req := &queryRequest{
+			Query:         r.URL.Query().Get("query"),
+			OperationName: r.URL.Query().Get("operationName"),
+		}
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+		return req, nil
+
+	case http.MethodPost:
+		var req queryRequest
+		body := http.MaxBytesReader(nil, r.Body, maxRequestBodySize)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, fmt.Errorf("request body exceeds the maximum size of %d bytes", maxRequestBodySize)
+			}
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("request body must not be empty")
+			}
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+		return &req, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported method %s", r.Method)
+	}
+}
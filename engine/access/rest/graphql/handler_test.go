@@ -0,0 +1,220 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// response is the decoded body of a GraphQL response.
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// execute executes the query against a handler backed by the given access API, and returns the decoded
+// response.
+func execute(t *testing.T, api access.API, config Config, query string, variables map[string]interface{}) response {
+	return executeOperation(t, api, config, query, "", variables)
+}
+
+// executeOperation executes the named operation of the query against a handler backed by the given
+// access API, and returns the decoded response.
+func executeOperation(t *testing.T, api access.API, config Config, query string, operationName string, variables map[string]interface{}) response {
+	schema, err := NewSchema(api, flow.Testnet.Chain(), config)
+	require.NoError(t, err)
+	handler := NewHandler(zerolog.Nop(), config, schema)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return resp
+}
+
+const blockTransactionsQuery = `
+query ($height: Uint64!) {
+	block(height: $height) {
+		id
+		height
+		status
+		collections {
+			id
+			transactions {
+				id
+				result {
+					status
+					blockId
+				}
+			}
+		}
+	}
+}`
+
+// TestNestedQuery tests that the fields of nested types are resolved from a single query.
+func TestNestedQuery(t *testing.T) {
+	collection := unittest.CollectionFixture(2)
+	light := collection.Light()
+	block := unittest.BlockWithGuaranteesFixture([]*flow.CollectionGuarantee{
+		{CollectionID: light.ID()},
+	})
+	blockID := block.ID()
+
+	api := accessmock.NewAPI(t)
+	api.On("GetBlockByHeight", mock.Anything, block.Header.Height).
+		Return(block, flow.BlockStatusSealed, nil).Once()
+	api.On("GetCollectionByID", mock.Anything, light.ID()).
+		Return(&light, nil).Once()
+	for _, tx := range collection.Transactions {
+		api.On("GetTransaction", mock.Anything, tx.ID()).
+			Return(tx, nil).Once()
+		api.On("GetTransactionResult", mock.Anything, tx.ID(), flow.ZeroID, flow.ZeroID, eventEncodingVersion).
+			Return(&access.TransactionResult{
+				TransactionID: tx.ID(),
+				BlockID:       blockID,
+				Status:        flow.TransactionStatusSealed,
+			}, nil).Once()
+	}
+
+	resp := execute(t, api, NewDefaultConfig(), blockTransactionsQuery, map[string]interface{}{
+		"height": block.Header.Height,
+	})
+	require.Empty(t, resp.Errors)
+
+	blockData := resp.Data["block"].(map[string]interface{})
+	assert.Equal(t, blockID.String(), blockData["id"])
+	assert.Equal(t, "BLOCK_SEALED", blockData["status"])
+
+	collections := blockData["collections"].([]interface{})
+	require.Len(t, collections, 1)
+	transactions := collections[0].(map[string]interface{})["transactions"].([]interface{})
+	require.Len(t, transactions, len(collection.Transactions))
+	for i, tx := range collection.Transactions {
+		txData := transactions[i].(map[string]interface{})
+		assert.Equal(t, tx.ID().String(), txData["id"])
+		result := txData["result"].(map[string]interface{})
+		assert.Equal(t, flow.TransactionStatusSealed.String(), result["status"])
+		assert.Equal(t, blockID.String(), result["blockId"])
+	}
+}
+
+// TestQueryCostLimit tests that queries whose estimated cost exceeds the cost limit, or whose cost cannot
+// be estimated, are rejected before they are executed, and that fields which exceed the limit during execution are resolved with an error,
+// without calling the access API.
+func TestQueryCostLimit(t *testing.T) {
+	t.Run("estimated cost exceeds limit", func(t *testing.T) {
+		config := NewDefaultConfig()
+		config.MaxQueryCost = 2
+
+		api := accessmock.NewAPI(t)
+		resp := execute(t, api, config, blockTransactionsQuery, map[string]interface{}{
+			"height": 1,
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, codes.ResourceExhausted.String(), resp.Errors[0].Extensions["code"])
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("cost cannot be estimated", func(t *testing.T) {
+		// the query is valid, but the operation to estimate cannot be determined
+		api := accessmock.NewAPI(t)
+		resp := executeOperation(t, api, NewDefaultConfig(), `query first { block { id } } query second { block { id } }`, "third", nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, codes.InvalidArgument.String(), resp.Errors[0].Extensions["code"])
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("cost exceeds limit during execution", func(t *testing.T) {
+		// the collection has more transactions than estimated, so the query is executed, but the limit
+		// only covers the collection and the estimated number of transactions
+		collection := unittest.CollectionFixture(2 * estimatedListSize)
+		light := collection.Light()
+
+		config := NewDefaultConfig()
+		config.MaxQueryCost = 1 + estimatedListSize
+
+		api := accessmock.NewAPI(t)
+		api.On("GetCollectionByID", mock.Anything, light.ID()).
+			Return(&light, nil).Once()
+		for _, tx := range collection.Transactions[:estimatedListSize] {
+			api.On("GetTransaction", mock.Anything, tx.ID()).
+				Return(tx, nil).Once()
+		}
+
+		resp := execute(t, api, config, `query ($id: ID!) { collection(id: $id) { transactions { id } } }`, map[string]interface{}{
+			"id": light.ID().String(),
+		})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, codes.ResourceExhausted.String(), resp.Errors[0].Extensions["code"])
+	})
+}
+
+// TestQueryDepthLimit tests that queries nested deeper than the depth limit are rejected.
+func TestQueryDepthLimit(t *testing.T) {
+	config := NewDefaultConfig()
+	config.MaxQueryDepth = 3
+
+	api := accessmock.NewAPI(t)
+	resp := execute(t, api, config, `{ block { parent { parent { parent { id } } } } }`, nil)
+	require.NotEmpty(t, resp.Errors)
+	assert.Nil(t, resp.Data)
+}
+
+// TestInvalidArguments tests that invalid arguments are resolved with an InvalidArgument error, without
+// calling the access API.
+func TestInvalidArguments(t *testing.T) {
+	api := accessmock.NewAPI(t)
+
+	t.Run("invalid id", func(t *testing.T) {
+		resp := execute(t, api, NewDefaultConfig(), `{ transaction(id: "invalid") { id } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, codes.InvalidArgument.String(), resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("height range too large", func(t *testing.T) {
+		config := NewDefaultConfig()
+		config.MaxHeightRange = 10
+
+		resp := execute(t, api, config, `{ blocks(startHeight: "1", endHeight: "100") { id } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, codes.InvalidArgument.String(), resp.Errors[0].Extensions["code"])
+	})
+}
+
+// TestInvalidRequest tests that malformed requests are rejected.
+func TestInvalidRequest(t *testing.T) {
+	schema, err := NewSchema(accessmock.NewAPI(t), flow.Testnet.Chain(), NewDefaultConfig())
+	require.NoError(t, err)
+	handler := NewHandler(zerolog.Nop(), NewDefaultConfig(), schema)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader([]byte("{")))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,481 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The graphql library does not expose the parsed query, so queries are parsed here to estimate their cost
+// before they are executed. The parser only keeps what is needed to estimate the cost: the fields, their
+// arguments, and the fragments they use. Queries it cannot parse are left to the graphql library to reject.
+
+// queryDocument is a parsed query, containing its operations and fragments.
+type queryDocument struct {
+	operations []*queryOperation
+	fragments  map[string]*queryFragment
+}
+
+// queryOperation is an operation of a query.
+type queryOperation struct {
+	kind       string
+	name       string
+	selections []*querySelection
+}
+
+// queryFragment is a named fragment of a query.
+type queryFragment struct {
+	typeCondition string
+	selections    []*querySelection
+}
+
+// querySelection is a field, a fragment spread or an inline fragment of a selection set.
+type querySelection struct {
+	// field is the name of the selected field, if the selection is a field.
+	field string
+	// arguments are the arguments of the field. Values are either a literal, or a queryVariable.
+	arguments map[string]interface{}
+	// fragment is the name of the spread fragment, if the selection is a fragment spread.
+	fragment string
+	// typeCondition is the type condition of inline fragments, if any.
+	typeCondition string
+	selections    []*querySelection
+}
+
+// queryVariable is a reference to a variable of the query in an argument value.
+type queryVariable string
+
+// byteOrderMark is the unicode byte order mark, which is ignored like whitespace.
+const byteOrderMark = "\ufeff"
+
+// tokenKind is the kind of a lexical token of a query.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenNumber
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// queryParser is a recursive descent parser of GraphQL queries.
+type queryParser struct {
+	src string
+	pos int
+	tok token
+}
+
+// parseQuery parses a GraphQL query.
+//
+// Expected errors during normal operation:
+//   - if the query is not syntactically valid.
+func parseQuery(query string) (*queryDocument, error) {
+	p := &queryParser{src: query}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &queryDocument{fragments: make(map[string]*queryFragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &queryOperation{kind: "query", selections: selections})
+
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+
+		case p.peek(tokenName, "fragment"):
+			name, fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = fragment
+
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.tok.value, p.pos)
+		}
+	}
+	return doc, nil
+}
+
+func (p *queryParser) parseOperation() (*queryOperation, error) {
+	op := &queryOperation{kind: p.tok.value}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek(tokenPunctuator, "(") {
+		// variable definitions do not affect the cost of the query
+		if err := p.skipBalanced("(", ")"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *queryParser) parseFragment() (string, *queryFragment, error) {
+	if err := p.next(); err != nil {
+		return "", nil, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := p.expect(tokenName, "on"); err != nil {
+		return "", nil, err
+	}
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := p.parseDirectives(); err != nil {
+		return "", nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return "", nil, err
+	}
+	return name, &queryFragment{typeCondition: typeCondition, selections: selections}, nil
+}
+
+func (p *queryParser) parseSelectionSet() ([]*querySelection, error) {
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	var selections []*querySelection
+	for !p.peek(tokenPunctuator, "}") {
+		if p.tok.kind == tokenEOF {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	return selections, p.next()
+}
+
+func (p *queryParser) parseSelection() (*querySelection, error) {
+	if p.peek(tokenPunctuator, "...") {
+		return p.parseFragmentSelection()
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	selection := &querySelection{field: name}
+	if p.peek(tokenPunctuator, ":") {
+		// the name was an alias
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		selection.field, err = p.expectName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek(tokenPunctuator, "(") {
+		selection.arguments, err = p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if p.peek(tokenPunctuator, "{") {
+		selection.selections, err = p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return selection, nil
+}
+
+func (p *queryParser) parseFragmentSelection() (*querySelection, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	selection := &querySelection{}
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		selection.fragment = p.tok.value
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return selection, p.parseDirectives()
+	}
+
+	if p.peek(tokenName, "on") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		typeCondition, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		selection.typeCondition = typeCondition
+	}
+	if err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	selection.selections, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
+
+func (p *queryParser) parseArguments() (map[string]interface{}, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := make(map[string]interface{})
+	for !p.peek(tokenPunctuator, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		arguments[name] = value
+	}
+	return arguments, p.next()
+}
+
+// parseValue parses an argument value. Variables are returned as queryVariable, strings and numbers as
+// their string representation. Other values are not needed to estimate the cost of a query, and are
+// returned as nil.
+func (p *queryParser) parseValue() (interface{}, error) {
+	switch {
+	case p.peek(tokenPunctuator, "$"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		return queryVariable(name), nil
+
+	case p.peek(tokenPunctuator, "["):
+		return nil, p.skipBalanced("[", "]")
+
+	case p.peek(tokenPunctuator, "{"):
+		return nil, p.skipBalanced("{", "}")
+
+	case p.tok.kind == tokenString, p.tok.kind == tokenNumber:
+		value := p.tok.value
+		return value, p.next()
+
+	case p.tok.kind == tokenName:
+		// booleans, null and enum values
+		return nil, p.next()
+
+	default:
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.tok.value, p.pos)
+	}
+}
+
+func (p *queryParser) parseDirectives() error {
+	for p.peek(tokenPunctuator, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.peek(tokenPunctuator, "(") {
+			if _, err := p.parseArguments(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipBalanced skips the tokens up to and including the close punctuator matching the current open
+// punctuator.
+func (p *queryParser) skipBalanced(open, close string) error {
+	depth := 0
+	for {
+		switch {
+		case p.tok.kind == tokenEOF:
+			return fmt.Errorf("unterminated %q", open)
+		case p.peek(tokenPunctuator, open):
+			depth++
+		case p.peek(tokenPunctuator, close):
+			depth--
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func (p *queryParser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *queryParser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return fmt.Errorf("expected %q, found %q at offset %d", value, p.tok.value, p.pos)
+	}
+	return p.next()
+}
+
+func (p *queryParser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", fmt.Errorf("expected name, found %q at offset %d", p.tok.value, p.pos)
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+// next reads the next token of the query. Whitespace, commas and comments are ignored.
+func (p *queryParser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	// skip the byte order mark
+	if strings.HasPrefix(p.src[p.pos:], byteOrderMark) {
+		p.pos += len(byteOrderMark)
+		return p.next()
+	}
+
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokenEOF}
+		return nil
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{kind: tokenPunctuator, value: "..."}
+
+	case strings.IndexByte("!$&()=:@[]{|}", c) >= 0:
+		p.pos++
+		p.tok = token{kind: tokenPunctuator, value: p.src[start:p.pos]}
+
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenName, value: p.src[start:p.pos]}
+
+	case c == '-' || isDigit(c):
+		p.pos++
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || isLetter(p.src[p.pos]) || strings.IndexByte(".+-", p.src[p.pos]) >= 0) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenNumber, value: p.src[start:p.pos]}
+
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		for end >= 0 && p.src[p.pos+3+end-1] == '\\' {
+			// escaped triple quote
+			next := strings.Index(p.src[p.pos+3+end+3:], `"""`)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += 3 + next
+		}
+		if end < 0 {
+			return fmt.Errorf("unterminated block string at offset %d", start)
+		}
+		p.pos += 3 + end + 3
+		p.tok = token{kind: tokenString, value: p.src[start+3 : p.pos-3]}
+
+	case c == '"':
+		p.pos++
+		var value strings.Builder
+		for {
+			if p.pos >= len(p.src) || p.src[p.pos] == '\n' || p.src[p.pos] == '\r' {
+				return fmt.Errorf("unterminated string at offset %d", start)
+			}
+			c := p.src[p.pos]
+			p.pos++
+			if c == '"' {
+				break
+			}
+			if c == '\\' && p.pos < len(p.src) {
+				// escape sequences are kept as they are, except escaped quotes and backslashes
+				if p.src[p.pos] == '"' || p.src[p.pos] == '\\' {
+					c = p.src[p.pos]
+					p.pos++
+				}
+			}
+			value.WriteByte(c)
+		}
+		p.tok = token{kind: tokenString, value: value.String()}
+
+	default:
+		return fmt.Errorf("unexpected character %q at offset %d", c, start)
+	}
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,305 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventEncodingVersion is the encoding of event payloads returned by the GraphQL API, which matches the
+// encoding used by the REST API.
+const eventEncodingVersion = entities.EventEncodingVersion_JSON_CDC_V0
+
+// resolver is the resolver of the root Query type. It resolves all fields using the access API, and is
+// shared by the resolvers of the nested types.
+type resolver struct {
+	api    access.API
+	chain  flow.Chain
+	config Config
+}
+
+// Block resolves a block by ID or height, or the latest block if neither is provided.
+func (r *resolver) Block(ctx context.Context, args struct {
+	ID     *graphql.ID
+	Height *Uint64
+	Sealed bool
+}) (*blockResolver, error) {
+	switch {
+	case args.ID != nil && args.Height != nil:
+		return nil, status.Error(codes.InvalidArgument, "only one of id and height can be provided")
+	case args.ID != nil:
+		blockID, err := parseID(*args.ID)
+		if err != nil {
+			return nil, err
+		}
+		return r.blockByID(ctx, blockID)
+	case args.Height != nil:
+		return r.blockByHeight(ctx, uint64(*args.Height))
+	}
+
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	block, blockStatus, err := r.api.GetLatestBlock(ctx, args.Sealed)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{r: r, block: block, status: blockStatus}, nil
+}
+
+// Blocks resolves the blocks in a height range.
+func (r *resolver) Blocks(ctx context.Context, args struct {
+	StartHeight Uint64
+	EndHeight   Uint64
+}) ([]*blockResolver, error) {
+	err := r.validateHeightRange(uint64(args.StartHeight), uint64(args.EndHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*blockResolver, 0, args.EndHeight-args.StartHeight+1)
+	for height := uint64(args.StartHeight); height <= uint64(args.EndHeight); height++ {
+		block, err := r.blockByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// Collection resolves a collection by ID.
+func (r *resolver) Collection(ctx context.Context, args struct{ ID graphql.ID }) (*collectionResolver, error) {
+	collectionID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.collectionByID(ctx, collectionID)
+}
+
+// Transaction resolves a transaction by ID.
+func (r *resolver) Transaction(ctx context.Context, args struct{ ID graphql.ID }) (*transactionResolver, error) {
+	txID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.transactionByID(ctx, txID)
+}
+
+// TransactionResult resolves the result of a transaction. The optional block and collection IDs speed
+// up the lookup of the result.
+func (r *resolver) TransactionResult(ctx context.Context, args struct {
+	ID           graphql.ID
+	BlockID      *graphql.ID
+	CollectionID *graphql.ID
+}) (*transactionResultResolver, error) {
+	txID, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	blockID, err := parseOptionalID(args.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	collectionID, err := parseOptionalID(args.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	return r.transactionResult(ctx, txID, blockID, collectionID)
+}
+
+// Account resolves an account at the given height, or at the latest block if no height is provided.
+func (r *resolver) Account(ctx context.Context, args struct {
+	Address string
+	Height  *Uint64
+}) (*accountResolver, error) {
+	address, err := request.ParseAddress(args.Address, r.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q: %v", args.Address, err)
+	}
+
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+
+	var account *flow.Account
+	if args.Height == nil {
+		account, err = r.api.GetAccountAtLatestBlock(ctx, address)
+	} else {
+		account, err = r.api.GetAccountAtBlockHeight(ctx, address, uint64(*args.Height))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &accountResolver{account: account}, nil
+}
+
+// Events resolves the events of a type in a height range.
+func (r *resolver) Events(ctx context.Context, args struct {
+	Type        string
+	StartHeight Uint64
+	EndHeight   Uint64
+}) ([]*blockEventsResolver, error) {
+	eventType, err := parseEventType(args.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.validateHeightRange(uint64(args.StartHeight), uint64(args.EndHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	blockEvents, err := r.api.GetEventsForHeightRange(ctx, eventType, uint64(args.StartHeight), uint64(args.EndHeight), eventEncodingVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*blockEventsResolver, len(blockEvents))
+	for i := range blockEvents {
+		resolvers[i] = &blockEventsResolver{r: r, blockEvents: blockEvents[i]}
+	}
+	return resolvers, nil
+}
+
+// ExecutionResult resolves an execution result by ID, or by the ID of the executed block.
+func (r *resolver) ExecutionResult(ctx context.Context, args struct {
+	ID      *graphql.ID
+	BlockID *graphql.ID
+}) (*executionResultResolver, error) {
+	switch {
+	case args.ID != nil && args.BlockID == nil:
+		resultID, err := parseID(*args.ID)
+		if err != nil {
+			return nil, err
+		}
+		return r.executionResultByID(ctx, resultID)
+	case args.ID == nil && args.BlockID != nil:
+		blockID, err := parseID(*args.BlockID)
+		if err != nil {
+			return nil, err
+		}
+		return r.executionResultForBlockID(ctx, blockID)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "exactly one of id and blockId must be provided")
+	}
+}
+
+// parseEventType parses an event type from a query argument.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.InvalidArgument] if the event type is invalid.
+func parseEventType(raw string) (string, error) {
+	var eventType request.EventType
+	err := eventType.Parse(raw)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid event type %q: %v", raw, err)
+	}
+	return eventType.Flow(), nil
+}
+
+// validateHeightRange checks that the height range is valid, and not larger than the configured maximum.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.InvalidArgument] if the height range is invalid or too large.
+func (r *resolver) validateHeightRange(startHeight, endHeight uint64) error {
+	if startHeight > endHeight {
+		return status.Errorf(codes.InvalidArgument, "start height %d must not be greater than end height %d", startHeight, endHeight)
+	}
+	if endHeight-startHeight >= r.config.MaxHeightRange {
+		return status.Errorf(codes.InvalidArgument, "height range must not be larger than %d", r.config.MaxHeightRange)
+	}
+	return nil
+}
+
+func (r *resolver) blockByID(ctx context.Context, blockID flow.Identifier) (*blockResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	block, blockStatus, err := r.api.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{r: r, block: block, status: blockStatus}, nil
+}
+
+func (r *resolver) blockByHeight(ctx context.Context, height uint64) (*blockResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	block, blockStatus, err := r.api.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{r: r, block: block, status: blockStatus}, nil
+}
+
+func (r *resolver) collectionByID(ctx context.Context, collectionID flow.Identifier) (*collectionResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	collection, err := r.api.GetCollectionByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return &collectionResolver{r: r, collection: collection}, nil
+}
+
+func (r *resolver) transactionByID(ctx context.Context, txID flow.Identifier) (*transactionResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	tx, err := r.api.GetTransaction(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionResolver{r: r, tx: tx}, nil
+}
+
+func (r *resolver) transactionResult(
+	ctx context.Context,
+	txID flow.Identifier,
+	blockID flow.Identifier,
+	collectionID flow.Identifier,
+) (*transactionResultResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetTransactionResult(ctx, txID, blockID, collectionID, eventEncodingVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionResultResolver{r: r, result: result}, nil
+}
+
+func (r *resolver) executionResultByID(ctx context.Context, resultID flow.Identifier) (*executionResultResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetExecutionResultByID(ctx, resultID)
+	if err != nil {
+		return nil, err
+	}
+	return &executionResultResolver{r: r, result: result}, nil
+}
+
+func (r *resolver) executionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*executionResultResolver, error) {
+	if err := charge(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetExecutionResultForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &executionResultResolver{r: r, result: result}, nil
+}
@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Uint64 is the Uint64 scalar of the schema. It is serialized as a decimal string, like the uint64 values
+// of the REST API.
+type Uint64 uint64
+
+// ImplementsGraphQLType maps the type to the Uint64 scalar of the schema.
+func (Uint64) ImplementsGraphQLType(name string) bool {
+	return name == "Uint64"
+}
+
+// UnmarshalGraphQL decodes a Uint64 from a query argument or variable, which may be a string or an integer.
+func (u *Uint64) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := strconv.ParseUint(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Uint64 value %q: %w", input, err)
+		}
+		*u = Uint64(value)
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("invalid Uint64 value %d: value must not be negative", input)
+		}
+		*u = Uint64(input)
+	case float64:
+		// integers in variables are decoded from JSON as float64
+		if input < 0 || input > math.MaxUint64 || input != math.Trunc(input) {
+			return fmt.Errorf("invalid Uint64 value %v", input)
+		}
+		*u = Uint64(input)
+	default:
+		return fmt.Errorf("invalid Uint64 value of type %T", input)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the Uint64 as a decimal string.
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(u), 10))
+}
+
+// toID converts an identifier to a GraphQL ID.
+func toID(id flow.Identifier) graphql.ID {
+	return graphql.ID(id.String())
+}
+
+// toOptionalID converts an identifier to a GraphQL ID, or nil if the identifier is the zero ID.
+func toOptionalID(id flow.Identifier) *graphql.ID {
+	if id == flow.ZeroID {
+		return nil
+	}
+	gqlID := toID(id)
+	return &gqlID
+}
+
+// parseID parses an identifier from a query argument.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.InvalidArgument] if the ID is not a valid identifier.
+func parseID(id graphql.ID) (flow.Identifier, error) {
+	identifier, err := flow.HexStringToIdentifier(string(id))
+	if err != nil {
+		return flow.ZeroID, status.Errorf(codes.InvalidArgument, "invalid ID %q: %v", id, err)
+	}
+	return identifier, nil
+}
+
+// parseOptionalID parses an identifier from an optional query argument, returning the zero ID if the
+// argument was not provided.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.InvalidArgument] if the ID is not a valid identifier.
+func parseOptionalID(id *graphql.ID) (flow.Identifier, error) {
+	if id == nil {
+		return flow.ZeroID, nil
+	}
+	return parseID(*id)
+}
@@ -0,0 +1,37 @@
+package graphql
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// NewSchema parses the GraphQL schema of the access API and binds it to resolvers backed by the given
+// access API.
+//
+// No errors are expected during normal operation.
+func NewSchema(api access.API, chain flow.Chain, config Config) (*graphql.Schema, error) {
+	r := &resolver{
+		api:    api,
+		chain:  chain,
+		config: config,
+	}
+
+	schema, err := graphql.ParseSchema(
+		schemaSDL,
+		r,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(config.MaxQueryDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse graphql schema: %w", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,166 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// transactionResolver resolves the fields of a Transaction.
+type transactionResolver struct {
+	r  *resolver
+	tx *flow.TransactionBody
+}
+
+func (t *transactionResolver) ID() graphql.ID {
+	return toID(t.tx.ID())
+}
+
+func (t *transactionResolver) Script() string {
+	return util.ToBase64(t.tx.Script)
+}
+
+func (t *transactionResolver) Arguments() []string {
+	args := make([]string, len(t.tx.Arguments))
+	for i, arg := range t.tx.Arguments {
+		args[i] = util.ToBase64(arg)
+	}
+	return args
+}
+
+func (t *transactionResolver) ReferenceBlockID() graphql.ID {
+	return toID(t.tx.ReferenceBlockID)
+}
+
+func (t *transactionResolver) GasLimit() Uint64 {
+	return Uint64(t.tx.GasLimit)
+}
+
+func (t *transactionResolver) Payer() string {
+	return t.tx.Payer.String()
+}
+
+func (t *transactionResolver) ProposalKey() *proposalKeyResolver {
+	return &proposalKeyResolver{key: t.tx.ProposalKey}
+}
+
+func (t *transactionResolver) Authorizers() []string {
+	authorizers := make([]string, len(t.tx.Authorizers))
+	for i, authorizer := range t.tx.Authorizers {
+		authorizers[i] = authorizer.String()
+	}
+	return authorizers
+}
+
+func (t *transactionResolver) PayloadSignatures() []*transactionSignatureResolver {
+	return newTransactionSignatureResolvers(t.tx.PayloadSignatures)
+}
+
+func (t *transactionResolver) EnvelopeSignatures() []*transactionSignatureResolver {
+	return newTransactionSignatureResolvers(t.tx.EnvelopeSignatures)
+}
+
+func (t *transactionResolver) Result(ctx context.Context) (*transactionResultResolver, error) {
+	return t.r.transactionResult(ctx, t.tx.ID(), flow.ZeroID, flow.ZeroID)
+}
+
+// proposalKeyResolver resolves the fields of a ProposalKey.
+type proposalKeyResolver struct {
+	key flow.ProposalKey
+}
+
+func (p *proposalKeyResolver) Address() string {
+	return p.key.Address.String()
+}
+
+func (p *proposalKeyResolver) KeyIndex() Uint64 {
+	return Uint64(p.key.KeyIndex)
+}
+
+func (p *proposalKeyResolver) SequenceNumber() Uint64 {
+	return Uint64(p.key.SequenceNumber)
+}
+
+// transactionSignatureResolver resolves the fields of a TransactionSignature.
+type transactionSignatureResolver struct {
+	signature flow.TransactionSignature
+}
+
+func newTransactionSignatureResolvers(signatures []flow.TransactionSignature) []*transactionSignatureResolver {
+	resolvers := make([]*transactionSignatureResolver, len(signatures))
+	for i, signature := range signatures {
+		resolvers[i] = &transactionSignatureResolver{signature: signature}
+	}
+	return resolvers
+}
+
+func (s *transactionSignatureResolver) Address() string {
+	return s.signature.Address.String()
+}
+
+func (s *transactionSignatureResolver) KeyIndex() Uint64 {
+	return Uint64(s.signature.KeyIndex)
+}
+
+func (s *transactionSignatureResolver) Signature() string {
+	return util.ToBase64(s.signature.Signature)
+}
+
+// transactionResultResolver resolves the fields of a TransactionResult.
+type transactionResultResolver struct {
+	r      *resolver
+	result *access.TransactionResult
+}
+
+func (t *transactionResultResolver) TransactionID() graphql.ID {
+	return toID(t.result.TransactionID)
+}
+
+func (t *transactionResultResolver) BlockID() *graphql.ID {
+	return toOptionalID(t.result.BlockID)
+}
+
+func (t *transactionResultResolver) BlockHeight() Uint64 {
+	return Uint64(t.result.BlockHeight)
+}
+
+func (t *transactionResultResolver) CollectionID() *graphql.ID {
+	return toOptionalID(t.result.CollectionID)
+}
+
+func (t *transactionResultResolver) Status() string {
+	return t.result.Status.String()
+}
+
+func (t *transactionResultResolver) StatusCode() int32 {
+	return int32(t.result.StatusCode)
+}
+
+func (t *transactionResultResolver) ErrorMessage() string {
+	return t.result.ErrorMessage
+}
+
+func (t *transactionResultResolver) Events() []*eventResolver {
+	events := make([]*eventResolver, len(t.result.Events))
+	for i, event := range t.result.Events {
+		events[i] = &eventResolver{r: t.r, event: event}
+	}
+	return events
+}
+
+// Block resolves the block which includes the transaction, or nil if the transaction is not included
+// in a block yet.
+func (t *transactionResultResolver) Block(ctx context.Context) (*blockResolver, error) {
+	if t.result.BlockID == flow.ZeroID {
+		return nil, nil
+	}
+	return t.r.blockByID(ctx, t.result.BlockID)
+}
+
+func (t *transactionResultResolver) Transaction(ctx context.Context) (*transactionResolver, error) {
+	return t.r.transactionByID(ctx, t.result.TransactionID)
+}
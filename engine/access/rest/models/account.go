@@ -66,3 +66,44 @@ func (a *AccountPublicKeys) Build(accountKeys []flow.AccountPublicKey) {
 
 	*a = keys
 }
+
+func (a *AccountBalance) Build(balance uint64) {
+	a.Balance = util.FromUint64(balance)
+}
+
+func (a *AccountAvailableBalance) Build(availableBalance uint64) {
+	a.AvailableBalance = util.FromUint64(availableBalance)
+}
+
+func (a *AccountTransaction) Build(tx flow.AccountTransaction, link LinkGenerator) error {
+	a.BlockId = tx.BlockID.String()
+	a.BlockHeight = util.FromUint64(tx.BlockHeight)
+	a.TransactionId = tx.TransactionID.String()
+	a.TransactionIndex = util.FromUint64(uint64(tx.TransactionIndex))
+
+	a.Roles = make([]string, len(tx.Roles))
+	for i, role := range tx.Roles {
+		a.Roles[i] = role.String()
+	}
+
+	self, err := SelfLink(tx.TransactionID, link.TransactionLink)
+	if err != nil {
+		return err
+	}
+	a.Links = self
+
+	return nil
+}
+
+func (a *AccountTransactions) Build(txs []flow.AccountTransaction, links *Links, link LinkGenerator) error {
+	a.Transactions = make([]AccountTransaction, len(txs))
+	for i, tx := range txs {
+		err := a.Transactions[i].Build(tx, link)
+		if err != nil {
+			return err
+		}
+	}
+	a.Links = links
+
+	return nil
+}
@@ -153,3 +153,9 @@ func (a *AggregatedSignature) Build(signature flow.AggregatedSignature) {
 	a.VerifierSignatures = verifierSignatures
 	a.SignerIds = signerIDs
 }
+
+func (b *BlockDigest) Build(digest *flow.BlockDigest) {
+	b.BlockId = digest.ID().String()
+	b.Height = util.FromUint64(digest.Height)
+	b.Timestamp = digest.Timestamp
+}
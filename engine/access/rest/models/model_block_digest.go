@@ -0,0 +1,19 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+import (
+	"time"
+)
+
+type BlockDigest struct {
+	BlockId   string    `json:"block_id"`
+	Height    string    `json:"height"`
+	Timestamp time.Time `json:"timestamp"`
+}
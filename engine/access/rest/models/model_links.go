@@ -10,4 +10,12 @@ package models
 
 type Links struct {
 	Self string `json:"_self,omitempty"`
+	// Link to the next page of a paginated response. Omitted on the last page.
+	Next string `json:"next,omitempty"`
+	// Link to the previous page of a paginated response. Omitted on the first page.
+	Prev string `json:"prev,omitempty"`
+	// Opaque cursor of the next page of a paginated response.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Opaque cursor of the previous page of a paginated response.
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
@@ -0,0 +1,24 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type BlocksPage struct {
+	Blocks []*Block `json:"blocks"`
+	Links  *Links   `json:"_links"`
+}
+
+type BlockEventsPage struct {
+	BlockEvents []BlockEvents `json:"block_events"`
+	Links       *Links        `json:"_links"`
+}
+
+type TransactionResultsPage struct {
+	TransactionResults []TransactionResult `json:"transaction_results"`
+	Links              *Links              `json:"_links"`
+}
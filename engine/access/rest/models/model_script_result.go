@@ -0,0 +1,17 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ScriptResult struct {
+	// Base64 encoded JSON-Cadence value returned by the script.
+	Value           string `json:"value,omitempty"`
+	ComputationUsed string `json:"computation_used"`
+	// Message of the error returned while executing the script.
+	ErrorMessage string `json:"error_message,omitempty"`
+}
@@ -0,0 +1,15 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen.git)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionFees struct {
+	Amount          string `json:"amount"`
+	InclusionEffort string `json:"inclusion_effort"`
+	ExecutionEffort string `json:"execution_effort"`
+}
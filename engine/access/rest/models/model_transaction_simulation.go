@@ -0,0 +1,22 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen.git)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionSimulation struct {
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage    string `json:"error_message"`
+	ComputationUsed string `json:"computation_used"`
+	MemoryEstimate  string `json:"memory_estimate"`
+	// Change of the storage used by each account whose storage was updated, in bytes.
+	StorageDelta map[string]string `json:"storage_delta"`
+	Fees         *TransactionFees  `json:"fees"`
+	Events       []Event           `json:"events"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strconv"
+
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
@@ -149,3 +151,26 @@ func (p *ProposalKey) Build(key flow.ProposalKey) {
 	p.KeyIndex = util.FromUint64(key.KeyIndex)
 	p.SequenceNumber = util.FromUint64(key.SequenceNumber)
 }
+
+func (t *TransactionSimulation) Build(result *access.TransactionSimulationResult) {
+	var events Events
+	events.Build(result.Events)
+
+	storageDelta := make(map[string]string, len(result.StorageDelta))
+	for address, delta := range result.StorageDelta {
+		storageDelta[address.String()] = strconv.FormatInt(delta, 10)
+	}
+
+	t.BlockId = result.BlockID.String()
+	t.BlockHeight = util.FromUint64(result.BlockHeight)
+	t.ErrorMessage = result.ErrorMessage
+	t.ComputationUsed = util.FromUint64(result.ComputationUsed)
+	t.MemoryEstimate = util.FromUint64(result.MemoryEstimate)
+	t.StorageDelta = storageDelta
+	t.Fees = &TransactionFees{
+		Amount:          util.FromUint64(result.Fees.Amount),
+		InclusionEffort: util.FromUint64(result.Fees.InclusionEffort),
+		ExecutionEffort: util.FromUint64(result.Fees.ExecutionEffort),
+	}
+	t.Events = events
+}
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type GetAccountTransactions struct {
+	Address flow.Address
+	Limit   uint32
+	Cursor  *flow.AccountTransactionCursor
+}
+
+func (g *GetAccountTransactions) Build(r *Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountTransactions) Parse(
+	rawAddress string,
+	rawLimit string,
+	rawCursor string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	g.Address = address
+
+	// an empty limit is left as 0, which returns a page of the default size
+	if rawLimit != "" {
+		limit, err := util.ToUint64(rawLimit)
+		if err != nil {
+			return fmt.Errorf("invalid limit: %w", err)
+		}
+		if limit > math.MaxUint32 {
+			return fmt.Errorf("invalid limit: value must be at most %d", uint32(math.MaxUint32))
+		}
+		g.Limit = uint32(limit)
+	}
+
+	if rawCursor != "" {
+		cursor, err := util.ToAccountTransactionCursor(rawCursor)
+		if err != nil {
+			return err
+		}
+		g.Cursor = cursor
+	}
+
+	return nil
+}
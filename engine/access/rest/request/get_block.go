@@ -13,6 +13,7 @@ const MaxBlockRequestHeightRange = 50
 const idParam = "id"
 
 type GetBlock struct {
+	Pagination
 	Heights      []uint64
 	StartHeight  uint64
 	EndHeight    uint64
@@ -21,6 +22,15 @@ type GetBlock struct {
 }
 
 func (g *GetBlock) Build(r *Request) error {
+	err := g.Pagination.Parse(
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		MaxBlockRequestHeightRange,
+	)
+	if err != nil {
+		return err
+	}
+
 	return g.Parse(
 		r.GetQueryParams(heightQuery),
 		r.GetQueryParam(startHeightQuery),
@@ -62,11 +72,16 @@ func (g *GetBlock) Parse(rawHeights []string, rawStart string, rawEnd string) er
 		return fmt.Errorf("must provide either heights or start and end height range")
 	}
 
+	// paginated requests can span any range, since each page is limited instead
+	if g.Paginated() && len(g.Heights) > 0 {
+		return fmt.Errorf("pagination is only supported for start and end height ranges")
+	}
+
 	if g.StartHeight > g.EndHeight {
 		return fmt.Errorf("start height must be less than or equal to end height")
 	}
 	// check if range exceeds maximum but only if end is not equal to special value which is not known yet
-	if g.EndHeight-g.StartHeight >= MaxBlockRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
+	if !g.Paginated() && g.EndHeight-g.StartHeight >= MaxBlockRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
 		return fmt.Errorf("height range %d exceeds maximum allowed of %d", g.EndHeight-g.StartHeight, MaxBlockRequestHeightRange)
 	}
 
@@ -11,6 +11,7 @@ const blockQuery = "block_ids"
 const MaxEventRequestHeightRange = 250
 
 type GetEvents struct {
+	Pagination
 	StartHeight uint64
 	EndHeight   uint64
 	Type        string
@@ -18,6 +19,15 @@ type GetEvents struct {
 }
 
 func (g *GetEvents) Build(r *Request) error {
+	err := g.Pagination.Parse(
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		MaxEventRequestHeightRange,
+	)
+	if err != nil {
+		return err
+	}
+
 	return g.Parse(
 		r.GetQueryParam(eventTypeQuery),
 		r.GetQueryParam(startHeightQuery),
@@ -51,6 +61,10 @@ func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlo
 		return fmt.Errorf("can only provide either block IDs or start and end height range")
 	}
 
+	if g.Paginated() && len(blockIDs) > 0 {
+		return fmt.Errorf("pagination is only supported for start and end height ranges")
+	}
+
 	// if neither height nor start and end height are provided
 	if len(blockIDs) == 0 && (g.StartHeight == EmptyHeight || g.EndHeight == EmptyHeight) {
 		return fmt.Errorf("must provide either block IDs or start and end height range")
@@ -72,7 +86,8 @@ func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlo
 			return fmt.Errorf("start height must be less than or equal to end height")
 		}
 		// check if range exceeds maximum but only if end is not equal to special value which is not known yet
-		if g.EndHeight-g.StartHeight >= MaxEventRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
+		// paginated requests can span any range, since each page is limited instead
+		if !g.Paginated() && g.EndHeight-g.StartHeight >= MaxEventRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
 			return fmt.Errorf("height range %d exceeds maximum allowed of %d", g.EndHeight-g.StartHeight, MaxEventRequestHeightRange)
 		}
 	}
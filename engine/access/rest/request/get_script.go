@@ -3,27 +3,32 @@ package request
 import (
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/onflow/flow-go/model/flow"
 )
 
 const blockIDQuery = "block_id"
+const skipCacheQuery = "skip_cache"
 
 type GetScript struct {
 	BlockID     flow.Identifier
 	BlockHeight uint64
 	Script      Script
+	// SkipCache is true if the script must be executed even if a cached result is available
+	SkipCache bool
 }
 
 func (g *GetScript) Build(r *Request) error {
 	return g.Parse(
 		r.GetQueryParam(blockHeightQuery),
 		r.GetQueryParam(blockIDQuery),
+		r.GetQueryParam(skipCacheQuery),
 		r.Body,
 	)
 }
 
-func (g *GetScript) Parse(rawHeight string, rawID string, rawScript io.Reader) error {
+func (g *GetScript) Parse(rawHeight string, rawID string, rawSkipCache string, rawScript io.Reader) error {
 	var height Height
 	err := height.Parse(rawHeight)
 	if err != nil {
@@ -45,6 +50,14 @@ func (g *GetScript) Parse(rawHeight string, rawID string, rawScript io.Reader) e
 	}
 	g.Script = script
 
+	g.SkipCache = false
+	if rawSkipCache != "" {
+		g.SkipCache, err = strconv.ParseBool(rawSkipCache)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s", skipCacheQuery)
+		}
+	}
+
 	// default to last sealed block
 	if g.BlockHeight == EmptyHeight && g.BlockID == flow.ZeroID {
 		g.BlockHeight = SealedHeight
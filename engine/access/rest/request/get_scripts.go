@@ -0,0 +1,78 @@
+package request
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MaxScriptsPerBatch is the max number of scripts accepted in a single batch request.
+// The backend may enforce a lower limit.
+const MaxScriptsPerBatch = 500
+
+type scriptsBody struct {
+	Scripts []scriptBody `json:"scripts"`
+}
+
+// GetScripts is a request to execute a batch of scripts at the same block.
+type GetScripts struct {
+	BlockID     flow.Identifier
+	BlockHeight uint64
+	Scripts     []Script
+}
+
+func (g *GetScripts) Build(r *Request) error {
+	return g.Parse(
+		r.GetQueryParam(blockHeightQuery),
+		r.GetQueryParam(blockIDQuery),
+		r.Body,
+	)
+}
+
+func (g *GetScripts) Parse(rawHeight string, rawID string, rawScripts io.Reader) error {
+	var height Height
+	err := height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	g.BlockHeight = height.Flow()
+
+	var id ID
+	err = id.Parse(rawID)
+	if err != nil {
+		return err
+	}
+	g.BlockID = id.Flow()
+
+	var body scriptsBody
+	err = parseBody(rawScripts, &body)
+	if err != nil {
+		return err
+	}
+	if len(body.Scripts) == 0 {
+		return fmt.Errorf("at least one script must be provided")
+	}
+	if len(body.Scripts) > MaxScriptsPerBatch {
+		return fmt.Errorf("at most %d scripts can be requested at once", MaxScriptsPerBatch)
+	}
+
+	g.Scripts = make([]Script, len(body.Scripts))
+	for i, rawScript := range body.Scripts {
+		err = g.Scripts[i].parse(rawScript)
+		if err != nil {
+			return fmt.Errorf("invalid script at index %d: %w", i, err)
+		}
+	}
+
+	// default to last sealed block
+	if g.BlockHeight == EmptyHeight && g.BlockID == flow.ZeroID {
+		g.BlockHeight = SealedHeight
+	}
+
+	if g.BlockID != flow.ZeroID && g.BlockHeight != EmptyHeight {
+		return fmt.Errorf("can not provide both block ID and block height")
+	}
+
+	return nil
+}
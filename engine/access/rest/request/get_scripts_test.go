@@ -0,0 +1,60 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+func TestGetScripts_InvalidParse(t *testing.T) {
+	var getScripts GetScripts
+
+	validScript := fmt.Sprintf(`{ "script": "%s", "arguments": [] }`, util.ToBase64([]byte(`access(all) fun main() {}`)))
+	validBody := fmt.Sprintf(`{ "scripts": [%s] }`, validScript)
+	tooMany := fmt.Sprintf(`{ "scripts": [%s] }`, strings.TrimSuffix(strings.Repeat(validScript+",", MaxScriptsPerBatch+1), ","))
+
+	tests := []struct {
+		height  string
+		id      string
+		scripts string
+		err     string
+	}{
+		{"", "", "", "request body must not be empty"},
+		{"", "", `{ "scripts": [] }`, "at least one script must be provided"},
+		{"", "", tooMany, fmt.Sprintf("at most %d scripts can be requested at once", MaxScriptsPerBatch)},
+		{"", "", `{ "scripts": [{ "script": "!" }] }`, "invalid script at index 0: invalid script source encoding"},
+		{"1", "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7", validBody, "can not provide both block ID and block height"},
+		{"", "2", validBody, "invalid ID format"},
+		{"1", "", `{ "foo": "zoo" }`, `request body contains unknown field "foo"`},
+	}
+
+	for i, test := range tests {
+		err := getScripts.Parse(test.height, test.id, strings.NewReader(test.scripts))
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestGetScripts_ValidParse(t *testing.T) {
+	var getScripts GetScripts
+
+	source1 := "access(all) fun main() {}"
+	source2 := "access(all) fun main(): Int { return 1 }"
+	body := fmt.Sprintf(`{ "scripts": [{ "script": "%s", "arguments": [] }, { "script": "%s" }] }`,
+		util.ToBase64([]byte(source1)), util.ToBase64([]byte(source2)))
+
+	err := getScripts.Parse("1", "", strings.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), getScripts.BlockHeight)
+	require.Len(t, getScripts.Scripts, 2)
+	assert.Equal(t, source1, string(getScripts.Scripts[0].Source))
+	assert.Equal(t, source2, string(getScripts.Scripts[1].Source))
+
+	err = getScripts.Parse("", "", strings.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, SealedHeight, getScripts.BlockHeight)
+}
@@ -1,10 +1,15 @@
 package request
 
-import "github.com/onflow/flow-go/model/flow"
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
 
 const resultExpandable = "result"
 const blockIDQueryParam = "block_id"
 const collectionIDQueryParam = "collection_id"
+const MaxTransactionResultsPageSize = 100
 
 type TransactionOptionals struct {
 	BlockID      flow.Identifier
@@ -62,3 +67,36 @@ func (g *GetTransactionResult) Build(r *Request) error {
 
 	return err
 }
+
+type GetTransactionResultsByBlockID struct {
+	Pagination
+	BlockID flow.Identifier
+}
+
+func (g *GetTransactionResultsByBlockID) Build(r *Request) error {
+	err := g.Pagination.Parse(
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		MaxTransactionResultsPageSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	return g.Parse(r.GetQueryParam(blockIDQueryParam))
+}
+
+func (g *GetTransactionResultsByBlockID) Parse(rawBlockID string) error {
+	if rawBlockID == "" {
+		return fmt.Errorf("block ID must be provided")
+	}
+
+	var blockID ID
+	err := blockID.Parse(rawBlockID)
+	if err != nil {
+		return err
+	}
+	g.BlockID = blockID.Flow()
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+const limitQuery = "limit"
+const cursorQuery = "cursor"
+
+// Pagination contains the parameters of cursor based pagination.
+// A paginated request returns a page of at most Limit items starting at the position encoded in the
+// cursor, or at the first item if no cursor is provided.
+type Pagination struct {
+	// Limit is 0 if the request is not paginated
+	Limit uint64
+	// Cursor is the position of the first item of the page, or nil for the first page
+	Cursor *uint64
+}
+
+// Paginated returns true if the request asks for a paginated response.
+func (p *Pagination) Paginated() bool {
+	return p.Limit > 0
+}
+
+func (p *Pagination) Parse(rawLimit string, rawCursor string, maxLimit uint64) error {
+	if rawLimit == "" {
+		if rawCursor != "" {
+			return fmt.Errorf("limit must be provided when using a cursor")
+		}
+		return nil
+	}
+
+	limit, err := util.ToUint64(rawLimit)
+	if err != nil {
+		return fmt.Errorf("invalid limit: %w", err)
+	}
+	if limit == 0 || limit > maxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+	p.Limit = limit
+
+	if rawCursor != "" {
+		values, err := util.DecodeCursor(rawCursor, 1)
+		if err != nil {
+			return err
+		}
+		p.Cursor = &values[0]
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+func TestPagination_InvalidParse(t *testing.T) {
+	tests := []struct {
+		limit  string
+		cursor string
+		err    string
+	}{
+		{"", util.EncodeCursor(1), "limit must be provided when using a cursor"},
+		{"foo", "", "invalid limit: value must be an unsigned 64 bit integer"},
+		{"0", "", "limit must be between 1 and 10"},
+		{"11", "", "limit must be between 1 and 10"},
+		{"5", "foo", "invalid cursor"},
+		{"5", util.EncodeCursor(1, 2), "invalid cursor"},
+	}
+
+	for _, test := range tests {
+		var pagination Pagination
+		err := pagination.Parse(test.limit, test.cursor, 10)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test: limit %s, cursor %s", test.limit, test.cursor))
+	}
+}
+
+func TestPagination_ValidParse(t *testing.T) {
+	var pagination Pagination
+	err := pagination.Parse("", "", 10)
+	require.NoError(t, err)
+	assert.False(t, pagination.Paginated())
+
+	err = pagination.Parse("5", "", 10)
+	require.NoError(t, err)
+	assert.True(t, pagination.Paginated())
+	assert.Equal(t, uint64(5), pagination.Limit)
+	assert.Nil(t, pagination.Cursor)
+
+	err = pagination.Parse("10", util.EncodeCursor(42), 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), pagination.Limit)
+	require.NotNil(t, pagination.Cursor)
+	assert.Equal(t, uint64(42), *pagination.Cursor)
+}
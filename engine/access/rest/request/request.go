@@ -24,6 +24,12 @@ func (rd *Request) GetScriptRequest() (GetScript, error) {
 	return req, err
 }
 
+func (rd *Request) GetScriptsRequest() (GetScripts, error) {
+	var req GetScripts
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetBlockRequest() (GetBlock, error) {
 	var req GetBlock
 	err := req.Build(rd)
@@ -60,6 +66,24 @@ func (rd *Request) GetAccountKeyRequest() (GetAccountKey, error) {
 	return req, err
 }
 
+func (rd *Request) GetAccountKeysRequest() (GetAccountKeys, error) {
+	var req GetAccountKeys
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountBalanceRequest() (GetAccountBalance, error) {
+	var req GetAccountBalance
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountTransactionsRequest() (GetAccountTransactions, error) {
+	var req GetAccountTransactions
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetExecutionResultByBlockIDsRequest() (GetExecutionResultByBlockIDs, error) {
 	var req GetExecutionResultByBlockIDs
 	err := req.Build(rd)
@@ -84,6 +108,12 @@ func (rd *Request) GetTransactionResultRequest() (GetTransactionResult, error) {
 	return req, err
 }
 
+func (rd *Request) GetTransactionResultsByBlockIDRequest() (GetTransactionResultsByBlockID, error) {
+	var req GetTransactionResultsByBlockID
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetEventsRequest() (GetEvents, error) {
 	var req GetEvents
 	err := req.Build(rd)
@@ -96,12 +126,30 @@ func (rd *Request) CreateTransactionRequest() (CreateTransaction, error) {
 	return req, err
 }
 
+func (rd *Request) SimulateTransactionRequest() (SimulateTransaction, error) {
+	var req SimulateTransaction
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) SubscribeEventsRequest() (SubscribeEvents, error) {
 	var req SubscribeEvents
 	err := req.Build(rd)
 	return req, err
 }
 
+func (rd *Request) SubscribeAccountStatusesRequest() (SubscribeAccountStatuses, error) {
+	var req SubscribeAccountStatuses
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) SubscribeBlocksRequest() (SubscribeBlocks, error) {
+	var req SubscribeBlocks
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) Expands(field string) bool {
 	return rd.ExpandFields[field]
 }
@@ -129,6 +177,12 @@ func (rd *Request) GetQueryParams(name string) []string {
 	return toStringArray(param)
 }
 
+// GetQueryParamValues returns all values of a repeated query parameter. Unlike GetQueryParams, the
+// values are not split on commas.
+func (rd *Request) GetQueryParamValues(name string) []string {
+	return rd.Request.URL.Query()[name]
+}
+
 // Decorate takes http request and applies functions to produce our custom
 // request object decorated with values we need
 func Decorate(r *http.Request, chain flow.Chain) *Request {
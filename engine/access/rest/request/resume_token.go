@@ -0,0 +1,33 @@
+package request
+
+import (
+	"github.com/onflow/flow-go/engine/access/subscription"
+)
+
+const resumeTokenQuery = "resume_token"
+
+// ResumeToken is the resume token of a subscription, from which the subscription resumes instead of
+// its start block.
+type ResumeToken struct {
+	token *subscription.ResumeToken
+}
+
+func (r *ResumeToken) Parse(raw string) error {
+	if raw == "" { // allow empty
+		r.token = nil
+		return nil
+	}
+
+	token, err := subscription.ParseResumeToken(raw)
+	if err != nil {
+		return err
+	}
+
+	r.token = &token
+	return nil
+}
+
+// Flow returns the parsed resume token, or nil if no token was provided.
+func (r ResumeToken) Flow() *subscription.ResumeToken {
+	return r.token
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const skipSignatureVerificationQuery = "skip_signature_verification"
+
+type SimulateTransaction struct {
+	Transaction flow.TransactionBody
+	// SkipSignatureVerification is true if the signatures and sequence number of the transaction must not be checked
+	SkipSignatureVerification bool
+}
+
+func (s *SimulateTransaction) Build(r *Request) error {
+	return s.Parse(r.GetQueryParam(skipSignatureVerificationQuery), r.Body, r.Chain)
+}
+
+func (s *SimulateTransaction) Parse(rawSkipSignatureVerification string, rawTransaction io.Reader, chain flow.Chain) error {
+	s.SkipSignatureVerification = false
+	if rawSkipSignatureVerification != "" {
+		var err error
+		s.SkipSignatureVerification, err = strconv.ParseBool(rawSkipSignatureVerification)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s", skipSignatureVerificationQuery)
+		}
+	}
+
+	var tx Transaction
+	err := tx.parse(rawTransaction, chain, !s.SkipSignatureVerification)
+	if err != nil {
+		return err
+	}
+
+	s.Transaction = tx.Flow()
+	return nil
+}
@@ -0,0 +1,104 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SubscribeAccountStatuses holds the parameters of an account statuses subscription.
+type SubscribeAccountStatuses struct {
+	StartBlockID flow.Identifier
+	StartHeight  uint64
+	// ResumeToken is the token the subscription resumes from, or nil. If provided, StartHeight is the
+	// height of the token.
+	ResumeToken *subscription.ResumeToken
+
+	EventTypes []string
+	Addresses  []string
+
+	HeartbeatInterval uint64
+}
+
+func (s *SubscribeAccountStatuses) Build(r *Request) error {
+	return s.Parse(
+		r.GetQueryParam(startBlockIdQuery),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(resumeTokenQuery),
+		r.GetQueryParams(eventTypesQuery),
+		r.GetQueryParams(addressesQuery),
+		r.GetQueryParam(heartbeatIntervalQuery),
+	)
+}
+
+// Parse parses the raw subscription parameters.
+// If neither start block ID nor start height are provided, StartBlockID is flow.ZeroID and StartHeight
+// is EmptyHeight, and the subscription starts from the latest block.
+// A resume token replaces the start block, and sets StartHeight to the height of the token.
+func (s *SubscribeAccountStatuses) Parse(
+	rawStartBlockID string,
+	rawStartHeight string,
+	rawResumeToken string,
+	rawTypes []string,
+	rawAddresses []string,
+	rawHeartbeatInterval string,
+) error {
+	var startBlockID ID
+	err := startBlockID.Parse(rawStartBlockID)
+	if err != nil {
+		return err
+	}
+	s.StartBlockID = startBlockID.Flow()
+
+	var height Height
+	err = height.Parse(rawStartHeight)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	s.StartHeight = height.Flow()
+
+	// if both start_block_id and start_height are provided
+	if s.StartBlockID != flow.ZeroID && s.StartHeight != EmptyHeight {
+		return fmt.Errorf("can only provide either block ID or start height")
+	}
+
+	var resumeToken ResumeToken
+	err = resumeToken.Parse(rawResumeToken)
+	if err != nil {
+		return err
+	}
+	s.ResumeToken = resumeToken.Flow()
+
+	// the resume token replaces the start block
+	if s.ResumeToken != nil {
+		if s.StartBlockID != flow.ZeroID || s.StartHeight != EmptyHeight {
+			return fmt.Errorf("can only provide either resume token or start block")
+		}
+		s.StartHeight = s.ResumeToken.Height
+	}
+
+	var eventTypes EventTypes
+	err = eventTypes.Parse(rawTypes)
+	if err != nil {
+		return err
+	}
+
+	s.EventTypes = eventTypes.Flow()
+	s.Addresses = rawAddresses
+
+	// parse heartbeat interval
+	if rawHeartbeatInterval == "" {
+		// set zero if the interval wasn't passed in request, so we can check it later and apply any default value if needed
+		s.HeartbeatInterval = 0
+		return nil
+	}
+
+	s.HeartbeatInterval, err = strconv.ParseUint(rawHeartbeatInterval, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid heartbeat interval format")
+	}
+
+	return nil
+}
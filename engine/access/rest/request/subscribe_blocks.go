@@ -0,0 +1,96 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const blockStatusQuery = "block_status"
+
+// SubscribeBlocks holds the parameters of a block, block header or block digest subscription.
+type SubscribeBlocks struct {
+	StartBlockID flow.Identifier
+	StartHeight  uint64
+	// ResumeToken is the token the subscription resumes from, or nil. If provided, StartHeight is the
+	// height of the token.
+	ResumeToken *subscription.ResumeToken
+
+	BlockStatus flow.BlockStatus
+}
+
+func (s *SubscribeBlocks) Build(r *Request) error {
+	return s.Parse(
+		r.GetQueryParam(startBlockIdQuery),
+		r.GetQueryParam(startHeightQuery),
+		// the block streaming endpoints of the REST API do not issue resume tokens
+		"",
+		r.GetQueryParam(blockStatusQuery),
+	)
+}
+
+// Parse parses the raw subscription parameters.
+// If neither start block ID nor start height are provided, StartBlockID is flow.ZeroID and StartHeight
+// is EmptyHeight, and the subscription starts from the latest block.
+// A resume token replaces the start block, and sets StartHeight to the height of the token.
+// If no block status is provided, finalized blocks are streamed.
+func (s *SubscribeBlocks) Parse(
+	rawStartBlockID string,
+	rawStartHeight string,
+	rawResumeToken string,
+	rawBlockStatus string,
+) error {
+	var startBlockID ID
+	err := startBlockID.Parse(rawStartBlockID)
+	if err != nil {
+		return err
+	}
+	s.StartBlockID = startBlockID.Flow()
+
+	var height Height
+	err = height.Parse(rawStartHeight)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	s.StartHeight = height.Flow()
+
+	// if both start_block_id and start_height are provided
+	if s.StartBlockID != flow.ZeroID && s.StartHeight != EmptyHeight {
+		return fmt.Errorf("can only provide either block ID or start height")
+	}
+
+	var resumeToken ResumeToken
+	err = resumeToken.Parse(rawResumeToken)
+	if err != nil {
+		return err
+	}
+	s.ResumeToken = resumeToken.Flow()
+
+	// the resume token replaces the start block
+	if s.ResumeToken != nil {
+		if s.StartBlockID != flow.ZeroID || s.StartHeight != EmptyHeight {
+			return fmt.Errorf("can only provide either resume token or start block")
+		}
+		s.StartHeight = s.ResumeToken.Height
+	}
+
+	s.BlockStatus, err = parseBlockStatus(rawBlockStatus)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseBlockStatus parses the block status of a block subscription, defaulting to finalized.
+func parseBlockStatus(raw string) (flow.BlockStatus, error) {
+	switch raw {
+	case "", final:
+		return flow.BlockStatusFinalized, nil
+	case sealed:
+		return flow.BlockStatusSealed, nil
+	default:
+		return flow.BlockStatusUnknown, fmt.Errorf("invalid block status, must be either '%s' or '%s'", final, sealed)
+	}
+}
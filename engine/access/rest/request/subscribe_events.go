@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -12,15 +13,21 @@ const eventTypesQuery = "event_types"
 const addressesQuery = "addresses"
 const contractsQuery = "contracts"
 const heartbeatIntervalQuery = "heartbeat_interval"
+const fieldPredicatesQuery = "field_predicates"
 
 type SubscribeEvents struct {
 	StartBlockID flow.Identifier
 	StartHeight  uint64
+	// ResumeToken is the token the subscription resumes from, or nil. If provided, StartHeight is the
+	// height of the token.
+	ResumeToken *subscription.ResumeToken
 
 	EventTypes []string
 	Addresses  []string
 	Contracts  []string
 
+	FieldPredicates []string
+
 	HeartbeatInterval uint64
 }
 
@@ -28,9 +35,11 @@ func (g *SubscribeEvents) Build(r *Request) error {
 	return g.Parse(
 		r.GetQueryParam(startBlockIdQuery),
 		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(resumeTokenQuery),
 		r.GetQueryParams(eventTypesQuery),
 		r.GetQueryParams(addressesQuery),
 		r.GetQueryParams(contractsQuery),
+		r.GetQueryParamValues(fieldPredicatesQuery),
 		r.GetQueryParam(heartbeatIntervalQuery),
 	)
 }
@@ -38,9 +47,11 @@ func (g *SubscribeEvents) Build(r *Request) error {
 func (g *SubscribeEvents) Parse(
 	rawStartBlockID string,
 	rawStartHeight string,
+	rawResumeToken string,
 	rawTypes []string,
 	rawAddresses []string,
 	rawContracts []string,
+	rawFieldPredicates []string,
 	rawHeartbeatInterval string,
 ) error {
 	var startBlockID ID
@@ -62,6 +73,21 @@ func (g *SubscribeEvents) Parse(
 		return fmt.Errorf("can only provide either block ID or start height")
 	}
 
+	var resumeToken ResumeToken
+	err = resumeToken.Parse(rawResumeToken)
+	if err != nil {
+		return err
+	}
+	g.ResumeToken = resumeToken.Flow()
+
+	// the resume token replaces the start block
+	if g.ResumeToken != nil {
+		if g.StartBlockID != flow.ZeroID || g.StartHeight != EmptyHeight {
+			return fmt.Errorf("can only provide either resume token or start block")
+		}
+		g.StartHeight = g.ResumeToken.Height
+	}
+
 	// default to root block
 	if g.StartHeight == EmptyHeight {
 		g.StartHeight = 0
@@ -76,6 +102,7 @@ func (g *SubscribeEvents) Parse(
 	g.EventTypes = eventTypes.Flow()
 	g.Addresses = rawAddresses
 	g.Contracts = rawContracts
+	g.FieldPredicates = rawFieldPredicates
 
 	// parse heartbeat interval
 	if rawHeartbeatInterval == "" {
@@ -0,0 +1,88 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SubscribeExecutionData holds the parameters of a filtered execution data subscription.
+type SubscribeExecutionData struct {
+	StartBlockID flow.Identifier
+	StartHeight  uint64
+	// ResumeToken is the token the subscription resumes from, or nil. If provided, StartHeight is the
+	// height of the token.
+	ResumeToken *subscription.ResumeToken
+
+	Addresses []string
+	// RegisterKeyPrefixes are base64 encoded, the same way register keys are encoded in the responses.
+	RegisterKeyPrefixes []string
+
+	HeartbeatInterval uint64
+}
+
+// Parse parses the raw subscription parameters.
+// If neither start block ID nor start height are provided, StartBlockID is flow.ZeroID and StartHeight
+// is EmptyHeight, and the subscription starts from the latest block.
+// A resume token replaces the start block, and sets StartHeight to the height of the token.
+func (s *SubscribeExecutionData) Parse(
+	rawStartBlockID string,
+	rawStartHeight string,
+	rawResumeToken string,
+	rawAddresses []string,
+	rawRegisterKeyPrefixes []string,
+	rawHeartbeatInterval string,
+) error {
+	var startBlockID ID
+	err := startBlockID.Parse(rawStartBlockID)
+	if err != nil {
+		return err
+	}
+	s.StartBlockID = startBlockID.Flow()
+
+	var height Height
+	err = height.Parse(rawStartHeight)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	s.StartHeight = height.Flow()
+
+	// if both start_block_id and start_height are provided
+	if s.StartBlockID != flow.ZeroID && s.StartHeight != EmptyHeight {
+		return fmt.Errorf("can only provide either block ID or start height")
+	}
+
+	var resumeToken ResumeToken
+	err = resumeToken.Parse(rawResumeToken)
+	if err != nil {
+		return err
+	}
+	s.ResumeToken = resumeToken.Flow()
+
+	// the resume token replaces the start block
+	if s.ResumeToken != nil {
+		if s.StartBlockID != flow.ZeroID || s.StartHeight != EmptyHeight {
+			return fmt.Errorf("can only provide either resume token or start block")
+		}
+		s.StartHeight = s.ResumeToken.Height
+	}
+
+	s.Addresses = rawAddresses
+	s.RegisterKeyPrefixes = rawRegisterKeyPrefixes
+
+	// parse heartbeat interval
+	if rawHeartbeatInterval == "" {
+		// set zero if the interval wasn't passed in request, so we can check it later and apply any default value if needed
+		s.HeartbeatInterval = 0
+		return nil
+	}
+
+	s.HeartbeatInterval, err = strconv.ParseUint(rawHeartbeatInterval, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid heartbeat interval format")
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountBalance tests local getAccountBalance request.
+//
+// Runs the following tests:
+// 1. Get balance by address at latest sealed block.
+// 2. Get balance by address at latest finalized block.
+// 3. Get balance by address at height.
+// 4. Get balance by missing address.
+func TestGetAccountBalance(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("get balance by address at latest sealed block", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountBalanceRequest(t, account, "balance", sealedHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Balance, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{"balance": "%d"}`, account.Balance)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get balance by address at latest finalized block", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountBalanceRequest(t, account, "balance", finalHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, false).
+			Return(block, flow.BlockStatusFinalized, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Balance, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{"balance": "%d"}`, account.Balance)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get balance by address at height", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountBalanceRequest(t, account, "balance", "1337")
+
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Balance, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{"balance": "%d"}`, account.Balance)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get balance by missing address", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountBalanceRequest(t, account, "balance", "1337")
+
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(uint64(0), status.Errorf(codes.NotFound, "account not found")).
+			Once()
+
+		expected := `{"code": 404, "message": "Flow resource not found: account not found"}`
+
+		assertResponse(t, req, http.StatusNotFound, expected, backend)
+	})
+}
+
+// TestGetAccountAvailableBalance tests local getAccountAvailableBalance request.
+func TestGetAccountAvailableBalance(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	account := accountFixture(t)
+	var height uint64 = 100
+	block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+	availableBalance := account.Balance - 1
+
+	req := getAccountBalanceRequest(t, account, "available_balance", sealedHeightQueryParam)
+
+	backend.Mock.
+		On("GetLatestBlockHeader", mocktestify.Anything, true).
+		Return(block, flow.BlockStatusSealed, nil)
+
+	backend.Mock.
+		On("GetAccountAvailableBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+		Return(availableBalance, nil)
+
+	expected := fmt.Sprintf(`{"available_balance": "%d"}`, availableBalance)
+
+	assertOKResponse(t, req, expected, backend)
+}
+
+func getAccountBalanceRequest(t *testing.T, account *flow.Account, resource string, height string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/%s", account.Address.String(), resource))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+
+	return req
+}
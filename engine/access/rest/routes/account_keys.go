@@ -27,20 +27,41 @@ func GetAccountKeyByIndex(r *request.Request, backend access.API, link models.Li
 		req.Height = header.Height
 	}
 
-	account, err := backend.GetAccountAtBlockHeight(r.Context(), req.Address, req.Height)
+	accountKey, err := backend.GetAccountKeyByIndexAtBlockHeight(r.Context(), req.Address, req.Index, req.Height)
 	if err != nil {
-		err := fmt.Errorf("account with address: %s does not exist", req.Address)
-		return nil, models.NewNotFoundError(err.Error(), err)
+		return nil, err
 	}
 
 	var response models.AccountPublicKey
-	for _, key := range account.Keys {
-		if key.Index == int(req.Index) {
-			response.Build(key)
-			return response, nil
+	response.Build(*accountKey)
+	return response, nil
+}
+
+// GetAccountKeys handler retrieves all keys of an account by address and returns the response
+func GetAccountKeys(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountKeysRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	// In case we receive special height values 'final' and 'sealed',
+	// fetch that height and overwrite request with it.
+	if req.Height == request.FinalHeight || req.Height == request.SealedHeight {
+		isSealed := req.Height == request.SealedHeight
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), isSealed)
+		if err != nil {
+			err := fmt.Errorf("block with height: %d does not exist", req.Height)
+			return nil, models.NewNotFoundError(err.Error(), err)
 		}
+		req.Height = header.Height
+	}
+
+	accountKeys, err := backend.GetAccountKeysAtBlockHeight(r.Context(), req.Address, req.Height)
+	if err != nil {
+		return nil, err
 	}
 
-	err = fmt.Errorf("account key with index: %d does not exist", req.Index)
-	return nil, models.NewNotFoundError(err.Error(), err)
+	var response models.AccountPublicKeys
+	response.Build(accountKeys)
+	return response, nil
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	mocktestify "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/model/flow"
@@ -43,8 +45,8 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			Return(block, flow.BlockStatusSealed, nil)
 
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
-			Return(account, nil)
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(0), height).
+			Return(&account.Keys[0], nil)
 
 		expected := expectedAccountKeyResponse(account)
 
@@ -64,8 +66,8 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			Return(block, flow.BlockStatusFinalized, nil)
 
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
-			Return(account, nil)
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(0), height).
+			Return(&account.Keys[0], nil)
 
 		expected := expectedAccountKeyResponse(account)
 
@@ -85,15 +87,16 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			On("GetLatestBlockHeader", mocktestify.Anything, true).
 			Return(block, flow.BlockStatusSealed, nil)
 
+		err := status.Errorf(codes.NotFound, "account key with index %s not found", index)
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
-			Return(account, nil)
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(2), height).
+			Return(nil, err)
 
 		statusCode := 404
 		expected := fmt.Sprintf(`
           {
             "code": %d,
-            "message": "account key with index: %s does not exist"
+            "message": "Flow resource not found: account key with index %s not found"
           }
 		`, statusCode, index)
 
@@ -113,15 +116,16 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			On("GetLatestBlockHeader", mocktestify.Anything, false).
 			Return(block, flow.BlockStatusFinalized, nil)
 
+		err := status.Errorf(codes.NotFound, "account key with index %s not found", index)
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
-			Return(account, nil)
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(2), height).
+			Return(nil, err)
 
 		statusCode := 404
 		expected := fmt.Sprintf(`
           {
             "code": %d,
-            "message": "account key with index: %s does not exist"
+            "message": "Flow resource not found: account key with index %s not found"
           }
 		`, statusCode, index)
 
@@ -141,16 +145,16 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			On("GetLatestBlockHeader", mocktestify.Anything, true).
 			Return(block, flow.BlockStatusSealed, nil)
 
-		err := fmt.Errorf("account with address: %s does not exist", account.Address)
+		err := status.Errorf(codes.NotFound, "account with address %s not found", account.Address)
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(2), height).
 			Return(nil, err)
 
 		statusCode := 404
 		expected := fmt.Sprintf(`
           {
             "code": %d,
-            "message": "account with address: %s does not exist"
+            "message": "Flow resource not found: account with address %s not found"
           }
 		`, statusCode, account.Address)
 
@@ -170,16 +174,16 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 			On("GetLatestBlockHeader", mocktestify.Anything, false).
 			Return(block, flow.BlockStatusFinalized, nil)
 
-		err := fmt.Errorf("account with address: %s does not exist", account.Address)
+		err := status.Errorf(codes.NotFound, "account with address %s not found", account.Address)
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(2), height).
 			Return(nil, err)
 
 		statusCode := 404
 		expected := fmt.Sprintf(`
           {
             "code": %d,
-            "message": "account with address: %s does not exist"
+            "message": "Flow resource not found: account with address %s not found"
           }
 		`, statusCode, account.Address)
 
@@ -193,8 +197,8 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 		req := getAccountKeyByIndexRequest(t, account, "0", "1337")
 
 		backend.Mock.
-			On("GetAccountAtBlockHeight", mocktestify.Anything, account.Address, height).
-			Return(account, nil)
+			On("GetAccountKeyByIndexAtBlockHeight", mocktestify.Anything, account.Address, uint64(0), height).
+			Return(&account.Keys[0], nil)
 
 		expected := expectedAccountKeyResponse(account)
 
@@ -268,6 +272,88 @@ func TestGetAccountKeyByIndex(t *testing.T) {
 	}
 }
 
+// TestGetAccountKeys tests local getAccountKeys request.
+//
+// Runs the following tests:
+// 1. Get keys by address at latest sealed block.
+// 2. Get keys by address at height.
+// 3. Get keys by missing address.
+func TestGetAccountKeys(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("get keys by address at latest sealed block", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountKeysRequest(t, account, sealedHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountKeysAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Keys, nil).
+			Once()
+
+		expected := fmt.Sprintf("[%s]", expectedAccountKeyResponse(account))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get keys by address at height", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountKeysRequest(t, account, "1337")
+
+		backend.Mock.
+			On("GetAccountKeysAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Keys, nil).
+			Once()
+
+		expected := fmt.Sprintf("[%s]", expectedAccountKeyResponse(account))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get keys by missing address", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountKeysRequest(t, account, "1337")
+
+		err := status.Errorf(codes.NotFound, "account with address %s not found", account.Address)
+		backend.Mock.
+			On("GetAccountKeysAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(nil, err).
+			Once()
+
+		expected := fmt.Sprintf(`{"code": 404, "message": "Flow resource not found: account with address %s not found"}`, account.Address)
+
+		assertResponse(t, req, http.StatusNotFound, expected, backend)
+	})
+}
+
+func getAccountKeysRequest(t *testing.T, account *flow.Account, height string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/keys", account.Address.String()))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+
+	return req
+}
+
 func accountKeyURL(t *testing.T, address string, index string, height string) string {
 	u, err := url.ParseRequestURI(
 		fmt.Sprintf("/v1/accounts/%s/keys/%s", address, index),
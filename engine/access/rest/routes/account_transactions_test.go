@@ -0,0 +1,126 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountTransactions tests local getAccountTransactions request.
+//
+// Runs the following tests:
+// 1. Get the first page of transactions by address.
+// 2. Get a page of transactions by address and cursor.
+// 3. Get transactions with an invalid cursor.
+// 4. Get transactions with the index disabled.
+func TestGetAccountTransactions(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	address := unittest.RandomAddressFixture()
+	tx := flow.AccountTransaction{
+		Address:          address,
+		BlockID:          unittest.IdentifierFixture(),
+		BlockHeight:      100,
+		TransactionID:    unittest.IdentifierFixture(),
+		TransactionIndex: 2,
+		Roles:            []flow.TransactionRole{flow.TransactionRoleAuthorizer, flow.TransactionRolePayer},
+	}
+	nextCursor := &flow.AccountTransactionCursor{BlockHeight: 99, TransactionIndex: 0}
+
+	expectedTx := fmt.Sprintf(`{
+		"block_id": "%s",
+		"block_height": "100",
+		"transaction_id": "%s",
+		"transaction_index": "2",
+		"roles": ["authorizer", "payer"],
+		"_links": {"_self": "/v1/transactions/%s"}
+	}`, tx.BlockID, tx.TransactionID, tx.TransactionID)
+
+	t.Run("get first page by address", func(t *testing.T) {
+		req := getAccountTransactionsRequest(t, address, "1", "")
+
+		backend.Mock.
+			On("GetTransactionsByAccount", mocktestify.Anything, address, uint32(1), (*flow.AccountTransactionCursor)(nil)).
+			Return(&access.AccountTransactionsPage{
+				Transactions: []flow.AccountTransaction{tx},
+				NextCursor:   nextCursor,
+			}, nil).
+			Once()
+
+		next := util.FromAccountTransactionCursor(nextCursor)
+		nextReq := getAccountTransactionsRequest(t, address, "1", next)
+		expected := fmt.Sprintf(`{"transactions": [%s], "_links": {"_self": "%s", "next": "%s", "next_cursor": "%s"}}`,
+			expectedTx, req.URL.String(), nextReq.URL.String(), next)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get page by address and cursor", func(t *testing.T) {
+		cursor := tx.Cursor()
+		req := getAccountTransactionsRequest(t, address, "", util.FromAccountTransactionCursor(cursor))
+
+		backend.Mock.
+			On("GetTransactionsByAccount", mocktestify.Anything, address, uint32(0), cursor).
+			Return(&access.AccountTransactionsPage{
+				Transactions: []flow.AccountTransaction{tx},
+			}, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{"transactions": [%s], "_links": {"_self": "%s"}}`, expectedTx, req.URL.String())
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get transactions with invalid cursor", func(t *testing.T) {
+		req := getAccountTransactionsRequest(t, address, "", "invalid")
+
+		expected := `{"code": 400, "message": "invalid cursor"}`
+
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("get transactions with index disabled", func(t *testing.T) {
+		req := getAccountTransactionsRequest(t, address, "", "")
+
+		backend.Mock.
+			On("GetTransactionsByAccount", mocktestify.Anything, address, uint32(0), (*flow.AccountTransactionCursor)(nil)).
+			Return(nil, status.Errorf(codes.Unimplemented, "account transaction index is disabled")).
+			Once()
+
+		expected := `{"code": 501, "message": "Not implemented: account transaction index is disabled"}`
+
+		assertResponse(t, req, http.StatusNotImplemented, expected, backend)
+	})
+}
+
+func getAccountTransactionsRequest(t *testing.T, address flow.Address, limit string, cursor string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/transactions", address.String()))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+
+	return req
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/models"
 	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/rest/util"
 )
 
 // GetAccount handler retrieves account by address and returns the response
@@ -31,3 +32,80 @@ func GetAccount(r *request.Request, backend access.API, link models.LinkGenerato
 	err = response.Build(account, link, r.ExpandFields)
 	return response, err
 }
+
+// GetAccountBalance handler retrieves the balance of an account by address and returns the response
+func GetAccountBalance(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountBalanceRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	// in case we receive special height values 'final' and 'sealed', fetch that height and overwrite request with it
+	if req.Height == request.FinalHeight || req.Height == request.SealedHeight {
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), req.Height == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.Height = header.Height
+	}
+
+	balance, err := backend.GetAccountBalanceAtBlockHeight(r.Context(), req.Address, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountBalance
+	response.Build(balance)
+	return response, nil
+}
+
+// GetAccountAvailableBalance handler retrieves the available balance of an account by address and returns the response
+func GetAccountAvailableBalance(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountBalanceRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	// in case we receive special height values 'final' and 'sealed', fetch that height and overwrite request with it
+	if req.Height == request.FinalHeight || req.Height == request.SealedHeight {
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), req.Height == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.Height = header.Height
+	}
+
+	availableBalance, err := backend.GetAccountAvailableBalanceAtBlockHeight(r.Context(), req.Address, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountAvailableBalance
+	response.Build(availableBalance)
+	return response, nil
+}
+
+// GetAccountTransactions handler retrieves a page of the transaction history of an account by address and returns the response
+func GetAccountTransactions(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountTransactionsRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	page, err := backend.GetTransactionsByAccount(r.Context(), req.Address, req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	links := &models.Links{
+		Self: r.URL.String(),
+	}
+	if page.NextCursor != nil {
+		links.NextCursor = util.FromAccountTransactionCursor(page.NextCursor)
+		links.Next = pageLink(r, links.NextCursor)
+	}
+
+	var response models.AccountTransactions
+	err = response.Build(page.Transactions, links, link)
+	return response, err
+}
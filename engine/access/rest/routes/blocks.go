@@ -78,9 +78,31 @@ func GetBlocksByHeight(r *request.Request, backend access.API, link models.LinkG
 		}
 	}
 
+	if req.Paginated() {
+		startHeight, endHeight, links, err := paginate(r, req.StartHeight, req.EndHeight, req.Pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks, err := getBlocksInRange(startHeight, endHeight, r, backend, link)
+		if err != nil {
+			return nil, err
+		}
+
+		return models.BlocksPage{
+			Blocks: blocks,
+			Links:  links,
+		}, nil
+	}
+
+	return getBlocksInRange(req.StartHeight, req.EndHeight, r, backend, link)
+}
+
+// getBlocksInRange gets the blocks from start to end height (inclusive).
+func getBlocksInRange(startHeight uint64, endHeight uint64, r *request.Request, backend access.API, link models.LinkGenerator) ([]*models.Block, error) {
 	blocks := make([]*models.Block, 0)
 	// start and end height inclusive
-	for i := req.StartHeight; i <= req.EndHeight; i++ {
+	for i := startHeight; i <= endHeight; i++ {
 		block, err := getBlock(forHeight(i), r, backend, link)
 		if err != nil {
 			return nil, err
@@ -49,6 +49,32 @@ func prepareTestVectors(t *testing.T,
 
 	maxIDs := flow.IdentifierList(unittest.IdentifierListFixture(request.MaxBlockRequestHeightRange + 1))
 
+	// a range exceeding the max height range can be walked using pagination
+	firstPageRequest := getPageURL(t, "0", "1000", "4", "")
+	firstPageResponse := expectedBlocksPageResponse(
+		expectedBlockResponsesExpanded(blocks[0:4], executionResults[0:4], true, flow.BlockStatusSealed),
+		firstPageRequest.URL.String(),
+		fmt.Sprintf(`"next": "%s", "next_cursor": "%s"`,
+			getPageURL(t, "0", "1000", "4", util.EncodeCursor(4)).URL.String(), util.EncodeCursor(4)),
+	)
+
+	middlePageRequest := getPageURL(t, "0", "1000", "4", util.EncodeCursor(4))
+	middlePageResponse := expectedBlocksPageResponse(
+		expectedBlockResponsesExpanded(blocks[4:8], executionResults[4:8], true, flow.BlockStatusSealed),
+		middlePageRequest.URL.String(),
+		fmt.Sprintf(`"next": "%s", "next_cursor": "%s", "prev": "%s", "prev_cursor": "%s"`,
+			getPageURL(t, "0", "1000", "4", util.EncodeCursor(8)).URL.String(), util.EncodeCursor(8),
+			getPageURL(t, "0", "1000", "4", util.EncodeCursor(0)).URL.String(), util.EncodeCursor(0)),
+	)
+
+	lastPageRequest := getPageURL(t, "0", "9", "4", util.EncodeCursor(8))
+	lastPageResponse := expectedBlocksPageResponse(
+		expectedBlockResponsesExpanded(blocks[8:10], executionResults[8:10], true, flow.BlockStatusSealed),
+		lastPageRequest.URL.String(),
+		fmt.Sprintf(`"prev": "%s", "prev_cursor": "%s"`,
+			getPageURL(t, "0", "9", "4", util.EncodeCursor(4)).URL.String(), util.EncodeCursor(4)),
+	)
+
 	testVectors := []testVector{
 		{
 			description:      "Get single expanded block by ID",
@@ -92,6 +118,36 @@ func prepareTestVectors(t *testing.T,
 			expectedStatus:   http.StatusOK,
 			expectedResponse: multipleSealedBlockExpandedResponse,
 		},
+		{
+			description:      "Get first page of blocks by start and end height",
+			request:          firstPageRequest,
+			expectedStatus:   http.StatusOK,
+			expectedResponse: firstPageResponse,
+		},
+		{
+			description:      "Get middle page of blocks by start and end height",
+			request:          middlePageRequest,
+			expectedStatus:   http.StatusOK,
+			expectedResponse: middlePageResponse,
+		},
+		{
+			description:      "Get last page of blocks by start and end height",
+			request:          lastPageRequest,
+			expectedStatus:   http.StatusOK,
+			expectedResponse: lastPageResponse,
+		},
+		{
+			description:      "Get page of blocks with cursor outside of the range",
+			request:          getPageURL(t, "0", "9", "4", util.EncodeCursor(10)),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400, "message": "cursor is outside of the requested range"}`,
+		},
+		{
+			description:      "Get page of blocks with invalid cursor",
+			request:          getPageURL(t, "0", "9", "4", "invalid"),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400, "message": "invalid cursor"}`,
+		},
 		{
 			description:      "Get block by ID not found",
 			request:          getByIDsExpandedURL(t, []string{invalidID}),
@@ -187,6 +243,21 @@ func requestURL(t *testing.T, ids []string, start string, end string, expandResp
 	return req
 }
 
+func getPageURL(t *testing.T, start string, end string, limit string, cursor string) *http.Request {
+	req := getByStartEndHeightExpandedURL(t, start, end)
+	q := req.URL.Query()
+	q.Add("limit", limit)
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+	return req
+}
+
+func expectedBlocksPageResponse(blocks string, self string, links string) string {
+	return fmt.Sprintf(`{"blocks": %s, "_links": {"_self": "%s", %s}}`, blocks, self, links)
+}
+
 func getByIDsExpandedURL(t *testing.T, ids []string) *http.Request {
 	return requestURL(t, ids, "", "", true)
 }
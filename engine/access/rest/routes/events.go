@@ -51,6 +51,31 @@ func GetEvents(r *request.Request, backend access.API, _ models.LinkGenerator) (
 		}
 	}
 
+	// if the request is paginated then return events for the heights of the requested page
+	if req.Paginated() {
+		startHeight, endHeight, links, err := paginate(r, req.StartHeight, req.EndHeight, req.Pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := backend.GetEventsForHeightRange(
+			r.Context(),
+			req.Type,
+			startHeight,
+			endHeight,
+			entitiesproto.EventEncodingVersion_JSON_CDC_V0,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		blocksEvents.Build(events)
+		return models.BlockEventsPage{
+			BlockEvents: blocksEvents,
+			Links:       links,
+		}, nil
+	}
+
 	// if request provided block height range then return events for that range
 	events, err := backend.GetEventsForHeightRange(
 		r.Context(),
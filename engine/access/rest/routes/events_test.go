@@ -262,3 +262,82 @@ func testBlockEventResponse(t *testing.T, events []flow.BlockEvents) string {
 
 	return string(data)
 }
+
+// TestGetEventsPaginated tests walking a height range using the pagination links of the responses
+func TestGetEventsPaginated(t *testing.T) {
+	backend := mock.NewAPI(t)
+	eventType := "A.179b6b1cb6755e31.Foo.Bar"
+
+	events := make([]flow.BlockEvents, 5)
+	for i := range events {
+		header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(uint64(i)))
+		events[i] = unittest.BlockEventsFixture(header, 2)
+	}
+
+	for _, page := range [][2]int{{0, 1}, {2, 3}, {4, 4}} {
+		backend.Mock.
+			On("GetEventsForHeightRange", mocks.Anything, eventType, uint64(page[0]), uint64(page[1]), entities.EventEncodingVersion_JSON_CDC_V0).
+			Return(events[page[0]:page[1]+1], nil).
+			Once()
+	}
+
+	pageRequest := func(cursor string) *http.Request {
+		req := getEventReq(t, eventType, "0", "4", nil)
+		q := req.URL.Query()
+		q.Add("limit", "2")
+		if cursor != "" {
+			q.Add("cursor", cursor)
+		}
+		req.URL.RawQuery = q.Encode()
+		return req
+	}
+	pageLink := func(cursor string) string {
+		return pageRequest(cursor).URL.String()
+	}
+
+	expectedPage := func(events []flow.BlockEvents, self string, links string) string {
+		return fmt.Sprintf(`{"block_events": %s, "_links": {"_self": "%s"%s}}`, testBlockEventResponse(t, events), self, links)
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		req := pageRequest("")
+		expected := expectedPage(events[0:2], req.URL.String(), fmt.Sprintf(
+			`, "next": "%s", "next_cursor": "%s"`, pageLink(util.EncodeCursor(2)), util.EncodeCursor(2)))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("middle page", func(t *testing.T) {
+		req := pageRequest(util.EncodeCursor(2))
+		expected := expectedPage(events[2:4], req.URL.String(), fmt.Sprintf(
+			`, "next": "%s", "next_cursor": "%s", "prev": "%s", "prev_cursor": "%s"`,
+			pageLink(util.EncodeCursor(4)), util.EncodeCursor(4), pageLink(util.EncodeCursor(0)), util.EncodeCursor(0)))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		req := pageRequest(util.EncodeCursor(4))
+		expected := expectedPage(events[4:], req.URL.String(), fmt.Sprintf(
+			`, "prev": "%s", "prev_cursor": "%s"`, pageLink(util.EncodeCursor(2)), util.EncodeCursor(2)))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("cursor outside of the range", func(t *testing.T) {
+		req := pageRequest(util.EncodeCursor(5))
+		expected := `{"code": 400, "message": "cursor is outside of the requested range"}`
+
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("limit exceeds the max page size", func(t *testing.T) {
+		req := getEventReq(t, eventType, "0", "4", nil)
+		q := req.URL.Query()
+		q.Add("limit", "251")
+		req.URL.RawQuery = q.Encode()
+		expected := `{"code": 400, "message": "limit must be between 1 and 250"}`
+
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+}
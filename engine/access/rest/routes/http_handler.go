@@ -90,6 +90,11 @@ func (h *HttpHandler) errorHandler(w http.ResponseWriter, err error, errorLogger
 			h.errorResponse(w, http.StatusServiceUnavailable, msg, errorLogger)
 			return
 		}
+		if se.Code() == codes.Unimplemented {
+			msg := fmt.Sprintf("Not implemented: %s", se.Message())
+			h.errorResponse(w, http.StatusNotImplemented, msg, errorLogger)
+			return
+		}
 	}
 
 	// stop going further - catch all error
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+// paginate returns the first and last position (inclusive) of the items in the requested page of the
+// items at positions first to last (inclusive), along with the links to the current, previous and next pages.
+// Pages after the first one start at the position encoded in the request cursor.
+func paginate(r *request.Request, first uint64, last uint64, pagination request.Pagination) (uint64, uint64, *models.Links, error) {
+	start := first
+	if pagination.Cursor != nil {
+		start = *pagination.Cursor
+		if start < first || start > last {
+			return 0, 0, nil, models.NewBadRequestError(fmt.Errorf("cursor is outside of the requested range"))
+		}
+	}
+
+	end := last
+	if last-start >= pagination.Limit {
+		end = start + pagination.Limit - 1
+	}
+
+	links := &models.Links{
+		Self: r.URL.String(),
+	}
+	if end < last {
+		links.NextCursor = util.EncodeCursor(end + 1)
+		links.Next = pageLink(r, links.NextCursor)
+	}
+	if start > first {
+		prev := first
+		if start-first > pagination.Limit {
+			prev = start - pagination.Limit
+		}
+		links.PrevCursor = util.EncodeCursor(prev)
+		links.Prev = pageLink(r, links.PrevCursor)
+	}
+
+	return start, end, links, nil
+}
+
+// pageLink returns the link to the page of the current request starting at the given cursor.
+func pageLink(r *request.Request, cursor string) string {
+	u := *r.URL
+	query := u.Query()
+	query.Set("cursor", cursor)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
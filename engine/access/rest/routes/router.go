@@ -10,8 +10,12 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/engine/access/rest/graphql"
 	"github.com/onflow/flow-go/engine/access/rest/middleware"
 	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
 	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/state_stream/backend"
 	"github.com/onflow/flow-go/model/flow"
@@ -62,12 +66,13 @@ func (b *RouterBuilder) AddRestRoutes(backend access.API, chain flow.Chain) *Rou
 // AddWsRoutes adds WebSocket routes to the router.
 func (b *RouterBuilder) AddWsRoutes(
 	stateStreamApi state_stream.API,
+	accessApi access.API,
 	chain flow.Chain,
 	stateStreamConfig backend.Config,
 ) *RouterBuilder {
-
+	linkGenerator := models.NewLinkGeneratorImpl(b.v1SubRouter)
 	for _, r := range WSRoutes {
-		h := NewWSHandler(b.logger, stateStreamApi, r.Handler, chain, stateStreamConfig)
+		h := NewWSHandler(b.logger, stateStreamApi, accessApi, linkGenerator, r.Handler, chain, stateStreamConfig)
 		b.v1SubRouter.
 			Methods(r.Method).
 			Path(r.Pattern).
@@ -78,6 +83,55 @@ func (b *RouterBuilder) AddWsRoutes(
 	return b
 }
 
+// AddWebsocketsRoute adds the multiplexed WebSocket route to the router. A single connection to this
+// route can be used to manage multiple subscriptions.
+func (b *RouterBuilder) AddWebsocketsRoute(
+	chain flow.Chain,
+	config websockets.Config,
+	stateStreamApi state_stream.API,
+	accessApi access.API,
+	stateStreamConfig backend.Config,
+) *RouterBuilder {
+	linkGenerator := models.NewLinkGeneratorImpl(b.v1SubRouter)
+	dataProviderFactory := data_providers.NewDataProviderFactory(b.logger, stateStreamApi, accessApi, chain, linkGenerator, stateStreamConfig)
+	h := websockets.NewHandler(b.logger, config, dataProviderFactory, stateStreamConfig.MaxGlobalStreams)
+	b.v1SubRouter.
+		Methods(http.MethodGet).
+		Path("/ws").
+		Name("ws").
+		Handler(h)
+
+	return b
+}
+
+// AddGraphQLRoute adds the GraphQL route to the router. Queries are resolved using the access API.
+//
+// No errors are expected during normal operation.
+func (b *RouterBuilder) AddGraphQLRoute(
+	backend access.API,
+	chain flow.Chain,
+	config graphql.Config,
+) (*RouterBuilder, error) {
+	schema, err := graphql.NewSchema(backend, chain, config)
+	if err != nil {
+		return nil, err
+	}
+	h := graphql.NewHandler(b.logger, config, schema)
+	b.v1SubRouter.
+		Methods(http.MethodGet, http.MethodPost).
+		Path("/graphql").
+		Name("graphql").
+		Handler(h)
+
+	return b, nil
+}
+
+// AddClientQuotas enforces the per-client quotas of the given manager for all routes.
+func (b *RouterBuilder) AddClientQuotas(quotas *quota.Manager) *RouterBuilder {
+	b.v1SubRouter.Use(quotas.Middleware())
+	return b
+}
+
 func (b *RouterBuilder) Build() *mux.Router {
 	return b.router
 }
@@ -106,11 +160,21 @@ var Routes = []route{{
 	Pattern: "/transactions",
 	Name:    "createTransaction",
 	Handler: CreateTransaction,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/transactions/simulate",
+	Name:    "simulateTransaction",
+	Handler: SimulateTransaction,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/transaction_results/{id}",
 	Name:    "getTransactionResultByID",
 	Handler: GetTransactionResultByID,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/transaction_results",
+	Name:    "getTransactionResultsByBlockID",
+	Handler: GetTransactionResultsByBlockID,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/blocks/{id}",
@@ -146,6 +210,11 @@ var Routes = []route{{
 	Pattern: "/scripts",
 	Name:    "executeScript",
 	Handler: ExecuteScript,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/scripts/batch",
+	Name:    "executeScripts",
+	Handler: ExecuteScripts,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/accounts/{address}",
@@ -156,6 +225,26 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}/keys/{index}",
 	Name:    "getAccountKeyByIndex",
 	Handler: GetAccountKeyByIndex,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/keys",
+	Name:    "getAccountKeys",
+	Handler: GetAccountKeys,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/balance",
+	Name:    "getAccountBalance",
+	Handler: GetAccountBalance,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/available_balance",
+	Name:    "getAccountAvailableBalance",
+	Handler: GetAccountAvailableBalance,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/transactions",
+	Name:    "getAccountTransactions",
+	Handler: GetAccountTransactions,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
@@ -178,10 +267,35 @@ var WSRoutes = []wsroute{{
 	Pattern: "/subscribe_events",
 	Name:    "subscribeEvents",
 	Handler: SubscribeEvents,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_account_statuses",
+	Name:    "subscribeAccountStatuses",
+	Handler: SubscribeAccountStatuses,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_blocks",
+	Name:    "subscribeBlocks",
+	Handler: SubscribeBlocks,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_block_headers",
+	Name:    "subscribeBlockHeaders",
+	Handler: SubscribeBlockHeaders,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/subscribe_block_digests",
+	Name:    "subscribeBlockDigests",
+	Handler: SubscribeBlockDigests,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/send_and_subscribe_transaction_statuses",
+	Name:    "sendAndSubscribeTransactionStatuses",
+	Handler: SendAndSubscribeTransactionStatuses,
 }}
 
 var routeUrlMap = map[string]string{}
-var routeRE = regexp.MustCompile(`(?i)/v1/(\w+)(/(\w+)(/(\w+))?)?`)
+var routeRE = regexp.MustCompile(`(?i)/v1/(\w+)(/(\w+)(/(\w+)(/(\w+))?)?)?`)
 
 func init() {
 	for _, r := range Routes {
@@ -190,6 +304,8 @@ func init() {
 	for _, r := range WSRoutes {
 		routeUrlMap[r.Pattern] = r.Name
 	}
+	routeUrlMap["/ws"] = "ws"
+	routeUrlMap["/graphql"] = "graphql"
 }
 
 func URLToRoute(url string) (string, error) {
@@ -207,7 +323,7 @@ func URLToRoute(url string) (string, error) {
 
 func normalizeURL(url string) (string, error) {
 	matches := routeRE.FindAllStringSubmatch(url, -1)
-	if len(matches) != 1 || len(matches[0]) != 6 {
+	if len(matches) != 1 || len(matches[0]) != 8 {
 		return "", fmt.Errorf("invalid url")
 	}
 
@@ -215,6 +331,11 @@ func normalizeURL(url string) (string, error) {
 	//      /v1/blocks/1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef/payload
 	// groups  [  1  ] [                                3                             ] [  5  ]
 	// normalized form like /v1/blocks/{id}/payload
+	//
+	// given a URL like
+	//      /v1/accounts/1234567890abcdef/keys/0
+	// groups  [   1   ] [      3       ] [ 5] 7
+	// normalized form like /v1/accounts/{address}/keys/{index}
 
 	parts := []string{matches[0][1]}
 
@@ -230,8 +351,17 @@ func normalizeURL(url string) (string, error) {
 	case 16:
 		// address based resource. e.g. /v1/accounts/1234567890abcdef
 		parts = append(parts, "{address}")
-		if matches[0][5] == "keys" {
-			parts = append(parts, "keys", "{index}")
+		switch matches[0][5] {
+		case "":
+		case "keys":
+			// e.g. /v1/accounts/1234567890abcdef/keys/0
+			parts = append(parts, "keys")
+			if matches[0][7] != "" {
+				parts = append(parts, "{index}")
+			}
+		default:
+			// e.g. /v1/accounts/1234567890abcdef/balance
+			parts = append(parts, matches[0][5])
 		}
 	default:
 		// named resource. e.g. /v1/network/parameters
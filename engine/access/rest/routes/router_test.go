@@ -19,6 +19,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/transactions",
 			expected: "createTransaction",
 		},
+		{
+			name:     "/v1/transactions/simulate",
+			url:      "/v1/transactions/simulate",
+			expected: "simulateTransaction",
+		},
 		{
 			name:     "/v1/transactions/{id}",
 			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -29,6 +34,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionResultByID",
 		},
+		{
+			name:     "/v1/transaction_results",
+			url:      "/v1/transaction_results",
+			expected: "getTransactionResultsByBlockID",
+		},
 		{
 			name:     "/v1/blocks",
 			url:      "/v1/blocks",
@@ -64,6 +74,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/batch",
+			url:      "/v1/scripts/batch",
+			expected: "executeScripts",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -74,6 +89,26 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys/0",
 			expected: "getAccountKeyByIndex",
 		},
+		{
+			name:     "/v1/accounts/{address}/keys",
+			url:      "/v1/accounts/6a587be304c1224c/keys",
+			expected: "getAccountKeys",
+		},
+		{
+			name:     "/v1/accounts/{address}/balance",
+			url:      "/v1/accounts/6a587be304c1224c/balance",
+			expected: "getAccountBalance",
+		},
+		{
+			name:     "/v1/accounts/{address}/available_balance",
+			url:      "/v1/accounts/6a587be304c1224c/available_balance",
+			expected: "getAccountAvailableBalance",
+		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
@@ -94,6 +129,36 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/subscribe_events",
 			expected: "subscribeEvents",
 		},
+		{
+			name:     "/v1/subscribe_account_statuses",
+			url:      "/v1/subscribe_account_statuses",
+			expected: "subscribeAccountStatuses",
+		},
+		{
+			name:     "/v1/subscribe_blocks",
+			url:      "/v1/subscribe_blocks",
+			expected: "subscribeBlocks",
+		},
+		{
+			name:     "/v1/subscribe_block_headers",
+			url:      "/v1/subscribe_block_headers",
+			expected: "subscribeBlockHeaders",
+		},
+		{
+			name:     "/v1/subscribe_block_digests",
+			url:      "/v1/subscribe_block_digests",
+			expected: "subscribeBlockDigests",
+		},
+		{
+			name:     "/v1/send_and_subscribe_transaction_statuses",
+			url:      "/v1/send_and_subscribe_transaction_statuses",
+			expected: "sendAndSubscribeTransactionStatuses",
+		},
+		{
+			name:     "/v1/ws",
+			url:      "/v1/ws",
+			expected: "ws",
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +181,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/transactions",
 			expected: "createTransaction",
 		},
+		{
+			name:     "/v1/transactions/simulate",
+			url:      "/v1/transactions/simulate",
+			expected: "simulateTransaction",
+		},
 		{
 			name:     "/v1/transactions/{id}",
 			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -126,6 +196,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionResultByID",
 		},
+		{
+			name:     "/v1/transaction_results",
+			url:      "/v1/transaction_results",
+			expected: "getTransactionResultsByBlockID",
+		},
 		{
 			name:     "/v1/blocks",
 			url:      "/v1/blocks",
@@ -161,6 +236,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/batch",
+			url:      "/v1/scripts/batch",
+			expected: "executeScripts",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -171,6 +251,26 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys/0",
 			expected: "getAccountKeyByIndex",
 		},
+		{
+			name:     "/v1/accounts/{address}/keys",
+			url:      "/v1/accounts/6a587be304c1224c/keys",
+			expected: "getAccountKeys",
+		},
+		{
+			name:     "/v1/accounts/{address}/balance",
+			url:      "/v1/accounts/6a587be304c1224c/balance",
+			expected: "getAccountBalance",
+		},
+		{
+			name:     "/v1/accounts/{address}/available_balance",
+			url:      "/v1/accounts/6a587be304c1224c/available_balance",
+			expected: "getAccountAvailableBalance",
+		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
@@ -191,6 +291,36 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/subscribe_events",
 			expected: "subscribeEvents",
 		},
+		{
+			name:     "/v1/subscribe_account_statuses",
+			url:      "/v1/subscribe_account_statuses",
+			expected: "subscribeAccountStatuses",
+		},
+		{
+			name:     "/v1/subscribe_blocks",
+			url:      "/v1/subscribe_blocks",
+			expected: "subscribeBlocks",
+		},
+		{
+			name:     "/v1/subscribe_block_headers",
+			url:      "/v1/subscribe_block_headers",
+			expected: "subscribeBlockHeaders",
+		},
+		{
+			name:     "/v1/subscribe_block_digests",
+			url:      "/v1/subscribe_block_digests",
+			expected: "subscribeBlockDigests",
+		},
+		{
+			name:     "/v1/send_and_subscribe_transaction_statuses",
+			url:      "/v1/send_and_subscribe_transaction_statuses",
+			expected: "sendAndSubscribeTransactionStatuses",
+		},
+		{
+			name:     "/v1/ws",
+			url:      "/v1/ws",
+			expected: "ws",
+		},
 	}
 
 	for _, tt := range tests {
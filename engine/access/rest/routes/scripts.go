@@ -14,22 +14,69 @@ func ExecuteScript(r *request.Request, backend access.API, _ models.LinkGenerato
 		return nil, models.NewBadRequestError(err)
 	}
 
+	ctx := r.Context()
+	if req.SkipCache {
+		ctx = access.WithScriptCacheBypass(ctx)
+	}
+
 	if req.BlockID != flow.ZeroID {
-		return backend.ExecuteScriptAtBlockID(r.Context(), req.BlockID, req.Script.Source, req.Script.Args)
+		return backend.ExecuteScriptAtBlockID(ctx, req.BlockID, req.Script.Source, req.Script.Args)
 	}
 
 	// default to sealed height
 	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.EmptyHeight {
-		return backend.ExecuteScriptAtLatestBlock(r.Context(), req.Script.Source, req.Script.Args)
+		return backend.ExecuteScriptAtLatestBlock(ctx, req.Script.Source, req.Script.Args)
 	}
 
 	if req.BlockHeight == request.FinalHeight {
-		finalBlock, _, err := backend.GetLatestBlockHeader(r.Context(), false)
+		finalBlock, _, err := backend.GetLatestBlockHeader(ctx, false)
 		if err != nil {
 			return nil, err
 		}
 		req.BlockHeight = finalBlock.Height
 	}
 
-	return backend.ExecuteScriptAtBlockHeight(r.Context(), req.BlockHeight, req.Script.Source, req.Script.Args)
+	return backend.ExecuteScriptAtBlockHeight(ctx, req.BlockHeight, req.Script.Source, req.Script.Args)
+}
+
+// ExecuteScripts handler sends the batch of scripts from the request to be executed at the same block.
+func ExecuteScripts(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetScriptsRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	blockID := req.BlockID
+	if blockID == flow.ZeroID {
+		var header *flow.Header
+		switch req.BlockHeight {
+		case request.SealedHeight, request.EmptyHeight:
+			header, _, err = backend.GetLatestBlockHeader(r.Context(), true)
+		case request.FinalHeight:
+			header, _, err = backend.GetLatestBlockHeader(r.Context(), false)
+		default:
+			header, _, err = backend.GetBlockHeaderByHeight(r.Context(), req.BlockHeight)
+		}
+		if err != nil {
+			return nil, err
+		}
+		blockID = header.ID()
+	}
+
+	scripts := make([]access.Script, len(req.Scripts))
+	for i, script := range req.Scripts {
+		scripts[i] = access.Script{
+			Source:    script.Source,
+			Arguments: script.Args,
+		}
+	}
+
+	results, err := backend.ExecuteScriptsAtBlockID(r.Context(), blockID, scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ScriptResults
+	response.Build(results)
+	return response, nil
 }
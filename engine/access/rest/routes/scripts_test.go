@@ -13,9 +13,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func scriptReq(id string, height string, body interface{}) *http.Request {
@@ -88,6 +90,23 @@ func TestScripts(t *testing.T) {
 		), backend)
 	})
 
+	t.Run("skip cache", func(t *testing.T) {
+		backend := &mock.API{}
+		backend.Mock.
+			On("ExecuteScriptAtLatestBlock", mocks.MatchedBy(access.IsScriptCacheBypassed), validCode, [][]byte{validArgs}).
+			Return([]byte("hello world"), nil)
+
+		req := scriptReq("", sealedHeightQueryParam, validBody)
+		q := req.URL.Query()
+		q.Add("skip_cache", "true")
+		req.URL.RawQuery = q.Encode()
+
+		assertOKResponse(t, req, fmt.Sprintf(
+			"\"%s\"",
+			base64.StdEncoding.EncodeToString([]byte(`hello world`)),
+		), backend)
+	})
+
 	t.Run("get error", func(t *testing.T) {
 		backend := &mock.API{}
 		backend.Mock.
@@ -129,3 +148,99 @@ func TestScripts(t *testing.T) {
 		}
 	})
 }
+
+func scriptsReq(id string, height string, body interface{}) *http.Request {
+	u, _ := url.ParseRequestURI("/v1/scripts/batch")
+	q := u.Query()
+
+	if id != "" {
+		q.Add("block_id", id)
+	}
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+
+	return req
+}
+
+func TestExecuteScripts(t *testing.T) {
+	validCode := []byte(`access(all) fun main(foo: String): String { return foo }`)
+	validArgs := []byte(`{ "type": "String", "value": "hello world" }`)
+	validBody := map[string]interface{}{
+		"scripts": []map[string]interface{}{
+			{
+				"script":    util.ToBase64(validCode),
+				"arguments": []string{util.ToBase64(validArgs)},
+			},
+			{
+				"script": util.ToBase64(validCode),
+			},
+		},
+	}
+	scripts := []access.Script{
+		{Source: validCode, Arguments: [][]byte{validArgs}},
+		{Source: validCode, Arguments: [][]byte{}},
+	}
+	results := []access.ScriptResult{
+		{Value: []byte("hello world"), ComputationUsed: 10},
+		{Error: status.Error(codes.InvalidArgument, "missing argument")},
+	}
+	expected := fmt.Sprintf(`[
+		{"value": "%s", "computation_used": "10"},
+		{"computation_used": "0", "error_message": "missing argument"}
+	]`, base64.StdEncoding.EncodeToString([]byte(`hello world`)))
+
+	t.Run("get by ID", func(t *testing.T) {
+		backend := &mock.API{}
+		id := unittest.IdentifierFixture()
+
+		backend.Mock.
+			On("ExecuteScriptsAtBlockID", mocks.Anything, id, scripts).
+			Return(results, nil)
+
+		req := scriptsReq(id.String(), "", validBody)
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get by height", func(t *testing.T) {
+		backend := &mock.API{}
+		header := unittest.BlockHeaderFixture()
+
+		backend.Mock.
+			On("GetBlockHeaderByHeight", mocks.Anything, header.Height).
+			Return(header, flow.BlockStatusSealed, nil)
+		backend.Mock.
+			On("ExecuteScriptsAtBlockID", mocks.Anything, header.ID(), scripts).
+			Return(results, nil)
+
+		req := scriptsReq("", fmt.Sprintf("%d", header.Height), validBody)
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get by latest sealed height", func(t *testing.T) {
+		backend := &mock.API{}
+		header := unittest.BlockHeaderFixture()
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocks.Anything, true).
+			Return(header, flow.BlockStatusSealed, nil)
+		backend.Mock.
+			On("ExecuteScriptsAtBlockID", mocks.Anything, header.ID(), scripts).
+			Return(results, nil)
+
+		req := scriptsReq("", "", validBody)
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		backend := &mock.API{}
+
+		req := scriptsReq("", "", map[string]interface{}{"scripts": []string{}})
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400,"message":"at least one script must be provided"}`, backend)
+	})
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SubscribeAccountStatuses create websocket connection and write to it requested account statuses.
+func SubscribeAccountStatuses(
+	ctx context.Context,
+	r *request.Request,
+	wsController *WebsocketController,
+) (subscription.Subscription, error) {
+	req, err := r.SubscribeAccountStatusesRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+	// Retrieve the filter parameters from the request, if provided
+	filter, err := state_stream.NewAccountStatusFilter(
+		wsController.eventFilterConfig,
+		r.Chain,
+		req.EventTypes,
+		req.Addresses,
+	)
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	// Check if heartbeat interval was passed via request
+	if req.HeartbeatInterval > 0 {
+		wsController.heartbeatInterval = req.HeartbeatInterval
+	}
+	wsController.resumeToken = req.ResumeToken
+
+	api := wsController.api
+	switch {
+	case req.StartBlockID != flow.ZeroID:
+		return api.SubscribeAccountStatusesFromStartBlockID(ctx, req.StartBlockID, filter), nil
+	case req.StartHeight != request.EmptyHeight:
+		return api.SubscribeAccountStatusesFromStartHeight(ctx, req.StartHeight, filter), nil
+	default:
+		return api.SubscribeAccountStatusesFromLatestBlock(ctx, filter), nil
+	}
+}
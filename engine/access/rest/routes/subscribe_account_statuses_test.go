@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	mockstatestream "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
+)
+
+// TestSubscribeAccountStatuses tests streaming account statuses starting from a block ID, a start height and
+// the latest block, including heartbeat handling for blocks without matching account events.
+func TestSubscribeAccountStatuses(t *testing.T) {
+	address := unittest.RandomAddressFixtureForChain(chainID)
+	eventType := string(flow.EventAccountCreated)
+	eventsGenerator := generator.EventGenerator(generator.WithEncoding(entities.EventEncodingVersion_CCF_V0))
+
+	parent := unittest.BlockHeaderFixture()
+	blocks := make([]*flow.Header, 3)
+	for i := range blocks {
+		blocks[i] = unittest.BlockHeaderWithParentFixture(parent)
+		parent = blocks[i]
+	}
+
+	tests := []struct {
+		name         string
+		startBlockID flow.Identifier
+		startHeight  uint64
+	}{
+		{
+			name:         "from latest block",
+			startBlockID: flow.ZeroID,
+			startHeight:  request.EmptyHeight,
+		},
+		{
+			name:         "from start height",
+			startBlockID: flow.ZeroID,
+			startHeight:  blocks[0].Height,
+		},
+		{
+			name:         "from start block id",
+			startBlockID: blocks[0].ID(),
+			startHeight:  request.EmptyHeight,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := state_stream.NewAccountStatusFilter(
+				state_stream.DefaultEventFilterConfig,
+				chainID.Chain(),
+				[]string{eventType},
+				[]string{address.String()},
+			)
+			require.NoError(t, err)
+
+			// the second block has no account events, and is skipped since the heartbeat interval is 2
+			responses := make([]*backend.AccountStatusesResponse, len(blocks))
+			for i, header := range blocks {
+				accountEvents := map[string]flow.EventsList{}
+				if i != 1 {
+					event := eventsGenerator.New()
+					event.Type = flow.EventAccountCreated
+					accountEvents[address.String()] = flow.EventsList{event}
+				}
+				responses[i] = &backend.AccountStatusesResponse{
+					BlockID:       header.ID(),
+					Height:        header.Height,
+					AccountEvents: accountEvents,
+				}
+			}
+			expected := []*backend.AccountStatusesResponse{responses[0], responses[2]}
+
+			ch := make(chan interface{})
+			go func() {
+				for _, resp := range responses {
+					ch <- resp
+				}
+			}()
+			var chReadOnly <-chan interface{} = ch
+
+			sub := mockstatestream.NewSubscription(t)
+			sub.Mock.On("Channel").Return(chReadOnly)
+
+			stateStreamBackend := mockstatestream.NewAPI(t)
+			switch {
+			case test.startBlockID != flow.ZeroID:
+				stateStreamBackend.On("SubscribeAccountStatusesFromStartBlockID", mocks.Anything, test.startBlockID, filter).Return(sub)
+			case test.startHeight != request.EmptyHeight:
+				stateStreamBackend.On("SubscribeAccountStatusesFromStartHeight", mocks.Anything, test.startHeight, filter).Return(sub)
+			default:
+				stateStreamBackend.On("SubscribeAccountStatusesFromLatestBlock", mocks.Anything, filter).Return(sub)
+			}
+
+			u, _ := url.Parse("/v1/subscribe_account_statuses")
+			q := u.Query()
+			if test.startBlockID != flow.ZeroID {
+				q.Add(startBlockIdQueryParam, test.startBlockID.String())
+			}
+			if test.startHeight != request.EmptyHeight {
+				q.Add(startHeightQueryParam, fmt.Sprintf("%d", test.startHeight))
+			}
+			q.Add(eventTypesQueryParams, eventType)
+			q.Add(addressesQueryParams, address.String())
+			q.Add(heartbeatIntervalQueryParam, "2")
+			u.RawQuery = q.Encode()
+
+			respRecorder := newTestHijackResponseRecorder()
+			// closing the connection after 1 second
+			go func() {
+				time.Sleep(1 * time.Second)
+				respRecorder.Close()
+			}()
+			executeWsRequest(newWebSocketRequest(t, u), stateStreamBackend, nil, respRecorder, chainID.Chain())
+
+			messages := respRecorder.readTextMessages(t)
+			require.Len(t, messages, len(expected))
+			for i, msg := range messages {
+				var actual backend.AccountStatusesResponse
+				require.NoError(t, json.Unmarshal(msg, &actual))
+				require.Equal(t, expected[i].BlockID, actual.BlockID)
+				require.Equal(t, expected[i].Height, actual.Height)
+				require.Len(t, actual.AccountEvents[address.String()], 1)
+
+				// payload must decode to valid json-cdc encoded data
+				_, err := jsoncdc.Decode(nil, actual.AccountEvents[address.String()][0].Payload)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SubscribeBlocks create websocket connection and write to it requested blocks.
+func SubscribeBlocks(
+	ctx context.Context,
+	r *request.Request,
+	wsController *WebsocketController,
+) (subscription.Subscription, error) {
+	req, err := r.SubscribeBlocksRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	wsController.blockStatus = req.BlockStatus
+	wsController.expandFields = r.ExpandFields
+
+	api := wsController.accessAPI
+	switch {
+	case req.StartBlockID != flow.ZeroID:
+		return api.SubscribeBlocksFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus), nil
+	case req.StartHeight != request.EmptyHeight:
+		return api.SubscribeBlocksFromStartHeight(ctx, req.StartHeight, req.BlockStatus), nil
+	default:
+		return api.SubscribeBlocksFromLatest(ctx, req.BlockStatus), nil
+	}
+}
+
+// SubscribeBlockHeaders create websocket connection and write to it requested block headers.
+func SubscribeBlockHeaders(
+	ctx context.Context,
+	r *request.Request,
+	wsController *WebsocketController,
+) (subscription.Subscription, error) {
+	req, err := r.SubscribeBlocksRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	api := wsController.accessAPI
+	switch {
+	case req.StartBlockID != flow.ZeroID:
+		return api.SubscribeBlockHeadersFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus), nil
+	case req.StartHeight != request.EmptyHeight:
+		return api.SubscribeBlockHeadersFromStartHeight(ctx, req.StartHeight, req.BlockStatus), nil
+	default:
+		return api.SubscribeBlockHeadersFromLatest(ctx, req.BlockStatus), nil
+	}
+}
+
+// SubscribeBlockDigests create websocket connection and write to it requested block digests.
+func SubscribeBlockDigests(
+	ctx context.Context,
+	r *request.Request,
+	wsController *WebsocketController,
+) (subscription.Subscription, error) {
+	req, err := r.SubscribeBlocksRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	api := wsController.accessAPI
+	switch {
+	case req.StartBlockID != flow.ZeroID:
+		return api.SubscribeBlockDigestsFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus), nil
+	case req.StartHeight != request.EmptyHeight:
+		return api.SubscribeBlockDigestsFromStartHeight(ctx, req.StartHeight, req.BlockStatus), nil
+	default:
+		return api.SubscribeBlockDigestsFromLatest(ctx, req.BlockStatus), nil
+	}
+}
@@ -0,0 +1,221 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	mockstatestream "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const blockStatusQueryParam = "block_status"
+
+type SubscribeBlocksSuite struct {
+	suite.Suite
+
+	blocks []*flow.Block
+}
+
+func TestSubscribeBlocksSuite(t *testing.T) {
+	suite.Run(t, new(SubscribeBlocksSuite))
+}
+
+func (s *SubscribeBlocksSuite) SetupTest() {
+	parent := unittest.BlockHeaderFixture()
+
+	blockCount := 5
+	s.blocks = make([]*flow.Block, 0, blockCount)
+	for i := 0; i < blockCount; i++ {
+		block := unittest.BlockWithParentFixture(parent)
+		parent = block.Header
+		s.blocks = append(s.blocks, block)
+	}
+}
+
+// TestSubscribeBlocks tests streaming full blocks, block headers and block digests, starting from a block ID,
+// a start height and the latest block.
+func (s *SubscribeBlocksSuite) TestSubscribeBlocks() {
+	type testCase struct {
+		name         string
+		startBlockID flow.Identifier
+		startHeight  uint64
+		blockStatus  string
+		status       flow.BlockStatus
+	}
+
+	testVectors := []testCase{
+		{
+			name:         "from latest finalized",
+			startBlockID: flow.ZeroID,
+			startHeight:  request.EmptyHeight,
+			status:       flow.BlockStatusFinalized,
+		},
+		{
+			name:         "from start height sealed",
+			startBlockID: flow.ZeroID,
+			startHeight:  s.blocks[0].Header.Height,
+			blockStatus:  "sealed",
+			status:       flow.BlockStatusSealed,
+		},
+		{
+			name:         "from start block id finalized",
+			startBlockID: s.blocks[0].ID(),
+			startHeight:  request.EmptyHeight,
+			blockStatus:  "final",
+			status:       flow.BlockStatusFinalized,
+		},
+	}
+
+	// mockSubscription configures the access API mock to return a subscription streaming the given data
+	mockSubscription := func(api *mock.API, prefix string, test testCase, data []interface{}) {
+		sub := mockstatestream.NewSubscription(s.T())
+		ch := make(chan interface{})
+		go func() {
+			for _, d := range data {
+				ch <- d
+			}
+		}()
+		var chReadOnly <-chan interface{} = ch
+		sub.Mock.On("Channel").Return(chReadOnly)
+
+		switch {
+		case test.startBlockID != flow.ZeroID:
+			api.On(prefix+"FromStartBlockID", mocks.Anything, test.startBlockID, test.status).Return(sub)
+		case test.startHeight != request.EmptyHeight:
+			api.On(prefix+"FromStartHeight", mocks.Anything, test.startHeight, test.status).Return(sub)
+		default:
+			api.On(prefix+"FromLatest", mocks.Anything, test.status).Return(sub)
+		}
+	}
+
+	for _, test := range testVectors {
+		s.Run(fmt.Sprintf("blocks %s", test.name), func() {
+			api := mock.NewAPI(s.T())
+			data := make([]interface{}, len(s.blocks))
+			for i, block := range s.blocks {
+				data[i] = block
+			}
+			mockSubscription(api, "SubscribeBlocks", test, data)
+
+			messages := s.executeRequest("/v1/subscribe_blocks", test.startBlockID, test.startHeight, test.blockStatus, api)
+			require.Len(s.T(), messages, len(s.blocks))
+			for i, msg := range messages {
+				var block models.Block
+				require.NoError(s.T(), json.Unmarshal(msg, &block))
+				require.Equal(s.T(), s.blocks[i].ID().String(), block.Header.Id)
+				require.Equal(s.T(), test.status.String(), block.BlockStatus)
+				require.NotEmpty(s.T(), block.Expandable.Payload)
+			}
+		})
+
+		s.Run(fmt.Sprintf("block headers %s", test.name), func() {
+			api := mock.NewAPI(s.T())
+			data := make([]interface{}, len(s.blocks))
+			for i, block := range s.blocks {
+				data[i] = block.Header
+			}
+			mockSubscription(api, "SubscribeBlockHeaders", test, data)
+
+			messages := s.executeRequest("/v1/subscribe_block_headers", test.startBlockID, test.startHeight, test.blockStatus, api)
+			require.Len(s.T(), messages, len(s.blocks))
+			for i, msg := range messages {
+				var header models.BlockHeader
+				require.NoError(s.T(), json.Unmarshal(msg, &header))
+				require.Equal(s.T(), s.blocks[i].ID().String(), header.Id)
+				require.Equal(s.T(), fmt.Sprint(s.blocks[i].Header.Height), header.Height)
+			}
+		})
+
+		s.Run(fmt.Sprintf("block digests %s", test.name), func() {
+			api := mock.NewAPI(s.T())
+			data := make([]interface{}, len(s.blocks))
+			for i, block := range s.blocks {
+				data[i] = flow.NewBlockDigest(block.ID(), block.Header.Height, block.Header.Timestamp)
+			}
+			mockSubscription(api, "SubscribeBlockDigests", test, data)
+
+			messages := s.executeRequest("/v1/subscribe_block_digests", test.startBlockID, test.startHeight, test.blockStatus, api)
+			require.Len(s.T(), messages, len(s.blocks))
+			for i, msg := range messages {
+				var digest models.BlockDigest
+				require.NoError(s.T(), json.Unmarshal(msg, &digest))
+				require.Equal(s.T(), s.blocks[i].ID().String(), digest.BlockId)
+				require.Equal(s.T(), fmt.Sprint(s.blocks[i].Header.Height), digest.Height)
+			}
+		})
+	}
+}
+
+func (s *SubscribeBlocksSuite) TestSubscribeBlocksHandlesErrors() {
+	s.Run("returns error for block id and height", func() {
+		api := mock.NewAPI(s.T())
+		req := getSubscribeBlocksRequest(s.T(), "/v1/subscribe_blocks", s.blocks[0].ID(), s.blocks[0].Header.Height, "")
+		respRecorder := newTestHijackResponseRecorder()
+		executeWsRequest(req, nil, api, respRecorder, chainID.Chain())
+		requireError(s.T(), respRecorder, "can only provide either block ID or start height")
+	})
+
+	s.Run("returns error for invalid block status", func() {
+		api := mock.NewAPI(s.T())
+		req := getSubscribeBlocksRequest(s.T(), "/v1/subscribe_block_headers", flow.ZeroID, request.EmptyHeight, "executed")
+		respRecorder := newTestHijackResponseRecorder()
+		executeWsRequest(req, nil, api, respRecorder, chainID.Chain())
+		requireError(s.T(), respRecorder, "invalid block status")
+	})
+}
+
+// executeRequest executes a block subscription request, and returns the messages written to the client
+// after closing the connection.
+func (s *SubscribeBlocksSuite) executeRequest(
+	path string,
+	startBlockID flow.Identifier,
+	startHeight uint64,
+	blockStatus string,
+	api *mock.API,
+) [][]byte {
+	req := getSubscribeBlocksRequest(s.T(), path, startBlockID, startHeight, blockStatus)
+	respRecorder := newTestHijackResponseRecorder()
+	// closing the connection after all messages were sent
+	go func() {
+		time.Sleep(1 * time.Second)
+		respRecorder.Close()
+	}()
+	executeWsRequest(req, nil, api, respRecorder, chainID.Chain())
+	return respRecorder.readTextMessages(s.T())
+}
+
+func getSubscribeBlocksRequest(
+	t *testing.T,
+	path string,
+	startBlockID flow.Identifier,
+	startHeight uint64,
+	blockStatus string,
+) *http.Request {
+	u, _ := url.Parse(path)
+	q := u.Query()
+
+	if startBlockID != flow.ZeroID {
+		q.Add(startBlockIdQueryParam, startBlockID.String())
+	}
+	if startHeight != request.EmptyHeight {
+		q.Add(startHeightQueryParam, fmt.Sprintf("%d", startHeight))
+	}
+	if blockStatus != "" {
+		q.Add(blockStatusQueryParam, blockStatus)
+	}
+	u.RawQuery = q.Encode()
+
+	return newWebSocketRequest(t, u)
+}
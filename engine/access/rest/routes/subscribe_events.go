@@ -26,6 +26,7 @@ func SubscribeEvents(
 		req.EventTypes,
 		req.Addresses,
 		req.Contracts,
+		req.FieldPredicates,
 	)
 	if err != nil {
 		return nil, models.NewBadRequestError(err)
@@ -35,6 +36,7 @@ func SubscribeEvents(
 	if req.HeartbeatInterval > 0 {
 		wsController.heartbeatInterval = req.HeartbeatInterval
 	}
+	wsController.resumeToken = req.ResumeToken
 
 	return wsController.api.SubscribeEvents(ctx, req.StartBlockID, req.StartHeight, filter), nil
 }
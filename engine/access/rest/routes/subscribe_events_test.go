@@ -175,7 +175,8 @@ func (s *SubscribeEventsSuite) TestSubscribeEvents() {
 				chainID.Chain(),
 				test.eventTypes,
 				test.addresses,
-				test.contracts)
+				test.contracts,
+				nil)
 			require.NoError(s.T(), err)
 
 			var expectedEventsResponses []*backend.EventsResponse
@@ -251,7 +252,7 @@ func (s *SubscribeEventsSuite) TestSubscribeEvents() {
 				time.Sleep(1 * time.Second)
 				respRecorder.Close()
 			}()
-			executeWsRequest(req, stateStreamBackend, respRecorder, chainID.Chain())
+			executeWsRequest(req, stateStreamBackend, nil, respRecorder, chainID.Chain())
 			requireResponse(s.T(), respRecorder, expectedEventsResponses)
 		})
 	}
@@ -263,7 +264,7 @@ func (s *SubscribeEventsSuite) TestSubscribeEventsHandlesErrors() {
 		req, err := getSubscribeEventsRequest(s.T(), s.blocks[0].ID(), s.blocks[0].Header.Height, nil, nil, nil, 1, nil)
 		require.NoError(s.T(), err)
 		respRecorder := newTestHijackResponseRecorder()
-		executeWsRequest(req, stateStreamBackend, respRecorder, chainID.Chain())
+		executeWsRequest(req, stateStreamBackend, nil, respRecorder, chainID.Chain())
 		requireError(s.T(), respRecorder, "can only provide either block ID or start height")
 	})
 
@@ -288,7 +289,7 @@ func (s *SubscribeEventsSuite) TestSubscribeEventsHandlesErrors() {
 		req, err := getSubscribeEventsRequest(s.T(), invalidBlock.ID(), request.EmptyHeight, nil, nil, nil, 1, nil)
 		require.NoError(s.T(), err)
 		respRecorder := newTestHijackResponseRecorder()
-		executeWsRequest(req, stateStreamBackend, respRecorder, chainID.Chain())
+		executeWsRequest(req, stateStreamBackend, nil, respRecorder, chainID.Chain())
 		requireError(s.T(), respRecorder, "stream encountered an error: subscription error")
 	})
 
@@ -297,7 +298,7 @@ func (s *SubscribeEventsSuite) TestSubscribeEventsHandlesErrors() {
 		req, err := getSubscribeEventsRequest(s.T(), s.blocks[0].ID(), request.EmptyHeight, []string{"foo"}, nil, nil, 1, nil)
 		require.NoError(s.T(), err)
 		respRecorder := newTestHijackResponseRecorder()
-		executeWsRequest(req, stateStreamBackend, respRecorder, chainID.Chain())
+		executeWsRequest(req, stateStreamBackend, nil, respRecorder, chainID.Chain())
 		requireError(s.T(), respRecorder, "invalid event type format")
 	})
 
@@ -322,7 +323,7 @@ func (s *SubscribeEventsSuite) TestSubscribeEventsHandlesErrors() {
 		req, err := getSubscribeEventsRequest(s.T(), s.blocks[0].ID(), request.EmptyHeight, nil, nil, nil, 1, nil)
 		require.NoError(s.T(), err)
 		respRecorder := newTestHijackResponseRecorder()
-		executeWsRequest(req, stateStreamBackend, respRecorder, chainID.Chain())
+		executeWsRequest(req, stateStreamBackend, nil, respRecorder, chainID.Chain())
 		requireError(s.T(), respRecorder, "subscription channel closed")
 	})
 }
@@ -381,6 +382,22 @@ func getSubscribeEventsRequest(t *testing.T,
 	return req, nil
 }
 
+// newWebSocketRequest creates a request to upgrade to a WebSocket connection for the given url.
+func newWebSocketRequest(t *testing.T, u *url.URL) *http.Request {
+	key, err := generateWebSocketKey()
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Connection", "upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-Websocket-Version", "13")
+	req.Header.Set("Sec-Websocket-Key", key)
+
+	return req
+}
+
 func generateWebSocketKey() (string, error) {
 	// Generate 16 random bytes.
 	keyBytes := make([]byte, 16)
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/subscription"
+)
+
+// SendAndSubscribeTransactionStatuses reads a transaction from the first message sent by the client over the
+// websocket connection, sends it to the network and writes the transaction statuses to the connection until
+// the transaction is sealed or expired.
+//
+// The message must contain the transaction in the same format as the body of the create transaction endpoint.
+func SendAndSubscribeTransactionStatuses(
+	ctx context.Context,
+	r *request.Request,
+	wsController *WebsocketController,
+) (subscription.Subscription, error) {
+	// the read loop is not started yet, so the transaction message can be read directly from the connection
+	_, msg, err := wsController.conn.ReadMessage()
+	if err != nil {
+		return nil, models.NewRestError(http.StatusRequestTimeout, "could not read transaction message", err)
+	}
+
+	var req request.CreateTransaction
+	err = req.Parse(bytes.NewReader(msg), r.Chain)
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	err = wsController.accessAPI.SendTransaction(ctx, &req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return wsController.accessAPI.SubscribeTransactionStatuses(ctx, &req.Transaction, entities.EventEncodingVersion_JSON_CDC_V0), nil
+}
@@ -0,0 +1,91 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	mockstatestream "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSendAndSubscribeTransactionStatuses tests sending a transaction received over the websocket connection,
+// and streaming its statuses until it is sealed.
+func TestSendAndSubscribeTransactionStatuses(t *testing.T) {
+	u, _ := url.Parse("/v1/send_and_subscribe_transaction_statuses")
+
+	t.Run("happy path", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture()
+		tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+		tx.Arguments = [][]uint8{}
+		txID := tx.ID()
+
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusFinalized,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+
+		ch := make(chan interface{})
+		go func() {
+			for _, status := range statuses {
+				ch <- []*access.TransactionResult{{
+					TransactionID: txID,
+					Status:        status,
+				}}
+			}
+		}()
+		var chReadOnly <-chan interface{} = ch
+
+		sub := mockstatestream.NewSubscription(t)
+		sub.Mock.On("Channel").Return(chReadOnly)
+
+		api := mock.NewAPI(t)
+		api.On("SendTransaction", mocks.Anything, &tx).Return(nil).Once()
+		api.On("SubscribeTransactionStatuses", mocks.Anything, &tx, entities.EventEncodingVersion_JSON_CDC_V0).Return(sub).Once()
+
+		body, err := json.Marshal(unittest.CreateSendTxHttpPayload(tx))
+		require.NoError(t, err)
+
+		respRecorder := newTestHijackResponseRecorder()
+		respRecorder.sendClientMessage(body)
+		// closing the connection after 1 second
+		go func() {
+			time.Sleep(1 * time.Second)
+			respRecorder.Close()
+		}()
+		executeWsRequest(newWebSocketRequest(t, u), nil, api, respRecorder, chainID.Chain())
+
+		messages := respRecorder.readTextMessages(t)
+		require.Len(t, messages, len(statuses))
+		for i, msg := range messages {
+			var results []models.TransactionResult
+			require.NoError(t, json.Unmarshal(msg, &results))
+			require.Len(t, results, 1)
+
+			var expectedStatus models.TransactionStatus
+			expectedStatus.Build(statuses[i])
+			require.Equal(t, expectedStatus, *results[0].Status)
+			require.Equal(t, "/v1/transaction_results/"+txID.String(), results[0].Links.Self)
+		}
+	})
+
+	t.Run("returns error for invalid transaction", func(t *testing.T) {
+		api := mock.NewAPI(t)
+
+		respRecorder := newTestHijackResponseRecorder()
+		respRecorder.sendClientMessage([]byte(`{"script": "foo"}`))
+		executeWsRequest(newWebSocketRequest(t, u), nil, api, respRecorder, chainID.Chain())
+		requireError(t, respRecorder, "proposal key not provided")
+	})
+}
@@ -3,8 +3,10 @@ package routes
 import (
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/access"
@@ -43,6 +46,8 @@ const (
 type fakeNetConn struct {
 	io.Writer
 	closed chan struct{}
+	// clientData holds the raw data sent by the client, which is read before blocking until the connection is closed
+	clientData *bytes.Buffer
 }
 
 var _ net.Conn = (*fakeNetConn)(nil)
@@ -63,6 +68,9 @@ func (c fakeNetConn) SetDeadline(t time.Time) error      { return nil }
 func (c fakeNetConn) SetReadDeadline(t time.Time) error  { return nil }
 func (c fakeNetConn) SetWriteDeadline(t time.Time) error { return nil }
 func (c fakeNetConn) Read(p []byte) (n int, err error) {
+	if c.clientData != nil && c.clientData.Len() > 0 {
+		return c.clientData.Read(p)
+	}
 	<-c.closed
 	return 0, fmt.Errorf("closed")
 }
@@ -88,6 +96,7 @@ type testHijackResponseRecorder struct {
 	*httptest.ResponseRecorder
 	closed       chan struct{}
 	responseBuff *bytes.Buffer
+	clientData   *bytes.Buffer
 }
 
 var _ http.Hijacker = (*testHijackResponseRecorder)(nil)
@@ -100,7 +109,7 @@ func (w *testHijackResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, erro
 	w.responseBuff = bytes.NewBuffer(make([]byte, 0))
 	w.closed = make(chan struct{}, 1)
 
-	return fakeNetConn{w.responseBuff, w.closed}, bufio.NewReadWriter(br, bw), nil
+	return fakeNetConn{w.responseBuff, w.closed, w.clientData}, bufio.NewReadWriter(br, bw), nil
 }
 
 func (w *testHijackResponseRecorder) Close() error {
@@ -119,6 +128,65 @@ func newTestHijackResponseRecorder() *testHijackResponseRecorder {
 	}
 }
 
+// sendClientMessage queues a text message that is read by the server from the hijacked connection.
+func (w *testHijackResponseRecorder) sendClientMessage(msg []byte) {
+	if w.clientData == nil {
+		w.clientData = bytes.NewBuffer(nil)
+	}
+
+	// client frames must be masked. use a zero masking key so the payload is sent as is.
+	w.clientData.WriteByte(0x80 | websocket.TextMessage)
+	switch {
+	case len(msg) < 126:
+		w.clientData.WriteByte(0x80 | byte(len(msg)))
+	case len(msg) <= math.MaxUint16:
+		w.clientData.WriteByte(0x80 | 126)
+		_ = binary.Write(w.clientData, binary.BigEndian, uint16(len(msg)))
+	default:
+		w.clientData.WriteByte(0x80 | 127)
+		_ = binary.Write(w.clientData, binary.BigEndian, uint64(len(msg)))
+	}
+	w.clientData.Write([]byte{0, 0, 0, 0})
+	w.clientData.Write(msg)
+}
+
+// readTextMessages waits until the connection is closed and returns the payloads of all text messages
+// written by the server.
+func (w *testHijackResponseRecorder) readTextMessages(t *testing.T) [][]byte {
+	<-w.closed
+
+	// skip the handshake response
+	data := w.responseBuff.Bytes()
+	end := bytes.Index(data, []byte("\r\n\r\n"))
+	require.GreaterOrEqual(t, end, 0)
+	data = data[end+4:]
+
+	var messages [][]byte
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), 2)
+		opcode := int(data[0] & 0x0f)
+		length := uint64(data[1] & 0x7f)
+		data = data[2:]
+
+		// server frames are never masked
+		switch length {
+		case 126:
+			length = uint64(binary.BigEndian.Uint16(data))
+			data = data[2:]
+		case 127:
+			length = binary.BigEndian.Uint64(data)
+			data = data[8:]
+		}
+		require.GreaterOrEqual(t, uint64(len(data)), length)
+
+		if opcode == websocket.TextMessage {
+			messages = append(messages, data[:length])
+		}
+		data = data[length:]
+	}
+	return messages
+}
+
 func executeRequest(req *http.Request, backend access.API) *httptest.ResponseRecorder {
 	router := NewRouterBuilder(
 		unittest.Logger(),
@@ -133,7 +201,7 @@ func executeRequest(req *http.Request, backend access.API) *httptest.ResponseRec
 	return rr
 }
 
-func executeWsRequest(req *http.Request, stateStreamApi state_stream.API, responseRecorder *testHijackResponseRecorder, chain flow.Chain) {
+func executeWsRequest(req *http.Request, stateStreamApi state_stream.API, accessApi access.API, responseRecorder *testHijackResponseRecorder, chain flow.Chain) {
 	restCollector := metrics.NewNoopCollector()
 
 	config := backend.Config{
@@ -142,8 +210,10 @@ func executeWsRequest(req *http.Request, stateStreamApi state_stream.API, respon
 		HeartbeatInterval: subscription.DefaultHeartbeatInterval,
 	}
 
-	router := NewRouterBuilder(unittest.Logger(), restCollector).AddWsRoutes(
+	// rest routes are required to generate links in responses
+	router := NewRouterBuilder(unittest.Logger(), restCollector).AddRestRoutes(accessApi, chain).AddWsRoutes(
 		stateStreamApi,
+		accessApi,
 		chain, config).Build()
 	router.ServeHTTP(responseRecorder, req)
 }
@@ -63,6 +63,52 @@ func GetTransactionResultByID(r *request.Request, backend access.API, link model
 	return response, nil
 }
 
+// GetTransactionResultsByBlockID retrieves the transaction results of the block with the requested ID.
+func GetTransactionResultsByBlockID(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetTransactionResultsByBlockIDRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	txrs, err := backend.GetTransactionResultsByBlockID(
+		r.Context(),
+		req.BlockID,
+		entitiesproto.EventEncodingVersion_JSON_CDC_V0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Paginated() {
+		return buildTransactionResults(txrs, link), nil
+	}
+
+	if len(txrs) == 0 {
+		return models.TransactionResultsPage{
+			TransactionResults: []models.TransactionResult{},
+			Links:              &models.Links{Self: r.URL.String()},
+		}, nil
+	}
+
+	start, end, links, err := paginate(r, 0, uint64(len(txrs)-1), req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.TransactionResultsPage{
+		TransactionResults: buildTransactionResults(txrs[start:end+1], link),
+		Links:              links,
+	}, nil
+}
+
+func buildTransactionResults(txrs []*access.TransactionResult, link models.LinkGenerator) []models.TransactionResult {
+	response := make([]models.TransactionResult, len(txrs))
+	for i, txr := range txrs {
+		response[i].Build(txr, txr.TransactionID, link)
+	}
+	return response
+}
+
 // CreateTransaction creates a new transaction from provided payload.
 func CreateTransaction(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
 	req, err := r.CreateTransactionRequest()
@@ -79,3 +125,26 @@ func CreateTransaction(r *request.Request, backend access.API, link models.LinkG
 	response.Build(&req.Transaction, nil, link)
 	return response, nil
 }
+
+// SimulateTransaction executes the provided transaction against the latest sealed execution state
+// without submitting it, returning its events, error, resource usage and fees.
+func SimulateTransaction(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.SimulateTransactionRequest()
+	if err != nil {
+		return nil, models.NewBadRequestError(err)
+	}
+
+	result, err := backend.SimulateTransaction(
+		r.Context(),
+		&req.Transaction,
+		req.SkipSignatureVerification,
+		entitiesproto.EventEncodingVersion_JSON_CDC_V0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TransactionSimulation
+	response.Build(result)
+	return response, nil
+}
@@ -340,6 +340,89 @@ func TestGetTransactionResult(t *testing.T) {
 	})
 }
 
+func TestGetTransactionResultsByBlockID(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	txrs := make([]*access.TransactionResult, 3)
+	for i := range txrs {
+		txrs[i] = &access.TransactionResult{
+			Status:        flow.TransactionStatusSealed,
+			Events:        []flow.Event{},
+			BlockID:       blockID,
+			TransactionID: unittest.IdentifierFixture(),
+			CollectionID:  unittest.IdentifierFixture(),
+		}
+	}
+
+	expectedResults := func(txrs []*access.TransactionResult) string {
+		results := make([]string, len(txrs))
+		for i, txr := range txrs {
+			results[i] = fmt.Sprintf(`{
+				"block_id": "%s",
+				"collection_id": "%s",
+				"execution": "Success",
+				"status": "Sealed",
+				"status_code": 0,
+				"error_message": "",
+				"computation_used": "0",
+				"events": [],
+				"_links": {
+					"_self": "/v1/transaction_results/%s"
+				}
+			}`, blockID.String(), txr.CollectionID.String(), txr.TransactionID.String())
+		}
+		return fmt.Sprintf("[%s]", strings.Join(results, ","))
+	}
+
+	getResultsReq := func(limit string, cursor string) *http.Request {
+		u, _ := url.Parse("/v1/transaction_results")
+		q := u.Query()
+		q.Add("block_id", blockID.String())
+		if limit != "" {
+			q.Add("limit", limit)
+		}
+		if cursor != "" {
+			q.Add("cursor", cursor)
+		}
+		u.RawQuery = q.Encode()
+
+		req, _ := http.NewRequest("GET", u.String(), nil)
+		return req
+	}
+
+	backend := mock.NewAPI(t)
+	backend.Mock.
+		On("GetTransactionResultsByBlockID", mocks.Anything, blockID, entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(txrs, nil)
+
+	t.Run("get all results", func(t *testing.T) {
+		req := getResultsReq("", "")
+		assertOKResponse(t, req, expectedResults(txrs), backend)
+	})
+
+	t.Run("get first page of results", func(t *testing.T) {
+		req := getResultsReq("2", "")
+		expected := fmt.Sprintf(`{"transaction_results": %s, "_links": {"_self": "%s", "next": "%s", "next_cursor": "%s"}}`,
+			expectedResults(txrs[:2]), req.URL.String(), getResultsReq("2", util.EncodeCursor(2)).URL.String(), util.EncodeCursor(2))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get last page of results", func(t *testing.T) {
+		req := getResultsReq("2", util.EncodeCursor(2))
+		expected := fmt.Sprintf(`{"transaction_results": %s, "_links": {"_self": "%s", "prev": "%s", "prev_cursor": "%s"}}`,
+			expectedResults(txrs[2:]), req.URL.String(), getResultsReq("2", util.EncodeCursor(0)).URL.String(), util.EncodeCursor(0))
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get results without block ID", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/transaction_results", nil)
+		expected := `{"code": 400, "message": "block ID must be provided"}`
+
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+}
+
 func TestCreateTransaction(t *testing.T) {
 	backend := &mock.API{}
 
@@ -423,6 +506,114 @@ func TestCreateTransaction(t *testing.T) {
 	})
 }
 
+func simulateTransactionReq(body interface{}, skipSignatureVerification string) *http.Request {
+	u, _ := url.Parse("/v1/transactions/simulate")
+	if skipSignatureVerification != "" {
+		q := u.Query()
+		q.Add("skip_signature_verification", skipSignatureVerification)
+		u.RawQuery = q.Encode()
+	}
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	return req
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	backend := &mock.API{}
+
+	tx := unittest.TransactionBodyFixture()
+	tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+	tx.Arguments = [][]uint8{}
+
+	blockID := unittest.IdentifierFixture()
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID(), 255)
+	result := &access.TransactionSimulationResult{
+		BlockID:         blockID,
+		BlockHeight:     100,
+		Events:          []flow.Event{event},
+		ComputationUsed: 20,
+		MemoryEstimate:  1000,
+		StorageDelta:    map[flow.Address]int64{tx.Payer: -8},
+		Fees: access.TransactionFees{
+			Amount:          1_000,
+			InclusionEffort: 100_000_000,
+			ExecutionEffort: 2_000,
+		},
+	}
+
+	expected := fmt.Sprintf(`{
+		"block_id": "%s",
+		"block_height": "100",
+		"error_message": "",
+		"computation_used": "20",
+		"memory_estimate": "1000",
+		"storage_delta": {"%s": "-8"},
+		"fees": {
+			"amount": "1000",
+			"inclusion_effort": "100000000",
+			"execution_effort": "2000"
+		},
+		"events": [{
+			"type": "flow.AccountCreated",
+			"transaction_id": "%s",
+			"transaction_index": "0",
+			"event_index": "0",
+			"payload": "%s"
+		}]
+	}`, blockID, tx.Payer, tx.ID(), util.ToBase64(event.Payload))
+
+	t.Run("simulate", func(t *testing.T) {
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), "")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, &tx, false, entities.EventEncodingVersion_JSON_CDC_V0).
+			Return(result, nil).
+			Once()
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("simulate without signatures", func(t *testing.T) {
+		payload := unittest.CreateSendTxHttpPayload(tx)
+		delete(payload, "payload_signatures")
+		delete(payload, "envelope_signatures")
+		req := simulateTransactionReq(payload, "true")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, mocks.AnythingOfType("*flow.TransactionBody"), true, entities.EventEncodingVersion_JSON_CDC_V0).
+			Return(result, nil).
+			Once()
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("signatures required", func(t *testing.T) {
+		payload := unittest.CreateSendTxHttpPayload(tx)
+		delete(payload, "envelope_signatures")
+		req := simulateTransactionReq(payload, "false")
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"envelope signatures not provided"}`, backend)
+	})
+
+	t.Run("invalid skip signature verification", func(t *testing.T) {
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), "yo")
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid value for skip_signature_verification"}`, backend)
+	})
+
+	t.Run("local execution state unavailable", func(t *testing.T) {
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), "")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, &tx, false, entities.EventEncodingVersion_JSON_CDC_V0).
+			Return(nil, status.Error(codes.Unimplemented, "transaction simulation requires script execution using the local execution state")).
+			Once()
+
+		assertResponse(t, req, http.StatusNotImplemented, `{"code":501, "message":"Not implemented: transaction simulation requires script execution using the local execution state"}`, backend)
+	})
+}
+
 func transactionResultFixture(tx flow.Transaction) *access.TransactionResult {
 	cid := unittest.IdentifierFixture()
 	return &access.TransactionResult{
@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog"
 	"go.uber.org/atomic"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/models"
 	"github.com/onflow/flow-go/engine/access/rest/request"
 	"github.com/onflow/flow-go/engine/access/state_stream"
@@ -36,12 +37,18 @@ const (
 type WebsocketController struct {
 	logger            zerolog.Logger
 	conn              *websocket.Conn                // the WebSocket connection for communication with the client
-	api               state_stream.API               // the state_stream.API instance for managing event subscriptions
+	api               state_stream.API               // the state_stream.API instance for managing event and account status subscriptions
+	accessAPI         access.API                     // the access.API instance for managing block and transaction status subscriptions
+	linkGenerator     models.LinkGenerator           // the link generator used to build block and transaction result responses
 	eventFilterConfig state_stream.EventFilterConfig // the configuration for filtering events
 	maxStreams        int32                          // the maximum number of streams allowed
 	activeStreamCount *atomic.Int32                  // the current number of active streams
 	readChannel       chan error                     // channel which notify closing connection by the client and provide errors to the client
 	heartbeatInterval uint64                         // the interval to deliver heartbeat messages to client[IN BLOCKS]
+	maxEvents         uint                           // the max number of events of a single event or account status response
+	blockStatus       flow.BlockStatus               // the status of the streamed blocks, used to build block responses
+	expandFields      map[string]bool                // the fields to expand in block responses
+	resumeToken       *subscription.ResumeToken      // the token the subscription resumes from, events delivered before it are not sent again
 }
 
 // SetWebsocketConf used to set read and write deadlines for WebSocket connections and establishes a Pong handler to
@@ -107,11 +114,11 @@ func (wsController *WebsocketController) wsErrorHandler(err error) {
 	}
 }
 
-// writeEvents is used for writing events and pings to the WebSocket connection for a given subscription.
-// It listens to the subscription's channel for events and writes them to the WebSocket connection.
+// writeResponses is used for writing subscription responses and pings to the WebSocket connection for a given subscription.
+// It listens to the subscription's channel for responses and writes them to the WebSocket connection.
 // If an error occurs or the subscription channel is closed, it handles the error or termination accordingly.
 // The function uses a ticker to periodically send ping messages to the client to maintain the connection.
-func (wsController *WebsocketController) writeEvents(sub subscription.Subscription) {
+func (wsController *WebsocketController) writeResponses(sub subscription.Subscription) {
 	ticker := time.NewTicker(pingPeriod)
 	defer ticker.Stop()
 
@@ -127,7 +134,7 @@ func (wsController *WebsocketController) writeEvents(sub subscription.Subscripti
 				wsController.wsErrorHandler(err)
 			}
 			return
-		case event, ok := <-sub.Channel():
+		case data, ok := <-sub.Channel():
 			if !ok {
 				if sub.Err() != nil {
 					err := fmt.Errorf("stream encountered an error: %v", sub.Err())
@@ -144,15 +151,15 @@ func (wsController *WebsocketController) writeEvents(sub subscription.Subscripti
 				return
 			}
 
-			resp, ok := event.(*backend.EventsResponse)
-			if !ok {
-				err = fmt.Errorf("unexpected response type: %s", event)
+			resps, isEmpty, err := wsController.buildResponse(data)
+			if err != nil {
 				wsController.wsErrorHandler(err)
 				return
 			}
-			// responses with empty events increase heartbeat interval counter, when threshold is met a heartbeat
+
+			// empty responses increase heartbeat interval counter, when threshold is met a heartbeat
 			// message will be emitted.
-			if len(resp.Events) == 0 {
+			if isEmpty {
 				blocksSinceLastMessage++
 				if blocksSinceLastMessage < wsController.heartbeatInterval {
 					continue
@@ -160,23 +167,13 @@ func (wsController *WebsocketController) writeEvents(sub subscription.Subscripti
 				blocksSinceLastMessage = 0
 			}
 
-			// EventsResponse contains CCF encoded events, and this API returns JSON-CDC events.
-			// convert event payload formats.
-			for i, e := range resp.Events {
-				payload, err := convert.CcfPayloadToJsonPayload(e.Payload)
+			// Write the responses to the WebSocket connection
+			for _, resp := range resps {
+				err = wsController.conn.WriteJSON(resp)
 				if err != nil {
-					err = fmt.Errorf("could not convert event payload from CCF to Json: %w", err)
 					wsController.wsErrorHandler(err)
 					return
 				}
-				resp.Events[i].Payload = payload
-			}
-
-			// Write the response to the WebSocket connection
-			err = wsController.conn.WriteJSON(event)
-			if err != nil {
-				wsController.wsErrorHandler(err)
-				return
 			}
 		case <-ticker.C:
 			err := wsController.conn.SetWriteDeadline(time.Now().Add(writeWait))
@@ -192,6 +189,97 @@ func (wsController *WebsocketController) writeEvents(sub subscription.Subscripti
 	}
 }
 
+// buildResponse converts a value received from a subscription into the responses written to the client.
+// The events of blocks with more than maxEvents events are split into several responses at transaction
+// boundaries, each carrying the resume token of its position in the block.
+// It also returns whether the value carries no data for the block, in which case it is only sent
+// as a heartbeat message.
+//
+// No errors are expected during normal operation.
+func (wsController *WebsocketController) buildResponse(data interface{}) ([]interface{}, bool, error) {
+	switch resp := data.(type) {
+	case *backend.EventsResponse:
+		resp.Events = wsController.resumeToken.FilterEvents(resp.Height, resp.Events)
+
+		// EventsResponse contains CCF encoded events, and this API returns JSON-CDC events.
+		// convert event payload formats.
+		err := convertEventPayloads(resp.Events)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var resps []interface{}
+		for _, part := range subscription.SplitEvents(resp.Height, resp.Events, wsController.maxEvents) {
+			partResp := *resp
+			partResp.Events = part.Events
+			partResp.ResumeToken = part.ResumeToken.Encode()
+			resps = append(resps, &partResp)
+		}
+		return resps, len(resp.Events) == 0, nil
+
+	case *backend.AccountStatusesResponse:
+		resp.AccountEvents = wsController.resumeToken.FilterAccountEvents(resp.Height, resp.AccountEvents)
+
+		// AccountStatusesResponse contains CCF encoded events, and this API returns JSON-CDC events.
+		// convert event payload formats.
+		for _, events := range resp.AccountEvents {
+			err := convertEventPayloads(events)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		var resps []interface{}
+		for _, part := range subscription.SplitAccountEvents(resp.Height, resp.AccountEvents, wsController.maxEvents) {
+			partResp := *resp
+			partResp.AccountEvents = part.AccountEvents
+			partResp.ResumeToken = part.ResumeToken.Encode()
+			resps = append(resps, &partResp)
+		}
+		return resps, len(resp.AccountEvents) == 0, nil
+
+	case *flow.Block:
+		var block models.Block
+		err := block.Build(resp, nil, wsController.linkGenerator, wsController.blockStatus, wsController.expandFields)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not build block response: %w", err)
+		}
+		return []interface{}{block}, false, nil
+
+	case *flow.Header:
+		var header models.BlockHeader
+		header.Build(resp)
+		return []interface{}{header}, false, nil
+
+	case *flow.BlockDigest:
+		var digest models.BlockDigest
+		digest.Build(resp)
+		return []interface{}{digest}, false, nil
+
+	case []*access.TransactionResult:
+		results := make([]models.TransactionResult, len(resp))
+		for i, txResult := range resp {
+			results[i].Build(txResult, txResult.TransactionID, wsController.linkGenerator)
+		}
+		return []interface{}{results}, false, nil
+
+	default:
+		return nil, false, fmt.Errorf("unexpected response type: %T", data)
+	}
+}
+
+// convertEventPayloads converts the payloads of the given CCF encoded events to JSON-CDC in place.
+func convertEventPayloads(events flow.EventsList) error {
+	for i, e := range events {
+		payload, err := convert.CcfPayloadToJsonPayload(e.Payload)
+		if err != nil {
+			return fmt.Errorf("could not convert event payload from CCF to Json: %w", err)
+		}
+		events[i].Payload = payload
+	}
+	return nil
+}
+
 // read function handles WebSocket messages from the client.
 // It continuously reads messages from the WebSocket connection and closes
 // the associated read channel when the connection is closed by client or when an
@@ -239,9 +327,12 @@ type WSHandler struct {
 	subscribeFunc SubscribeHandlerFunc
 
 	api                      state_stream.API
+	accessAPI                access.API
+	linkGenerator            models.LinkGenerator
 	eventFilterConfig        state_stream.EventFilterConfig
 	maxStreams               int32
 	defaultHeartbeatInterval uint64
+	maxEventsPerResponse     uint
 	activeStreamCount        *atomic.Int32
 }
 
@@ -250,6 +341,8 @@ var _ http.Handler = (*WSHandler)(nil)
 func NewWSHandler(
 	logger zerolog.Logger,
 	api state_stream.API,
+	accessAPI access.API,
+	linkGenerator models.LinkGenerator,
 	subscribeFunc SubscribeHandlerFunc,
 	chain flow.Chain,
 	stateStreamConfig backend.Config,
@@ -257,9 +350,12 @@ func NewWSHandler(
 	handler := &WSHandler{
 		subscribeFunc:            subscribeFunc,
 		api:                      api,
+		accessAPI:                accessAPI,
+		linkGenerator:            linkGenerator,
 		eventFilterConfig:        stateStreamConfig.EventFilterConfig,
 		maxStreams:               int32(stateStreamConfig.MaxGlobalStreams),
 		defaultHeartbeatInterval: stateStreamConfig.HeartbeatInterval,
+		maxEventsPerResponse:     stateStreamConfig.MaxEventsPerResponse,
 		activeStreamCount:        atomic.NewInt32(0),
 		HttpHandler:              NewHttpHandler(logger, chain),
 	}
@@ -297,11 +393,14 @@ func (h *WSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		logger:            logger,
 		conn:              conn,
 		api:               h.api,
+		accessAPI:         h.accessAPI,
+		linkGenerator:     h.linkGenerator,
 		eventFilterConfig: h.eventFilterConfig,
 		maxStreams:        h.maxStreams,
 		activeStreamCount: h.activeStreamCount,
 		readChannel:       make(chan error),
 		heartbeatInterval: h.defaultHeartbeatInterval, // set default heartbeat interval from state stream config
+		maxEvents:         h.maxEventsPerResponse,
 	}
 
 	err = wsController.SetWebsocketConf()
@@ -330,5 +429,5 @@ func (h *WSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go wsController.read()
-	wsController.writeEvents(sub)
+	wsController.writeResponses(sub)
 }
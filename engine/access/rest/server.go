@@ -8,7 +8,10 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/engine/access/rest/graphql"
 	"github.com/onflow/flow-go/engine/access/rest/routes"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/state_stream/backend"
 	"github.com/onflow/flow-go/model/flow"
@@ -27,13 +30,16 @@ const (
 )
 
 type Config struct {
-	ListenAddress string
-	WriteTimeout  time.Duration
-	ReadTimeout   time.Duration
-	IdleTimeout   time.Duration
+	ListenAddress   string
+	WriteTimeout    time.Duration
+	ReadTimeout     time.Duration
+	IdleTimeout     time.Duration
+	WebSocketConfig websockets.Config
+	GraphQLConfig   graphql.Config
 }
 
-// NewServer returns an HTTP server initialized with the REST API handler
+// NewServer returns an HTTP server initialized with the REST API handler. If clientQuotas is not nil, the
+// per-client quotas are enforced for all requests.
 func NewServer(serverAPI access.API,
 	config Config,
 	logger zerolog.Logger,
@@ -41,10 +47,21 @@ func NewServer(serverAPI access.API,
 	restCollector module.RestMetrics,
 	stateStreamApi state_stream.API,
 	stateStreamConfig backend.Config,
+	clientQuotas *quota.Manager,
 ) (*http.Server, error) {
 	builder := routes.NewRouterBuilder(logger, restCollector).AddRestRoutes(serverAPI, chain)
 	if stateStreamApi != nil {
-		builder.AddWsRoutes(stateStreamApi, chain, stateStreamConfig)
+		builder.AddWsRoutes(stateStreamApi, serverAPI, chain, stateStreamConfig)
+		builder.AddWebsocketsRoute(chain, config.WebSocketConfig, stateStreamApi, serverAPI, stateStreamConfig)
+	}
+	if config.GraphQLConfig.Enabled {
+		if _, err := builder.AddGraphQLRoute(serverAPI, chain, config.GraphQLConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	if clientQuotas != nil {
+		builder.AddClientQuotas(clientQuotas)
 	}
 
 	c := cors.New(cors.Options{
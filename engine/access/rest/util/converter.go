@@ -2,8 +2,12 @@ package util
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"strconv"
+
+	"github.com/onflow/flow-go/model/flow"
 )
 
 // FromUint64 convert uint64 to string
@@ -29,3 +33,45 @@ func ToBase64(byteValue []byte) string {
 func FromBase64(bytesStr string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(bytesStr)
 }
+
+// EncodeCursor encodes the given position values as an opaque cursor string
+func EncodeCursor(values ...uint64) string {
+	encoded := make([]byte, 8*len(values))
+	for i, value := range values {
+		binary.BigEndian.PutUint64(encoded[8*i:], value)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor decodes the opaque cursor string into the given number of position values
+func DecodeCursor(cursor string, count int) ([]uint64, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(encoded) != 8*count {
+		return nil, fmt.Errorf("invalid cursor") // hide error from user
+	}
+	values := make([]uint64, count)
+	for i := range values {
+		values[i] = binary.BigEndian.Uint64(encoded[8*i:])
+	}
+	return values, nil
+}
+
+// FromAccountTransactionCursor converts the account transaction cursor to an opaque cursor string
+func FromAccountTransactionCursor(cursor *flow.AccountTransactionCursor) string {
+	return EncodeCursor(cursor.BlockHeight, uint64(cursor.TransactionIndex))
+}
+
+// ToAccountTransactionCursor converts the opaque cursor string to an account transaction cursor
+func ToAccountTransactionCursor(cursorStr string) (*flow.AccountTransactionCursor, error) {
+	values, err := DecodeCursor(cursorStr, 2)
+	if err != nil {
+		return nil, err
+	}
+	if values[1] > math.MaxUint32 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &flow.AccountTransactionCursor{
+		BlockHeight:      values[0],
+		TransactionIndex: uint32(values[1]),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package websockets
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxConnections is the default maximum number of websocket connections served at the same time.
+	DefaultMaxConnections = 1000
+
+	// DefaultMaxSubscriptionsPerConnection is the default maximum number of active subscriptions of a
+	// single websocket connection.
+	DefaultMaxSubscriptionsPerConnection = 20
+
+	// DefaultMaxResponsesPerSecond is the default maximum number of subscription responses sent per second
+	// on a single websocket connection. A value of 0 disables the limit.
+	DefaultMaxResponsesPerSecond = 0
+
+	// DefaultSendBufferSize is the default number of responses buffered for a connection before
+	// subscriptions are blocked.
+	DefaultSendBufferSize = 64
+
+	// Time allowed to read the next pong message from the peer.
+	pongWait = 10 * time.Second
+
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+
+	// Maximum size of a message read from the peer.
+	maxMessageSize = 2 << 20 // 2MB
+)
+
+// Config contains the configuration of the multiplexed websocket endpoint.
+type Config struct {
+	// MaxConnections is the maximum number of connections served at the same time.
+	MaxConnections uint64
+	// MaxSubscriptionsPerConnection is the maximum number of active subscriptions of a single connection.
+	MaxSubscriptionsPerConnection uint64
+	// MaxResponsesPerSecond is the maximum number of subscription responses sent per second on a single
+	// connection. A value of 0 disables the limit.
+	MaxResponsesPerSecond float64
+	// SendBufferSize is the number of responses buffered for a connection before subscriptions are blocked.
+	SendBufferSize uint
+}
+
+// NewDefaultWebsocketConfig returns the default websocket configuration.
+func NewDefaultWebsocketConfig() Config {
+	return Config{
+		MaxConnections:                DefaultMaxConnections,
+		MaxSubscriptionsPerConnection: DefaultMaxSubscriptionsPerConnection,
+		MaxResponsesPerSecond:         DefaultMaxResponsesPerSecond,
+		SendBufferSize:                DefaultSendBufferSize,
+	}
+}
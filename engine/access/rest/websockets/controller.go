@@ -0,0 +1,376 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+)
+
+// Controller manages a single multiplexed websocket connection. Clients send JSON messages to subscribe to
+// topics, unsubscribe from them, and list their active subscriptions. The data of all subscriptions is
+// written to the same connection, tagged with the subscription ID.
+//
+// The controller runs two routines: the reader handles client messages, and the writer sends responses and
+// keepalive pings. Each subscription is streamed by its own data provider routine.
+type Controller struct {
+	logger              zerolog.Logger
+	config              Config
+	conn                *websocket.Conn
+	dataProviderFactory data_providers.DataProviderFactory
+
+	// multiplexedStream is the channel all responses are written to, before they are sent to the client
+	multiplexedStream chan interface{}
+	// limiter limits the rate of subscription responses sent to the client. nil if there is no limit.
+	limiter *rate.Limiter
+
+	maxStreams        int32         // the maximum number of streams of all connections
+	activeStreamCount *atomic.Int32 // the current number of streams of all connections, shared by all controllers
+
+	mu            sync.Mutex
+	dataProviders map[string]data_providers.DataProvider
+	wg            sync.WaitGroup
+}
+
+// NewController creates a new Controller for the given connection. Each subscription counts as one of
+// the at most maxStreams streams counted by activeStreamCount.
+func NewController(
+	logger zerolog.Logger,
+	config Config,
+	conn *websocket.Conn,
+	dataProviderFactory data_providers.DataProviderFactory,
+	maxStreams int32,
+	activeStreamCount *atomic.Int32,
+) *Controller {
+	var limiter *rate.Limiter
+	if config.MaxResponsesPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(config.MaxResponsesPerSecond), 1)
+	}
+
+	return &Controller{
+		logger:              logger.With().Str("component", "websocket_controller").Logger(),
+		config:              config,
+		conn:                conn,
+		dataProviderFactory: dataProviderFactory,
+		multiplexedStream:   make(chan interface{}, config.SendBufferSize),
+		limiter:             limiter,
+		maxStreams:          maxStreams,
+		activeStreamCount:   activeStreamCount,
+		dataProviders:       make(map[string]data_providers.DataProvider),
+	}
+}
+
+// HandleConnection serves the connection until the client disconnects or an error occurs.
+// All subscriptions are cancelled before it returns.
+func (c *Controller) HandleConnection(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := c.configureKeepalive()
+	if err != nil {
+		c.logger.Debug().Err(err).Msg("failed to configure connection keepalive")
+		return
+	}
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		c.writeMessages(ctx)
+		// unblock the reader if the writer failed
+		cancel()
+		_ = c.conn.Close()
+	}()
+
+	c.readMessages(ctx)
+
+	cancel()
+	c.shutdown()
+	<-writerDone
+}
+
+// configureKeepalive sets the read limit and the initial read deadline, which is extended whenever
+// a pong message is received from the client.
+func (c *Controller) configureKeepalive() error {
+	c.conn.SetReadLimit(maxMessageSize)
+
+	err := c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	if err != nil {
+		return fmt.Errorf("failed to set the initial read deadline: %w", err)
+	}
+
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	return nil
+}
+
+// readMessages reads and handles client messages until the connection is closed.
+func (c *Controller) readMessages(ctx context.Context) {
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) && ctx.Err() == nil {
+				c.logger.Debug().Err(err).Msg("failed to read websocket message")
+			}
+			return
+		}
+
+		c.handleMessage(ctx, msg)
+	}
+}
+
+// handleMessage handles a single client message. Invalid messages are answered with an error response,
+// without closing the connection.
+func (c *Controller) handleMessage(ctx context.Context, msg []byte) {
+	var base models.BaseMessageRequest
+	err := json.Unmarshal(msg, &base)
+	if err != nil {
+		c.sendError(ctx, base, http.StatusBadRequest, fmt.Errorf("invalid message: %w", err))
+		return
+	}
+
+	switch base.Action {
+	case models.SubscribeAction:
+		var req models.SubscribeMessageRequest
+		err := json.Unmarshal(msg, &req)
+		if err != nil {
+			c.sendError(ctx, base, http.StatusBadRequest, fmt.Errorf("invalid subscribe message: %w", err))
+			return
+		}
+		c.handleSubscribe(ctx, req)
+
+	case models.UnsubscribeAction:
+		c.handleUnsubscribe(ctx, models.UnsubscribeMessageRequest{BaseMessageRequest: base})
+
+	case models.ListSubscriptionsAction:
+		c.handleListSubscriptions(ctx, models.ListSubscriptionsMessageRequest{BaseMessageRequest: base})
+
+	default:
+		c.sendError(ctx, base, http.StatusBadRequest, fmt.Errorf("unknown action '%s'", base.Action))
+	}
+}
+
+// handleSubscribe starts a new subscription. The lock is only held while the subscription is added, so
+// that responses are sent without holding it.
+func (c *Controller) handleSubscribe(ctx context.Context, req models.SubscribeMessageRequest) {
+	if req.SubscriptionID == "" {
+		req.SubscriptionID = uuid.New().String()
+	}
+
+	provider, release, code, err := c.addSubscription(ctx, req)
+	if err != nil {
+		c.sendError(ctx, req.BaseMessageRequest, code, err)
+		return
+	}
+
+	// the response is queued before the provider is started, so it is always sent before any data
+	c.send(ctx, &models.SubscribeMessageResponse{
+		BaseMessageResponse: models.BaseMessageResponse{
+			SubscriptionID: req.SubscriptionID,
+			Action:         models.SubscribeAction,
+		},
+		Topic: req.Topic,
+	})
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer release()
+		c.runDataProvider(ctx, provider)
+	}()
+}
+
+// addSubscription creates the data provider of the subscription and adds it to the active subscriptions.
+// The subscription counts against the limits of the connection, the global stream limit, and the stream
+// quota of the client. The returned function releases the streams, and must be called once the
+// subscription ends.
+// If the subscription is rejected, the status code and the error to send to the client are returned.
+func (c *Controller) addSubscription(ctx context.Context, req models.SubscribeMessageRequest) (data_providers.DataProvider, func(), int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if uint64(len(c.dataProviders)) >= c.config.MaxSubscriptionsPerConnection {
+		err := fmt.Errorf("maximum number of subscriptions per connection reached: %d", c.config.MaxSubscriptionsPerConnection)
+		return nil, nil, http.StatusTooManyRequests, err
+	}
+	if _, ok := c.dataProviders[req.SubscriptionID]; ok {
+		err := fmt.Errorf("subscription ID '%s' is already in use", req.SubscriptionID)
+		return nil, nil, http.StatusBadRequest, err
+	}
+
+	if c.activeStreamCount.Inc() > c.maxStreams {
+		c.activeStreamCount.Dec()
+		return nil, nil, http.StatusServiceUnavailable, errors.New("maximum number of streams reached")
+	}
+
+	// each subscription counts against the stream quota of the client, if quotas are enforced
+	releaseQuota, err := quota.OpenStream(ctx)
+	if err != nil {
+		c.activeStreamCount.Dec()
+		return nil, nil, http.StatusTooManyRequests, err
+	}
+	release := func() {
+		releaseQuota()
+		c.activeStreamCount.Dec()
+	}
+
+	provider, err := c.dataProviderFactory.NewDataProvider(ctx, req.SubscriptionID, req.Topic, req.Arguments, c.multiplexedStream)
+	if err != nil {
+		release()
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("could not subscribe: %w", err)
+	}
+	c.dataProviders[req.SubscriptionID] = provider
+
+	return provider, release, 0, nil
+}
+
+// runDataProvider streams the data of the given provider until it ends, and reports subscription errors
+// to the client.
+func (c *Controller) runDataProvider(ctx context.Context, provider data_providers.DataProvider) {
+	err := provider.Run()
+
+	c.mu.Lock()
+	current, active := c.dataProviders[provider.ID()]
+	if active && current == provider {
+		delete(c.dataProviders, provider.ID())
+	}
+	c.mu.Unlock()
+
+	if err != nil && active && ctx.Err() == nil {
+		c.logger.Debug().Err(err).Str("subscription_id", provider.ID()).Msg("subscription failed")
+		c.sendError(ctx, models.BaseMessageRequest{
+			Action:         models.SubscribeAction,
+			SubscriptionID: provider.ID(),
+		}, http.StatusInternalServerError, err)
+	}
+}
+
+func (c *Controller) handleUnsubscribe(ctx context.Context, req models.UnsubscribeMessageRequest) {
+	c.mu.Lock()
+	provider, ok := c.dataProviders[req.SubscriptionID]
+	if ok {
+		delete(c.dataProviders, req.SubscriptionID)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		err := fmt.Errorf("subscription '%s' not found", req.SubscriptionID)
+		c.sendError(ctx, req.BaseMessageRequest, http.StatusNotFound, err)
+		return
+	}
+
+	provider.Close()
+
+	c.send(ctx, &models.UnsubscribeMessageResponse{
+		BaseMessageResponse: models.BaseMessageResponse{
+			SubscriptionID: req.SubscriptionID,
+			Action:         models.UnsubscribeAction,
+		},
+	})
+}
+
+func (c *Controller) handleListSubscriptions(ctx context.Context, _ models.ListSubscriptionsMessageRequest) {
+	c.mu.Lock()
+	subscriptions := make([]*models.SubscriptionEntry, 0, len(c.dataProviders))
+	for _, provider := range c.dataProviders {
+		subscriptions = append(subscriptions, &models.SubscriptionEntry{
+			SubscriptionID: provider.ID(),
+			Topic:          provider.Topic(),
+			Arguments:      provider.Arguments(),
+		})
+	}
+	c.mu.Unlock()
+
+	c.send(ctx, &models.ListSubscriptionsMessageResponse{
+		BaseMessageResponse: models.BaseMessageResponse{
+			Action: models.ListSubscriptionsAction,
+		},
+		Subscriptions: subscriptions,
+	})
+}
+
+// writeMessages writes responses and keepalive pings to the connection until the context is cancelled
+// or writing fails.
+func (c *Controller) writeMessages(ctx context.Context) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case msg := <-c.multiplexedStream:
+			if _, ok := msg.(*models.SubscriptionResponse); ok && c.limiter != nil {
+				if err := c.limiter.Wait(ctx); err != nil {
+					return
+				}
+			}
+
+			err := c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err != nil {
+				c.logger.Debug().Err(err).Msg("failed to set the write deadline")
+				return
+			}
+			err = c.conn.WriteJSON(msg)
+			if err != nil {
+				c.logger.Debug().Err(err).Msg("failed to write websocket message")
+				return
+			}
+
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				if !errors.Is(err, websocket.ErrCloseSent) {
+					c.logger.Debug().Err(err).Msg("failed to write ping message")
+				}
+				return
+			}
+		}
+	}
+}
+
+// send queues a message to be written to the client. Messages are dropped once the connection is closing.
+func (c *Controller) send(ctx context.Context, msg interface{}) {
+	select {
+	case <-ctx.Done():
+	case c.multiplexedStream <- msg:
+	}
+}
+
+// sendError queues an error response to the given client message.
+func (c *Controller) sendError(ctx context.Context, req models.BaseMessageRequest, code int, err error) {
+	c.send(ctx, &models.BaseMessageResponse{
+		SubscriptionID: req.SubscriptionID,
+		Action:         req.Action,
+		Error: &models.ErrorMessage{
+			Code:    code,
+			Message: err.Error(),
+		},
+	})
+}
+
+// shutdown closes all data providers and waits for them to finish.
+func (c *Controller) shutdown() {
+	c.mu.Lock()
+	for id, provider := range c.dataProviders {
+		provider.Close()
+		delete(c.dataProviders, id)
+	}
+	c.mu.Unlock()
+
+	c.wg.Wait()
+}
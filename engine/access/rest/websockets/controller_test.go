@@ -0,0 +1,347 @@
+package websockets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	restmodels "github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	statestreammock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type ControllerSuite struct {
+	suite.Suite
+
+	accessApi        *accessmock.API
+	config           Config
+	maxGlobalStreams uint32
+	factory          data_providers.DataProviderFactory
+	server           *httptest.Server
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerSuite))
+}
+
+func (s *ControllerSuite) SetupTest() {
+	s.accessApi = accessmock.NewAPI(s.T())
+	s.config = NewDefaultWebsocketConfig()
+	s.config.MaxSubscriptionsPerConnection = 2
+	s.maxGlobalStreams = 10
+
+	stateStreamConfig := backend.Config{
+		EventFilterConfig: state_stream.DefaultEventFilterConfig,
+		HeartbeatInterval: 1,
+	}
+	s.factory = data_providers.NewDataProviderFactory(
+		unittest.Logger(),
+		statestreammock.NewAPI(s.T()),
+		s.accessApi,
+		flow.Testnet.Chain(),
+		nil,
+		stateStreamConfig,
+	)
+
+	s.startServer()
+}
+
+// startServer starts a test server using the configuration of the suite.
+func (s *ControllerSuite) startServer() {
+	if s.server != nil {
+		s.server.Close()
+	}
+	s.server = httptest.NewServer(NewHandler(unittest.Logger(), s.config, s.factory, s.maxGlobalStreams))
+	s.T().Cleanup(s.server.Close)
+}
+
+// connect opens a websocket connection to the test server.
+func (s *ControllerSuite) connect() *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), resp.Body.Close())
+	s.T().Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// mockHeadersSubscription configures the access API to stream the given headers, and returns a channel
+// which is closed once the subscription's context is cancelled.
+func (s *ControllerSuite) mockHeadersSubscription(headers []*flow.Header) <-chan struct{} {
+	cancelled := make(chan struct{})
+	ch := make(chan interface{})
+	var chReadOnly <-chan interface{} = ch
+
+	sub := statestreammock.NewSubscription(s.T())
+	sub.On("Channel").Return(chReadOnly)
+	sub.On("Err").Return(nil).Maybe()
+
+	s.accessApi.
+		On("SubscribeBlockHeadersFromLatest", mocks.Anything, flow.BlockStatusFinalized).
+		Run(func(args mocks.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			go func() {
+				defer close(cancelled)
+				// the backend closes the subscription channel once the context is cancelled
+				defer close(ch)
+				for _, header := range headers {
+					select {
+					case <-ctx.Done():
+						return
+					case ch <- header:
+					}
+				}
+				<-ctx.Done()
+			}()
+		}).
+		Return(sub).
+		Once()
+
+	return cancelled
+}
+
+func (s *ControllerSuite) send(conn *websocket.Conn, msg interface{}) {
+	require.NoError(s.T(), conn.WriteJSON(msg))
+}
+
+func (s *ControllerSuite) receive(conn *websocket.Conn, v interface{}) {
+	require.NoError(s.T(), conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(s.T(), conn.ReadJSON(v))
+}
+
+// TestSubscribeUnsubscribe tests subscribing to a topic, listing the active subscriptions, receiving the
+// subscription data and unsubscribing.
+func (s *ControllerSuite) TestSubscribeUnsubscribe() {
+	headers := []*flow.Header{unittest.BlockHeaderFixture(), unittest.BlockHeaderFixture()}
+	cancelled := s.mockHeadersSubscription(headers)
+
+	conn := s.connect()
+	s.send(conn, models.SubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{
+			Action:         models.SubscribeAction,
+			SubscriptionID: "headers",
+		},
+		Topic: data_providers.BlockHeadersTopic,
+	})
+
+	var subscribeResp models.SubscribeMessageResponse
+	s.receive(conn, &subscribeResp)
+	s.Require().Nil(subscribeResp.Error)
+	s.Require().Equal("headers", subscribeResp.SubscriptionID)
+	s.Require().Equal(data_providers.BlockHeadersTopic, subscribeResp.Topic)
+
+	for _, header := range headers {
+		var resp struct {
+			SubscriptionID string                 `json:"subscription_id"`
+			Topic          string                 `json:"topic"`
+			Payload        restmodels.BlockHeader `json:"payload"`
+		}
+		s.receive(conn, &resp)
+		s.Require().Equal("headers", resp.SubscriptionID)
+		s.Require().Equal(data_providers.BlockHeadersTopic, resp.Topic)
+		s.Require().Equal(header.ID().String(), resp.Payload.Id)
+	}
+
+	s.send(conn, models.ListSubscriptionsMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.ListSubscriptionsAction},
+	})
+	var listResp models.ListSubscriptionsMessageResponse
+	s.receive(conn, &listResp)
+	s.Require().Len(listResp.Subscriptions, 1)
+	s.Require().Equal("headers", listResp.Subscriptions[0].SubscriptionID)
+	s.Require().Equal(data_providers.BlockHeadersTopic, listResp.Subscriptions[0].Topic)
+
+	s.send(conn, models.UnsubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{
+			Action:         models.UnsubscribeAction,
+			SubscriptionID: "headers",
+		},
+	})
+	var unsubscribeResp models.UnsubscribeMessageResponse
+	s.receive(conn, &unsubscribeResp)
+	s.Require().Nil(unsubscribeResp.Error)
+	s.Require().Equal("headers", unsubscribeResp.SubscriptionID)
+	unittest.RequireCloseBefore(s.T(), cancelled, time.Second, "subscription was not cancelled")
+
+	s.send(conn, models.ListSubscriptionsMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.ListSubscriptionsAction},
+	})
+	s.receive(conn, &listResp)
+	s.Require().Empty(listResp.Subscriptions)
+}
+
+// TestDisconnectCancelsSubscriptions tests that all subscriptions are cancelled when the client disconnects.
+func (s *ControllerSuite) TestDisconnectCancelsSubscriptions() {
+	cancelled := s.mockHeadersSubscription(nil)
+
+	conn := s.connect()
+	s.send(conn, models.SubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+		Topic:              data_providers.BlockHeadersTopic,
+	})
+
+	var subscribeResp models.SubscribeMessageResponse
+	s.receive(conn, &subscribeResp)
+	s.Require().Nil(subscribeResp.Error)
+	// a subscription ID is generated if none was provided
+	s.Require().NotEmpty(subscribeResp.SubscriptionID)
+
+	require.NoError(s.T(), conn.Close())
+	unittest.RequireCloseBefore(s.T(), cancelled, time.Second, "subscription was not cancelled")
+}
+
+// TestErrors tests that invalid client messages are answered with an error response without closing
+// the connection.
+func (s *ControllerSuite) TestErrors() {
+	conn := s.connect()
+
+	requireError := func(code int, contains string) {
+		var resp models.BaseMessageResponse
+		s.receive(conn, &resp)
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(code, resp.Error.Code)
+		s.Require().Contains(resp.Error.Message, contains)
+	}
+
+	s.Run("invalid message", func() {
+		require.NoError(s.T(), conn.WriteMessage(websocket.TextMessage, []byte("foo")))
+		requireError(http.StatusBadRequest, "invalid message")
+	})
+
+	s.Run("unknown action", func() {
+		s.send(conn, models.BaseMessageRequest{Action: "foo"})
+		requireError(http.StatusBadRequest, "unknown action")
+	})
+
+	s.Run("unknown topic", func() {
+		s.send(conn, models.SubscribeMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+			Topic:              "foo",
+		})
+		requireError(http.StatusBadRequest, "unsupported topic")
+	})
+
+	s.Run("invalid arguments", func() {
+		s.send(conn, models.SubscribeMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+			Topic:              data_providers.BlocksTopic,
+			Arguments:          models.Arguments{"block_status": "executed"},
+		})
+		requireError(http.StatusBadRequest, "invalid block status")
+	})
+
+	s.Run("unknown subscription", func() {
+		s.send(conn, models.UnsubscribeMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{
+				Action:         models.UnsubscribeAction,
+				SubscriptionID: "foo",
+			},
+		})
+		requireError(http.StatusNotFound, "subscription 'foo' not found")
+	})
+}
+
+// TestMaxSubscriptions tests that the number of subscriptions per connection is limited.
+func (s *ControllerSuite) TestMaxSubscriptions() {
+	conn := s.connect()
+
+	for i := uint64(0); i < s.config.MaxSubscriptionsPerConnection; i++ {
+		s.mockHeadersSubscription(nil)
+		s.send(conn, models.SubscribeMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+			Topic:              data_providers.BlockHeadersTopic,
+		})
+		var resp models.SubscribeMessageResponse
+		s.receive(conn, &resp)
+		s.Require().Nil(resp.Error)
+	}
+
+	s.send(conn, models.SubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+		Topic:              data_providers.BlockHeadersTopic,
+	})
+	var resp models.SubscribeMessageResponse
+	s.receive(conn, &resp)
+	s.Require().NotNil(resp.Error)
+	s.Require().Equal(http.StatusTooManyRequests, resp.Error.Code)
+}
+
+// subscribeHeaders subscribes to block headers, and returns the response.
+func (s *ControllerSuite) subscribeHeaders(conn *websocket.Conn) models.SubscribeMessageResponse {
+	s.send(conn, models.SubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.SubscribeAction},
+		Topic:              data_providers.BlockHeadersTopic,
+	})
+	var resp models.SubscribeMessageResponse
+	s.receive(conn, &resp)
+	return resp
+}
+
+// TestMaxGlobalStreams tests that the subscriptions of all connections count against the global maximum
+// number of streams, and that streams are released when subscriptions end.
+func (s *ControllerSuite) TestMaxGlobalStreams() {
+	s.maxGlobalStreams = 1
+	s.startServer()
+
+	cancelled := s.mockHeadersSubscription(nil)
+	conn := s.connect()
+	resp := s.subscribeHeaders(conn)
+	s.Require().Nil(resp.Error)
+
+	other := s.connect()
+	resp = s.subscribeHeaders(other)
+	s.Require().NotNil(resp.Error)
+	s.Require().Equal(http.StatusServiceUnavailable, resp.Error.Code)
+
+	// the stream is released once the subscription is cancelled
+	require.NoError(s.T(), conn.Close())
+	unittest.RequireCloseBefore(s.T(), cancelled, time.Second, "subscription was not cancelled")
+
+	s.mockHeadersSubscription(nil)
+	require.Eventually(s.T(), func() bool {
+		return s.subscribeHeaders(other).Error == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestMaxConnections tests that the number of connections served at the same time is limited.
+func (s *ControllerSuite) TestMaxConnections() {
+	s.config.MaxConnections = 1
+	s.startServer()
+
+	conn := s.connect()
+
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().ErrorIs(err, websocket.ErrBadHandshake)
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	require.NoError(s.T(), resp.Body.Close())
+
+	// connections can be opened again once the connection is closed
+	require.NoError(s.T(), conn.Close())
+	require.Eventually(s.T(), func() bool {
+		other, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			return false
+		}
+		_ = resp.Body.Close()
+		_ = other.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+}
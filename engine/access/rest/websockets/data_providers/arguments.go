@@ -0,0 +1,44 @@
+package data_providers
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+)
+
+// argumentsReader reads subscription arguments, and records the first invalid argument it encounters.
+type argumentsReader struct {
+	arguments models.Arguments
+	err       error
+}
+
+func newArgumentsReader(arguments models.Arguments) *argumentsReader {
+	return &argumentsReader{arguments: arguments}
+}
+
+// string returns the string argument with the given name, or an empty string if it was not provided
+// or is invalid.
+func (r *argumentsReader) string(name string) string {
+	value, err := r.arguments.String(name)
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	return value
+}
+
+// strings returns the list argument with the given name, or nil if it was not provided or is invalid.
+func (r *argumentsReader) strings(name string) []string {
+	values, err := r.arguments.Strings(name)
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	return values
+}
+
+// list returns the list argument with the given name without splitting strings on commas, or nil if it
+// was not provided or is invalid.
+func (r *argumentsReader) list(name string) []string {
+	values, err := r.arguments.List(name)
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	return values
+}
@@ -0,0 +1,120 @@
+package data_providers
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+)
+
+// DataProvider streams the data of a single subscription of a websocket connection.
+type DataProvider interface {
+	// ID returns the subscription ID of the data provider.
+	ID() string
+	// Topic returns the topic the data provider streams.
+	Topic() string
+	// Arguments returns the arguments the subscription was created with.
+	Arguments() models.Arguments
+	// Run streams the subscription data to the connection until the subscription ends or the data
+	// provider is closed. It blocks until streaming is done.
+	//
+	// No errors are expected during normal operation. Errors returned describe why the subscription
+	// ended, and are reported to the client.
+	Run() error
+	// Close stops the data provider, and cancels the underlying subscription.
+	Close()
+}
+
+// dataProvider is a DataProvider for subscriptions producing values of type T.
+type dataProvider[T any] struct {
+	subscriptionID string
+	topic          string
+	arguments      models.Arguments
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	send         chan<- interface{}
+	subscription subscription.Subscription
+
+	// buildPayload converts a value received from the subscription into the payloads sent to the client.
+	// Nothing is sent for the value if no payloads are returned.
+	buildPayload func(T) ([]payload, error)
+}
+
+// payload is a message sent to the client for a value received from the subscription.
+type payload struct {
+	data interface{}
+	// resumeToken is the encoded token of the position after the payload.
+	resumeToken string
+}
+
+var _ DataProvider = (*dataProvider[any])(nil)
+
+// newDataProvider creates a new data provider. The subscription must be created using the context
+// passed to subscribe, which is cancelled when the data provider is closed.
+func newDataProvider[T any](
+	ctx context.Context,
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	send chan<- interface{},
+	subscribe func(ctx context.Context) subscription.Subscription,
+	buildPayload func(T) ([]payload, error),
+) *dataProvider[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &dataProvider[T]{
+		subscriptionID: subscriptionID,
+		topic:          topic,
+		arguments:      arguments,
+		ctx:            ctx,
+		cancel:         cancel,
+		send:           send,
+		subscription:   subscribe(ctx),
+		buildPayload:   buildPayload,
+	}
+}
+
+func (p *dataProvider[T]) ID() string {
+	return p.subscriptionID
+}
+
+func (p *dataProvider[T]) Topic() string {
+	return p.topic
+}
+
+func (p *dataProvider[T]) Arguments() models.Arguments {
+	return p.arguments
+}
+
+func (p *dataProvider[T]) Run() error {
+	err := subscription.HandleSubscription(p.subscription, func(value T) error {
+		payloads, err := p.buildPayload(value)
+		if err != nil {
+			return err
+		}
+
+		for _, payload := range payloads {
+			select {
+			case <-p.ctx.Done():
+				// the data provider was closed. the subscription channel is closed by the backend.
+				return nil
+			case p.send <- &models.SubscriptionResponse{
+				SubscriptionID: p.subscriptionID,
+				Topic:          p.topic,
+				Payload:        payload.data,
+				ResumeToken:    payload.resumeToken,
+			}:
+			}
+		}
+		return nil
+	})
+	if p.ctx.Err() != nil {
+		// the data provider was closed, so the subscription did not end because of an error
+		return nil
+	}
+	return err
+}
+
+func (p *dataProvider[T]) Close() {
+	p.cancel()
+}
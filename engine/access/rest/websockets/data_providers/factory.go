@@ -0,0 +1,502 @@
+package data_providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/access"
+	restmodels "github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Topics supported by the data provider factory.
+const (
+	EventsTopic              = "events"
+	AccountStatusesTopic     = "account_statuses"
+	BlocksTopic              = "blocks"
+	BlockHeadersTopic        = "block_headers"
+	BlockDigestsTopic        = "block_digests"
+	TransactionStatusesTopic = "send_and_get_transaction_statuses"
+	ExecutionDataTopic       = "execution_data"
+)
+
+// Argument names of the supported topics.
+const (
+	startBlockIDArgument        = "start_block_id"
+	startHeightArgument         = "start_height"
+	blockStatusArgument         = "block_status"
+	eventTypesArgument          = "event_types"
+	addressesArgument           = "addresses"
+	contractsArgument           = "contracts"
+	fieldPredicatesArgument     = "field_predicates"
+	registerKeyPrefixesArgument = "register_key_prefixes"
+	heartbeatIntervalArgument   = "heartbeat_interval"
+	resumeTokenArgument         = "resume_token"
+)
+
+// DataProviderFactory creates data providers for subscription requests.
+type DataProviderFactory interface {
+	// NewDataProvider creates a data provider streaming the given topic to the send channel.
+	// The data provider's subscription is cancelled when ctx is cancelled, or the data provider is closed.
+	//
+	// Expected errors during normal operation:
+	//   - if the topic is not supported, or the arguments are invalid for the topic
+	NewDataProvider(
+		ctx context.Context,
+		subscriptionID string,
+		topic string,
+		arguments models.Arguments,
+		send chan<- interface{},
+	) (DataProvider, error)
+}
+
+// DataProviderFactoryImpl creates data providers backed by the access and state stream APIs.
+type DataProviderFactoryImpl struct {
+	logger         zerolog.Logger
+	stateStreamApi state_stream.API
+	accessApi      access.API
+	chain          flow.Chain
+	linkGenerator  restmodels.LinkGenerator
+
+	eventFilterConfig        state_stream.EventFilterConfig
+	defaultHeartbeatInterval uint64
+	maxEventsPerResponse     uint
+}
+
+var _ DataProviderFactory = (*DataProviderFactoryImpl)(nil)
+
+// NewDataProviderFactory creates a new DataProviderFactoryImpl.
+func NewDataProviderFactory(
+	logger zerolog.Logger,
+	stateStreamApi state_stream.API,
+	accessApi access.API,
+	chain flow.Chain,
+	linkGenerator restmodels.LinkGenerator,
+	stateStreamConfig backend.Config,
+) *DataProviderFactoryImpl {
+	return &DataProviderFactoryImpl{
+		logger:                   logger.With().Str("component", "data_provider_factory").Logger(),
+		stateStreamApi:           stateStreamApi,
+		accessApi:                accessApi,
+		chain:                    chain,
+		linkGenerator:            linkGenerator,
+		eventFilterConfig:        stateStreamConfig.EventFilterConfig,
+		defaultHeartbeatInterval: stateStreamConfig.HeartbeatInterval,
+		maxEventsPerResponse:     stateStreamConfig.MaxEventsPerResponse,
+	}
+}
+
+func (f *DataProviderFactoryImpl) NewDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	switch topic {
+	case EventsTopic:
+		return f.newEventsDataProvider(ctx, subscriptionID, arguments, send)
+	case AccountStatusesTopic:
+		return f.newAccountStatusesDataProvider(ctx, subscriptionID, arguments, send)
+	case BlocksTopic, BlockHeadersTopic, BlockDigestsTopic:
+		return f.newBlocksDataProvider(ctx, subscriptionID, topic, arguments, send)
+	case TransactionStatusesTopic:
+		return f.newTransactionStatusesDataProvider(ctx, subscriptionID, arguments, send)
+	case ExecutionDataTopic:
+		return f.newExecutionDataProvider(ctx, subscriptionID, arguments, send)
+	default:
+		return nil, fmt.Errorf("unsupported topic '%s'", topic)
+	}
+}
+
+// newEventsDataProvider creates a data provider streaming events matching the event filter arguments.
+func (f *DataProviderFactoryImpl) newEventsDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	args := newArgumentsReader(arguments)
+	startBlockID := args.string(startBlockIDArgument)
+	startHeight := args.string(startHeightArgument)
+	resumeToken := args.string(resumeTokenArgument)
+	eventTypes := args.strings(eventTypesArgument)
+	addresses := args.strings(addressesArgument)
+	contracts := args.strings(contractsArgument)
+	fieldPredicates := args.list(fieldPredicatesArgument)
+	heartbeatInterval := args.string(heartbeatIntervalArgument)
+	if args.err != nil {
+		return nil, args.err
+	}
+
+	var req request.SubscribeEvents
+	err := req.Parse(startBlockID, startHeight, resumeToken, eventTypes, addresses, contracts, fieldPredicates, heartbeatInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := state_stream.NewEventFilter(f.eventFilterConfig, f.chain, req.EventTypes, req.Addresses, req.Contracts, req.FieldPredicates)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat := f.newHeartbeat(req.HeartbeatInterval)
+	return newDataProvider(ctx, subscriptionID, EventsTopic, arguments, send,
+		func(ctx context.Context) subscription.Subscription {
+			return f.stateStreamApi.SubscribeEvents(ctx, req.StartBlockID, req.StartHeight, filter)
+		},
+		func(resp *backend.EventsResponse) ([]payload, error) {
+			resp.Events = req.ResumeToken.FilterEvents(resp.Height, resp.Events)
+			if !heartbeat(len(resp.Events) == 0) {
+				return nil, nil
+			}
+			err := convertEventPayloads(resp.Events)
+			if err != nil {
+				return nil, err
+			}
+
+			// the events of large blocks are sent in several responses, each carrying its position in the block
+			parts := subscription.SplitEvents(resp.Height, resp.Events, f.maxEventsPerResponse)
+			payloads := make([]payload, len(parts))
+			for i, part := range parts {
+				partResp := *resp
+				partResp.Events = part.Events
+				partResp.ResumeToken = part.ResumeToken.Encode()
+				payloads[i] = payload{data: &partResp, resumeToken: partResp.ResumeToken}
+			}
+			return payloads, nil
+		},
+	), nil
+}
+
+// newAccountStatusesDataProvider creates a data provider streaming account statuses matching the
+// account status filter arguments.
+func (f *DataProviderFactoryImpl) newAccountStatusesDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	args := newArgumentsReader(arguments)
+	startBlockID := args.string(startBlockIDArgument)
+	startHeight := args.string(startHeightArgument)
+	resumeToken := args.string(resumeTokenArgument)
+	eventTypes := args.strings(eventTypesArgument)
+	addresses := args.strings(addressesArgument)
+	heartbeatInterval := args.string(heartbeatIntervalArgument)
+	if args.err != nil {
+		return nil, args.err
+	}
+
+	var req request.SubscribeAccountStatuses
+	err := req.Parse(startBlockID, startHeight, resumeToken, eventTypes, addresses, heartbeatInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := state_stream.NewAccountStatusFilter(f.eventFilterConfig, f.chain, req.EventTypes, req.Addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat := f.newHeartbeat(req.HeartbeatInterval)
+	return newDataProvider(ctx, subscriptionID, AccountStatusesTopic, arguments, send,
+		func(ctx context.Context) subscription.Subscription {
+			switch {
+			case req.StartBlockID != flow.ZeroID:
+				return f.stateStreamApi.SubscribeAccountStatusesFromStartBlockID(ctx, req.StartBlockID, filter)
+			case req.StartHeight != request.EmptyHeight:
+				return f.stateStreamApi.SubscribeAccountStatusesFromStartHeight(ctx, req.StartHeight, filter)
+			default:
+				return f.stateStreamApi.SubscribeAccountStatusesFromLatestBlock(ctx, filter)
+			}
+		},
+		func(resp *backend.AccountStatusesResponse) ([]payload, error) {
+			resp.AccountEvents = req.ResumeToken.FilterAccountEvents(resp.Height, resp.AccountEvents)
+			if !heartbeat(len(resp.AccountEvents) == 0) {
+				return nil, nil
+			}
+			for _, events := range resp.AccountEvents {
+				err := convertEventPayloads(events)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// the events of large blocks are sent in several responses, each carrying its position in the block
+			parts := subscription.SplitAccountEvents(resp.Height, resp.AccountEvents, f.maxEventsPerResponse)
+			payloads := make([]payload, len(parts))
+			for i, part := range parts {
+				partResp := *resp
+				partResp.AccountEvents = part.AccountEvents
+				partResp.ResumeToken = part.ResumeToken.Encode()
+				payloads[i] = payload{data: &partResp, resumeToken: partResp.ResumeToken}
+			}
+			return payloads, nil
+		},
+	), nil
+}
+
+// newBlocksDataProvider creates a data provider streaming full blocks, block headers or block digests.
+func (f *DataProviderFactoryImpl) newBlocksDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	args := newArgumentsReader(arguments)
+	startBlockID := args.string(startBlockIDArgument)
+	startHeight := args.string(startHeightArgument)
+	resumeToken := args.string(resumeTokenArgument)
+	blockStatus := args.string(blockStatusArgument)
+	if args.err != nil {
+		return nil, args.err
+	}
+
+	var req request.SubscribeBlocks
+	err := req.Parse(startBlockID, startHeight, resumeToken, blockStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	switch topic {
+	case BlocksTopic:
+		return newDataProvider(ctx, subscriptionID, topic, arguments, send,
+			func(ctx context.Context) subscription.Subscription {
+				switch {
+				case req.StartBlockID != flow.ZeroID:
+					return f.accessApi.SubscribeBlocksFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus)
+				case req.StartHeight != request.EmptyHeight:
+					return f.accessApi.SubscribeBlocksFromStartHeight(ctx, req.StartHeight, req.BlockStatus)
+				default:
+					return f.accessApi.SubscribeBlocksFromLatest(ctx, req.BlockStatus)
+				}
+			},
+			func(block *flow.Block) ([]payload, error) {
+				var resp restmodels.Block
+				err := resp.Build(block, nil, f.linkGenerator, req.BlockStatus, nil)
+				if err != nil {
+					return nil, fmt.Errorf("could not build block response: %w", err)
+				}
+				return []payload{{data: &resp, resumeToken: blockResumeToken(block.Header.Height)}}, nil
+			},
+		), nil
+
+	case BlockHeadersTopic:
+		return newDataProvider(ctx, subscriptionID, topic, arguments, send,
+			func(ctx context.Context) subscription.Subscription {
+				switch {
+				case req.StartBlockID != flow.ZeroID:
+					return f.accessApi.SubscribeBlockHeadersFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus)
+				case req.StartHeight != request.EmptyHeight:
+					return f.accessApi.SubscribeBlockHeadersFromStartHeight(ctx, req.StartHeight, req.BlockStatus)
+				default:
+					return f.accessApi.SubscribeBlockHeadersFromLatest(ctx, req.BlockStatus)
+				}
+			},
+			func(header *flow.Header) ([]payload, error) {
+				var resp restmodels.BlockHeader
+				resp.Build(header)
+				return []payload{{data: &resp, resumeToken: blockResumeToken(header.Height)}}, nil
+			},
+		), nil
+
+	default:
+		return newDataProvider(ctx, subscriptionID, topic, arguments, send,
+			func(ctx context.Context) subscription.Subscription {
+				switch {
+				case req.StartBlockID != flow.ZeroID:
+					return f.accessApi.SubscribeBlockDigestsFromStartBlockID(ctx, req.StartBlockID, req.BlockStatus)
+				case req.StartHeight != request.EmptyHeight:
+					return f.accessApi.SubscribeBlockDigestsFromStartHeight(ctx, req.StartHeight, req.BlockStatus)
+				default:
+					return f.accessApi.SubscribeBlockDigestsFromLatest(ctx, req.BlockStatus)
+				}
+			},
+			func(digest *flow.BlockDigest) ([]payload, error) {
+				var resp restmodels.BlockDigest
+				resp.Build(digest)
+				return []payload{{data: &resp, resumeToken: blockResumeToken(digest.Height)}}, nil
+			},
+		), nil
+	}
+}
+
+// newTransactionStatusesDataProvider sends the transaction provided in the arguments, and creates a
+// data provider streaming its statuses until it is sealed or expired.
+// The arguments must contain the transaction in the same format as the body of the create transaction endpoint.
+// If they also contain a resume token, the transaction is not sent again, and statuses delivered before the
+// token was issued are skipped.
+func (f *DataProviderFactoryImpl) newTransactionStatusesDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	rawResumeToken, err := arguments.String(resumeTokenArgument)
+	if err != nil {
+		return nil, err
+	}
+	var resumeToken request.ResumeToken
+	err = resumeToken.Parse(rawResumeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the transaction is parsed from the other arguments
+	txArguments := make(models.Arguments, len(arguments))
+	for name, value := range arguments {
+		if name != resumeTokenArgument {
+			txArguments[name] = value
+		}
+	}
+	body, err := json.Marshal(txArguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	var req request.CreateTransaction
+	err = req.Parse(bytes.NewReader(body), f.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// a resumed subscription was created by a request which already sent the transaction
+	token := resumeToken.Flow()
+	if token == nil {
+		err = f.accessApi.SendTransaction(ctx, &req.Transaction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send transaction: %w", err)
+		}
+	}
+
+	return newDataProvider(ctx, subscriptionID, TransactionStatusesTopic, arguments, send,
+		func(ctx context.Context) subscription.Subscription {
+			return f.accessApi.SubscribeTransactionStatuses(ctx, &req.Transaction, entities.EventEncodingVersion_JSON_CDC_V0)
+		},
+		func(results []*access.TransactionResult) ([]payload, error) {
+			var payloads []payload
+			for _, result := range results {
+				if token.TransactionStatusDelivered(result.Status) {
+					continue
+				}
+				// each status is sent in its own response, so it can be resumed from
+				var resp restmodels.TransactionResult
+				resp.Build(result, result.TransactionID, f.linkGenerator)
+				payloads = append(payloads, payload{
+					data:        []restmodels.TransactionResult{resp},
+					resumeToken: subscription.NewTransactionStatusResumeToken(result.Status).Encode(),
+				})
+			}
+			return payloads, nil
+		},
+	), nil
+}
+
+// newExecutionDataProvider creates a data provider streaming the register updates of execution data
+// matching the register filter arguments, together with the transactions of the chunks containing them.
+func (f *DataProviderFactoryImpl) newExecutionDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (DataProvider, error) {
+	args := newArgumentsReader(arguments)
+	startBlockID := args.string(startBlockIDArgument)
+	startHeight := args.string(startHeightArgument)
+	resumeToken := args.string(resumeTokenArgument)
+	addresses := args.strings(addressesArgument)
+	registerKeyPrefixes := args.strings(registerKeyPrefixesArgument)
+	heartbeatInterval := args.string(heartbeatIntervalArgument)
+	if args.err != nil {
+		return nil, args.err
+	}
+
+	var req request.SubscribeExecutionData
+	err := req.Parse(startBlockID, startHeight, resumeToken, addresses, registerKeyPrefixes, heartbeatInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := state_stream.NewRegisterFilter(f.eventFilterConfig, f.chain, req.Addresses, req.RegisterKeyPrefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat := f.newHeartbeat(req.HeartbeatInterval)
+	return newDataProvider(ctx, subscriptionID, ExecutionDataTopic, arguments, send,
+		func(ctx context.Context) subscription.Subscription {
+			switch {
+			case req.StartBlockID != flow.ZeroID:
+				return f.stateStreamApi.SubscribeFilteredExecutionDataFromStartBlockID(ctx, req.StartBlockID, filter)
+			case req.StartHeight != request.EmptyHeight:
+				return f.stateStreamApi.SubscribeFilteredExecutionDataFromStartBlockHeight(ctx, req.StartHeight, filter)
+			default:
+				return f.stateStreamApi.SubscribeFilteredExecutionDataFromLatest(ctx, filter)
+			}
+		},
+		func(resp *backend.ExecutionDataResponse) ([]payload, error) {
+			if !heartbeat(len(resp.ExecutionData.ChunkExecutionDatas) == 0) {
+				return nil, nil
+			}
+			var executionData models.ExecutionData
+			err := executionData.Build(resp.Height, resp.ExecutionData, f.linkGenerator)
+			if err != nil {
+				return nil, fmt.Errorf("could not build execution data response: %w", err)
+			}
+			return []payload{{data: &executionData, resumeToken: resp.ResumeToken}}, nil
+		},
+	), nil
+}
+
+// newHeartbeat returns a function which decides whether a response is sent to the client.
+// Responses without data are only sent once every heartbeat interval blocks, so the client knows
+// the subscription is making progress. A zero interval uses the default heartbeat interval.
+func (f *DataProviderFactoryImpl) newHeartbeat(interval uint64) func(isEmpty bool) bool {
+	if interval == 0 {
+		interval = f.defaultHeartbeatInterval
+	}
+
+	blocksSinceLastMessage := uint64(0)
+	return func(isEmpty bool) bool {
+		if !isEmpty {
+			return true
+		}
+		blocksSinceLastMessage++
+		if blocksSinceLastMessage < interval {
+			return false
+		}
+		blocksSinceLastMessage = 0
+		return true
+	}
+}
+
+// blockResumeToken returns the encoded resume token of the position after the block at the given height.
+func blockResumeToken(height uint64) string {
+	return subscription.NewResumeToken(height+1, 0).Encode()
+}
+
+// convertEventPayloads converts the payloads of the given CCF encoded events to JSON-CDC in place.
+func convertEventPayloads(events flow.EventsList) error {
+	for i, e := range events {
+		payload, err := convert.CcfPayloadToJsonPayload(e.Payload)
+		if err != nil {
+			return fmt.Errorf("could not convert event payload from CCF to Json: %w", err)
+		}
+		events[i].Payload = payload
+	}
+	return nil
+}
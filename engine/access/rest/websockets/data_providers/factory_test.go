@@ -0,0 +1,284 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	statestreammock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
+)
+
+// mockSubscription returns a subscription streaming the given values.
+func mockSubscription(t *testing.T, values ...interface{}) *statestreammock.Subscription {
+	ch := make(chan interface{}, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	var chReadOnly <-chan interface{} = ch
+
+	sub := statestreammock.NewSubscription(t)
+	sub.On("Channel").Return(chReadOnly)
+	sub.On("Err").Return(nil)
+	return sub
+}
+
+func newTestFactory(t *testing.T) (*DataProviderFactoryImpl, *statestreammock.API, *accessmock.API) {
+	stateStreamApi := statestreammock.NewAPI(t)
+	accessApi := accessmock.NewAPI(t)
+	factory := NewDataProviderFactory(
+		unittest.Logger(),
+		stateStreamApi,
+		accessApi,
+		flow.Testnet.Chain(),
+		nil,
+		backend.Config{
+			EventFilterConfig: state_stream.DefaultEventFilterConfig,
+			HeartbeatInterval: 1,
+		},
+	)
+	return factory, stateStreamApi, accessApi
+}
+
+// TestEventsDataProvider tests that the events data provider converts event payloads to JSON-CDC, and only
+// sends responses without events once every heartbeat interval.
+func TestEventsDataProvider(t *testing.T) {
+	factory, stateStreamApi, _ := newTestFactory(t)
+
+	eventsGenerator := generator.EventGenerator(generator.WithEncoding(entities.EventEncodingVersion_CCF_V0))
+	responses := []interface{}{
+		&backend.EventsResponse{Height: 1, Events: flow.EventsList{eventsGenerator.New()}},
+		&backend.EventsResponse{Height: 2},
+		&backend.EventsResponse{Height: 3},
+		&backend.EventsResponse{Height: 4, Events: flow.EventsList{eventsGenerator.New()}},
+	}
+	stateStreamApi.
+		On("SubscribeEvents", mocks.Anything, flow.ZeroID, uint64(0), mocks.Anything).
+		Return(mockSubscription(t, responses...))
+
+	send := make(chan interface{}, len(responses))
+	provider, err := factory.NewDataProvider(context.Background(), "id", EventsTopic, models.Arguments{
+		"heartbeat_interval": "2",
+	}, send)
+	require.NoError(t, err)
+	require.NoError(t, provider.Run())
+	close(send)
+
+	var heights []uint64
+	for msg := range send {
+		resp := msg.(*models.SubscriptionResponse)
+		require.Equal(t, "id", resp.SubscriptionID)
+		require.Equal(t, EventsTopic, resp.Topic)
+
+		events := resp.Payload.(*backend.EventsResponse)
+		heights = append(heights, events.Height)
+		for _, event := range events.Events {
+			_, err := jsoncdc.Decode(nil, event.Payload)
+			require.NoError(t, err)
+		}
+	}
+	// the second empty response is sent as heartbeat
+	require.Equal(t, []uint64{1, 3, 4}, heights)
+}
+
+// TestBlockDigestsDataProvider tests that block digests are streamed from the requested start height.
+func TestBlockDigestsDataProvider(t *testing.T) {
+	factory, _, accessApi := newTestFactory(t)
+
+	header := unittest.BlockHeaderFixture()
+	digest := flow.NewBlockDigest(header.ID(), header.Height, header.Timestamp)
+	accessApi.
+		On("SubscribeBlockDigestsFromStartHeight", mocks.Anything, header.Height, flow.BlockStatusSealed).
+		Return(mockSubscription(t, digest))
+
+	send := make(chan interface{}, 1)
+	provider, err := factory.NewDataProvider(context.Background(), "id", BlockDigestsTopic, models.Arguments{
+		"start_height": fmt.Sprint(header.Height),
+		"block_status": "sealed",
+	}, send)
+	require.NoError(t, err)
+	require.NoError(t, provider.Run())
+
+	resp := (<-send).(*models.SubscriptionResponse)
+	require.Equal(t, BlockDigestsTopic, resp.Topic)
+	require.NotNil(t, resp.Payload)
+	require.Equal(t, subscription.NewResumeToken(header.Height+1, 0).Encode(), resp.ResumeToken)
+}
+
+// TestBlockDigestsDataProviderResume tests that block subscriptions resume from the height of the resume token.
+func TestBlockDigestsDataProviderResume(t *testing.T) {
+	factory, _, accessApi := newTestFactory(t)
+
+	header := unittest.BlockHeaderFixture()
+	digest := flow.NewBlockDigest(header.ID(), header.Height, header.Timestamp)
+	accessApi.
+		On("SubscribeBlockDigestsFromStartHeight", mocks.Anything, header.Height, flow.BlockStatusFinalized).
+		Return(mockSubscription(t, digest))
+
+	send := make(chan interface{}, 1)
+	provider, err := factory.NewDataProvider(context.Background(), "id", BlockDigestsTopic, models.Arguments{
+		"resume_token": subscription.NewResumeToken(header.Height, 0).Encode(),
+	}, send)
+	require.NoError(t, err)
+	require.NoError(t, provider.Run())
+
+	resp := (<-send).(*models.SubscriptionResponse)
+	require.Equal(t, subscription.NewResumeToken(header.Height+1, 0).Encode(), resp.ResumeToken)
+}
+
+// TestEventsDataProviderSplitsLargeBlocks tests that the events of blocks with more than the max events
+// per response are sent in several responses, each resuming from the transaction after it.
+func TestEventsDataProviderSplitsLargeBlocks(t *testing.T) {
+	stateStreamApi := statestreammock.NewAPI(t)
+	factory := NewDataProviderFactory(
+		unittest.Logger(),
+		stateStreamApi,
+		accessmock.NewAPI(t),
+		flow.Testnet.Chain(),
+		nil,
+		backend.Config{
+			EventFilterConfig:    state_stream.DefaultEventFilterConfig,
+			HeartbeatInterval:    1,
+			MaxEventsPerResponse: 1,
+		},
+	)
+
+	events := flow.EventsList{
+		unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0),
+		unittest.EventFixture(flow.EventAccountCreated, 2, 1, unittest.IdentifierFixture(), 0),
+	}
+	for i := range events {
+		events[i].Payload = generator.EventGenerator(generator.WithEncoding(entities.EventEncodingVersion_CCF_V0)).New().Payload
+	}
+	stateStreamApi.
+		On("SubscribeEvents", mocks.Anything, flow.ZeroID, uint64(0), mocks.Anything).
+		Return(mockSubscription(t, &backend.EventsResponse{Height: 10, Events: events}))
+
+	send := make(chan interface{}, len(events))
+	provider, err := factory.NewDataProvider(context.Background(), "id", EventsTopic, models.Arguments{}, send)
+	require.NoError(t, err)
+	require.NoError(t, provider.Run())
+	close(send)
+
+	expectedTokens := []string{
+		subscription.NewResumeToken(10, 2).Encode(),
+		subscription.NewResumeToken(11, 0).Encode(),
+	}
+	var tokens []string
+	for msg := range send {
+		resp := msg.(*models.SubscriptionResponse)
+		payload := resp.Payload.(*backend.EventsResponse)
+		require.Len(t, payload.Events, 1)
+		require.Equal(t, resp.ResumeToken, payload.ResumeToken)
+		tokens = append(tokens, resp.ResumeToken)
+	}
+	require.Equal(t, expectedTokens, tokens)
+}
+
+// TestExecutionDataProvider tests that the register updates of filtered execution data are streamed, and
+// responses without matching chunks are only sent once every heartbeat interval.
+func TestExecutionDataProvider(t *testing.T) {
+	factory, stateStreamApi, _ := newTestFactory(t)
+
+	registerID := unittest.RegisterIDFixture()
+	value := unittest.RandomBytes(8)
+	trieUpdate := &ledger.TrieUpdate{
+		RootHash: testutils.RootHashFixture(),
+		Paths:    testutils.RandomPaths(1),
+		Payloads: []*ledger.Payload{ledger.NewPayload(convert.RegisterIDToLedgerKey(registerID), value)},
+	}
+	executionData := unittest.BlockExecutionDataFixture(
+		unittest.WithChunkExecutionDatas(&execution_data.ChunkExecutionData{TrieUpdate: trieUpdate}),
+	)
+	responses := []interface{}{
+		&backend.ExecutionDataResponse{Height: 1, ExecutionData: executionData},
+		&backend.ExecutionDataResponse{Height: 2, ExecutionData: unittest.BlockExecutionDataFixture()},
+		&backend.ExecutionDataResponse{Height: 3, ExecutionData: unittest.BlockExecutionDataFixture()},
+	}
+	address := flow.BytesToAddress([]byte(registerID.Owner))
+	stateStreamApi.
+		On("SubscribeFilteredExecutionDataFromLatest", mocks.Anything, mocks.Anything).
+		Return(mockSubscription(t, responses...))
+
+	send := make(chan interface{}, len(responses))
+	provider, err := factory.NewDataProvider(context.Background(), "id", ExecutionDataTopic, models.Arguments{
+		"addresses":          []interface{}{address.Hex()},
+		"heartbeat_interval": "2",
+	}, send)
+	require.NoError(t, err)
+	require.NoError(t, provider.Run())
+	close(send)
+
+	var payloads []*models.ExecutionData
+	for msg := range send {
+		resp := msg.(*models.SubscriptionResponse)
+		require.Equal(t, ExecutionDataTopic, resp.Topic)
+		payloads = append(payloads, resp.Payload.(*models.ExecutionData))
+	}
+	// the second empty response is sent as heartbeat
+	require.Len(t, payloads, 2)
+	require.Equal(t, "1", payloads[0].Height)
+	require.Equal(t, "3", payloads[1].Height)
+
+	require.Len(t, payloads[0].Chunks, 1)
+	require.Equal(t, []*models.RegisterUpdate{{
+		Address: address.String(),
+		Key:     util.ToBase64([]byte(registerID.Key)),
+		Value:   util.ToBase64(value),
+	}}, payloads[0].Chunks[0].RegisterUpdates)
+}
+
+// TestInvalidArguments tests that invalid subscription arguments are rejected.
+func TestInvalidArguments(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	send := make(chan interface{})
+
+	tests := []struct {
+		name      string
+		topic     string
+		arguments models.Arguments
+		err       string
+	}{
+		{"unsupported topic", "foo", nil, "unsupported topic"},
+		{"non string argument", BlocksTopic, models.Arguments{"start_height": 1}, "argument 'start_height' must be a string"},
+		{"invalid list argument", EventsTopic, models.Arguments{"event_types": []interface{}{1}}, "argument 'event_types' must be a list of strings"},
+		{"both start block id and height", BlockHeadersTopic, models.Arguments{
+			"start_height":   "1",
+			"start_block_id": unittest.IdentifierFixture().String(),
+		}, "can only provide either block ID or start height"},
+		{"invalid event type", AccountStatusesTopic, models.Arguments{"event_types": []interface{}{"foo"}}, "invalid event type"},
+		{"invalid transaction", TransactionStatusesTopic, models.Arguments{"script": "foo"}, "proposal key not provided"},
+		{"invalid register filter address", ExecutionDataTopic, models.Arguments{"addresses": "foo"}, "invalid address"},
+		{"invalid resume token", EventsTopic, models.Arguments{"resume_token": "foo"}, "invalid resume token"},
+		{"both resume token and start height", AccountStatusesTopic, models.Arguments{
+			"start_height": "1",
+			"resume_token": subscription.NewResumeToken(1, 0).Encode(),
+		}, "can only provide either resume token or start block"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := factory.NewDataProvider(context.Background(), "id", test.topic, test.arguments, send)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
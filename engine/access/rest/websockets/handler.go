@@ -0,0 +1,75 @@
+package websockets
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+)
+
+// Handler upgrades HTTP requests to multiplexed websocket connections, and serves each connection
+// with a new Controller. The number of connections is limited by the configured maximum, and the
+// subscriptions of all connections count against the global maximum number of streams.
+type Handler struct {
+	logger              zerolog.Logger
+	config              Config
+	dataProviderFactory data_providers.DataProviderFactory
+	maxStreams          int32         // the maximum number of streams of all connections
+	activeStreamCount   *atomic.Int32 // the current number of streams of all connections
+	activeConnections   *atomic.Uint64
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new Handler. Each subscription counts as one of at most maxGlobalStreams streams.
+func NewHandler(
+	logger zerolog.Logger,
+	config Config,
+	dataProviderFactory data_providers.DataProviderFactory,
+	maxGlobalStreams uint32,
+) *Handler {
+	return &Handler{
+		logger:              logger.With().Str("component", "websocket_handler").Logger(),
+		config:              config,
+		dataProviderFactory: dataProviderFactory,
+		maxStreams:          int32(maxGlobalStreams),
+		activeStreamCount:   atomic.NewInt32(0),
+		activeConnections:   atomic.NewUint64(0),
+	}
+}
+
+// ServeHTTP upgrades the connection and serves it until the client disconnects.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.activeConnections.Inc() > h.config.MaxConnections {
+		h.activeConnections.Dec()
+		http.Error(w, "maximum number of connections reached", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.activeConnections.Dec()
+
+	upgrader := websocket.Upgrader{
+		// allow all origins by default, operators can override using a proxy
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+	// the upgrader responds to the client if the upgrade fails
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Debug().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	logger := h.logger.With().Str("remote_addr", r.RemoteAddr).Logger()
+	controller := NewController(logger, h.config, conn, h.dataProviderFactory, h.maxStreams, h.activeStreamCount)
+
+	// the context is not cancelled with the request context, since the request context is not cancelled
+	// when a hijacked connection is closed. It keeps the values of the request context, like the client
+	// quotas which subscriptions count against.
+	controller.HandleConnection(context.WithoutCancel(r.Context()))
+}
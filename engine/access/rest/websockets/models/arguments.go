@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Arguments contains the arguments of a subscription. Values are either strings, lists of strings,
+// or JSON objects for topics which accept structured input.
+type Arguments map[string]interface{}
+
+// String returns the string argument with the given name, or an empty string if it was not provided.
+//
+// Expected errors during normal operation:
+//   - if the argument is not a string
+func (a Arguments) String(name string) (string, error) {
+	raw, ok := a[name]
+	if !ok || raw == nil {
+		return "", nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("argument '%s' must be a string", name)
+	}
+	return value, nil
+}
+
+// Strings returns the list argument with the given name, or nil if it was not provided.
+// The argument may be provided as a list of strings, or as a comma separated string.
+//
+// Expected errors during normal operation:
+//   - if the argument is neither a string nor a list of strings
+func (a Arguments) Strings(name string) ([]string, error) {
+	raw, ok := a[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	switch value := raw.(type) {
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		return strings.Split(value, ","), nil
+	case []string:
+		return value, nil
+	case []interface{}:
+		values := make([]string, len(value))
+		for i, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("argument '%s' must be a list of strings", name)
+			}
+			values[i] = s
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("argument '%s' must be a list of strings", name)
+	}
+}
+
+// List returns the list argument with the given name, or nil if it was not provided. Unlike Strings,
+// a string argument is not split on commas, and is returned as a list with a single element.
+//
+// Expected errors during normal operation:
+//   - if the argument is neither a string nor a list of strings
+func (a Arguments) List(name string) ([]string, error) {
+	if value, ok := a[name].(string); ok {
+		if value == "" {
+			return nil, nil
+		}
+		return []string{value}, nil
+	}
+	return a.Strings(name)
+}
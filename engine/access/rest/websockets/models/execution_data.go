@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+
+	restmodels "github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// ExecutionData is the payload of filtered execution data subscriptions. It contains the register
+// updates of a block matching the filter of the subscription.
+type ExecutionData struct {
+	BlockID string                `json:"block_id"`
+	Height  string                `json:"height"`
+	Chunks  []*ChunkExecutionData `json:"chunks"`
+}
+
+// ChunkExecutionData contains the matching register updates of a chunk, and the transactions executed
+// in the chunk.
+type ChunkExecutionData struct {
+	Transactions    restmodels.Transactions `json:"transactions"`
+	RegisterUpdates []*RegisterUpdate       `json:"register_updates"`
+}
+
+// RegisterUpdate is the new value of a register. The register key and value are base64 encoded.
+type RegisterUpdate struct {
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// Build populates the ExecutionData from the execution data of the block at the given height.
+//
+// No errors are expected during normal operation.
+func (e *ExecutionData) Build(
+	height uint64,
+	executionData *execution_data.BlockExecutionData,
+	link restmodels.LinkGenerator,
+) error {
+	e.BlockID = executionData.BlockID.String()
+	e.Height = strconv.FormatUint(height, 10)
+	e.Chunks = make([]*ChunkExecutionData, 0, len(executionData.ChunkExecutionDatas))
+
+	for _, chunk := range executionData.ChunkExecutionDatas {
+		var transactions []*flow.TransactionBody
+		if chunk.Collection != nil {
+			transactions = chunk.Collection.Transactions
+		}
+
+		c := &ChunkExecutionData{
+			RegisterUpdates: make([]*RegisterUpdate, 0),
+		}
+		c.Transactions.Build(transactions, link)
+		if chunk.TrieUpdate != nil {
+			for _, payload := range chunk.TrieUpdate.Payloads {
+				registerID, value, err := convert.PayloadToRegister(payload)
+				if err != nil {
+					return fmt.Errorf("could not convert payload: %w", err)
+				}
+				c.RegisterUpdates = append(c.RegisterUpdates, &RegisterUpdate{
+					Address: flow.BytesToAddress([]byte(registerID.Owner)).String(),
+					Key:     util.ToBase64([]byte(registerID.Key)),
+					Value:   util.ToBase64(value),
+				})
+			}
+		}
+		e.Chunks = append(e.Chunks, c)
+	}
+
+	return nil
+}
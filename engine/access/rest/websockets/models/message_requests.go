@@ -0,0 +1,33 @@
+package models
+
+const (
+	SubscribeAction         = "subscribe"
+	UnsubscribeAction       = "unsubscribe"
+	ListSubscriptionsAction = "list_subscriptions"
+)
+
+// BaseMessageRequest represents the fields common to all messages sent by the client.
+type BaseMessageRequest struct {
+	// Action is the action requested by the client, e.g. "subscribe".
+	Action string `json:"action"`
+	// SubscriptionID identifies the subscription the message refers to. It is optional for subscribe
+	// requests, in which case the server generates one.
+	SubscriptionID string `json:"subscription_id,omitempty"`
+}
+
+// SubscribeMessageRequest represents a request to subscribe to a topic.
+type SubscribeMessageRequest struct {
+	BaseMessageRequest
+	Topic     string    `json:"topic"`
+	Arguments Arguments `json:"arguments"`
+}
+
+// UnsubscribeMessageRequest represents a request to cancel an existing subscription.
+type UnsubscribeMessageRequest struct {
+	BaseMessageRequest
+}
+
+// ListSubscriptionsMessageRequest represents a request to list the active subscriptions of the connection.
+type ListSubscriptionsMessageRequest struct {
+	BaseMessageRequest
+}
@@ -0,0 +1,52 @@
+package models
+
+// BaseMessageResponse represents the fields common to all responses to client messages.
+type BaseMessageResponse struct {
+	// SubscriptionID identifies the subscription the response refers to, if any.
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	// Action is the action of the client message this is a response to.
+	Action string `json:"action,omitempty"`
+	// Error is set if the client message could not be handled.
+	Error *ErrorMessage `json:"error,omitempty"`
+}
+
+// ErrorMessage describes an error that occurred while handling a client message or a subscription.
+type ErrorMessage struct {
+	// Code is an HTTP status code describing the error.
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SubscribeMessageResponse is sent to the client once a subscription was created.
+type SubscribeMessageResponse struct {
+	BaseMessageResponse
+	Topic string `json:"topic"`
+}
+
+// UnsubscribeMessageResponse is sent to the client once a subscription was cancelled.
+type UnsubscribeMessageResponse struct {
+	BaseMessageResponse
+}
+
+// ListSubscriptionsMessageResponse contains the active subscriptions of the connection.
+type ListSubscriptionsMessageResponse struct {
+	BaseMessageResponse
+	Subscriptions []*SubscriptionEntry `json:"subscriptions"`
+}
+
+// SubscriptionEntry describes an active subscription.
+type SubscriptionEntry struct {
+	SubscriptionID string    `json:"subscription_id"`
+	Topic          string    `json:"topic"`
+	Arguments      Arguments `json:"arguments"`
+}
+
+// SubscriptionResponse wraps the data produced by a subscription.
+type SubscriptionResponse struct {
+	SubscriptionID string      `json:"subscription_id"`
+	Topic          string      `json:"topic"`
+	Payload        interface{} `json:"payload"`
+	// ResumeToken is the token from which the subscription resumes after this response. It is passed as
+	// the resume_token argument of a new subscription to the topic.
+	ResumeToken string `json:"resume_token,omitempty"`
+}
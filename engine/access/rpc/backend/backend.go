@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/counters"
 	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/pruner"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -35,6 +36,18 @@ const maxAttemptsForExecutionReceipt = 3
 // DefaultMaxHeightRange is the default maximum size of range requests.
 const DefaultMaxHeightRange = 250
 
+// DefaultEventTypeIndexMaxHeightRange is the default maximum size of event height range requests
+// served using the event type index.
+const DefaultEventTypeIndexMaxHeightRange = 10_000
+
+// DefaultEventTypeIndexMaxEvents is the default maximum number of events returned by event height
+// range requests served using the event type index.
+const DefaultEventTypeIndexMaxEvents = 10_000
+
+// DefaultAccountTransactionsMaxPageSize is the default maximum number of transactions returned in a
+// single page of the transaction history of an account.
+const DefaultAccountTransactionsMaxPageSize = 100
+
 // DefaultSnapshotHistoryLimit the amount of blocks to look back in state
 // when recursively searching for a valid snapshot
 const DefaultSnapshotHistoryLimit = 500
@@ -61,6 +74,7 @@ var (
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Account transaction history calls are handled by backendAccountTransactions.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -70,6 +84,7 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendAccountTransactions
 	backendExecutionResults
 	backendNetwork
 	backendSubscribeBlocks
@@ -110,6 +125,8 @@ type Params struct {
 	TxErrorMessagesCacheSize  uint
 	ScriptExecutor            execution.ScriptExecutor
 	ScriptExecutionMode       IndexQueryMode
+	ScriptResultCacheSize     uint
+	ScriptResultCacheTTL      time.Duration
 	EventQueryMode            IndexQueryMode
 	BlockTracker              subscription.BlockTracker
 	SubscriptionHandler       *subscription.SubscriptionHandler
@@ -118,6 +135,37 @@ type Params struct {
 	TxResultQueryMode   IndexQueryMode
 	TxResultsIndex      *index.TransactionResultsIndex
 	LastFullBlockHeight *counters.PersistentStrictMonotonicCounter
+
+	// EventTypeIndexMaxHeightRange is the max size of height range requests served using the event
+	// type index of EventsIndex. It is only used if the event type index is enabled.
+	EventTypeIndexMaxHeightRange uint
+
+	// EventTypeIndexMaxEvents is the max number of events returned by height range requests served
+	// using the event type index. It is only used if the event type index is enabled.
+	EventTypeIndexMaxEvents uint
+
+	// AccountTransactionsIndex is nil if the account transaction index is disabled.
+	AccountTransactionsIndex *index.AccountTransactionsIndex
+	// AccountTransactionsMaxPageSize is the max number of transactions returned in a single page of
+	// the transaction history of an account.
+	AccountTransactionsMaxPageSize uint32
+
+	// TxErrorMessages looks up the error messages of failed transactions whose results are served from
+	// the local indexes. If nil, error messages are fetched from execution nodes.
+	TxErrorMessages TransactionErrorMessage
+
+	// TxResubmissions reports the resubmissions of pending transactions to transaction status
+	// subscriptions. It is nil if the node does not resubmit transactions.
+	TxResubmissions TransactionResubmissions
+
+	// ScriptBatchMaxSize is the max number of scripts in a batch, 0 means no limit.
+	ScriptBatchMaxSize uint
+	// ScriptBatchComputationLimit is the max computation used by all scripts of a batch, 0 means no limit.
+	ScriptBatchComputationLimit uint64
+
+	// PrunedHeightReporter reports the lowest height with available block data.
+	// It is nil if block data pruning is disabled.
+	PrunedHeightReporter pruner.LowestHeightReporter
 }
 
 var _ TransactionErrorMessage = (*Backend)(nil)
@@ -164,6 +212,11 @@ func New(params Params) (*Backend, error) {
 	// initialize node version info
 	nodeInfo := getNodeVersionInfo(params.State.Params())
 
+	prunedData := &prunedDataChecker{
+		headers:  params.Headers,
+		reporter: params.PrunedHeightReporter,
+	}
+
 	transactionsLocalDataProvider := &TransactionsLocalDataProvider{
 		state:               params.State,
 		collections:         params.Collections,
@@ -172,6 +225,7 @@ func New(params Params) (*Backend, error) {
 		txResultsIndex:      params.TxResultsIndex,
 		systemTxID:          systemTxID,
 		lastFullBlockHeight: params.LastFullBlockHeight,
+		prunedData:          prunedData,
 	}
 
 	b := &Backend{
@@ -189,6 +243,10 @@ func New(params Params) (*Backend, error) {
 			nodeCommunicator:  params.Communicator,
 			scriptExecutor:    params.ScriptExecutor,
 			scriptExecMode:    params.ScriptExecutionMode,
+			scriptResults:     newScriptResultCache(params.ScriptResultCacheSize, params.ScriptResultCacheTTL, params.AccessMetrics),
+
+			scriptBatchMaxSize:          params.ScriptBatchMaxSize,
+			scriptBatchComputationLimit: params.ScriptBatchComputationLimit,
 		},
 		backendEvents: backendEvents{
 			log:               params.Log,
@@ -201,14 +259,19 @@ func New(params Params) (*Backend, error) {
 			nodeCommunicator:  params.Communicator,
 			queryMode:         params.EventQueryMode,
 			eventsIndex:       params.EventsIndex,
+			prunedData:        prunedData,
+
+			eventTypeIndexMaxHeightRange: params.EventTypeIndexMaxHeightRange,
+			eventTypeIndexMaxEvents:      params.EventTypeIndexMaxEvents,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: params.Headers,
 			state:   params.State,
 		},
 		backendBlockDetails: backendBlockDetails{
-			blocks: params.Blocks,
-			state:  params.State,
+			blocks:     params.Blocks,
+			state:      params.State,
+			prunedData: prunedData,
 		},
 		backendAccounts: backendAccounts{
 			log:               params.Log,
@@ -220,6 +283,10 @@ func New(params Params) (*Backend, error) {
 			scriptExecutor:    params.ScriptExecutor,
 			scriptExecMode:    params.ScriptExecutionMode,
 		},
+		backendAccountTransactions: backendAccountTransactions{
+			accountTxsIndex: params.AccountTransactionsIndex,
+			maxPageSize:     params.AccountTransactionsMaxPageSize,
+		},
 		backendExecutionResults: backendExecutionResults{
 			executionResults: params.ExecutionResults,
 		},
@@ -263,16 +330,21 @@ func New(params Params) (*Backend, error) {
 		txResultQueryMode:             params.TxResultQueryMode,
 		systemTx:                      systemTx,
 		systemTxID:                    systemTxID,
+		prunedData:                    prunedData,
 	}
 
 	// TODO: The TransactionErrorMessage interface should be reorganized in future, as it is implemented in backendTransactions but used in TransactionsLocalDataProvider, and its initialization is somewhat quirky.
 	b.backendTransactions.txErrorMessages = b
+	if params.TxErrorMessages != nil {
+		b.backendTransactions.txErrorMessages = params.TxErrorMessages
+	}
 
 	b.backendSubscribeTransactions = backendSubscribeTransactions{
 		txLocalDataProvider: transactionsLocalDataProvider,
 		backendTransactions: &b.backendTransactions,
 		log:                 params.Log,
 		executionResults:    params.ExecutionResults,
+		txResubmissions:     params.TxResubmissions,
 		subscriptionHandler: params.SubscriptionHandler,
 		blockTracker:        params.BlockTracker,
 	}
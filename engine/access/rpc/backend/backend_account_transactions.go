@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendAccountTransactions struct {
+	// accountTxsIndex is nil if the account transaction index is disabled
+	accountTxsIndex *index.AccountTransactionsIndex
+	maxPageSize     uint32
+}
+
+// GetTransactionsByAccount returns up to limit transactions the account with the given address was
+// involved in, most recent first, starting at the given cursor (inclusive). A nil cursor starts at
+// the most recent indexed transaction. A limit of 0 returns a page of the max page size.
+func (b *backendAccountTransactions) GetTransactionsByAccount(
+	_ context.Context,
+	address flow.Address,
+	limit uint32,
+	cursor *flow.AccountTransactionCursor,
+) (*access.AccountTransactionsPage, error) {
+	if b.accountTxsIndex == nil {
+		return nil, status.Errorf(codes.Unimplemented, "account transaction index is disabled")
+	}
+
+	if limit == 0 {
+		limit = b.maxPageSize
+	}
+	if limit > b.maxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "limit %d exceeds the max page size %d", limit, b.maxPageSize)
+	}
+
+	// fetch one additional transaction to find the start of the next page
+	txs, err := b.accountTxsIndex.ByAddress(address, cursor, limit+1)
+	if err != nil {
+		if errors.Is(err, indexer.ErrIndexNotInitialized) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account transaction index is not available: %v", err)
+		}
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, status.Errorf(codes.OutOfRange, "cursor height %d is not indexed", cursor.BlockHeight)
+		}
+		return nil, rpc.ConvertError(err, "failed to get account transactions", codes.Internal)
+	}
+
+	page := &access.AccountTransactionsPage{
+		Transactions: txs,
+	}
+	if uint32(len(txs)) > limit {
+		page.Transactions = txs[:limit]
+		page.NextCursor = txs[limit].Cursor()
+	}
+
+	return page, nil
+}
@@ -0,0 +1,96 @@
+package backend
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetTransactionsByAccount(t *testing.T) {
+	address := unittest.RandomAddressFixture()
+	latestHeight := uint64(20)
+
+	txs := make([]flow.AccountTransaction, 5)
+	for i := range txs {
+		txs[i] = flow.AccountTransaction{
+			Address:          address,
+			BlockID:          unittest.IdentifierFixture(),
+			BlockHeight:      latestHeight - uint64(i),
+			TransactionID:    unittest.IdentifierFixture(),
+			TransactionIndex: 0,
+			Roles:            []flow.TransactionRole{flow.TransactionRolePayer},
+		}
+	}
+
+	newBackend := func(t *testing.T) (*backendAccountTransactions, *storagemock.AccountTransactions) {
+		accountTxs := storagemock.NewAccountTransactions(t)
+
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(uint64(1), nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(latestHeight, nil).Maybe()
+
+		accountTxsIndex := index.NewAccountTransactionsIndex(index.NewReporter(), accountTxs)
+		require.NoError(t, accountTxsIndex.Initialize(reporter))
+
+		return &backendAccountTransactions{
+			accountTxsIndex: accountTxsIndex,
+			maxPageSize:     3,
+		}, accountTxs
+	}
+
+	t.Run("first page starts at the latest indexed height", func(t *testing.T) {
+		backend, accountTxs := newBackend(t)
+
+		cursor := &flow.AccountTransactionCursor{BlockHeight: latestHeight, TransactionIndex: math.MaxUint32}
+		accountTxs.On("ByAddress", address, cursor, uint32(3)).Return(txs[:3], nil)
+
+		page, err := backend.GetTransactionsByAccount(context.Background(), address, 2, nil)
+		require.NoError(t, err)
+		require.Equal(t, txs[:2], page.Transactions)
+		require.Equal(t, txs[2].Cursor(), page.NextCursor)
+	})
+
+	t.Run("last page has no next cursor", func(t *testing.T) {
+		backend, accountTxs := newBackend(t)
+
+		cursor := txs[3].Cursor()
+		accountTxs.On("ByAddress", address, cursor, uint32(4)).Return(txs[3:], nil)
+
+		page, err := backend.GetTransactionsByAccount(context.Background(), address, 0, cursor)
+		require.NoError(t, err)
+		require.Equal(t, txs[3:], page.Transactions)
+		require.Nil(t, page.NextCursor)
+	})
+
+	t.Run("limit exceeds max page size", func(t *testing.T) {
+		backend, _ := newBackend(t)
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 4, nil)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("cursor above the indexed heights", func(t *testing.T) {
+		backend, _ := newBackend(t)
+
+		cursor := &flow.AccountTransactionCursor{BlockHeight: latestHeight + 1}
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 0, cursor)
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("index disabled", func(t *testing.T) {
+		backend := &backendAccountTransactions{maxPageSize: 3}
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 0, nil)
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
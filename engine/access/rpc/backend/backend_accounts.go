@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/engine/access/rpc/connection"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/environment"
 	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/execution"
@@ -23,6 +27,22 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// accountBalanceScript is executed on execution nodes to get the balance of an account, without
+// fetching the whole account.
+var accountBalanceScript = []byte(`
+access(all) fun main(address: Address): UFix64 {
+	return getAccount(address).balance
+}
+`)
+
+// accountAvailableBalanceScript is executed on execution nodes to get the available balance of an account,
+// since execution nodes do not expose it directly.
+var accountAvailableBalanceScript = []byte(`
+access(all) fun main(address: Address): UFix64 {
+	return getAccount(address).availableBalance
+}
+`)
+
 type backendAccounts struct {
 	log               zerolog.Logger
 	state             protocol.State
@@ -80,6 +100,141 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	return account, nil
 }
 
+// GetAccountBalanceAtLatestBlock returns the account balance at the latest sealed block.
+func (b *backendAccounts) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	sealed, err := b.sealedHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.getAccountBalanceAtBlock(ctx, address, sealed.ID(), sealed.Height)
+}
+
+// GetAccountBalanceAtBlockHeight returns the account balance at the given block height.
+func (b *backendAccounts) GetAccountBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	blockID, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return 0, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountBalanceAtBlock(ctx, address, blockID, height)
+}
+
+// GetAccountBalanceAtBlockID returns the account balance at the given block ID.
+func (b *backendAccounts) GetAccountBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error) {
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return 0, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountBalanceAtBlock(ctx, address, blockID, header.Height)
+}
+
+// GetAccountAvailableBalanceAtLatestBlock returns the account available balance at the latest sealed block.
+// The available balance is the balance of the account minus the balance reserved for its storage.
+func (b *backendAccounts) GetAccountAvailableBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	sealed, err := b.sealedHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.getAccountAvailableBalanceAtBlock(ctx, address, sealed.ID(), sealed.Height)
+}
+
+// GetAccountAvailableBalanceAtBlockHeight returns the account available balance at the given block height.
+func (b *backendAccounts) GetAccountAvailableBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	blockID, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return 0, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountAvailableBalanceAtBlock(ctx, address, blockID, height)
+}
+
+// GetAccountAvailableBalanceAtBlockID returns the account available balance at the given block ID.
+func (b *backendAccounts) GetAccountAvailableBalanceAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error) {
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return 0, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountAvailableBalanceAtBlock(ctx, address, blockID, header.Height)
+}
+
+// GetAccountKeysAtLatestBlock returns the public keys of the account at the latest sealed block.
+func (b *backendAccounts) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]flow.AccountPublicKey, error) {
+	sealed, err := b.sealedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.getAccountKeysAtBlock(ctx, address, sealed.ID(), sealed.Height)
+}
+
+// GetAccountKeysAtBlockHeight returns the public keys of the account at the given block height.
+func (b *backendAccounts) GetAccountKeysAtBlockHeight(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error) {
+	blockID, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountKeysAtBlock(ctx, address, blockID, height)
+}
+
+// GetAccountKeysAtBlockID returns the public keys of the account at the given block ID.
+func (b *backendAccounts) GetAccountKeysAtBlockID(ctx context.Context, address flow.Address, blockID flow.Identifier) ([]flow.AccountPublicKey, error) {
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountKeysAtBlock(ctx, address, blockID, header.Height)
+}
+
+// GetAccountKeyByIndexAtLatestBlock returns the public key of the account with the given key index
+// at the latest sealed block.
+func (b *backendAccounts) GetAccountKeyByIndexAtLatestBlock(ctx context.Context, address flow.Address, keyIndex uint64) (*flow.AccountPublicKey, error) {
+	sealed, err := b.sealedHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.getAccountKeyByIndexAtBlock(ctx, address, keyIndex, sealed.ID(), sealed.Height)
+}
+
+// GetAccountKeyByIndexAtBlockHeight returns the public key of the account with the given key index
+// at the given block height.
+func (b *backendAccounts) GetAccountKeyByIndexAtBlockHeight(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	blockID, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountKeyByIndexAtBlock(ctx, address, keyIndex, blockID, height)
+}
+
+// GetAccountKeyByIndexAtBlockID returns the public key of the account with the given key index
+// at the given block ID.
+func (b *backendAccounts) GetAccountKeyByIndexAtBlockID(ctx context.Context, address flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error) {
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.getAccountKeyByIndexAtBlock(ctx, address, keyIndex, blockID, header.Height)
+}
+
+// sealedHeader returns the header of the latest sealed block.
+func (b *backendAccounts) sealedHeader(ctx context.Context) (*flow.Header, error) {
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return nil, err
+	}
+	return sealed, nil
+}
+
 // getAccountAtBlock returns the account details at the given block
 //
 // The data may be sourced from the local storage or from an execution node depending on the nodes's
@@ -126,6 +281,224 @@ func (b *backendAccounts) getAccountAtBlock(
 	}
 }
 
+// getAccountBalanceAtBlock returns the balance of the account at the given block.
+//
+// The data may be sourced from the local storage or from an execution node depending on the nodes's
+// configuration and the availability of the data.
+func (b *backendAccounts) getAccountBalanceAtBlock(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+) (uint64, error) {
+	return queryAccountAtBlock(
+		b,
+		func() (uint64, error) {
+			balance, err := b.scriptExecutor.GetAccountBalance(ctx, address, height)
+			if err != nil {
+				return 0, convertAccountError(err, address, height)
+			}
+			return balance, nil
+		},
+		func() (uint64, error) {
+			return b.getAccountBalanceFromAnyExeNode(ctx, address, blockID, accountBalanceScript)
+		},
+		newAccountDataComparer[uint64](b.log, "balance", blockID, address, func(exec, local uint64) bool {
+			return exec == local
+		}),
+	)
+}
+
+// getAccountAvailableBalanceAtBlock returns the available balance of the account at the given block.
+//
+// The data may be sourced from the local storage or from an execution node depending on the nodes's
+// configuration and the availability of the data.
+func (b *backendAccounts) getAccountAvailableBalanceAtBlock(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+) (uint64, error) {
+	return queryAccountAtBlock(
+		b,
+		func() (uint64, error) {
+			balance, err := b.scriptExecutor.GetAccountAvailableBalance(ctx, address, height)
+			if err != nil {
+				return 0, convertAccountError(err, address, height)
+			}
+			return balance, nil
+		},
+		func() (uint64, error) {
+			return b.getAccountBalanceFromAnyExeNode(ctx, address, blockID, accountAvailableBalanceScript)
+		},
+		newAccountDataComparer[uint64](b.log, "available balance", blockID, address, func(exec, local uint64) bool {
+			return exec == local
+		}),
+	)
+}
+
+// getAccountKeysAtBlock returns the public keys of the account at the given block.
+//
+// The data may be sourced from the local storage or from an execution node depending on the nodes's
+// configuration and the availability of the data.
+func (b *backendAccounts) getAccountKeysAtBlock(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	height uint64,
+) ([]flow.AccountPublicKey, error) {
+	return queryAccountAtBlock(
+		b,
+		func() ([]flow.AccountPublicKey, error) {
+			keys, err := b.scriptExecutor.GetAccountKeys(ctx, address, height)
+			if err != nil {
+				return nil, convertAccountError(err, address, height)
+			}
+			return keys, nil
+		},
+		func() ([]flow.AccountPublicKey, error) {
+			return b.getAccountKeysFromAnyExeNode(ctx, address, blockID)
+		},
+		newAccountDataComparer(b.log, "keys", blockID, address, func(exec, local []flow.AccountPublicKey) bool {
+			if len(exec) != len(local) {
+				return false
+			}
+			for i := range exec {
+				if !accountKeysEqual(exec[i], local[i]) {
+					return false
+				}
+			}
+			return true
+		}),
+	)
+}
+
+// getAccountKeyByIndexAtBlock returns the public key of the account with the given key index at
+// the given block.
+//
+// The data may be sourced from the local storage or from an execution node depending on the nodes's
+// configuration and the availability of the data.
+func (b *backendAccounts) getAccountKeyByIndexAtBlock(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+	blockID flow.Identifier,
+	height uint64,
+) (*flow.AccountPublicKey, error) {
+	return queryAccountAtBlock(
+		b,
+		func() (*flow.AccountPublicKey, error) {
+			key, err := b.scriptExecutor.GetAccountKey(ctx, address, keyIndex, height)
+			if err != nil {
+				if fvmerrors.IsAccountPublicKeyNotFoundError(err) {
+					return nil, status.Errorf(codes.NotFound, "account key with index %d not found", keyIndex)
+				}
+				return nil, convertAccountError(err, address, height)
+			}
+			return key, nil
+		},
+		func() (*flow.AccountPublicKey, error) {
+			return b.getAccountKeyByIndexFromAnyExeNode(ctx, address, keyIndex, blockID)
+		},
+		newAccountDataComparer(b.log, "key", blockID, address, func(exec, local *flow.AccountPublicKey) bool {
+			return accountKeysEqual(*exec, *local)
+		}),
+	)
+}
+
+// queryAccountAtBlock retrieves account data either from the local storage using `local`, or from
+// an execution node using `execNode`, depending on the configured script execution mode.
+// `compare` is called with both results when both sources are queried.
+func queryAccountAtBlock[T any](
+	b *backendAccounts,
+	local func() (T, error),
+	execNode func() (T, error),
+	compare func(execResult T, execErr error, localResult T, localErr error),
+) (T, error) {
+	switch b.scriptExecMode {
+	case IndexQueryModeExecutionNodesOnly:
+		return execNode()
+
+	case IndexQueryModeLocalOnly:
+		return local()
+
+	case IndexQueryModeFailover:
+		localResult, localErr := local()
+		if localErr == nil {
+			return localResult, nil
+		}
+		execResult, execErr := execNode()
+
+		compare(execResult, execErr, localResult, localErr)
+
+		return execResult, execErr
+
+	case IndexQueryModeCompare:
+		execResult, execErr := execNode()
+		// Only compare actual get account errors from the EN, not system errors
+		if execErr != nil && !isInvalidArgumentError(execErr) {
+			var empty T
+			return empty, execErr
+		}
+		localResult, localErr := local()
+
+		compare(execResult, execErr, localResult, localErr)
+
+		// always return EN results
+		return execResult, execErr
+
+	default:
+		var empty T
+		return empty, status.Errorf(codes.Internal, "unknown execution mode: %v", b.scriptExecMode)
+	}
+}
+
+// newAccountDataComparer returns a function that compares the account data and errors returned from
+// local and remote lookups, and logs them if they are different.
+func newAccountDataComparer[T any](
+	log zerolog.Logger,
+	dataName string,
+	blockID flow.Identifier,
+	address flow.Address,
+	equal func(exec, local T) bool,
+) func(execResult T, execErr error, localResult T, localErr error) {
+	return func(execResult T, execErr error, localResult T, localErr error) {
+		if log.GetLevel() > zerolog.DebugLevel {
+			return
+		}
+
+		lg := log.With().
+			Hex("block_id", blockID[:]).
+			Str("address", address.String()).
+			Logger()
+
+		// errors are different
+		if execErr != localErr {
+			lg.Debug().
+				AnErr("execution_node_error", execErr).
+				AnErr("local_error", localErr).
+				Msgf("errors from getting account %s on local and EN do not match", dataName)
+			return
+		}
+
+		// both errors are nil, compare the results
+		if execErr == nil && !equal(execResult, localResult) {
+			lg.Debug().Msgf("account %s from local and EN do not match", dataName)
+		}
+	}
+}
+
+// accountKeysEqual returns true if both account keys are identical.
+func accountKeysEqual(a, b flow.AccountPublicKey) bool {
+	return a.Index == b.Index &&
+		a.PublicKey.Equals(b.PublicKey) &&
+		a.SignAlgo == b.SignAlgo &&
+		a.HashAlgo == b.HashAlgo &&
+		a.SeqNumber == b.SeqNumber &&
+		a.Weight == b.Weight &&
+		a.Revoked == b.Revoked
+}
+
 // getAccountFromLocalStorage retrieves the given account from the local storage.
 func (b *backendAccounts) getAccountFromLocalStorage(
 	ctx context.Context,
@@ -200,6 +573,245 @@ func (b *backendAccounts) getAccountFromAnyExeNode(
 	return account, nil
 }
 
+// getAccountBalanceFromAnyExeNode retrieves a balance of the given account from any EN in `execNodes`,
+// by executing the given script, which takes the account's address and returns a UFix64.
+func (b *backendAccounts) getAccountBalanceFromAnyExeNode(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	script []byte,
+) (uint64, error) {
+	encodedAddress, err := jsoncdc.Encode(cadence.NewAddress(address))
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to encode address argument: %v", err)
+	}
+
+	req := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    script,
+		Arguments: [][]byte{encodedAddress},
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return 0, rpc.ConvertError(err, "failed to find execution node to query", codes.Internal)
+	}
+
+	var resp *execproto.ExecuteScriptAtBlockIDResponse
+	errToReturn := b.nodeCommunicator.CallAvailableNode(
+		execNodes,
+		func(node *flow.IdentitySkeleton) error {
+			var err error
+			start := time.Now()
+
+			resp, err = b.tryExecuteScript(ctx, node, req)
+			duration := time.Since(start)
+
+			lg := b.log.With().
+				Str("execution_node", node.String()).
+				Hex("block_id", req.GetBlockId()).
+				Hex("address", address.Bytes()).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Logger()
+
+			if err != nil {
+				lg.Err(err).Msg("failed to get account balance")
+				return err
+			}
+
+			// return if any execution node replied successfully
+			lg.Debug().Msg("Successfully got account balance")
+			return nil
+		},
+		nil,
+	)
+
+	if errToReturn != nil {
+		return 0, rpc.ConvertError(errToReturn, "failed to get account balance from the execution node", codes.Internal)
+	}
+
+	value, err := jsoncdc.Decode(nil, resp.GetValue())
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to decode account balance: %v", err)
+	}
+
+	balance, ok := value.(cadence.UFix64)
+	if !ok {
+		return 0, status.Errorf(codes.Internal, "unexpected account balance type: %T", value)
+	}
+
+	return uint64(balance), nil
+}
+
+// getAccountKeysFromAnyExeNode retrieves the public keys of the given account from any EN in `execNodes`.
+// Only the account status register and the public key registers are read, so the account's contracts
+// are not fetched.
+func (b *backendAccounts) getAccountKeysFromAnyExeNode(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+) ([]flow.AccountPublicKey, error) {
+	var keys []flow.AccountPublicKey
+	err := b.readRegistersFromAnyExeNode(ctx, address, blockID, func(readRegister registerReader) error {
+		statusValue, err := readRegister(flow.AccountStatusRegisterID(address))
+		if err != nil {
+			return err
+		}
+		if len(statusValue) == 0 {
+			return status.Errorf(codes.NotFound, "account with address %s not found", address)
+		}
+
+		accountStatus, err := environment.AccountStatusFromBytes(statusValue)
+		if err != nil {
+			return fmt.Errorf("failed to decode account status: %w", err)
+		}
+
+		keys = make([]flow.AccountPublicKey, accountStatus.PublicKeyCount())
+		for i := range keys {
+			keys[i], err = readAccountKey(readRegister, address, uint64(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// getAccountKeyByIndexFromAnyExeNode retrieves the public key of the given account with the given
+// key index from any EN in `execNodes`. Only the register of the key is read.
+func (b *backendAccounts) getAccountKeyByIndexFromAnyExeNode(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+	blockID flow.Identifier,
+) (*flow.AccountPublicKey, error) {
+	var key flow.AccountPublicKey
+	err := b.readRegistersFromAnyExeNode(ctx, address, blockID, func(readRegister registerReader) error {
+		var err error
+		key, err = readAccountKey(readRegister, address, keyIndex)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// registerReader reads the value of a register, returning an empty value if the register is not set.
+type registerReader func(registerID flow.RegisterID) (flow.RegisterValue, error)
+
+// readAccountKey reads and decodes the public key of the given account with the given key index.
+// Expected errors during normal operations:
+//   - codes.NotFound if the key does not exist
+func readAccountKey(readRegister registerReader, address flow.Address, keyIndex uint64) (flow.AccountPublicKey, error) {
+	value, err := readRegister(flow.PublicKeyRegisterID(address, keyIndex))
+	if err != nil {
+		return flow.AccountPublicKey{}, err
+	}
+	if len(value) == 0 {
+		return flow.AccountPublicKey{}, status.Errorf(codes.NotFound, "account key with index %d not found", keyIndex)
+	}
+
+	key, err := flow.DecodeAccountPublicKey(value, keyIndex)
+	if err != nil {
+		return flow.AccountPublicKey{}, fmt.Errorf("failed to decode account key with index %d: %w", keyIndex, err)
+	}
+	return key, nil
+}
+
+// readRegistersFromAnyExeNode calls `read` with a reader of the registers at the given block on any EN
+// in `execNodes`, so that all registers are read from the same EN. If `read` fails on an EN, the next
+// one is tried, unless the error is codes.NotFound, which is returned as is.
+func (b *backendAccounts) readRegistersFromAnyExeNode(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+	read func(readRegister registerReader) error,
+) error {
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return rpc.ConvertError(err, "failed to find execution node to query", codes.Internal)
+	}
+
+	errToReturn := b.nodeCommunicator.CallAvailableNode(
+		execNodes,
+		func(node *flow.IdentitySkeleton) error {
+			start := time.Now()
+
+			err := b.tryReadRegisters(ctx, node, blockID, read)
+			duration := time.Since(start)
+
+			lg := b.log.With().
+				Str("execution_node", node.String()).
+				Hex("block_id", blockID[:]).
+				Hex("address", address.Bytes()).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Logger()
+
+			if err != nil {
+				lg.Err(err).Msg("failed to read account registers")
+				return err
+			}
+
+			// return if any execution node replied successfully
+			lg.Debug().Msg("Successfully read account registers")
+			return nil
+		},
+		func(_ *flow.IdentitySkeleton, err error) bool {
+			return status.Code(err) == codes.NotFound
+		},
+	)
+
+	return rpc.ConvertError(errToReturn, "failed to read account registers from the execution node", codes.Internal)
+}
+
+// tryReadRegisters attempts to read registers at the given block from the given execution node.
+func (b *backendAccounts) tryReadRegisters(
+	ctx context.Context,
+	execNode *flow.IdentitySkeleton,
+	blockID flow.Identifier,
+	read func(readRegister registerReader) error,
+) error {
+	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	return read(func(registerID flow.RegisterID) (flow.RegisterValue, error) {
+		resp, err := execRPCClient.GetRegisterAtBlockID(ctx, &execproto.GetRegisterAtBlockIDRequest{
+			BlockId:       blockID[:],
+			RegisterOwner: []byte(registerID.Owner),
+			RegisterKey:   []byte(registerID.Key),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.GetValue(), nil
+	})
+}
+
+// tryExecuteScript attempts to execute the script on the given execution node.
+func (b *backendAccounts) tryExecuteScript(
+	ctx context.Context,
+	execNode *flow.IdentitySkeleton,
+	req *execproto.ExecuteScriptAtBlockIDRequest,
+) (*execproto.ExecuteScriptAtBlockIDResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return execRPCClient.ExecuteScriptAtBlockID(ctx, req)
+}
+
 // tryGetAccount attempts to get the account from the given execution node.
 func (b *backendAccounts) tryGetAccount(
 	ctx context.Context,
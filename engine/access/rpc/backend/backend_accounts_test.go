@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -14,6 +16,8 @@ import (
 	access "github.com/onflow/flow-go/engine/access/mock"
 	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/environment"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	execmock "github.com/onflow/flow-go/module/execution/mock"
 	"github.com/onflow/flow-go/module/irrecoverable"
@@ -119,6 +123,50 @@ func (s *BackendAccountsSuite) setupENSuccessResponse(blockID flow.Identifier) {
 		}, nil)
 }
 
+// setupENScriptResponse configures the execution node client to return the given balance when
+// executing the given balance script for the account
+func (s *BackendAccountsSuite) setupENScriptResponse(blockID flow.Identifier, script []byte, balance uint64) {
+	encodedAddress, err := jsoncdc.Encode(cadence.NewAddress(s.account.Address))
+	s.Require().NoError(err)
+	encodedBalance, err := jsoncdc.Encode(cadence.UFix64(balance))
+	s.Require().NoError(err)
+
+	expectedExecRequest := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    script,
+		Arguments: [][]byte{encodedAddress},
+	}
+	s.execClient.On("ExecuteScriptAtBlockID", mock.Anything, expectedExecRequest).
+		Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: encodedBalance}, nil)
+}
+
+// setupENRegisterResponses configures the execution node client to return the account status and
+// public key registers of the account, and empty values for any other register
+func (s *BackendAccountsSuite) setupENRegisterResponses(blockID flow.Identifier) {
+	registerRequest := func(registerID flow.RegisterID) *execproto.GetRegisterAtBlockIDRequest {
+		return &execproto.GetRegisterAtBlockIDRequest{
+			BlockId:       blockID[:],
+			RegisterOwner: []byte(registerID.Owner),
+			RegisterKey:   []byte(registerID.Key),
+		}
+	}
+
+	accountStatus := environment.NewAccountStatus()
+	accountStatus.SetPublicKeyCount(uint64(len(s.account.Keys)))
+	s.execClient.On("GetRegisterAtBlockID", mock.Anything, registerRequest(flow.AccountStatusRegisterID(s.account.Address))).
+		Return(&execproto.GetRegisterAtBlockIDResponse{Value: accountStatus.ToBytes()}, nil).Maybe()
+
+	for i, key := range s.account.Keys {
+		encodedKey, err := flow.EncodeAccountPublicKey(key)
+		s.Require().NoError(err)
+		s.execClient.On("GetRegisterAtBlockID", mock.Anything, registerRequest(flow.PublicKeyRegisterID(s.account.Address, uint64(i)))).
+			Return(&execproto.GetRegisterAtBlockIDResponse{Value: encodedKey}, nil).Maybe()
+	}
+
+	s.execClient.On("GetRegisterAtBlockID", mock.Anything, mock.Anything).
+		Return(&execproto.GetRegisterAtBlockIDResponse{}, nil).Maybe()
+}
+
 // setupENFailingResponse configures the execution node client to return an error
 func (s *BackendAccountsSuite) setupENFailingResponse(blockID flow.Identifier, err error) {
 	failingRequest := &execproto.GetAccountAtBlockIDRequest{
@@ -341,6 +389,142 @@ func (s *BackendAccountsSuite) TestGetAccountAtLatestBlockFromStorage_Inconsiste
 	})
 }
 
+// TestGetAccountBalance_HappyPath tests successfully getting account balances from execution nodes
+// and from local storage
+func (s *BackendAccountsSuite) TestGetAccountBalance_HappyPath() {
+	ctx := context.Background()
+
+	s.Run("from execution nodes", func() {
+		s.setupExecutionNodes(s.block)
+		s.setupENScriptResponse(s.block.ID(), accountBalanceScript, s.account.Balance)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		s.testGetAccountBalance(ctx, backend, s.account.Balance)
+	})
+
+	s.Run("from local storage", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("GetAccountBalance", mock.Anything, s.account.Address, s.block.Header.Height).
+			Return(s.account.Balance, nil)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.testGetAccountBalance(ctx, backend, s.account.Balance)
+	})
+}
+
+// TestGetAccountAvailableBalance_HappyPath tests successfully getting account available balances
+// from execution nodes and from local storage
+func (s *BackendAccountsSuite) TestGetAccountAvailableBalance_HappyPath() {
+	ctx := context.Background()
+	availableBalance := s.account.Balance - 1
+
+	s.Run("from execution nodes", func() {
+		s.setupExecutionNodes(s.block)
+		s.setupENScriptResponse(s.block.ID(), accountAvailableBalanceScript, availableBalance)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		s.testGetAccountAvailableBalance(ctx, backend, availableBalance)
+	})
+
+	s.Run("from local storage", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("GetAccountAvailableBalance", mock.Anything, s.account.Address, s.block.Header.Height).
+			Return(availableBalance, nil)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.testGetAccountAvailableBalance(ctx, backend, availableBalance)
+	})
+}
+
+// TestGetAccountKeys_HappyPath tests successfully getting account keys from execution nodes and
+// from local storage
+func (s *BackendAccountsSuite) TestGetAccountKeys_HappyPath() {
+	ctx := context.Background()
+
+	s.Run("from execution nodes", func() {
+		s.setupExecutionNodes(s.block)
+		s.setupENRegisterResponses(s.block.ID())
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		s.testGetAccountKeys(ctx, backend)
+
+		// the account status register of a missing account is empty
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.ID(), nil).Once()
+		actual, err := backend.GetAccountKeysAtBlockHeight(ctx, s.failingAddress, s.block.Header.Height)
+		s.Require().Error(err)
+		s.Require().Equal(codes.NotFound, status.Code(err))
+		s.Require().Nil(actual)
+	})
+
+	s.Run("from local storage", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("GetAccountKeys", mock.Anything, s.account.Address, s.block.Header.Height).
+			Return(s.account.Keys, nil)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.testGetAccountKeys(ctx, backend)
+	})
+}
+
+// TestGetAccountKeyByIndex tests getting a single account key from execution nodes and from local
+// storage, and that a missing key index results in a NotFound error
+func (s *BackendAccountsSuite) TestGetAccountKeyByIndex() {
+	ctx := context.Background()
+	height := s.block.Header.Height
+	missingIndex := uint64(len(s.account.Keys))
+
+	s.Run("from execution nodes", func() {
+		s.setupExecutionNodes(s.block)
+		s.setupENRegisterResponses(s.block.ID())
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		s.testGetAccountKeyByIndex(ctx, backend)
+
+		s.headers.On("BlockIDByHeight", height).Return(s.block.ID(), nil).Once()
+		actual, err := backend.GetAccountKeyByIndexAtBlockHeight(ctx, s.account.Address, missingIndex, height)
+		s.Require().Error(err)
+		s.Require().Equal(codes.NotFound, status.Code(err))
+		s.Require().Nil(actual)
+	})
+
+	s.Run("from local storage", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("GetAccountKey", mock.Anything, s.account.Address, uint64(0), height).
+			Return(&s.account.Keys[0], nil)
+		scriptExecutor.On("GetAccountKey", mock.Anything, s.account.Address, missingIndex, height).
+			Return(nil, fvmerrors.NewAccountPublicKeyNotFoundError(s.account.Address, missingIndex))
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.testGetAccountKeyByIndex(ctx, backend)
+
+		s.headers.On("BlockIDByHeight", height).Return(s.block.ID(), nil).Once()
+		actual, err := backend.GetAccountKeyByIndexAtBlockHeight(ctx, s.account.Address, missingIndex, height)
+		s.Require().Error(err)
+		s.Require().Equal(codes.NotFound, status.Code(err))
+		s.Require().Nil(actual)
+	})
+}
+
 func (s *BackendAccountsSuite) testGetAccount(ctx context.Context, backend *backendAccounts, statusCode codes.Code) {
 	s.state.On("Sealed").Return(s.snapshot, nil).Once()
 	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
@@ -388,3 +572,81 @@ func (s *BackendAccountsSuite) testGetAccountAtBlockHeight(ctx context.Context,
 		s.Require().Nil(actual)
 	}
 }
+
+func (s *BackendAccountsSuite) testGetAccountBalance(ctx context.Context, backend *backendAccounts, expected uint64) {
+	s.state.On("Sealed").Return(s.snapshot, nil).Once()
+	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+	s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.ID(), nil).Once()
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	actual, err := backend.GetAccountBalanceAtLatestBlock(ctx, s.account.Address)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountBalanceAtBlockHeight(ctx, s.account.Address, s.block.Header.Height)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountBalanceAtBlockID(ctx, s.account.Address, s.block.ID())
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+}
+
+func (s *BackendAccountsSuite) testGetAccountAvailableBalance(ctx context.Context, backend *backendAccounts, expected uint64) {
+	s.state.On("Sealed").Return(s.snapshot, nil).Once()
+	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+	s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.ID(), nil).Once()
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	actual, err := backend.GetAccountAvailableBalanceAtLatestBlock(ctx, s.account.Address)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountAvailableBalanceAtBlockHeight(ctx, s.account.Address, s.block.Header.Height)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountAvailableBalanceAtBlockID(ctx, s.account.Address, s.block.ID())
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+}
+
+func (s *BackendAccountsSuite) testGetAccountKeys(ctx context.Context, backend *backendAccounts) {
+	s.state.On("Sealed").Return(s.snapshot, nil).Once()
+	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+	s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.ID(), nil).Once()
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	actual, err := backend.GetAccountKeysAtLatestBlock(ctx, s.account.Address)
+	s.Require().NoError(err)
+	s.Require().Equal(s.account.Keys, actual)
+
+	actual, err = backend.GetAccountKeysAtBlockHeight(ctx, s.account.Address, s.block.Header.Height)
+	s.Require().NoError(err)
+	s.Require().Equal(s.account.Keys, actual)
+
+	actual, err = backend.GetAccountKeysAtBlockID(ctx, s.account.Address, s.block.ID())
+	s.Require().NoError(err)
+	s.Require().Equal(s.account.Keys, actual)
+}
+
+func (s *BackendAccountsSuite) testGetAccountKeyByIndex(ctx context.Context, backend *backendAccounts) {
+	s.state.On("Sealed").Return(s.snapshot, nil).Once()
+	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+	s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.ID(), nil).Once()
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	expected := &s.account.Keys[0]
+
+	actual, err := backend.GetAccountKeyByIndexAtLatestBlock(ctx, s.account.Address, 0)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountKeyByIndexAtBlockHeight(ctx, s.account.Address, 0, s.block.Header.Height)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+
+	actual, err = backend.GetAccountKeyByIndexAtBlockID(ctx, s.account.Address, 0, s.block.ID())
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/onflow/flow/protobuf/go/flow/entities"
@@ -38,6 +39,14 @@ type backendEvents struct {
 	nodeCommunicator  Communicator
 	queryMode         IndexQueryMode
 	eventsIndex       *index.EventsIndex
+	prunedData        *prunedDataChecker
+
+	// eventTypeIndexMaxHeightRange is the max size of height range requests served using the
+	// event type index
+	eventTypeIndexMaxHeightRange uint
+	// eventTypeIndexMaxEvents is the max number of events returned by height range requests served
+	// using the event type index
+	eventTypeIndexMaxEvents uint
 }
 
 // blockMetadata is used to capture information about requested blocks to avoid repeated blockID
@@ -50,6 +59,8 @@ type blockMetadata struct {
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
 // the end block height (inclusive) that have the given type.
+// If the event type index is enabled, larger height ranges are supported, as long as they contain at
+// most the configured maximum number of events.
 func (b *backendEvents) GetEventsForHeightRange(
 	ctx context.Context,
 	eventType string,
@@ -61,10 +72,18 @@ func (b *backendEvents) GetEventsForHeightRange(
 		return nil, status.Error(codes.InvalidArgument, "start height must not be larger than end height")
 	}
 
+	// the event type index only loads the events of the requested type, so it supports larger
+	// height ranges
+	useEventTypeIndex := b.eventTypeIndexEnabled()
+	maxHeightRange := b.maxHeightRange
+	if useEventTypeIndex {
+		maxHeightRange = b.eventTypeIndexMaxHeightRange
+	}
+
 	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
-	if rangeSize > uint64(b.maxHeightRange) {
+	if rangeSize > uint64(maxHeightRange) {
 		return nil, status.Errorf(codes.InvalidArgument,
-			"requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxHeightRange)
+			"requested block range (%d) exceeded maximum (%d)", rangeSize, maxHeightRange)
 	}
 
 	// get the latest sealed block header
@@ -96,6 +115,34 @@ func (b *backendEvents) GetEventsForHeightRange(
 		endHeight = sealed.Height
 	}
 
+	if useEventTypeIndex {
+		resp, err := b.getEventsFromEventTypeIndex(eventType, startHeight, endHeight, requiredEventEncodingVersion)
+		if err == nil {
+			return resp, nil
+		}
+		if !errors.Is(err, storage.ErrHeightNotIndexed) && !errors.Is(err, indexer.ErrIndexNotInitialized) {
+			return nil, err
+		}
+
+		if b.queryMode == IndexQueryModeLocalOnly {
+			if prunedErr := b.prunedData.checkHeight(startHeight); prunedErr != nil {
+				return nil, prunedErr
+			}
+			return nil, status.Errorf(codes.NotFound,
+				"events not found in local storage for height range [%d, %d]: %v", startHeight, endHeight, err)
+		}
+
+		// the range is not covered by the event type index, so fall back to querying block by block
+		// if the range is small enough.
+		if rangeSize = endHeight - startHeight + 1; rangeSize > uint64(b.maxHeightRange) {
+			return nil, status.Errorf(codes.OutOfRange,
+				"requested block range (%d) is not indexed locally and exceeded maximum (%d) for non-indexed queries",
+				rangeSize, b.maxHeightRange)
+		}
+
+		b.log.Debug().Err(err).Msg("failed to get events from event type index")
+	}
+
 	// find the block headers for all the blocks between min and max height (inclusive)
 	blockHeaders := make([]blockMetadata, 0, endHeight-startHeight+1)
 
@@ -176,6 +223,11 @@ func (b *backendEvents) getBlockEvents(
 		}
 		// all blocks should be available.
 		if len(missingBlocks) > 0 {
+			for _, blockInfo := range missingBlocks {
+				if prunedErr := b.prunedData.checkHeight(blockInfo.Height); prunedErr != nil {
+					return nil, prunedErr
+				}
+			}
 			return nil, status.Errorf(codes.NotFound, "events not found in local storage for %d blocks", len(missingBlocks))
 		}
 		return localResponse, nil
@@ -218,6 +270,89 @@ func (b *backendEvents) getBlockEvents(
 	}
 }
 
+// eventTypeIndexEnabled returns true if events for height ranges are queried using the event type index.
+func (b *backendEvents) eventTypeIndexEnabled() bool {
+	if b.queryMode != IndexQueryModeLocalOnly && b.queryMode != IndexQueryModeFailover {
+		return false
+	}
+	return b.eventsIndex != nil && b.eventsIndex.EventTypeIndexEnabled()
+}
+
+// getEventsFromEventTypeIndex retrieves events of the given type for all sealed blocks between the
+// start and end block height (inclusive) using the event type index.
+// As for the other query paths, the response contains all blocks within the range, including blocks
+// without events of the given type.
+//
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the events index has not been initialized
+//   - storage.ErrHeightNotIndexed if any height within the range is not indexed
+//
+// All other errors are returned as status errors.
+func (b *backendEvents) getEventsFromEventTypeIndex(
+	eventType string,
+	startHeight, endHeight uint64,
+	requiredEventEncodingVersion entities.EventEncodingVersion,
+) ([]flow.BlockEvents, error) {
+	target := flow.EventType(eventType)
+
+	if _, err := events.ValidateEvent(target, b.chain); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid event type: %v", err)
+	}
+
+	indexed, err := b.eventsIndex.ByEventType(target, startHeight, endHeight, b.eventTypeIndexMaxEvents)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) || errors.Is(err, indexer.ErrIndexNotInitialized) {
+			return nil, err
+		}
+		if errors.Is(err, storage.ErrLimitExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"more than %d events of type %s in height range [%d, %d], request a smaller height range",
+				b.eventTypeIndexMaxEvents, eventType, startHeight, endHeight)
+		}
+		err = fmt.Errorf("failed to get events for height range [%d, %d]: %w", startHeight, endHeight, err)
+		return nil, rpc.ConvertError(err, "failed to get events from storage", codes.Internal)
+	}
+
+	resp := make([]flow.BlockEvents, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		blockID, err := b.headers.BlockIDByHeight(height)
+		if err != nil {
+			return nil, rpc.ConvertStorageError(fmt.Errorf("failed to get blockID for %d: %w", height, err))
+		}
+		header, err := b.headers.ByBlockID(blockID)
+		if err != nil {
+			return nil, rpc.ConvertStorageError(fmt.Errorf("failed to get block header for %d: %w", height, err))
+		}
+
+		blockEvents := flow.BlockEvents{
+			BlockID:        blockID,
+			BlockHeight:    height,
+			BlockTimestamp: header.Timestamp,
+			Events:         make([]flow.Event, 0),
+		}
+		if len(indexed) > 0 && indexed[0].BlockHeight == height {
+			blockEvents.Events = indexed[0].Events
+			indexed = indexed[1:]
+		}
+
+		// events are encoded in CCF format in storage. convert to JSON-CDC if requested
+		if requiredEventEncodingVersion == entities.EventEncodingVersion_JSON_CDC_V0 {
+			for j, e := range blockEvents.Events {
+				payload, err := convert.CcfPayloadToJsonPayload(e.Payload)
+				if err != nil {
+					err = fmt.Errorf("failed to convert event payload for block %s: %w", blockID, err)
+					return nil, rpc.ConvertError(err, "failed to convert event payload", codes.Internal)
+				}
+				blockEvents.Events[j].Payload = payload
+			}
+		}
+
+		resp = append(resp, blockEvents)
+	}
+
+	return resp, nil
+}
+
 // getBlockEventsFromStorage retrieves events for all the specified blocks that have the given type
 // from the local storage
 func (b *backendEvents) getBlockEventsFromStorage(
@@ -381,20 +516,23 @@ func verifyAndConvertToAccessEvents(
 }
 
 // getEventsFromAnyExeNode retrieves the given events from any EN in `execNodes`.
-// We attempt querying each EN in sequence. If any EN returns a valid response, then errors from
+// We attempt querying each EN in sequence, or concurrently if the request is hedged. If any EN returns a valid response, then errors from
 // other ENs are logged and swallowed. If all ENs fail to return a valid response, then an
 // error aggregating all failures is returned.
 func (b *backendEvents) getEventsFromAnyExeNode(ctx context.Context,
 	execNodes flow.IdentitySkeletonList,
 	req *execproto.GetEventsForBlockIDsRequest) (*execproto.GetEventsForBlockIDsResponse, *flow.IdentitySkeleton, error) {
+	// calls to several nodes may complete concurrently if the request is hedged, in which case only
+	// the first successful response is kept
+	var mu sync.Mutex
 	var resp *execproto.GetEventsForBlockIDsResponse
 	var execNode *flow.IdentitySkeleton
-	errToReturn := b.nodeCommunicator.CallAvailableNode(
+	errToReturn := b.nodeCommunicator.CallAvailableNodeHedged(
+		ctx,
 		execNodes,
-		func(node *flow.IdentitySkeleton) error {
-			var err error
+		func(ctx context.Context, node *flow.IdentitySkeleton) error {
 			start := time.Now()
-			resp, err = b.tryGetEvents(ctx, node, req)
+			nodeResp, err := b.tryGetEvents(ctx, node, req)
 			duration := time.Since(start)
 
 			logger := b.log.With().
@@ -407,7 +545,12 @@ func (b *backendEvents) getEventsFromAnyExeNode(ctx context.Context,
 			if err == nil {
 				// return if any execution node replied successfully
 				logger.Debug().Msg("Successfully got events")
-				execNode = node
+				mu.Lock()
+				defer mu.Unlock()
+				if execNode == nil {
+					resp = nodeResp
+					execNode = node
+				}
 				return nil
 			}
 
@@ -433,6 +433,135 @@ func (s *BackendEventsSuite) TestGetEventsForHeightRange_HandlesErrors() {
 	})
 }
 
+// TestGetEventsForHeightRange_EventTypeIndex tests that height range queries use the event type index
+// if it is enabled, and fall back to per block queries if the range is not indexed.
+func (s *BackendEventsSuite) TestGetEventsForHeightRange_EventTypeIndex() {
+	ctx := context.Background()
+
+	startHeight := s.blocks[0].Header.Height
+	endHeight := s.sealedHead.Height
+
+	s.state.On("Sealed").Return(s.snapshot)
+	s.snapshot.On("Head").Return(s.sealedHead, nil)
+
+	// only the second and fourth block contain events of the target type
+	indexedBlocks := []*flow.Block{s.blocks[1], s.blocks[3]}
+
+	setupBackend := func(queryMode IndexQueryMode, indexedStartHeight uint64) *backendEvents {
+		reporter := syncmock.NewIndexReporter(s.T())
+		reporter.On("LowestIndexedHeight").Return(indexedStartHeight, nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(endHeight, nil).Maybe()
+
+		eventTypeIndex := storagemock.NewEventTypeIndex(s.T())
+		eventTypeIndex.
+			On("ByEventType", flow.EventType(targetEvent), startHeight, endHeight, uint(DefaultEventTypeIndexMaxEvents)).
+			Return(func(flow.EventType, uint64, uint64, uint) ([]flow.BlockEvents, error) {
+				resp := make([]flow.BlockEvents, len(indexedBlocks))
+				for i, block := range indexedBlocks {
+					resp[i] = flow.BlockEvents{
+						BlockID:     block.ID(),
+						BlockHeight: block.Header.Height,
+						Events:      []flow.Event{s.blockEvents[0]},
+					}
+				}
+				return resp, nil
+			}).Maybe()
+
+		eventsIndex := index.NewEventsIndex(index.NewReporter(), s.events, index.WithEventTypeIndex(eventTypeIndex))
+		err := eventsIndex.Initialize(reporter)
+		s.Require().NoError(err)
+
+		backend := s.defaultBackend()
+		backend.queryMode = queryMode
+		backend.eventsIndex = eventsIndex
+		backend.eventTypeIndexMaxHeightRange = DefaultEventTypeIndexMaxHeightRange
+		backend.eventTypeIndexMaxEvents = DefaultEventTypeIndexMaxEvents
+		return backend
+	}
+
+	for _, tt := range s.testCases {
+		if tt.queryMode == IndexQueryModeExecutionNodesOnly {
+			continue
+		}
+
+		s.Run(fmt.Sprintf("returns all blocks - %s - %s", tt.encoding.String(), tt.queryMode), func() {
+			backend := setupBackend(tt.queryMode, startHeight)
+
+			// the range is larger than the max range supported without the event type index
+			response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, startHeight+DefaultMaxHeightRange, tt.encoding)
+			s.Require().NoError(err)
+
+			// blocks without events of the given type are included with empty events
+			s.Require().Len(response, int(endHeight-startHeight+1))
+			for i, blockEvents := range response {
+				block := s.blocks[i]
+				s.Assert().Equal(block.ID(), blockEvents.BlockID)
+				s.Assert().Equal(block.Header.Height, blockEvents.BlockHeight)
+				s.Assert().Equal(block.Header.Timestamp, blockEvents.BlockTimestamp)
+
+				if block != s.blocks[1] && block != s.blocks[3] {
+					s.Assert().Empty(blockEvents.Events)
+					continue
+				}
+				s.Require().Len(blockEvents.Events, 1)
+				s.assertEncoding(&blockEvents.Events[0], tt.encoding)
+			}
+		})
+	}
+
+	s.Run("returns error for range larger than max", func() {
+		backend := setupBackend(IndexQueryModeLocalOnly, startHeight)
+
+		response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, startHeight+DefaultEventTypeIndexMaxHeightRange, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		s.Assert().Nil(response)
+	})
+
+	s.Run("returns error if the range contains too many events", func() {
+		backend := setupBackend(IndexQueryModeLocalOnly, startHeight)
+		backend.eventTypeIndexMaxEvents = 1
+
+		eventTypeIndex := storagemock.NewEventTypeIndex(s.T())
+		eventTypeIndex.On("ByEventType", flow.EventType(targetEvent), startHeight, endHeight, uint(1)).
+			Return(nil, storage.ErrLimitExceeded)
+		reporter := syncmock.NewIndexReporter(s.T())
+		reporter.On("LowestIndexedHeight").Return(startHeight, nil)
+		reporter.On("HighestIndexedHeight").Return(endHeight, nil)
+		backend.eventsIndex = index.NewEventsIndex(index.NewReporter(), s.events, index.WithEventTypeIndex(eventTypeIndex))
+		s.Require().NoError(backend.eventsIndex.Initialize(reporter))
+
+		response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.ResourceExhausted, status.Code(err))
+		s.Assert().Nil(response)
+	})
+
+	s.Run("local only returns not found if range is not indexed", func() {
+		backend := setupBackend(IndexQueryModeLocalOnly, startHeight+1)
+
+		response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.NotFound, status.Code(err))
+		s.Assert().Nil(response)
+	})
+
+	s.Run("failover falls back to per block queries if range is not indexed", func() {
+		backend := setupBackend(IndexQueryModeFailover, startHeight+1)
+		s.setupENSuccessResponse(targetEvent, []*flow.Block{s.blocks[0]})
+
+		response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Require().NoError(err)
+		s.assertResponse(response, entities.EventEncodingVersion_CCF_V0)
+	})
+
+	s.Run("failover returns error if range is not indexed and larger than max", func() {
+		backend := setupBackend(IndexQueryModeFailover, startHeight+1)
+		backend.maxHeightRange = 3
+
+		response, err := backend.GetEventsForHeightRange(ctx, targetEvent, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.OutOfRange, status.Code(err))
+		s.Assert().Nil(response)
+	})
+}
+
 func (s *BackendEventsSuite) TestGetEventsForBlockIDs_HandlesErrors() {
 	ctx := context.Background()
 
@@ -466,6 +595,34 @@ func (s *BackendEventsSuite) TestGetEventsForBlockIDs_HandlesErrors() {
 		s.Assert().Equal(codes.NotFound, status.Code(err))
 		s.Assert().Nil(response)
 	})
+
+	s.Run("returns pruned error for pruned blocks in local only mode", func() {
+		// the block data of the first 2 blocks was pruned, so their events were removed
+		prunedHeight := prunedHeightReporter(s.blocks[2].Header.Height)
+		eventsIndex := index.NewEventsIndex(index.NewReporter(index.WithPrunedHeightReporter(prunedHeight)), s.events)
+
+		reporter := syncmock.NewIndexReporter(s.T())
+		reporter.On("LowestIndexedHeight").Return(s.blocks[0].Header.Height, nil)
+		reporter.On("HighestIndexedHeight").Return(s.sealedHead.Height, nil)
+		err := eventsIndex.Initialize(reporter)
+		s.Require().NoError(err)
+
+		backend := s.defaultBackend()
+		backend.queryMode = IndexQueryModeLocalOnly
+		backend.eventsIndex = eventsIndex
+		backend.prunedData = &prunedDataChecker{headers: s.headers, reporter: prunedHeight}
+
+		response, err := backend.GetEventsForBlockIDs(ctx, targetEvent, s.blockIDs, encoding)
+		s.Assert().Equal(codes.OutOfRange, status.Code(err))
+		s.Assert().Contains(err.Error(), "has been pruned")
+		s.Assert().Nil(response)
+	})
+}
+
+type prunedHeightReporter uint64
+
+func (r prunedHeightReporter) LowestAvailableHeight() uint64 {
+	return uint64(r)
 }
 
 func (s *BackendEventsSuite) assertResponse(response []flow.BlockEvents, encoding entities.EventEncodingVersion) {
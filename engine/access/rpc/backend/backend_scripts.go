@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"crypto/md5" //nolint:gosec
+	"sync"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/onflow/flow-go/engine/access/rpc/connection"
 	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/fvm"
 	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
@@ -37,6 +39,13 @@ type backendScripts struct {
 	nodeCommunicator  Communicator
 	scriptExecutor    execution.ScriptExecutor
 	scriptExecMode    IndexQueryMode
+	// scriptResults is nil if caching of script results is disabled
+	scriptResults *scriptResultCache
+
+	// scriptBatchMaxSize is the max number of scripts in a batch, 0 means no limit
+	scriptBatchMaxSize uint
+	// scriptBatchComputationLimit is the max computation used by all scripts of a batch, 0 means no limit
+	scriptBatchComputationLimit uint64
 }
 
 // scriptExecutionRequest encapsulates the data needed to execute a script to make it easier
@@ -47,6 +56,9 @@ type scriptExecutionRequest struct {
 	script             []byte
 	arguments          [][]byte
 	insecureScriptHash [md5.Size]byte
+
+	// key is computed on first use, see cacheKey
+	key *scriptResultCacheKey
 }
 
 func newScriptExecutionRequest(blockID flow.Identifier, height uint64, script []byte, arguments [][]byte) *scriptExecutionRequest {
@@ -63,6 +75,18 @@ func newScriptExecutionRequest(blockID flow.Identifier, height uint64, script []
 	}
 }
 
+// cacheKey returns the key of the request's result in the script result cache.
+func (r *scriptExecutionRequest) cacheKey() scriptResultCacheKey {
+	if r.key == nil {
+		r.key = &scriptResultCacheKey{
+			blockID:       r.blockID,
+			scriptHash:    flow.MakeIDFromFingerPrint(r.script),
+			argumentsHash: flow.MakeID(r.arguments),
+		}
+	}
+	return *r.key
+}
+
 // ExecuteScriptAtLatestBlock executes provided script at the latest sealed block.
 func (b *backendScripts) ExecuteScriptAtLatestBlock(
 	ctx context.Context,
@@ -167,10 +191,16 @@ func (b *backendScripts) executeScript(
 }
 
 // executeScriptLocally executes the provided script using the local execution state.
+// Results of previous executions are returned from the script result cache if it is enabled, unless
+// the client requested to bypass the cache.
 func (b *backendScripts) executeScriptLocally(
 	ctx context.Context,
 	r *scriptExecutionRequest,
 ) ([]byte, time.Duration, error) {
+	if result, ok := b.scriptResults.get(ctx, r); ok {
+		return result, 0, nil
+	}
+
 	execStartTime := time.Now()
 
 	result, err := b.scriptExecutor.ExecuteAtBlockHeight(ctx, r.script, r.arguments, r.height)
@@ -213,6 +243,8 @@ func (b *backendScripts) executeScriptLocally(
 	// log execution time
 	b.metrics.ScriptExecuted(execDuration, len(r.script))
 
+	b.scriptResults.add(r, result)
+
 	return result, execDuration, nil
 }
 
@@ -227,26 +259,51 @@ func (b *backendScripts) executeScriptOnAvailableExecutionNodes(
 		return nil, 0, status.Errorf(codes.Internal, "failed to find script executors at blockId %v: %v", r.blockID.String(), err)
 	}
 
+	result, _, execDuration, err := b.executeScriptOnExecutionNodes(ctx, executors, r)
+	return result, execDuration, err
+}
+
+// executeScriptOnExecutionNodes executes the provided script using the given execution nodes,
+// returning the result and the computation used by the script.
+func (b *backendScripts) executeScriptOnExecutionNodes(
+	ctx context.Context,
+	executors flow.IdentitySkeletonList,
+	r *scriptExecutionRequest,
+) ([]byte, uint64, time.Duration, error) {
 	lg := b.log.With().
 		Hex("block_id", logging.ID(r.blockID)).
 		Hex("script_hash", r.insecureScriptHash[:]).
 		Logger()
 
+	// calls to several nodes may complete concurrently if the request is hedged, in which case only
+	// the first successful result is kept
+	var mu sync.Mutex
+	var done bool
 	var result []byte
+	var computationUsed uint64
 	var execDuration time.Duration
-	errToReturn := b.nodeCommunicator.CallAvailableNode(
+	errToReturn := b.nodeCommunicator.CallAvailableNodeHedged(
+		ctx,
 		executors,
-		func(node *flow.IdentitySkeleton) error {
+		func(ctx context.Context, node *flow.IdentitySkeleton) error {
 			execStartTime := time.Now()
 
-			result, err = b.tryExecuteScriptOnExecutionNode(ctx, node.Address, r)
+			nodeResult, nodeComputationUsed, err := b.tryExecuteScriptOnExecutionNode(ctx, node.Address, r)
 
 			executionTime := time.Now()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if done {
+				return err
+			}
 			execDuration = executionTime.Sub(execStartTime)
 
 			if err != nil {
 				return err
 			}
+			done = true
+			result, computationUsed = nodeResult, nodeComputationUsed
 
 			if b.log.GetLevel() == zerolog.DebugLevel {
 				if b.shouldLogScript(executionTime, r.insecureScriptHash) {
@@ -277,14 +334,20 @@ func (b *backendScripts) executeScriptOnAvailableExecutionNodes(
 	)
 
 	if errToReturn != nil {
-		if status.Code(errToReturn) != codes.InvalidArgument {
+		if status.Code(errToReturn) == codes.InvalidArgument {
+			// execution nodes don't report the computation used by failed scripts, so failed scripts are
+			// accounted for with the default computation limit of scripts
+			execution.ReportComputation(ctx, fvm.DefaultComputationLimit)
+		} else {
 			b.metrics.ScriptExecutionErrorOnExecutionNode()
 			b.log.Error().Err(errToReturn).Msg("script execution failed for execution node internal reasons")
 		}
-		return nil, execDuration, rpc.ConvertError(errToReturn, "failed to execute script on execution nodes", codes.Internal)
+		return nil, 0, execDuration, rpc.ConvertError(errToReturn, "failed to execute script on execution nodes", codes.Internal)
 	}
 
-	return result, execDuration, nil
+	execution.ReportComputation(ctx, computationUsed)
+
+	return result, computationUsed, execDuration, nil
 }
 
 // tryExecuteScriptOnExecutionNode attempts to execute the script on the given execution node.
@@ -292,10 +355,10 @@ func (b *backendScripts) tryExecuteScriptOnExecutionNode(
 	ctx context.Context,
 	executorAddress string,
 	r *scriptExecutionRequest,
-) ([]byte, error) {
+) ([]byte, uint64, error) {
 	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(executorAddress)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create client for execution node %s: %v",
+		return nil, 0, status.Errorf(codes.Internal, "failed to create client for execution node %s: %v",
 			executorAddress, err)
 	}
 	defer closer.Close()
@@ -306,9 +369,9 @@ func (b *backendScripts) tryExecuteScriptOnExecutionNode(
 		Arguments: r.arguments,
 	})
 	if err != nil {
-		return nil, status.Errorf(status.Code(err), "failed to execute the script on the execution node %s: %v", executorAddress, err)
+		return nil, 0, status.Errorf(status.Code(err), "failed to execute the script on the execution node %s: %v", executorAddress, err)
 	}
-	return execResp.GetValue(), nil
+	return execResp.GetValue(), execResp.GetComputationUsage(), nil
 }
 
 // isInvalidArgumentError checks if the error is from an invalid argument
@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// DefaultScriptBatchMaxSize is the default max number of scripts in a single batch.
+const DefaultScriptBatchMaxSize = 50
+
+// DefaultScriptBatchComputationLimit is the default max computation used by all scripts of a batch.
+const DefaultScriptBatchComputationLimit = 1_000_000
+
+// ExecuteScriptsAtBlockID executes the provided batch of scripts at the provided block ID.
+// Results are returned in the order of the scripts, and failures of individual scripts are returned
+// as status errors in their result.
+func (b *backendScripts) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	scripts []access.Script,
+) ([]access.ScriptResult, error) {
+	if len(scripts) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one script must be provided")
+	}
+	if b.scriptBatchMaxSize > 0 && uint(len(scripts)) > b.scriptBatchMaxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "too many scripts in batch: %d, max allowed: %d",
+			len(scripts), b.scriptBatchMaxSize)
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	batch := make([]execution.Script, len(scripts))
+	for i, script := range scripts {
+		batch[i] = execution.Script{
+			Source:    script.Source,
+			Arguments: script.Arguments,
+		}
+	}
+
+	switch b.scriptExecMode {
+	case IndexQueryModeExecutionNodesOnly:
+		return b.executeScriptsOnAvailableExecutionNodes(ctx, header, batch, b.scriptBatchComputationLimit)
+
+	case IndexQueryModeLocalOnly:
+		return b.executeScriptsLocally(ctx, header, batch)
+
+	case IndexQueryModeFailover:
+		localResults, localErr := b.executeScriptsLocally(ctx, header, batch)
+		if localErr != nil {
+			if status.Code(localErr) == codes.Canceled {
+				return nil, localErr
+			}
+			return b.executeScriptsOnAvailableExecutionNodes(ctx, header, batch, b.scriptBatchComputationLimit)
+		}
+		return b.failoverScripts(ctx, header, batch, localResults)
+
+	case IndexQueryModeCompare:
+		execResults, execErr := b.executeScriptsOnAvailableExecutionNodes(ctx, header, batch, b.scriptBatchComputationLimit)
+		if execErr != nil {
+			return nil, execErr
+		}
+
+		localResults, localErr := b.executeScriptsLocally(ctx, header, batch)
+		for i, script := range batch {
+			localResult := &access.ScriptResult{Error: localErr}
+			if localErr == nil {
+				localResult = &localResults[i]
+			}
+			b.compareScriptResults(header, script, &execResults[i], localResult)
+		}
+
+		// always return EN results
+		return execResults, nil
+
+	default:
+		return nil, status.Errorf(codes.Internal, "unknown script execution mode: %v", b.scriptExecMode)
+	}
+}
+
+// executeScriptsLocally executes the batch of scripts using the local execution state.
+// Failures of individual scripts are returned as status errors in their result.
+func (b *backendScripts) executeScriptsLocally(
+	ctx context.Context,
+	header *flow.Header,
+	batch []execution.Script,
+) ([]access.ScriptResult, error) {
+	results, err := b.scriptExecutor.ExecuteBatchAtBlockHeight(ctx, batch, header.Height, b.scriptBatchComputationLimit)
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, header.Height, "failed to execute scripts")
+	}
+
+	converted := make([]access.ScriptResult, len(results))
+	for i, result := range results {
+		converted[i] = access.ScriptResult{
+			Value:           result.Value,
+			ComputationUsed: result.ComputationUsed,
+		}
+		if result.Err == nil {
+			continue
+		}
+
+		converted[i].Error = convertBatchScriptExecutionError(result.Err, header.Height)
+		switch status.Code(converted[i].Error) {
+		case codes.InvalidArgument, codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted:
+		default:
+			b.log.Error().Err(result.Err).
+				Hex("block_id", logging.ID(header.ID())).
+				Msg("script of batch failed to execute locally")
+			b.metrics.ScriptExecutionErrorLocal()
+		}
+	}
+
+	return converted, nil
+}
+
+// executeScriptsOnAvailableExecutionNodes executes the batch of scripts using the execution nodes
+// which executed the block. The computation used by all scripts is limited by computationLimit, as far as
+// the computation limit of the execution nodes allows.
+// Failures of individual scripts are returned as status errors in their result.
+func (b *backendScripts) executeScriptsOnAvailableExecutionNodes(
+	ctx context.Context,
+	header *flow.Header,
+	batch []execution.Script,
+	computationLimit uint64,
+) ([]access.ScriptResult, error) {
+	blockID := header.ID()
+	executors, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find script executors at blockId %v: %v", blockID.String(), err)
+	}
+
+	// execution nodes apply their own computation limit to each script, so the default limit is reserved
+	// for each running script, and the batch may exceed its limit if execution nodes use a higher one.
+	results := execution.ExecuteBatch(ctx, batch, computationLimit, fvm.DefaultComputationLimit, func(ctx context.Context, script execution.Script, _ uint64) ([]byte, uint64, error) {
+		r := newScriptExecutionRequest(blockID, header.Height, script.Source, script.Arguments)
+		result, computationUsed, _, err := b.executeScriptOnExecutionNodes(ctx, executors, r)
+		return result, computationUsed, err
+	})
+
+	converted := make([]access.ScriptResult, len(results))
+	for i, result := range results {
+		converted[i] = access.ScriptResult{
+			Value:           result.Value,
+			ComputationUsed: result.ComputationUsed,
+			Error:           convertBatchScriptExecutionError(result.Err, header.Height),
+		}
+	}
+
+	return converted, nil
+}
+
+// failoverScripts re-executes the scripts of the batch which failed to execute locally for reasons
+// other than user errors on the execution nodes, sharing the computation limit of the batch.
+func (b *backendScripts) failoverScripts(
+	ctx context.Context,
+	header *flow.Header,
+	batch []execution.Script,
+	localResults []access.ScriptResult,
+) ([]access.ScriptResult, error) {
+	var computationUsed uint64
+	var failed []int
+	for i, result := range localResults {
+		computationUsed += result.ComputationUsed
+		// Note: scripts that timeout are retried on the execution nodes since ANs may have performance
+		// issues for some scripts. Unlike single scripts, scripts that exceeded resource limits are not
+		// retried, since they would exceed them on the execution nodes as well while using the
+		// computation limit of the batch.
+		switch status.Code(result.Error) {
+		case codes.OK, codes.InvalidArgument, codes.Canceled, codes.ResourceExhausted:
+		default:
+			failed = append(failed, i)
+		}
+	}
+	if len(failed) == 0 {
+		return localResults, nil
+	}
+
+	computationLimit := b.scriptBatchComputationLimit
+	if computationLimit > 0 {
+		if computationUsed >= computationLimit {
+			return localResults, nil
+		}
+		computationLimit -= computationUsed
+	}
+
+	retried := make([]execution.Script, len(failed))
+	for i, index := range failed {
+		retried[i] = batch[index]
+	}
+
+	execResults, err := b.executeScriptsOnAvailableExecutionNodes(ctx, header, retried, computationLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, index := range failed {
+		localResults[index] = execResults[i]
+	}
+
+	return localResults, nil
+}
+
+// compareScriptResults compares the results of a script of a batch executed on the execution nodes
+// and locally.
+func (b *backendScripts) compareScriptResults(
+	header *flow.Header,
+	script execution.Script,
+	execResult *access.ScriptResult,
+	localResult *access.ScriptResult,
+) {
+	// results of scripts skipped because of the computation limit cannot be compared
+	if status.Code(execResult.Error) == codes.ResourceExhausted || status.Code(localResult.Error) == codes.ResourceExhausted {
+		return
+	}
+
+	r := newScriptExecutionRequest(header.ID(), header.Height, script.Source, script.Arguments)
+	resultComparer := newScriptResultComparison(b.log, b.metrics, r)
+	_ = resultComparer.compare(
+		newScriptResult(execResult.Value, 0, execResult.Error),
+		newScriptResult(localResult.Value, 0, localResult.Error),
+	)
+}
+
+// convertBatchScriptExecutionError converts an error returned for a script of a batch to a gRPC error
+func convertBatchScriptExecutionError(err error, height uint64) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, execution.ErrBatchComputationLimitExceeded) {
+		return status.Errorf(codes.ResourceExhausted, "script not executed: %v", err)
+	}
+
+	// errors returned by execution nodes are already converted
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return convertScriptExecutionError(err, height)
+}
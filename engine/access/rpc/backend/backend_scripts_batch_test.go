@@ -0,0 +1,167 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accessapi "github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/module/execution"
+	execmock "github.com/onflow/flow-go/module/execution/mock"
+	"github.com/onflow/flow-go/storage"
+)
+
+// batchScripts returns a batch containing a succeeding and a failing script
+func (s *BackendScriptsSuite) batchScripts() ([]accessapi.Script, []execution.Script) {
+	scripts := []accessapi.Script{
+		{Source: s.script, Arguments: s.arguments},
+		{Source: s.failingScript, Arguments: s.arguments},
+	}
+	batch := []execution.Script{
+		{Source: s.script, Arguments: s.arguments},
+		{Source: s.failingScript, Arguments: s.arguments},
+	}
+	return scripts, batch
+}
+
+// TestExecuteScriptsFromStorage tests that the backend executes batches of scripts using the local
+// storage, returning failures of individual scripts in their result
+func (s *BackendScriptsSuite) TestExecuteScriptsFromStorage() {
+	ctx := context.Background()
+	scripts, batch := s.batchScripts()
+
+	scriptExecutor := execmock.NewScriptExecutor(s.T())
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeLocalOnly
+	backend.scriptExecutor = scriptExecutor
+	backend.scriptBatchComputationLimit = 1000
+
+	s.Run("happy path", func() {
+		scriptExecutor.On("ExecuteBatchAtBlockHeight", mock.Anything, batch, s.block.Header.Height, uint64(1000)).
+			Return([]execution.ScriptResult{
+				{Value: expectedResponse, ComputationUsed: 10},
+				{Err: cadenceErr},
+			}, nil).Once()
+		s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+		results, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+
+		s.Assert().Equal(expectedResponse, results[0].Value)
+		s.Assert().Equal(uint64(10), results[0].ComputationUsed)
+		s.Assert().NoError(results[0].Error)
+
+		s.Assert().Nil(results[1].Value)
+		s.Assert().Equal(codes.InvalidArgument, status.Code(results[1].Error))
+	})
+
+	s.Run("computation limit reached", func() {
+		scriptExecutor.On("ExecuteBatchAtBlockHeight", mock.Anything, batch, s.block.Header.Height, uint64(1000)).
+			Return([]execution.ScriptResult{
+				{Value: expectedResponse, ComputationUsed: 1000},
+				{Err: fmt.Errorf("%w (%d)", execution.ErrBatchComputationLimitExceeded, 1000)},
+			}, nil).Once()
+		s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+		results, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.Assert().NoError(results[0].Error)
+		s.Assert().Equal(codes.ResourceExhausted, status.Code(results[1].Error))
+	})
+
+	s.Run("block not indexed", func() {
+		scriptExecutor.On("ExecuteBatchAtBlockHeight", mock.Anything, batch, s.block.Header.Height, uint64(1000)).
+			Return(nil, storage.ErrHeightNotIndexed).Once()
+		s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+		results, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+		s.Require().Error(err)
+		s.Assert().Equal(codes.OutOfRange, status.Code(err))
+		s.Assert().Nil(results)
+	})
+}
+
+// TestExecuteScriptsOnExecutionNode tests that the backend executes batches of scripts on execution
+// nodes, returning failures of individual scripts in their result
+func (s *BackendScriptsSuite) TestExecuteScriptsOnExecutionNode() {
+	ctx := context.Background()
+	scripts, _ := s.batchScripts()
+
+	s.setupExecutionNodes(s.block)
+	s.setupENSuccessResponse(s.block.ID())
+	s.setupENFailingResponse(s.block.ID(), status.Error(codes.InvalidArgument, "cadence error"))
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+	results, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+
+	s.Assert().Equal(expectedResponse, results[0].Value)
+	s.Assert().NoError(results[0].Error)
+
+	s.Assert().Nil(results[1].Value)
+	s.Assert().Equal(codes.InvalidArgument, status.Code(results[1].Error))
+}
+
+// TestExecuteScriptsWithFailover tests that scripts of a batch which failed to execute locally for
+// reasons other than user errors are executed on execution nodes
+func (s *BackendScriptsSuite) TestExecuteScriptsWithFailover() {
+	ctx := context.Background()
+	scripts, batch := s.batchScripts()
+
+	s.setupExecutionNodes(s.block)
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+	// only the script failing locally with an internal error is executed on the execution nodes
+	blockID := s.block.ID()
+	s.execClient.On("ExecuteScriptAtBlockID", mock.Anything, &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    s.failingScript,
+		Arguments: s.arguments,
+	}).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: expectedResponse}, nil).Once()
+
+	scriptExecutor := execmock.NewScriptExecutor(s.T())
+	scriptExecutor.On("ExecuteBatchAtBlockHeight", mock.Anything, batch, s.block.Header.Height, uint64(0)).
+		Return([]execution.ScriptResult{
+			{Value: expectedResponse},
+			{Err: fvmFailureErr},
+		}, nil).Once()
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeFailover
+	backend.scriptExecutor = scriptExecutor
+
+	results, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+	for _, result := range results {
+		s.Assert().NoError(result.Error)
+		s.Assert().Equal(expectedResponse, result.Value)
+	}
+}
+
+// TestExecuteScripts_InvalidBatch tests that empty and oversized batches are rejected
+func (s *BackendScriptsSuite) TestExecuteScripts_InvalidBatch() {
+	ctx := context.Background()
+	scripts, _ := s.batchScripts()
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeLocalOnly
+	backend.scriptBatchMaxSize = 1
+
+	_, err := backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), nil)
+	s.Assert().Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = backend.ExecuteScriptsAtBlockID(ctx, s.block.ID(), scripts)
+	s.Assert().Equal(codes.InvalidArgument, status.Code(err))
+}
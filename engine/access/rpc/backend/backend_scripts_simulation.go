@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// SimulateTransaction executes the transaction against the execution state of the latest sealed block
+// without committing any of its changes, returning its events, error, resource usage and fees.
+// Signature and sequence number checks are skipped if skipSignatureVerification is set.
+//
+// Transactions can only be simulated using the local execution state, since execution nodes do not
+// expose an API to simulate transactions.
+func (b *backendScripts) SimulateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	skipSignatureVerification bool,
+	requiredEventEncodingVersion entities.EventEncodingVersion,
+) (*access.TransactionSimulationResult, error) {
+	if b.scriptExecMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.Unimplemented, "transaction simulation requires script execution using the local execution state")
+	}
+
+	header, err := b.state.Sealed().Head()
+	if err != nil {
+		// the latest sealed header MUST be available
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return nil, err
+	}
+
+	simulation, err := b.scriptExecutor.SimulateTransactionAtBlockHeight(ctx, tx, header.Height, skipSignatureVerification)
+	if err != nil {
+		b.log.Error().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Hex("tx_id", logging.Entity(tx)).
+			Msg("failed to simulate transaction")
+		return nil, rpc.ConvertIndexError(err, header.Height, "failed to simulate transaction")
+	}
+
+	events := []flow.Event(simulation.Events)
+	if requiredEventEncodingVersion == entities.EventEncodingVersion_JSON_CDC_V0 {
+		events, err = convert.CcfEventsToJsonEvents(events)
+		if err != nil {
+			return nil, rpc.ConvertError(err, "failed to convert event payloads", codes.Internal)
+		}
+	}
+
+	result := &access.TransactionSimulationResult{
+		BlockID:         header.ID(),
+		BlockHeight:     header.Height,
+		Events:          events,
+		ComputationUsed: simulation.ComputationUsed,
+		MemoryEstimate:  simulation.MemoryEstimate,
+		StorageDelta:    simulation.StorageDelta,
+		Fees: access.TransactionFees{
+			Amount:          simulation.Fees.Amount,
+			InclusionEffort: simulation.Fees.InclusionEffort,
+			ExecutionEffort: simulation.Fees.ExecutionEffort,
+		},
+	}
+	if simulation.Err != nil {
+		result.ErrorMessage = simulation.Err.Error()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	execmock "github.com/onflow/flow-go/module/execution/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSimulateTransaction tests that the backend simulates transactions using the local execution
+// state of the latest sealed block
+func (s *BackendScriptsSuite) TestSimulateTransaction() {
+	ctx := context.Background()
+	tx := unittest.TransactionBodyFixture()
+	address := unittest.RandomAddressFixture()
+
+	scriptExecutor := execmock.NewScriptExecutor(s.T())
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeFailover
+	backend.scriptExecutor = scriptExecutor
+
+	s.Run("happy path", func() {
+		s.state.On("Sealed").Return(s.snapshot, nil).Once()
+		s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+
+		scriptExecutor.On("SimulateTransactionAtBlockHeight", mock.Anything, &tx, s.block.Header.Height, true).
+			Return(&execution.TransactionSimulation{
+				Events:          flow.EventsList{unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID(), 0)},
+				ComputationUsed: 10,
+				MemoryEstimate:  100,
+				StorageDelta:    map[flow.Address]int64{address: 8},
+				Fees:            execution.TransactionFees{Amount: 3, InclusionEffort: 1, ExecutionEffort: 2},
+			}, nil).Once()
+
+		result, err := backend.SimulateTransaction(ctx, &tx, true, entities.EventEncodingVersion_CCF_V0)
+		s.Require().NoError(err)
+
+		s.Assert().Equal(s.block.ID(), result.BlockID)
+		s.Assert().Equal(s.block.Header.Height, result.BlockHeight)
+		s.Assert().Len(result.Events, 1)
+		s.Assert().Empty(result.ErrorMessage)
+		s.Assert().Equal(uint64(10), result.ComputationUsed)
+		s.Assert().Equal(uint64(100), result.MemoryEstimate)
+		s.Assert().Equal(map[flow.Address]int64{address: 8}, result.StorageDelta)
+		s.Assert().Equal(uint64(3), result.Fees.Amount)
+		s.Assert().Equal(uint64(1), result.Fees.InclusionEffort)
+		s.Assert().Equal(uint64(2), result.Fees.ExecutionEffort)
+	})
+
+	s.Run("failed transaction", func() {
+		s.state.On("Sealed").Return(s.snapshot, nil).Once()
+		s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+
+		txErr := errors.NewInvalidProposalSignatureError(tx.ProposalKey, errors.NewValueErrorf("sig", "invalid signature"))
+		scriptExecutor.On("SimulateTransactionAtBlockHeight", mock.Anything, &tx, s.block.Header.Height, false).
+			Return(&execution.TransactionSimulation{Err: txErr}, nil).Once()
+
+		result, err := backend.SimulateTransaction(ctx, &tx, false, entities.EventEncodingVersion_CCF_V0)
+		s.Require().NoError(err)
+		s.Assert().Equal(txErr.Error(), result.ErrorMessage)
+	})
+
+	s.Run("block not indexed", func() {
+		s.state.On("Sealed").Return(s.snapshot, nil).Once()
+		s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+
+		scriptExecutor.On("SimulateTransactionAtBlockHeight", mock.Anything, &tx, s.block.Header.Height, false).
+			Return(nil, storage.ErrHeightNotIndexed).Once()
+
+		result, err := backend.SimulateTransaction(ctx, &tx, false, entities.EventEncodingVersion_CCF_V0)
+		s.Require().Error(err)
+		s.Assert().Equal(codes.OutOfRange, status.Code(err))
+		s.Assert().Nil(result)
+	})
+
+	s.Run("execution nodes only", func() {
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		result, err := backend.SimulateTransaction(ctx, &tx, false, entities.EventEncodingVersion_CCF_V0)
+		s.Require().Error(err)
+		s.Assert().Equal(codes.Unimplemented, status.Code(err))
+		s.Assert().Nil(result)
+	})
+}
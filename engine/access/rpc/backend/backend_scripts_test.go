@@ -16,6 +16,7 @@ import (
 
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 
+	accessapi "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
 	fvmerrors "github.com/onflow/flow-go/fvm/errors"
@@ -228,6 +229,55 @@ func (s *BackendScriptsSuite) TestExecuteScriptFromStorage_HappyPath() {
 	})
 }
 
+// TestExecuteScriptFromStorage_ResultCache tests that results of scripts executed using the local
+// storage are served from the script result cache, unless the client requested to bypass the cache
+func (s *BackendScriptsSuite) TestExecuteScriptFromStorage_ResultCache() {
+	ctx := context.Background()
+
+	scriptExecutor := execmock.NewScriptExecutor(s.T())
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeLocalOnly
+	backend.scriptExecutor = scriptExecutor
+	backend.scriptResults = newScriptResultCache(10, time.Minute, backend.metrics)
+
+	s.Run("identical executions are served from the cache", func() {
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, s.block.Header.Height).
+			Return(expectedResponse, nil).Once()
+
+		// the script is executed once, all other requests for the same block are served from the cache
+		s.testExecuteScriptAtLatestBlock(ctx, backend, codes.OK)
+		s.testExecuteScriptAtBlockID(ctx, backend, codes.OK)
+		s.testExecuteScriptAtBlockHeight(ctx, backend, codes.OK)
+	})
+
+	s.Run("different arguments are executed", func() {
+		arguments := [][]byte{[]byte("arg1"), []byte("arg3")}
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, arguments, s.block.Header.Height).
+			Return([]byte("other_response"), nil).Once()
+		s.headers.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil).Once()
+
+		actual, err := backend.ExecuteScriptAtBlockID(ctx, s.block.ID(), s.script, arguments)
+		s.Require().NoError(err)
+		s.Require().Equal([]byte("other_response"), actual)
+	})
+
+	s.Run("bypassing the cache executes the script", func() {
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, s.block.Header.Height).
+			Return(expectedResponse, nil).Once()
+
+		s.testExecuteScriptAtBlockID(accessapi.WithScriptCacheBypass(ctx), backend, codes.OK)
+	})
+
+	s.Run("failed executions are not cached", func() {
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.failingScript, s.arguments, s.block.Header.Height).
+			Return(nil, cadenceErr).Twice()
+
+		s.testExecuteScriptAtBlockID(ctx, backend, codes.InvalidArgument)
+		s.testExecuteScriptAtBlockID(ctx, backend, codes.InvalidArgument)
+	})
+}
+
 // TestExecuteScriptFromStorage_Fails tests that errors received from local storage are handled
 // and converted to the appropriate status code
 func (s *BackendScriptsSuite) TestExecuteScriptFromStorage_Fails() {
@@ -21,11 +21,19 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 )
 
+// TransactionResubmissions provides the number of times pending transactions were resubmitted by the node.
+type TransactionResubmissions interface {
+	// Resubmissions returns the number of times the transaction was resubmitted, or 0 if the transaction
+	// is not pending.
+	Resubmissions(txID flow.Identifier) uint
+}
+
 // backendSubscribeTransactions handles transaction subscriptions.
 type backendSubscribeTransactions struct {
 	txLocalDataProvider *TransactionsLocalDataProvider
 	backendTransactions *backendTransactions
 	executionResults    storage.ExecutionResults
+	txResubmissions     TransactionResubmissions // nil if the node does not resubmit transactions
 	log                 zerolog.Logger
 
 	subscriptionHandler *subscription.SubscriptionHandler
@@ -39,6 +47,7 @@ type TransactionSubscriptionMetadata struct {
 	blockWithTx          *flow.Header
 	txExecuted           bool
 	eventEncodingVersion entities.EventEncodingVersion
+	resubmissions        uint
 }
 
 // SubscribeTransactionStatuses subscribes to transaction status changes starting from the transaction reference block ID.
@@ -136,8 +145,12 @@ func (b *backendSubscribeTransactions) getTransactionStatusResponse(txInfo *Tran
 		}
 
 		// If the old and new transaction statuses are still the same, the status change should not be reported, so
-		// return here with no response.
+		// return here with no response. Resubmissions of pending transactions are reported by repeating the pending status.
 		if prevTxStatus == txInfo.Status {
+			if b.isResubmitted(txInfo) {
+				pendingTxResult := *txInfo.TransactionResult
+				return []*access.TransactionResult{&pendingTxResult}, nil
+			}
 			return nil, nil
 		}
 
@@ -145,6 +158,20 @@ func (b *backendSubscribeTransactions) getTransactionStatusResponse(txInfo *Tran
 	}
 }
 
+// isResubmitted returns true if the pending transaction was resubmitted since the last reported result.
+func (b *backendSubscribeTransactions) isResubmitted(txInfo *TransactionSubscriptionMetadata) bool {
+	if b.txResubmissions == nil || txInfo.Status != flow.TransactionStatusPending {
+		return false
+	}
+
+	resubmissions := b.txResubmissions.Resubmissions(txInfo.TransactionID)
+	if resubmissions <= txInfo.resubmissions {
+		return false
+	}
+	txInfo.resubmissions = resubmissions
+	return true
+}
+
 // generateResultsWithMissingStatuses checks if the current result differs from the previous result by more than one step.
 // If yes, it generates results for the missing transaction statuses. This is done because the subscription should send
 // responses for each of the statuses in the transaction lifecycle, and the message should be sent in the order of transaction statuses.
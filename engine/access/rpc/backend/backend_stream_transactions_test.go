@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -415,3 +416,66 @@ func (s *TransactionStatusSuite) TestSubscribeTransactionStatusExpired() {
 		assert.NoError(s.T(), sub.Err())
 	}, 100*time.Millisecond, "timed out waiting for subscription to shutdown")
 }
+
+// resubmissionsCounter is a TransactionResubmissions reporting the same number of resubmissions for all transactions
+type resubmissionsCounter struct {
+	count atomic.Uint32
+}
+
+func (r *resubmissionsCounter) Resubmissions(flow.Identifier) uint {
+	return uint(r.count.Load())
+}
+
+// TestSubscribeTransactionStatusResubmitted tests that the resubmissions of a pending transaction are reported by
+// repeating the pending status
+func (s *TransactionStatusSuite) TestSubscribeTransactionStatusResubmitted() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resubmissions := &resubmissionsCounter{}
+	params := s.backendParams()
+	params.TxResubmissions = resubmissions
+	backend, err := New(params)
+	s.Require().NoError(err)
+
+	// Generate sent transaction with ref block of the current finalized block
+	transaction := unittest.TransactionFixture()
+	transaction.SetReferenceBlockID(s.finalizedBlock.ID())
+	txId := transaction.ID()
+
+	checkNewSubscriptionMessage := func(sub subscription.Subscription, expectedTxStatus flow.TransactionStatus) {
+		unittest.RequireReturnsBefore(s.T(), func() {
+			v, ok := <-sub.Channel()
+			require.True(s.T(), ok, "channel closed while waiting for transaction info: %v", sub.Err())
+
+			txResults, ok := v.([]*accessapi.TransactionResult)
+			require.True(s.T(), ok, "unexpected response type: %T", v)
+			require.Len(s.T(), txResults, 1)
+
+			result := txResults[0]
+			assert.Equal(s.T(), txId, result.TransactionID)
+			assert.Equal(s.T(), expectedTxStatus, result.Status)
+		}, time.Second, fmt.Sprintf("timed out waiting for transaction info:\n\t- txID: %x", txId))
+	}
+
+	// Subscribe to transaction status and receive the first message with pending status
+	sub := backend.SubscribeTransactionStatuses(ctx, &transaction.TransactionBody, entities.EventEncodingVersion_CCF_V0)
+	checkNewSubscriptionMessage(sub, flow.TransactionStatusPending)
+
+	// New blocks without the transaction are not reported as long as the transaction is not resubmitted
+	s.sealedBlock = s.finalizedBlock
+	s.addNewFinalizedBlock(s.sealedBlock.Header, true)
+	select {
+	case v := <-sub.Channel():
+		s.Failf("unexpected transaction info", "received %v", v)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Each resubmission is reported with the pending status
+	for i := 0; i < 2; i++ {
+		resubmissions.count.Add(1)
+		s.sealedBlock = s.finalizedBlock
+		s.addNewFinalizedBlock(s.sealedBlock.Header, true)
+		checkNewSubscriptionMessage(sub, flow.TransactionStatusPending)
+	}
+}
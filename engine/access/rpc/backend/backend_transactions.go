@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -45,6 +46,8 @@ type backendTransactions struct {
 
 	systemTxID flow.Identifier
 	systemTx   *flow.TransactionBody
+
+	prunedData *prunedDataChecker
 }
 
 var _ TransactionErrorMessage = (*backendTransactions)(nil)
@@ -204,7 +207,7 @@ func (b *backendTransactions) GetTransactionsByBlockID(
 	// TODO: consider using storage.Index.ByBlockID, the index contains collection id and seals ID
 	block, err := b.blocks.ByID(blockID)
 	if err != nil {
-		return nil, rpc.ConvertStorageError(err)
+		return nil, b.prunedData.convertBlockIDLookupError(blockID, err)
 	}
 
 	for _, guarantee := range block.Payload.Guarantees {
@@ -374,7 +377,7 @@ func (b *backendTransactions) GetTransactionResultsByBlockID(
 	// TODO: consider using storage.Index.ByBlockID, the index contains collection id and seals ID
 	block, err := b.blocks.ByID(blockID)
 	if err != nil {
-		return nil, rpc.ConvertStorageError(err)
+		return nil, b.prunedData.convertBlockIDLookupError(blockID, err)
 	}
 
 	switch b.txResultQueryMode {
@@ -525,7 +528,7 @@ func (b *backendTransactions) GetTransactionResultByIndex(
 	// TODO: https://github.com/onflow/flow-go/issues/2175 so caching doesn't cause a circular dependency
 	block, err := b.blocks.ByID(blockID)
 	if err != nil {
-		return nil, rpc.ConvertStorageError(err)
+		return nil, b.prunedData.convertBlockIDLookupError(blockID, err)
 	}
 
 	switch b.txResultQueryMode {
@@ -606,7 +609,7 @@ func (b *backendTransactions) GetSystemTransaction(ctx context.Context, _ flow.I
 func (b *backendTransactions) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) (*access.TransactionResult, error) {
 	block, err := b.blocks.ByID(blockID)
 	if err != nil {
-		return nil, rpc.ConvertStorageError(err)
+		return nil, b.prunedData.convertBlockIDLookupError(blockID, err)
 	}
 
 	return b.lookupTransactionResult(ctx, b.systemTxID, block, requiredEventEncodingVersion)
@@ -804,21 +807,30 @@ func (b *backendTransactions) getTransactionResultFromAnyExeNode(
 		}
 	}()
 
+	// calls to several nodes may complete concurrently if the request is hedged, in which case only
+	// the first successful response is kept
+	var mu sync.Mutex
 	var resp *execproto.GetTransactionResultResponse
-	errToReturn = b.nodeCommunicator.CallAvailableNode(
+	errToReturn = b.nodeCommunicator.CallAvailableNodeHedged(
+		ctx,
 		execNodes,
-		func(node *flow.IdentitySkeleton) error {
-			var err error
-			resp, err = b.tryGetTransactionResult(ctx, node, req)
-			if err == nil {
+		func(ctx context.Context, node *flow.IdentitySkeleton) error {
+			nodeResp, err := b.tryGetTransactionResult(ctx, node, req)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if resp == nil {
+				resp = nodeResp
 				b.log.Debug().
 					Str("execution_node", node.String()).
 					Hex("block_id", req.GetBlockId()).
 					Hex("transaction_id", req.GetTransactionId()).
 					Msg("Successfully got transaction results from any node")
-				return nil
 			}
-			return err
+			return nil
 		},
 		nil,
 	)
@@ -19,6 +19,19 @@ type Config struct {
 	ScriptExecutionMode       string                          // the mode in which scripts are executed
 	EventQueryMode            string                          // the mode in which events are queried
 	TxResultQueryMode         string                          // the mode in which tx results are queried
+
+	EventTypeIndexMaxHeightRange   uint   // max size of event height range requests served using the event type index
+	EventTypeIndexMaxEvents        uint   // max number of events returned by event height range requests served using the event type index
+	AccountTransactionsMaxPageSize uint32 // max number of transactions in a page of the transaction history of an account
+
+	ScriptResultCacheSize uint          // max number of locally executed script results to cache, 0 disables the cache
+	ScriptResultCacheTTL  time.Duration // duration locally executed script results are cached for
+
+	ScriptBatchMaxSize          uint   // max number of scripts in a batch
+	ScriptBatchComputationLimit uint64 // max computation used by all scripts of a batch
+
+	NodeSelectionConfig  NodeScoreboardConfig // the configuration for the latency-aware selection of upstream nodes
+	RequestHedgingConfig RequestHedgingConfig // the configuration for hedging read-only requests to execution nodes
 }
 
 type IndexQueryMode int
@@ -1,6 +1,9 @@
 package backend
 
 import (
+	"context"
+	"time"
+
 	"github.com/hashicorp/go-multierror"
 	"github.com/sony/gobreaker"
 	"google.golang.org/grpc/codes"
@@ -23,6 +26,17 @@ type Communicator interface {
 		// It takes an error as input and returns a boolean value indicating whether the error should be considered terminal.
 		shouldTerminateOnError func(node *flow.IdentitySkeleton, err error) bool,
 	) error
+
+	// CallAvailableNodeHedged is like CallAvailableNode, but may call a second node concurrently if the call
+	// to the first node is slow. It must only be used for read-only requests.
+	CallAvailableNodeHedged(
+		ctx context.Context,
+		nodes flow.IdentitySkeletonList,
+		// Callback function that represents an action to be performed on a node, which may be invoked concurrently.
+		// It takes the context of the call and a node as input and returns an error indicating the result of the action.
+		call func(ctx context.Context, node *flow.IdentitySkeleton) error,
+		shouldTerminateOnError func(node *flow.IdentitySkeleton, err error) bool,
+	) error
 }
 
 var _ Communicator = (*NodeCommunicator)(nil)
@@ -30,6 +44,7 @@ var _ Communicator = (*NodeCommunicator)(nil)
 // NodeCommunicator is responsible for calling available nodes in the backend.
 type NodeCommunicator struct {
 	nodeSelectorFactory NodeSelectorFactory
+	hedging             *requestHedging // nil if request hedging is disabled
 }
 
 // NewNodeCommunicator creates a new instance of NodeCommunicator.
@@ -39,6 +54,17 @@ func NewNodeCommunicator(circuitBreakerEnabled bool) *NodeCommunicator {
 	}
 }
 
+// NewAdaptiveNodeCommunicator creates a new instance of NodeCommunicator which records the outcome of
+// requests in the provided scoreboard, and uses it to prefer healthy nodes with low latency.
+func NewAdaptiveNodeCommunicator(circuitBreakerEnabled bool, scoreboard *NodeScoreboard) *NodeCommunicator {
+	return &NodeCommunicator{
+		nodeSelectorFactory: NodeSelectorFactory{
+			circuitBreakerEnabled: circuitBreakerEnabled,
+			scoreboard:            scoreboard,
+		},
+	}
+}
+
 // CallAvailableNode calls the provided function on the available nodes.
 // It iterates through the nodes and executes the function.
 // If an error occurs, it applies the custom error terminator (if provided) and keeps track of the errors.
@@ -61,14 +87,19 @@ func (b *NodeCommunicator) CallAvailableNode(
 	}
 
 	for node := nodeSelector.Next(); node != nil; node = nodeSelector.Next() {
+		start := time.Now()
 		err := call(node)
 		if err == nil {
+			b.observe(node, time.Since(start), nil)
 			return nil
 		}
 
 		if shouldTerminateOnError != nil && shouldTerminateOnError(node, err) {
+			// terminal errors are caused by the request rather than the node, so the node is considered healthy
+			b.observe(node, time.Since(start), nil)
 			return err
 		}
+		b.observe(node, time.Since(start), err)
 
 		if err == gobreaker.ErrOpenState {
 			if !nodeSelector.HasNext() && errs == nil {
@@ -85,3 +116,12 @@ func (b *NodeCommunicator) CallAvailableNode(
 
 	return errs.ErrorOrNil()
 }
+
+// observe records the outcome of a request to the node in the scoreboard, if one is configured.
+// Requests canceled by the client say nothing about the health of the node and are not recorded.
+func (b *NodeCommunicator) observe(node *flow.IdentitySkeleton, duration time.Duration, err error) {
+	if b.nodeSelectorFactory.scoreboard == nil || status.Code(err) == codes.Canceled {
+		return
+	}
+	b.nodeSelectorFactory.scoreboard.Observe(node.NodeID, duration, err)
+}
@@ -0,0 +1,241 @@
+package backend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/utils/rand"
+)
+
+const (
+	// DefaultNodeLatencyDecay is the default weight of the latest request when updating the moving
+	// average of the latency and error rate of a node.
+	DefaultNodeLatencyDecay = 0.2
+
+	// DefaultNodeErrorRecoveryPeriod is the default duration after which the error rate of a node which
+	// received no requests is halved, allowing nodes which failed in the past to be selected again.
+	DefaultNodeErrorRecoveryPeriod = time.Minute
+
+	// nodeErrorPenalty is the factor by which the latency of a node is increased for each failed request
+	// in its error rate, e.g. a node failing half of its requests has a score 5 times its latency.
+	nodeErrorPenalty = 10
+)
+
+// NodeScoreboardConfig is the configuration of the latency-aware selection of upstream nodes.
+type NodeScoreboardConfig struct {
+	// Enabled specifies whether upstream nodes are selected based on their latency and error rate.
+	Enabled bool
+	// LatencyDecay is the weight of the latest request when updating the moving averages of a node,
+	// in the range (0, 1].
+	LatencyDecay float64
+	// ErrorRecoveryPeriod is the duration after which the error rate of a node which received no
+	// requests is halved.
+	ErrorRecoveryPeriod time.Duration
+	// CircuitBreakerRestoreTimeout is the duration a node is not preferred for after its circuit
+	// breaker opened. It should match the restore timeout of the circuit breaker.
+	CircuitBreakerRestoreTimeout time.Duration
+}
+
+// NodeScore is the health of an upstream node as tracked by the NodeScoreboard.
+type NodeScore struct {
+	NodeID flow.Identifier
+	// Latency is the exponentially weighted moving average of the latency of successful requests.
+	Latency time.Duration
+	// ErrorRate is the exponentially weighted moving average of the ratio of failed requests.
+	ErrorRate float64
+	// CircuitBreakerOpen is true if requests to the node were rejected by its open circuit breaker.
+	CircuitBreakerOpen bool
+	// Requests is the total number of requests sent to the node.
+	Requests uint64
+	// Failures is the total number of requests to the node which failed.
+	Failures uint64
+	// Score is the score used to select the node, lower is better.
+	Score float64
+}
+
+// nodeStats are the statistics tracked for an upstream node.
+type nodeStats struct {
+	latency          float64 // moving average of the latency in nanoseconds
+	errorRate        float64
+	requests         uint64
+	failures         uint64
+	lastUpdated      time.Time
+	breakerOpenUntil time.Time
+}
+
+// NodeScoreboard tracks the latency, error rate and circuit breaker state of upstream nodes, and
+// orders nodes so that healthy, low-latency nodes are preferred.
+//
+// Nodes are ordered using the power of two choices: two random nodes of the remaining nodes are
+// compared, and the one with the lower score is picked next. This prefers healthy nodes while still
+// spreading load, and sending some requests to slower nodes keeps their statistics up to date.
+//
+// NodeScoreboard is safe for concurrent use.
+type NodeScoreboard struct {
+	mu      sync.Mutex
+	config  NodeScoreboardConfig
+	metrics module.NodeSelectionMetrics
+	nodes   map[flow.Identifier]*nodeStats
+
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewNodeScoreboard creates a new scoreboard tracking the health of upstream nodes.
+func NewNodeScoreboard(config NodeScoreboardConfig, metrics module.NodeSelectionMetrics) *NodeScoreboard {
+	if config.LatencyDecay <= 0 || config.LatencyDecay > 1 {
+		config.LatencyDecay = DefaultNodeLatencyDecay
+	}
+	if config.ErrorRecoveryPeriod <= 0 {
+		config.ErrorRecoveryPeriod = DefaultNodeErrorRecoveryPeriod
+	}
+
+	return &NodeScoreboard{
+		config:  config,
+		metrics: metrics,
+		nodes:   make(map[flow.Identifier]*nodeStats),
+		now:     time.Now,
+	}
+}
+
+// Observe records the outcome of a request sent to the node. Requests rejected by the open circuit
+// breaker of the node mark the node as unavailable until the circuit breaker restores.
+func (s *NodeScoreboard) Observe(nodeID flow.Identifier, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	stats, ok := s.nodes[nodeID]
+	if !ok {
+		stats = &nodeStats{latency: float64(duration)}
+		s.nodes[nodeID] = stats
+	}
+
+	if err == gobreaker.ErrOpenState {
+		stats.breakerOpenUntil = now.Add(s.config.CircuitBreakerRestoreTimeout)
+		s.metrics.NodeCircuitBreakerOpen(nodeID)
+		return
+	}
+
+	alpha := s.config.LatencyDecay
+	stats.errorRate = s.decayedErrorRate(stats, now)
+	stats.requests++
+	stats.lastUpdated = now
+
+	if err != nil {
+		stats.failures++
+		stats.errorRate = alpha + (1-alpha)*stats.errorRate
+	} else {
+		stats.errorRate = (1 - alpha) * stats.errorRate
+		stats.latency = alpha*float64(duration) + (1-alpha)*stats.latency
+		stats.breakerOpenUntil = time.Time{}
+	}
+
+	s.metrics.NodeStatsUpdated(nodeID, time.Duration(stats.latency), stats.errorRate)
+}
+
+// Order returns the provided nodes in the order they should be tried, preferring healthy nodes with
+// low latency.
+//
+// No errors are expected during normal operation.
+func (s *NodeScoreboard) Order(nodes flow.IdentitySkeletonList) (flow.IdentitySkeletonList, error) {
+	s.mu.Lock()
+	now := s.now()
+	scores := make([]float64, len(nodes))
+	for i, node := range nodes {
+		scores[i] = s.score(s.nodes[node.NodeID], now)
+	}
+	s.mu.Unlock()
+
+	remaining := make([]int, len(nodes))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	ordered := make(flow.IdentitySkeletonList, 0, len(nodes))
+	for len(remaining) > 0 {
+		pick := 0
+		if len(remaining) > 1 {
+			first, err := rand.Uintn(uint(len(remaining)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to pick node: %w", err)
+			}
+			second, err := rand.Uintn(uint(len(remaining) - 1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to pick node: %w", err)
+			}
+			// make sure the two choices are distinct
+			if second >= first {
+				second++
+			}
+
+			pick = int(first)
+			if scores[remaining[second]] < scores[remaining[first]] {
+				pick = int(second)
+			}
+		}
+
+		ordered = append(ordered, nodes[remaining[pick]])
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+
+	return ordered, nil
+}
+
+// Scores returns the current scores of all tracked nodes, best first.
+func (s *NodeScoreboard) Scores() []NodeScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	scores := make([]NodeScore, 0, len(s.nodes))
+	for nodeID, stats := range s.nodes {
+		scores = append(scores, NodeScore{
+			NodeID:             nodeID,
+			Latency:            time.Duration(stats.latency),
+			ErrorRate:          s.decayedErrorRate(stats, now),
+			CircuitBreakerOpen: now.Before(stats.breakerOpenUntil),
+			Requests:           stats.requests,
+			Failures:           stats.failures,
+			Score:              s.score(stats, now),
+		})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score < scores[j].Score
+	})
+
+	return scores
+}
+
+// score returns the score of a node, lower is better. Nodes without statistics have the best score,
+// so they are tried and their statistics are collected, and nodes with an open circuit breaker have
+// the worst score.
+// Must be called with the lock held.
+func (s *NodeScoreboard) score(stats *nodeStats, now time.Time) float64 {
+	if stats == nil {
+		return 0
+	}
+	if now.Before(stats.breakerOpenUntil) {
+		return math.Inf(1)
+	}
+	return stats.latency * (1 + nodeErrorPenalty*s.decayedErrorRate(stats, now))
+}
+
+// decayedErrorRate returns the error rate of the node, halved for each recovery period elapsed since
+// the node last received a request.
+// Must be called with the lock held.
+func (s *NodeScoreboard) decayedErrorRate(stats *nodeStats, now time.Time) float64 {
+	if stats.lastUpdated.IsZero() {
+		return stats.errorRate
+	}
+	elapsed := now.Sub(stats.lastUpdated)
+	return stats.errorRate * math.Exp2(-float64(elapsed)/float64(s.config.ErrorRecoveryPeriod))
+}
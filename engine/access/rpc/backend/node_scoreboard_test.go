@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func newTestNodeScoreboard(now *time.Time) *NodeScoreboard {
+	scoreboard := NewNodeScoreboard(NodeScoreboardConfig{
+		Enabled:                      true,
+		LatencyDecay:                 0.5,
+		ErrorRecoveryPeriod:          time.Minute,
+		CircuitBreakerRestoreTimeout: 10 * time.Second,
+	}, metrics.NewNoopCollector())
+	scoreboard.now = func() time.Time { return *now }
+	return scoreboard
+}
+
+// TestNodeScoreboard_Observe tests that the scoreboard tracks the moving averages of the latency and
+// error rate of nodes
+func TestNodeScoreboard_Observe(t *testing.T) {
+	now := time.Now()
+	scoreboard := newTestNodeScoreboard(&now)
+	nodeID := unittest.IdentifierFixture()
+
+	scoreboard.Observe(nodeID, 100*time.Millisecond, nil)
+	scoreboard.Observe(nodeID, 300*time.Millisecond, nil)
+	scoreboard.Observe(nodeID, time.Second, fmt.Errorf("unavailable"))
+
+	scores := scoreboard.Scores()
+	require.Len(t, scores, 1)
+	assert.Equal(t, nodeID, scores[0].NodeID)
+	// failed requests do not update the latency
+	assert.Equal(t, 200*time.Millisecond, scores[0].Latency)
+	assert.Equal(t, 0.5, scores[0].ErrorRate)
+	assert.Equal(t, uint64(3), scores[0].Requests)
+	assert.Equal(t, uint64(1), scores[0].Failures)
+	assert.False(t, scores[0].CircuitBreakerOpen)
+
+	// the error rate of nodes without requests recovers over time
+	now = now.Add(time.Minute)
+	scores = scoreboard.Scores()
+	assert.Equal(t, 0.25, scores[0].ErrorRate)
+}
+
+// TestNodeScoreboard_CircuitBreaker tests that nodes with an open circuit breaker are tried last until
+// the circuit breaker restores
+func TestNodeScoreboard_CircuitBreaker(t *testing.T) {
+	now := time.Now()
+	scoreboard := newTestNodeScoreboard(&now)
+	nodes := unittest.IdentityListFixture(2).ToSkeleton()
+
+	scoreboard.Observe(nodes[0].NodeID, time.Millisecond, nil)
+	scoreboard.Observe(nodes[1].NodeID, time.Second, nil)
+	scoreboard.Observe(nodes[0].NodeID, 0, gobreaker.ErrOpenState)
+
+	scores := scoreboard.Scores()
+	require.Len(t, scores, 2)
+	assert.Equal(t, nodes[1].NodeID, scores[0].NodeID)
+	assert.True(t, scores[1].CircuitBreakerOpen)
+
+	for i := 0; i < 10; i++ {
+		ordered, err := scoreboard.Order(nodes)
+		require.NoError(t, err)
+		assert.Equal(t, flow.IdentitySkeletonList{nodes[1], nodes[0]}, ordered)
+	}
+
+	now = now.Add(10 * time.Second)
+	for i := 0; i < 10; i++ {
+		ordered, err := scoreboard.Order(nodes)
+		require.NoError(t, err)
+		assert.Equal(t, flow.IdentitySkeletonList{nodes[0], nodes[1]}, ordered)
+	}
+}
+
+// TestNodeScoreboard_Order tests that nodes are ordered using the power of two choices, preferring
+// nodes with lower latency and error rate
+func TestNodeScoreboard_Order(t *testing.T) {
+	now := time.Now()
+	scoreboard := newTestNodeScoreboard(&now)
+	nodes := unittest.IdentityListFixture(4).ToSkeleton()
+
+	scoreboard.Observe(nodes[0].NodeID, 10*time.Millisecond, nil)
+	scoreboard.Observe(nodes[1].NodeID, 20*time.Millisecond, nil)
+	scoreboard.Observe(nodes[2].NodeID, 10*time.Millisecond, fmt.Errorf("unavailable"))
+	// nodes[3] has no statistics yet, and is preferred to collect them
+
+	firstPicks := make(map[flow.Identifier]int)
+	for i := 0; i < 1000; i++ {
+		ordered, err := scoreboard.Order(nodes)
+		require.NoError(t, err)
+		require.ElementsMatch(t, nodes, ordered)
+		firstPicks[ordered[0].NodeID]++
+	}
+
+	// with two choices, the best node is picked first whenever it is one of the choices, and the worst
+	// node is never picked first
+	assert.Greater(t, firstPicks[nodes[3].NodeID], firstPicks[nodes[0].NodeID])
+	assert.Greater(t, firstPicks[nodes[0].NodeID], firstPicks[nodes[1].NodeID])
+	assert.Zero(t, firstPicks[nodes[2].NodeID])
+}
+
+// TestNodeSelectorFactory_Scoreboard tests that the node selector limits the number of nodes selected
+// using the scoreboard if the circuit breaker is disabled
+func TestNodeSelectorFactory_Scoreboard(t *testing.T) {
+	now := time.Now()
+	nodes := unittest.IdentityListFixture(5).ToSkeleton()
+
+	factory := NodeSelectorFactory{scoreboard: newTestNodeScoreboard(&now)}
+	selector, err := factory.SelectNodes(nodes)
+	require.NoError(t, err)
+
+	selected := 0
+	for node := selector.Next(); node != nil; node = selector.Next() {
+		selected++
+	}
+	assert.Equal(t, maxNodesCnt, selected)
+
+	factory.circuitBreakerEnabled = true
+	selector, err = factory.SelectNodes(nodes)
+	require.NoError(t, err)
+
+	selected = 0
+	for node := selector.Next(); node != nil; node = selector.Next() {
+		selected++
+	}
+	assert.Equal(t, len(nodes), selected)
+}
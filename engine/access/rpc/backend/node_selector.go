@@ -22,14 +22,28 @@ type NodeSelector interface {
 // Supported configurations:
 // circuitBreakerEnabled = true - nodes will be pseudo-randomly sampled and picked in-order.
 // circuitBreakerEnabled = false - nodes will be picked from proposed list in-order without any changes.
+// scoreboard != nil - nodes will be ordered by the scoreboard, preferring healthy nodes with low latency. If the
+// circuit breaker is disabled, only the configured number of the best nodes are picked.
 type NodeSelectorFactory struct {
 	circuitBreakerEnabled bool
+	scoreboard            *NodeScoreboard
 }
 
 // SelectNodes selects the configured number of node identities from the provided list of nodes
 // and returns the node selector to iterate through them.
 func (n *NodeSelectorFactory) SelectNodes(nodes flow.IdentitySkeletonList) (NodeSelector, error) {
 	var err error
+	if n.scoreboard != nil {
+		nodes, err = n.scoreboard.Order(nodes)
+		if err != nil {
+			return nil, fmt.Errorf("ordering failed: %w", err)
+		}
+		if !n.circuitBreakerEnabled && len(nodes) > maxNodesCnt {
+			nodes = nodes[:maxNodesCnt]
+		}
+		return NewMainNodeSelector(nodes), nil
+	}
+
 	// If the circuit breaker is disabled, the legacy logic should be used, which selects only a specified number of nodes.
 	if !n.circuitBreakerEnabled {
 		nodes, err = nodes.Sample(maxNodesCnt)
@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/pruner"
+	"github.com/onflow/flow-go/storage"
+)
+
+// prunedDataChecker converts block data lookup errors into descriptive errors when the requested
+// block data was removed by the block data pruner.
+// A nil checker converts all errors using rpc.ConvertStorageError or rpc.ConvertIndexError.
+type prunedDataChecker struct {
+	headers storage.Headers
+	// reporter is nil if block data pruning is disabled
+	reporter pruner.LowestHeightReporter
+}
+
+// convertBlockIDLookupError converts an error returned while looking up block data for the given
+// block ID into a status error.
+// If the block's data has been pruned, an OutOfRange error with the lowest available height is returned.
+func (c *prunedDataChecker) convertBlockIDLookupError(blockID flow.Identifier, err error) error {
+	if c == nil || c.reporter == nil || !isMissingDataError(err) {
+		return rpc.ConvertStorageError(err)
+	}
+
+	// headers are never pruned, so the height of pruned blocks is still known
+	header, headerErr := c.headers.ByBlockID(blockID)
+	if headerErr != nil {
+		return rpc.ConvertStorageError(err)
+	}
+
+	return c.convertHeightLookupError(header.Height, err)
+}
+
+// convertHeightLookupError converts an error returned while looking up block data for the given
+// height into a status error.
+// If the block's data has been pruned, an OutOfRange error with the lowest available height is returned.
+func (c *prunedDataChecker) convertHeightLookupError(height uint64, err error) error {
+	if isMissingDataError(err) {
+		if prunedErr := c.checkHeight(height); prunedErr != nil {
+			return prunedErr
+		}
+	}
+	return rpc.ConvertStorageError(err)
+}
+
+// convertIndexLookupError converts an error returned while looking up indexed data, such as events and
+// transaction results, of the block at the given height into a status error.
+// If the block's data has been pruned, an OutOfRange error with the lowest available height is returned.
+func (c *prunedDataChecker) convertIndexLookupError(height uint64, err error, defaultMsg string) error {
+	if isMissingDataError(err) {
+		if prunedErr := c.checkHeight(height); prunedErr != nil {
+			return prunedErr
+		}
+	}
+	return rpc.ConvertIndexError(err, height, defaultMsg)
+}
+
+// checkHeight returns an OutOfRange error with the lowest available height if the block data for the
+// given height has been pruned, and nil otherwise.
+func (c *prunedDataChecker) checkHeight(height uint64) error {
+	if c == nil || c.reporter == nil {
+		return nil
+	}
+
+	lowestHeight := c.reporter.LowestAvailableHeight()
+	if height < lowestHeight {
+		return status.Errorf(codes.OutOfRange,
+			"block data for height %d has been pruned, lowest available height is %d", height, lowestHeight)
+	}
+	return nil
+}
+
+// isMissingDataError returns true if the error indicates that the requested data is not available in
+// storage, which is the case for pruned data.
+func isMissingDataError(err error) bool {
+	return errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrHeightNotIndexed)
+}
@@ -0,0 +1,228 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+const (
+	// DefaultRequestHedgingDelay is the default duration after which a request which has not completed yet
+	// is sent to a second node.
+	DefaultRequestHedgingDelay = 200 * time.Millisecond
+
+	// DefaultRequestHedgingMaxRatio is the default maximum ratio of requests which are hedged.
+	DefaultRequestHedgingMaxRatio = 0.1
+
+	// requestHedgingBudgetBurst is the maximum number of hedges which can be issued in a burst, regardless
+	// of the ratio of hedged requests.
+	requestHedgingBudgetBurst = 10
+)
+
+// RequestHedgingConfig is the configuration of hedging for read-only requests to execution nodes.
+type RequestHedgingConfig struct {
+	// Enabled specifies whether read-only requests are hedged.
+	Enabled bool
+	// Delay is the duration after which a request which has not completed yet is sent to a second node.
+	Delay time.Duration
+	// MaxRatio is the maximum ratio of requests which are hedged, in the range (0, 1]. It bounds the
+	// additional load hedging puts on upstream nodes.
+	MaxRatio float64
+}
+
+// hedgingBudget limits the ratio of hedged requests using a token bucket. Each request adds the
+// configured ratio of a token to the bucket, and each hedge takes a full token.
+//
+// hedgingBudget is safe for concurrent use.
+type hedgingBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newHedgingBudget(ratio float64) *hedgingBudget {
+	return &hedgingBudget{
+		ratio:  ratio,
+		tokens: requestHedgingBudgetBurst,
+	}
+}
+
+// deposit records a request, adding to the budget.
+func (h *hedgingBudget) deposit() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tokens += h.ratio
+	if h.tokens > requestHedgingBudgetBurst {
+		h.tokens = requestHedgingBudgetBurst
+	}
+}
+
+// withdraw takes a token from the budget for a hedge. It returns false if the budget is exhausted.
+func (h *hedgingBudget) withdraw() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+// requestHedging holds the state of request hedging of a NodeCommunicator.
+type requestHedging struct {
+	delay   time.Duration
+	budget  *hedgingBudget
+	metrics module.RequestHedgingMetrics
+}
+
+// hedgedAttempt is the outcome of a call to a node made by CallAvailableNodeHedged.
+type hedgedAttempt struct {
+	node     *flow.IdentitySkeleton
+	hedge    bool
+	duration time.Duration
+	err      error
+}
+
+// WithRequestHedging enables hedging of the requests made using CallAvailableNodeHedged, and returns the
+// NodeCommunicator. Requests which have not completed after the configured delay are sent to a second
+// node, as long as the ratio of hedged requests stays within the configured maximum.
+func (b *NodeCommunicator) WithRequestHedging(config RequestHedgingConfig, metrics module.RequestHedgingMetrics) *NodeCommunicator {
+	if config.Delay <= 0 {
+		config.Delay = DefaultRequestHedgingDelay
+	}
+	if config.MaxRatio <= 0 || config.MaxRatio > 1 {
+		config.MaxRatio = DefaultRequestHedgingMaxRatio
+	}
+
+	b.hedging = &requestHedging{
+		delay:   config.Delay,
+		budget:  newHedgingBudget(config.MaxRatio),
+		metrics: metrics,
+	}
+	return b
+}
+
+// CallAvailableNodeHedged calls the provided function on the available nodes, like CallAvailableNode.
+// If request hedging is enabled and the call to a node has not completed after the hedging delay, the
+// next node is called as well. The first successful call wins, and the context of the other call is
+// canceled. If a call fails, the next node is called, unless another call is still pending.
+//
+// Since call may be invoked concurrently for different nodes, it must only be used for read-only
+// requests, and must discard its result if the result of another call was already accepted.
+func (b *NodeCommunicator) CallAvailableNodeHedged(
+	ctx context.Context,
+	nodes flow.IdentitySkeletonList,
+	call func(ctx context.Context, node *flow.IdentitySkeleton) error,
+	shouldTerminateOnError func(node *flow.IdentitySkeleton, err error) bool,
+) error {
+	if b.hedging == nil {
+		return b.CallAvailableNode(
+			nodes,
+			func(node *flow.IdentitySkeleton) error {
+				return call(ctx, node)
+			},
+			shouldTerminateOnError,
+		)
+	}
+
+	nodeSelector, err := b.nodeSelectorFactory.SelectNodes(nodes)
+	if err != nil {
+		return err
+	}
+
+	// canceling the context on return cancels the calls which did not win
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the channel is large enough for all calls, so calls completing after the return do not block
+	attempts := make(chan hedgedAttempt, len(nodes))
+	pending := 0
+	callNext := func(hedge bool) {
+		node := nodeSelector.Next()
+		if node == nil {
+			return
+		}
+		pending++
+		go func() {
+			start := time.Now()
+			err := call(ctx, node)
+			attempts <- hedgedAttempt{node: node, hedge: hedge, duration: time.Since(start), err: err}
+		}()
+	}
+
+	b.hedging.budget.deposit()
+	hedged := false
+	timer := time.NewTimer(b.hedging.delay)
+	defer timer.Stop()
+
+	var errs *multierror.Error
+	for callNext(false); pending > 0; {
+		select {
+		case <-timer.C:
+			if hedged || !nodeSelector.HasNext() {
+				continue
+			}
+			hedged = true
+			if !b.hedging.budget.withdraw() {
+				b.hedging.metrics.HedgingBudgetExhausted()
+				continue
+			}
+			b.hedging.metrics.HedgedRequestIssued()
+			callNext(true)
+
+		case attempt := <-attempts:
+			pending--
+			if attempt.err == nil {
+				b.observe(attempt.node, attempt.duration, nil)
+				if attempt.hedge {
+					b.hedging.metrics.HedgedRequestWon()
+				}
+				return nil
+			}
+
+			if shouldTerminateOnError != nil && shouldTerminateOnError(attempt.node, attempt.err) {
+				// terminal errors are caused by the request rather than the node, so the node is considered healthy
+				b.observe(attempt.node, attempt.duration, nil)
+				return attempt.err
+			}
+			b.observe(attempt.node, attempt.duration, attempt.err)
+
+			if attempt.err == gobreaker.ErrOpenState {
+				if !nodeSelector.HasNext() && errs == nil {
+					errs = multierror.Append(errs, status.Error(codes.Unavailable, "there are no available nodes"))
+				}
+			} else {
+				errs = multierror.Append(errs, attempt.err)
+				if len(errs.Errors) >= maxFailedRequestCount {
+					return errs.ErrorOrNil()
+				}
+			}
+
+			if pending == 0 {
+				callNext(false)
+				if !hedged {
+					// the hedging delay applies to the call to the next node
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					timer.Reset(b.hedging.delay)
+				}
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,259 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// hedgingMetrics counts the hedging metrics reported by the communicator
+type hedgingMetrics struct {
+	mu              sync.Mutex
+	issued          int
+	won             int
+	budgetExhausted int
+}
+
+func (m *hedgingMetrics) HedgedRequestIssued() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.issued++
+}
+
+func (m *hedgingMetrics) HedgedRequestWon() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.won++
+}
+
+func (m *hedgingMetrics) HedgingBudgetExhausted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.budgetExhausted++
+}
+
+// blockUntilCanceled is a call which only completes once its context is canceled
+func blockUntilCanceled(ctx context.Context) error {
+	<-ctx.Done()
+	return status.Error(codes.Canceled, ctx.Err().Error())
+}
+
+// TestCallAvailableNodeHedged tests that a slow call is hedged by calling the next node, and that the
+// first successful call wins
+func TestCallAvailableNodeHedged(t *testing.T) {
+	nodes := unittest.IdentityListFixture(3).ToSkeleton()
+
+	t.Run("hedge wins", func(t *testing.T) {
+		hedgingMetrics := &hedgingMetrics{}
+		communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+			Enabled:  true,
+			Delay:    10 * time.Millisecond,
+			MaxRatio: 1,
+		}, hedgingMetrics)
+
+		canceled := make(chan struct{})
+		var mu sync.Mutex
+		var called []flow.Identifier
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				mu.Lock()
+				called = append(called, node.NodeID)
+				mu.Unlock()
+
+				if node.NodeID == nodes[0].NodeID {
+					err := blockUntilCanceled(ctx)
+					close(canceled)
+					return err
+				}
+				return nil
+			},
+			nil,
+		)
+		require.NoError(t, err)
+
+		// the slow call is canceled once the hedged call succeeded
+		unittest.RequireCloseBefore(t, canceled, time.Second, "slow call was not canceled")
+		assert.Equal(t, []flow.Identifier{nodes[0].NodeID, nodes[1].NodeID}, called)
+		assert.Equal(t, 1, hedgingMetrics.issued)
+		assert.Equal(t, 1, hedgingMetrics.won)
+	})
+
+	t.Run("fast call is not hedged", func(t *testing.T) {
+		hedgingMetrics := &hedgingMetrics{}
+		communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+			Enabled:  true,
+			Delay:    time.Minute,
+			MaxRatio: 1,
+		}, hedgingMetrics)
+
+		calls := 0
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				calls++
+				return nil
+			},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Zero(t, hedgingMetrics.issued)
+	})
+
+	t.Run("failed calls fall back to the next node", func(t *testing.T) {
+		hedgingMetrics := &hedgingMetrics{}
+		communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+			Enabled:  true,
+			Delay:    time.Minute,
+			MaxRatio: 1,
+		}, hedgingMetrics)
+
+		var called []flow.Identifier
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				called = append(called, node.NodeID)
+				if node.NodeID == nodes[2].NodeID {
+					return nil
+				}
+				return status.Error(codes.Unavailable, "unavailable")
+			},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Identifier{nodes[0].NodeID, nodes[1].NodeID, nodes[2].NodeID}, called)
+		assert.Zero(t, hedgingMetrics.issued)
+	})
+
+	t.Run("terminal errors are returned", func(t *testing.T) {
+		communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+			Enabled:  true,
+			Delay:    time.Minute,
+			MaxRatio: 1,
+		}, metrics.NewNoopCollector())
+
+		expectedErr := status.Error(codes.InvalidArgument, "invalid script")
+		calls := 0
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				calls++
+				return expectedErr
+			},
+			func(node *flow.IdentitySkeleton, err error) bool {
+				return status.Code(err) == codes.InvalidArgument
+			},
+		)
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("all calls fail", func(t *testing.T) {
+		communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+			Enabled:  true,
+			Delay:    time.Minute,
+			MaxRatio: 1,
+		}, metrics.NewNoopCollector())
+
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				return fmt.Errorf("failed to call node %v", node.NodeID)
+			},
+			nil,
+		)
+		require.Error(t, err)
+		for _, node := range nodes {
+			assert.ErrorContains(t, err, node.NodeID.String())
+		}
+	})
+}
+
+// TestCallAvailableNodeHedged_Budget tests that requests are no longer hedged once the hedging budget
+// is exhausted, and that the budget is replenished by requests
+func TestCallAvailableNodeHedged_Budget(t *testing.T) {
+	nodes := unittest.IdentityListFixture(2).ToSkeleton()
+	hedgingMetrics := &hedgingMetrics{}
+	communicator := NewNodeCommunicator(true).WithRequestHedging(RequestHedgingConfig{
+		Enabled:  true,
+		Delay:    time.Millisecond,
+		MaxRatio: 0.5,
+	}, hedgingMetrics)
+
+	// the first node is slow, so each request is hedged as long as the budget allows
+	callSlowNode := func() {
+		err := communicator.CallAvailableNodeHedged(
+			context.Background(),
+			nodes,
+			func(ctx context.Context, node *flow.IdentitySkeleton) error {
+				if node.NodeID == nodes[0].NodeID {
+					select {
+					case <-ctx.Done():
+						return status.Error(codes.Canceled, ctx.Err().Error())
+					case <-time.After(100 * time.Millisecond):
+						return nil
+					}
+				}
+				return nil
+			},
+			nil,
+		)
+		require.NoError(t, err)
+	}
+
+	// the budget starts full, and each hedged request takes half a token net, so the budget is exhausted
+	// after the burst is used twice, less the first request which does not add to the full budget
+	for i := 0; i < 2*requestHedgingBudgetBurst; i++ {
+		callSlowNode()
+	}
+	assert.Equal(t, 2*requestHedgingBudgetBurst-1, hedgingMetrics.issued)
+	assert.Equal(t, 1, hedgingMetrics.budgetExhausted)
+
+	// the next request adds enough to the budget to hedge again
+	callSlowNode()
+	assert.Equal(t, 2*requestHedgingBudgetBurst, hedgingMetrics.issued)
+	assert.Equal(t, 1, hedgingMetrics.budgetExhausted)
+}
+
+// TestCallAvailableNodeHedged_Disabled tests that nodes are called in sequence if request hedging is
+// disabled
+func TestCallAvailableNodeHedged_Disabled(t *testing.T) {
+	nodes := unittest.IdentityListFixture(2).ToSkeleton()
+	communicator := NewNodeCommunicator(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var called []flow.Identifier
+	err := communicator.CallAvailableNodeHedged(
+		ctx,
+		nodes,
+		func(callCtx context.Context, node *flow.IdentitySkeleton) error {
+			called = append(called, node.NodeID)
+			if node.NodeID == nodes[0].NodeID {
+				// the call is not hedged, so it only completes once the request is canceled
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return blockUntilCanceled(callCtx)
+			}
+			return nil
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []flow.Identifier{nodes[0].NodeID, nodes[1].NodeID}, called)
+}
@@ -88,6 +88,37 @@ func (s *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byte
 	return s.scriptExecutor.ExecuteAtBlockHeight(ctx, script, arguments, height)
 }
 
+// ExecuteBatchAtBlockHeight executes the provided scripts at the provided block height against a
+// local execution state. Failures of individual scripts are returned in their result.
+//
+// Expected errors:
+//   - storage.ErrNotFound if the block height is not found
+//   - storage.ErrHeightNotIndexed if the data for the block height is not available. this could be because
+//     the height is not within the index block range, or the index is not ready.
+func (s *ScriptExecutor) ExecuteBatchAtBlockHeight(ctx context.Context, scripts []execution.Script, height uint64, computationLimit uint64) ([]execution.ScriptResult, error) {
+	if err := s.checkDataAvailable(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.ExecuteBatchAtBlockHeight(ctx, scripts, height, computationLimit)
+}
+
+// SimulateTransactionAtBlockHeight executes the provided transaction at the provided block height
+// against a local execution state without committing any of its changes. Failures of the transaction
+// are returned in the result.
+//
+// Expected errors:
+//   - storage.ErrNotFound if the block height is not found
+//   - storage.ErrHeightNotIndexed if the data for the block height is not available. this could be because
+//     the height is not within the index block range, or the index is not ready.
+func (s *ScriptExecutor) SimulateTransactionAtBlockHeight(ctx context.Context, tx *flow.TransactionBody, height uint64, skipSignatureVerification bool) (*execution.TransactionSimulation, error) {
+	if err := s.checkDataAvailable(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.SimulateTransactionAtBlockHeight(ctx, tx, height, skipSignatureVerification)
+}
+
 // GetAccountAtBlockHeight returns the account at the provided block height from a local execution state.
 //
 // Expected errors:
@@ -114,6 +145,19 @@ func (s *ScriptExecutor) GetAccountBalance(ctx context.Context, address flow.Add
 	return s.scriptExecutor.GetAccountBalance(ctx, address, height)
 }
 
+// GetAccountAvailableBalance returns the available balance of the account at the provided block height
+// from a local execution state.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *ScriptExecutor) GetAccountAvailableBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	if err := s.checkDataAvailable(height); err != nil {
+		return 0, err
+	}
+
+	return s.scriptExecutor.GetAccountAvailableBalance(ctx, address, height)
+}
+
 // GetAccountKeys returns
 // Expected errors:
 // - Script execution related errors
@@ -126,6 +170,19 @@ func (s *ScriptExecutor) GetAccountKeys(ctx context.Context, address flow.Addres
 	return s.scriptExecutor.GetAccountKeys(ctx, address, height)
 }
 
+// GetAccountKey returns the public key of the account with the given key index at the provided
+// block height from a local execution state.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *ScriptExecutor) GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	if err := s.checkDataAvailable(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.GetAccountKey(ctx, address, keyIndex, height)
+}
+
 func (s *ScriptExecutor) checkDataAvailable(height uint64) error {
 	if !s.initialized.Load() {
 		return fmt.Errorf("%w: script executor not initialized", storage.ErrHeightNotIndexed)
@@ -136,7 +193,7 @@ func (s *ScriptExecutor) checkDataAvailable(height uint64) error {
 		return fmt.Errorf("could not get highest indexed height: %w", err)
 	}
 	if height > highestHeight {
-		return fmt.Errorf("%w: block not indexed yet", storage.ErrHeightNotIndexed)
+		return fmt.Errorf("%w: block %d not indexed yet, highest indexed height is %d", storage.ErrHeightNotIndexed, height, highestHeight)
 	}
 
 	lowestHeight, err := s.indexReporter.LowestIndexedHeight()
@@ -144,7 +201,7 @@ func (s *ScriptExecutor) checkDataAvailable(height uint64) error {
 		return fmt.Errorf("could not get lowest indexed height: %w", err)
 	}
 	if height < lowestHeight {
-		return fmt.Errorf("%w: block is before lowest indexed height", storage.ErrHeightNotIndexed)
+		return fmt.Errorf("%w: block %d is before lowest indexed height %d", storage.ErrHeightNotIndexed, height, lowestHeight)
 	}
 
 	if height > s.maxCompatibleHeight.Load() || height < s.minCompatibleHeight.Load() {
@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// DefaultScriptResultCacheTTL is the default duration results are kept in the script result cache.
+const DefaultScriptResultCacheTTL = 10 * time.Minute
+
+// scriptResultCacheKey identifies a script execution.
+// Script execution is deterministic, so executing the same script with the same arguments at the same
+// block always produces the same result.
+type scriptResultCacheKey struct {
+	blockID       flow.Identifier
+	scriptHash    flow.Identifier
+	argumentsHash flow.Identifier
+}
+
+// scriptResultCache caches the results of scripts executed using the local execution state.
+// Only successful executions are cached. A nil cache is valid and never returns any results.
+type scriptResultCache struct {
+	metrics module.BackendScriptsMetrics
+	results *expirable.LRU[scriptResultCacheKey, []byte]
+}
+
+// newScriptResultCache creates a cache holding the results of at most size script executions, each
+// for at most ttl. If ttl is 0, results are only evicted when the cache is full.
+// Returns nil if size is 0, which disables caching.
+func newScriptResultCache(size uint, ttl time.Duration, metrics module.BackendScriptsMetrics) *scriptResultCache {
+	if size == 0 {
+		return nil
+	}
+	return &scriptResultCache{
+		metrics: metrics,
+		results: expirable.NewLRU[scriptResultCacheKey, []byte](int(size), nil, ttl),
+	}
+}
+
+// get returns the cached result of the given script execution request.
+// Returns false if the result is not cached, or if the client requested to bypass the cache.
+func (c *scriptResultCache) get(ctx context.Context, r *scriptExecutionRequest) ([]byte, bool) {
+	if c == nil || access.IsScriptCacheBypassed(ctx) {
+		return nil, false
+	}
+
+	result, ok := c.results.Get(r.cacheKey())
+	if !ok {
+		c.metrics.ScriptResultCacheMiss()
+		return nil, false
+	}
+
+	c.metrics.ScriptResultCacheHit()
+	return result, true
+}
+
+// add caches the result of the given script execution request.
+func (c *scriptResultCache) add(r *scriptExecutionRequest, result []byte) {
+	if c == nil {
+		return
+	}
+	c.results.Add(r.cacheKey(), result)
+}
@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// GenericTransactionErrorMessage is the error message returned for failed transactions by nodes which
+// cannot look up the error messages from execution nodes.
+const GenericTransactionErrorMessage = "transaction execution failed: the error message is not available from this node"
+
+// GenericTransactionErrorMessages is a TransactionErrorMessage which returns a generic error message
+// for all failed transactions. It is used by nodes serving transaction results from their local
+// indexes without access to execution nodes, since the execution data does not contain the error
+// messages of failed transactions.
+type GenericTransactionErrorMessages struct {
+	txResultsIndex *index.TransactionResultsIndex
+}
+
+var _ TransactionErrorMessage = (*GenericTransactionErrorMessages)(nil)
+
+// NewGenericTransactionErrorMessages creates a new GenericTransactionErrorMessages which uses the
+// provided index to find the failed transactions of a block.
+func NewGenericTransactionErrorMessages(txResultsIndex *index.TransactionResultsIndex) *GenericTransactionErrorMessages {
+	return &GenericTransactionErrorMessages{
+		txResultsIndex: txResultsIndex,
+	}
+}
+
+// LookupErrorMessageByTransactionID returns the generic error message. It must only be called for
+// failed transactions.
+func (g *GenericTransactionErrorMessages) LookupErrorMessageByTransactionID(
+	_ context.Context,
+	_ flow.Identifier,
+	_ flow.Identifier,
+) (string, error) {
+	return GenericTransactionErrorMessage, nil
+}
+
+// LookupErrorMessageByIndex returns the generic error message. It must only be called for failed
+// transactions.
+func (g *GenericTransactionErrorMessages) LookupErrorMessageByIndex(
+	_ context.Context,
+	_ flow.Identifier,
+	_ uint64,
+	_ uint32,
+) (string, error) {
+	return GenericTransactionErrorMessage, nil
+}
+
+// LookupErrorMessagesByBlockID returns the generic error message for all failed transactions of the
+// block.
+//
+// Expected errors during normal operation:
+//   - status.Error[codes.NotFound] - transaction results for given block ID are not available.
+//   - status.Error[codes.OutOfRange] - the block is outside the indexed range.
+func (g *GenericTransactionErrorMessages) LookupErrorMessagesByBlockID(
+	_ context.Context,
+	blockID flow.Identifier,
+	height uint64,
+) (map[flow.Identifier]string, error) {
+	txResults, err := g.txResultsIndex.ByBlockID(blockID, height)
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, height, "failed to get transaction results")
+	}
+
+	errorMessages := make(map[flow.Identifier]string)
+	for _, txResult := range txResults {
+		if txResult.Failed {
+			errorMessages[txResult.TransactionID] = GenericTransactionErrorMessage
+		}
+	}
+	return errorMessages, nil
+}
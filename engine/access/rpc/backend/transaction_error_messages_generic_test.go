@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGenericTransactionErrorMessages tests that the generic error message is returned for all failed
+// transactions, and that blocks outside the indexed range are reported as out of range.
+func (suite *Suite) TestGenericTransactionErrorMessages() {
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+
+	results := make([]flow.LightTransactionResult, 0)
+	for i := 0; i < 5; i++ {
+		results = append(results, flow.LightTransactionResult{
+			TransactionID: unittest.IdentifierFixture(),
+			Failed:        i%2 == 0,
+		})
+	}
+	suite.transactionResults.On("ByBlockID", blockID).Return(results, nil).Once()
+
+	reporter := syncmock.NewIndexReporter(suite.T())
+	reporter.On("LowestIndexedHeight").Return(block.Header.Height, nil)
+	reporter.On("HighestIndexedHeight").Return(block.Header.Height+10, nil)
+
+	txResultsIndex := index.NewTransactionResultsIndex(index.NewReporter(), suite.transactionResults)
+	err := txResultsIndex.Initialize(reporter)
+	suite.Require().NoError(err)
+
+	errorMessages := NewGenericTransactionErrorMessages(txResultsIndex)
+
+	suite.Run("by transaction ID", func() {
+		errMsg, err := errorMessages.LookupErrorMessageByTransactionID(context.Background(), blockID, results[0].TransactionID)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(GenericTransactionErrorMessage, errMsg)
+	})
+
+	suite.Run("by index", func() {
+		errMsg, err := errorMessages.LookupErrorMessageByIndex(context.Background(), blockID, block.Header.Height, 0)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(GenericTransactionErrorMessage, errMsg)
+	})
+
+	suite.Run("by block ID", func() {
+		errMsgs, err := errorMessages.LookupErrorMessagesByBlockID(context.Background(), blockID, block.Header.Height)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(map[flow.Identifier]string{
+			results[0].TransactionID: GenericTransactionErrorMessage,
+			results[2].TransactionID: GenericTransactionErrorMessage,
+			results[4].TransactionID: GenericTransactionErrorMessage,
+		}, errMsgs)
+	})
+
+	suite.Run("block below indexed range", func() {
+		errMsgs, err := errorMessages.LookupErrorMessagesByBlockID(context.Background(), blockID, block.Header.Height-1)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.OutOfRange, status.Code(err))
+		suite.Assert().Contains(err.Error(), "before lowest indexed height")
+		suite.Assert().Nil(errMsgs)
+	})
+}
@@ -58,6 +58,7 @@ type TransactionsLocalDataProvider struct {
 	txErrorMessages     TransactionErrorMessage
 	systemTxID          flow.Identifier
 	lastFullBlockHeight *counters.PersistentStrictMonotonicCounter
+	prunedData          *prunedDataChecker
 }
 
 // GetTransactionResultFromStorage retrieves a transaction result from storage by block ID and transaction ID.
@@ -78,7 +79,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultFromStorage(
 	blockID := block.ID()
 	txResult, err := t.txResultsIndex.ByBlockIDTransactionID(blockID, block.Header.Height, transactionID)
 	if err != nil {
-		return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get transaction result")
+		return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get transaction result")
 	}
 
 	var txErrorMessage string
@@ -106,7 +107,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultFromStorage(
 
 	events, err := t.eventsIndex.ByBlockIDTransactionID(blockID, block.Header.Height, transactionID)
 	if err != nil {
-		return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get events")
+		return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get events")
 	}
 
 	// events are encoded in CCF format in storage. convert to JSON-CDC if requested
@@ -145,7 +146,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultsByBlockIDFromStorag
 	blockID := block.ID()
 	txResults, err := t.txResultsIndex.ByBlockID(blockID, block.Header.Height)
 	if err != nil {
-		return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get transaction result")
+		return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get transaction result")
 	}
 
 	txErrors, err := t.txErrorMessages.LookupErrorMessagesByBlockID(ctx, blockID, block.Header.Height)
@@ -190,7 +191,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultsByBlockIDFromStorag
 
 		events, err := t.eventsIndex.ByBlockIDTransactionID(blockID, block.Header.Height, txResult.TransactionID)
 		if err != nil {
-			return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get events")
+			return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get events")
 		}
 
 		// events are encoded in CCF format in storage. convert to JSON-CDC if requested
@@ -239,7 +240,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultByIndexFromStorage(
 	blockID := block.ID()
 	txResult, err := t.txResultsIndex.ByBlockIDTransactionIndex(blockID, block.Header.Height, index)
 	if err != nil {
-		return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get transaction result")
+		return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get transaction result")
 	}
 
 	var txErrorMessage string
@@ -267,7 +268,7 @@ func (t *TransactionsLocalDataProvider) GetTransactionResultByIndexFromStorage(
 
 	events, err := t.eventsIndex.ByBlockIDTransactionIndex(blockID, block.Header.Height, index)
 	if err != nil {
-		return nil, rpc.ConvertIndexError(err, block.Header.Height, "failed to get events")
+		return nil, t.prunedData.convertIndexLookupError(block.Header.Height, err, "failed to get events")
 	}
 
 	// events are encoded in CCF format in storage. convert to JSON-CDC if requested
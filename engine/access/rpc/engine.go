@@ -15,6 +15,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/access/state_stream"
@@ -72,6 +73,8 @@ type Engine struct {
 
 	stateStreamBackend state_stream.API
 	stateStreamConfig  statestreambackend.Config
+
+	clientQuotas *quota.Manager // the per-client quotas enforced by the REST server, if any
 }
 type Option func(*RPCEngineBuilder)
 
@@ -242,7 +245,7 @@ func (e *Engine) serveREST(ctx irrecoverable.SignalerContext, ready component.Re
 	e.log.Info().Str("rest_api_address", e.config.RestConfig.ListenAddress).Msg("starting REST server on address")
 
 	r, err := rest.NewServer(e.restHandler, e.config.RestConfig, e.log, e.chain, e.restCollector, e.stateStreamBackend,
-		e.stateStreamConfig)
+		e.stateStreamConfig, e.clientQuotas)
 	if err != nil {
 		e.log.Err(err).Msg("failed to initialize the REST server")
 		ctx.Throw(err)
@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/state_synchronization"
 )
@@ -88,6 +89,14 @@ func (builder *RPCEngineBuilder) DefaultHandler(signerIndicesDecoder hotstuff.Bl
 	}
 }
 
+// WithClientQuotas specifies that the per-client quotas of the given manager should be enforced by the
+// REST server. Quotas of the gRPC servers are enforced by their interceptors.
+// Returns self-reference for chaining.
+func (builder *RPCEngineBuilder) WithClientQuotas(quotas *quota.Manager) *RPCEngineBuilder {
+	builder.Engine.clientQuotas = quotas
+	return builder
+}
+
 // WithMetrics specifies the metrics should be collected.
 // Returns self-reference for chaining.
 func (builder *RPCEngineBuilder) WithMetrics() *RPCEngineBuilder {
@@ -124,7 +124,7 @@ func NewAccountStatusFilter(
 	}
 
 	// Creates an `EventFilter` with the provided `eventTypes`.
-	filter, err := NewEventFilter(config, chain, filterEventTypes, []string{}, []string{})
+	filter, err := NewEventFilter(config, chain, filterEventTypes, []string{}, []string{}, nil)
 	if err != nil {
 		return AccountStatusFilter{}, err
 	}
@@ -57,6 +57,11 @@ type Config struct {
 
 	// HeartbeatInterval specifies the block interval at which heartbeat messages should be sent.
 	HeartbeatInterval uint64
+
+	// MaxEventsPerResponse is the max number of events of a single event or account status response.
+	// The events of blocks with more events are split across several responses at transaction
+	// boundaries, each carrying the resume token of its position in the block.
+	MaxEventsPerResponse uint
 }
 
 type GetExecutionDataFunc func(context.Context, uint64) (*execution_data.BlockExecutionDataEntity, error)
@@ -18,6 +18,16 @@ type AccountStatusesResponse struct {
 	BlockID       flow.Identifier
 	Height        uint64
 	AccountEvents map[string]flow.EventsList
+
+	// ResumeToken is the encoded resume token of the position after the response.
+	ResumeToken string
+}
+
+var _ subscription.Resumable = (*AccountStatusesResponse)(nil)
+
+// SetResumeToken sets the resume token of the response.
+func (r *AccountStatusesResponse) SetResumeToken(token subscription.ResumeToken) {
+	r.ResumeToken = token.Encode()
 }
 
 // AccountStatusesBackend is a struct representing a backend implementation for subscribing to account statuses changes.
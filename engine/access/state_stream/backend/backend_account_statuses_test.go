@@ -97,7 +97,12 @@ func (s *BackendAccountStatusesSuite) SetupTest() {
 		result := unittest.ExecutionResultFixture()
 
 		chunkDatas := []*execution_data.ChunkExecutionData{
-			unittest.ChunkExecutionDataFixture(s.T(), execution_data.DefaultMaxBlobSize/5, unittest.WithChunkEvents(events)),
+			unittest.ChunkExecutionDataFixture(
+				s.T(),
+				execution_data.DefaultMaxBlobSize/5,
+				unittest.WithChunkEvents(events),
+				withRegisterUpdates(s.registerID, unittest.RegisterIDFixture()),
+			),
 		}
 
 		execData := unittest.BlockExecutionDataFixture(
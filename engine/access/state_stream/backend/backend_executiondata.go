@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/model/flow"
@@ -21,6 +22,16 @@ type ExecutionDataResponse struct {
 	Height         uint64
 	ExecutionData  *execution_data.BlockExecutionData
 	BlockTimestamp time.Time
+
+	// ResumeToken is the encoded resume token of the position after the response.
+	ResumeToken string
+}
+
+var _ subscription.Resumable = (*ExecutionDataResponse)(nil)
+
+// SetResumeToken sets the resume token of the response.
+func (r *ExecutionDataResponse) SetResumeToken(token subscription.ResumeToken) {
+	r.ResumeToken = token.Encode()
 }
 
 type ExecutionDataBackend struct {
@@ -131,6 +142,74 @@ func (b *ExecutionDataBackend) SubscribeExecutionDataFromLatest(ctx context.Cont
 	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getResponse)
 }
 
+// SubscribeFilteredExecutionDataFromStartBlockID streams execution data for all blocks starting at the specified
+// block ID up until the latest available block. Once the latest is reached, the stream will remain open and
+// responses are sent for each new block as it becomes available.
+//
+// The execution data of each block only contains the chunks with register updates matching the filter, and
+// only the matching register updates of these chunks. A response is sent for every block, even if no register
+// updates match.
+//
+// Parameters:
+// - ctx: Context for the operation.
+// - startBlockID: The identifier of the starting block.
+// - filter: The register filter used to filter register updates.
+//
+// If invalid parameters are provided, failed subscription will be returned.
+func (b *ExecutionDataBackend) SubscribeFilteredExecutionDataFromStartBlockID(ctx context.Context, startBlockID flow.Identifier, filter state_stream.RegisterFilter) subscription.Subscription {
+	nextHeight, err := b.executionDataTracker.GetStartHeightFromBlockID(startBlockID)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not get start block height")
+	}
+
+	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getFilteredResponseFactory(filter))
+}
+
+// SubscribeFilteredExecutionDataFromStartBlockHeight streams execution data for all blocks starting at the
+// specified block height up until the latest available block. Once the latest is reached, the stream will
+// remain open and responses are sent for each new block as it becomes available.
+//
+// The execution data of each block only contains the chunks with register updates matching the filter, and
+// only the matching register updates of these chunks. A response is sent for every block, even if no register
+// updates match.
+//
+// Parameters:
+// - ctx: Context for the operation.
+// - startBlockHeight: The height of the starting block.
+// - filter: The register filter used to filter register updates.
+//
+// If invalid parameters are provided, failed subscription will be returned.
+func (b *ExecutionDataBackend) SubscribeFilteredExecutionDataFromStartBlockHeight(ctx context.Context, startBlockHeight uint64, filter state_stream.RegisterFilter) subscription.Subscription {
+	nextHeight, err := b.executionDataTracker.GetStartHeightFromHeight(startBlockHeight)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not get start block height")
+	}
+
+	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getFilteredResponseFactory(filter))
+}
+
+// SubscribeFilteredExecutionDataFromLatest streams execution data starting at the latest block.
+// Once the latest is reached, the stream will remain open and responses are sent for each new
+// block as it becomes available.
+//
+// The execution data of each block only contains the chunks with register updates matching the filter, and
+// only the matching register updates of these chunks. A response is sent for every block, even if no register
+// updates match.
+//
+// Parameters:
+// - ctx: Context for the operation.
+// - filter: The register filter used to filter register updates.
+//
+// If invalid parameters are provided, failed subscription will be returned.
+func (b *ExecutionDataBackend) SubscribeFilteredExecutionDataFromLatest(ctx context.Context, filter state_stream.RegisterFilter) subscription.Subscription {
+	nextHeight, err := b.executionDataTracker.GetStartHeightFromLatest(ctx)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not get start block height")
+	}
+
+	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getFilteredResponseFactory(filter))
+}
+
 func (b *ExecutionDataBackend) getResponse(ctx context.Context, height uint64) (interface{}, error) {
 	executionData, err := b.getExecutionData(ctx, height)
 	if err != nil {
@@ -142,3 +221,24 @@ func (b *ExecutionDataBackend) getResponse(ctx context.Context, height uint64) (
 		ExecutionData: executionData.BlockExecutionData,
 	}, nil
 }
+
+// getFilteredResponseFactory returns a function which returns the execution data response for a given height,
+// only containing the register updates matching the filter.
+func (b *ExecutionDataBackend) getFilteredResponseFactory(filter state_stream.RegisterFilter) subscription.GetDataByHeightFunc {
+	return func(ctx context.Context, height uint64) (interface{}, error) {
+		executionData, err := b.getExecutionData(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get execution data for block %d: %w", height, err)
+		}
+
+		filtered, err := filter.Filter(executionData.BlockExecutionData)
+		if err != nil {
+			return nil, fmt.Errorf("could not filter execution data for block %d: %w", height, err)
+		}
+
+		return &ExecutionDataResponse{
+			Height:        height,
+			ExecutionData: filtered,
+		}, nil
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/subscription"
 	subscriptionmock "github.com/onflow/flow-go/engine/access/subscription/mock"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/testutils"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/blobs"
 	"github.com/onflow/flow-go/module/execution"
@@ -118,7 +121,12 @@ func (s *BackendExecutionDataSuite) SetupTest() {
 			default:
 				events = flow.EventsList{blockEvents.Events[i]}
 			}
-			chunkDatas = append(chunkDatas, unittest.ChunkExecutionDataFixture(s.T(), execution_data.DefaultMaxBlobSize/5, unittest.WithChunkEvents(events)))
+			chunkDatas = append(chunkDatas, unittest.ChunkExecutionDataFixture(
+				s.T(),
+				execution_data.DefaultMaxBlobSize/5,
+				unittest.WithChunkEvents(events),
+				withRegisterUpdates(s.registerID, unittest.RegisterIDFixture()),
+			))
 		}
 		execData := unittest.BlockExecutionDataFixture(
 			unittest.WithBlockExecutionDataBlockID(block.ID()),
@@ -167,6 +175,7 @@ func (s *BackendExecutionDataSuite) SetupTestSuite(blockCount int) {
 	s.sealMap = make(map[flow.Identifier]*flow.Seal, blockCount)
 	s.resultMap = make(map[flow.Identifier]*flow.ExecutionResult, blockCount)
 	s.blocks = make([]*flow.Block, 0, blockCount)
+	s.registerID = unittest.RegisterIDFixture()
 
 	// generate blockCount consecutive blocks with associated seal, result and execution data
 	s.rootBlock = unittest.BlockFixture()
@@ -177,8 +186,6 @@ func (s *BackendExecutionDataSuite) SetupTestSuite(blockCount int) {
 }
 
 func (s *BackendExecutionDataSuite) SetupTestMocks() {
-	s.registerID = unittest.RegisterIDFixture()
-
 	s.eventsIndex = index.NewEventsIndex(index.NewReporter(), s.events)
 	s.registersAsync = execution.NewRegistersAsyncStore()
 	s.registers = storagemock.NewRegisterIndex(s.T())
@@ -492,6 +499,65 @@ func (s *BackendExecutionDataSuite) TestSubscribeExecutionDataFromLatest() {
 	s.subscribe(subFunc, tests)
 }
 
+// TestSubscribeFilteredExecutionDataFromStartBlockHeight tests that the execution data streamed by filtered
+// subscriptions only contains the register updates matching the filter.
+func (s *BackendExecutionDataSuite) TestSubscribeFilteredExecutionDataFromStartBlockHeight() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.executionDataTracker.On(
+		"GetStartHeightFromHeight",
+		mock.AnythingOfType("uint64"),
+	).Return(func(startHeight uint64) (uint64, error) {
+		return s.executionDataTrackerReal.GetStartHeightFromHeight(startHeight)
+	}, nil)
+
+	filter, err := state_stream.NewRegisterFilter(
+		state_stream.DefaultEventFilterConfig,
+		flow.Testnet.Chain(),
+		[]string{flow.BytesToAddress([]byte(s.registerID.Owner)).Hex()},
+		nil,
+	)
+	s.Require().NoError(err)
+
+	// backfill all blocks
+	s.highestBlockHeader = s.blocks[len(s.blocks)-1].Header
+
+	sub := s.backend.SubscribeFilteredExecutionDataFromStartBlockHeight(ctx, s.blocks[0].Header.Height, filter)
+	for _, b := range s.blocks {
+		execData := s.execDataMap[b.ID()]
+
+		unittest.RequireReturnsBefore(s.T(), func() {
+			v, ok := <-sub.Channel()
+			require.True(s.T(), ok, "channel closed while waiting for exec data for block %d %v: err: %v", b.Header.Height, b.ID(), sub.Err())
+
+			resp, ok := v.(*ExecutionDataResponse)
+			require.True(s.T(), ok, "unexpected response type: %T", v)
+
+			expected, err := filter.Filter(execData.BlockExecutionData)
+			require.NoError(s.T(), err)
+			require.NotEmpty(s.T(), expected.ChunkExecutionDatas)
+
+			assert.Equal(s.T(), b.Header.Height, resp.Height)
+			assert.Equal(s.T(), expected, resp.ExecutionData)
+		}, time.Second, fmt.Sprintf("timed out waiting for exec data for block %d %v", b.Header.Height, b.ID()))
+	}
+}
+
+// withRegisterUpdates sets the trie update of the chunk to updates of the given registers.
+func withRegisterUpdates(registerIDs ...flow.RegisterID) func(*execution_data.ChunkExecutionData) {
+	return func(chunk *execution_data.ChunkExecutionData) {
+		chunk.TrieUpdate = &ledger.TrieUpdate{
+			RootHash: testutils.RootHashFixture(),
+			Paths:    testutils.RandomPaths(len(registerIDs)),
+		}
+		for _, registerID := range registerIDs {
+			key := convert.RegisterIDToLedgerKey(registerID)
+			chunk.TrieUpdate.Payloads = append(chunk.TrieUpdate.Payloads, ledger.NewPayload(key, unittest.RandomBytes(8)))
+		}
+	}
+}
+
 func (s *BackendExecutionDataSuite) subscribe(subscribeFunc func(ctx context.Context, startBlockID flow.Identifier, startHeight uint64) subscription.Subscription, tests []executionDataTestType) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
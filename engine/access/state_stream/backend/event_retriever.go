@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/logging"
@@ -19,6 +20,16 @@ type EventsResponse struct {
 	Height         uint64
 	Events         flow.EventsList
 	BlockTimestamp time.Time
+
+	// ResumeToken is the encoded resume token of the position after the response.
+	ResumeToken string
+}
+
+var _ subscription.Resumable = (*EventsResponse)(nil)
+
+// SetResumeToken sets the resume token of the response.
+func (r *EventsResponse) SetResumeToken(token subscription.ResumeToken) {
+	r.ResumeToken = token.Encode()
 }
 
 // EventsRetriever retrieves events by block height. It can be configured to retrieve events from
@@ -3,7 +3,9 @@ package backend
 import (
 	"context"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -18,6 +20,24 @@ import (
 	"github.com/onflow/flow-go/module/counters"
 )
 
+// EventFieldPredicatesMetadataKey is the gRPC metadata key of the event field predicates of event
+// subscriptions. Each value of the key is a single predicate.
+const EventFieldPredicatesMetadataKey = "x-event-field-predicates"
+
+// RegisterOwnersMetadataKey is the gRPC metadata key of the register owners of filtered execution data
+// subscriptions. Each value of the key is a single hex encoded address.
+const RegisterOwnersMetadataKey = "x-register-owners"
+
+// RegisterKeyPrefixesMetadataKey is the gRPC metadata key of the register key prefixes of filtered
+// execution data subscriptions. Each value of the key is a single base64 encoded key prefix.
+const RegisterKeyPrefixesMetadataKey = "x-register-key-prefixes"
+
+// ResumeTokenMetadataKey is the gRPC metadata key of subscription resume tokens. Clients resume a
+// subscription by providing a token in the request metadata. Each response carries the token of the
+// position after it, which is read using subscription.MessageResumeToken, and the trailer of the stream
+// carries the token of the last response processed.
+const ResumeTokenMetadataKey = subscription.ResumeTokenMetadataKey
+
 type Handler struct {
 	subscription.StreamingData
 
@@ -26,6 +46,7 @@ type Handler struct {
 
 	eventFilterConfig        state_stream.EventFilterConfig
 	defaultHeartbeatInterval uint64
+	maxEventsPerResponse     uint
 }
 
 // sendSubscribeEventsResponseFunc is a callback function used to send
@@ -45,6 +66,7 @@ func NewHandler(api state_stream.API, chain flow.Chain, config Config) *Handler
 		chain:                    chain,
 		eventFilterConfig:        config.EventFilterConfig,
 		defaultHeartbeatInterval: config.HeartbeatInterval,
+		maxEventsPerResponse:     config.MaxEventsPerResponse,
 	}
 	return h
 }
@@ -79,8 +101,11 @@ func (h *Handler) GetExecutionDataByBlockID(ctx context.Context, request *execut
 // SubscribeExecutionData handles subscription requests for execution data starting at the specified block ID or block height.
 // The handler manages the subscription and sends the subscribed information to the client via the provided stream.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument   - if request contains invalid startBlockID.
+// - codes.InvalidArgument   - if invalid resume token is provided, if request contains invalid startBlockID.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeExecutionData(request *executiondata.SubscribeExecutionDataRequest, stream executiondata.ExecutionDataAPI_SubscribeExecutionDataServer) error {
@@ -100,9 +125,22 @@ func (h *Handler) SubscribeExecutionData(request *executiondata.SubscribeExecuti
 		startBlockID = blockID
 	}
 
-	sub := h.api.SubscribeExecutionData(stream.Context(), startBlockID, request.GetStartBlockHeight())
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
 
-	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion()))
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeExecutionDataFromStartBlockHeight(stream.Context(), resumeToken.Height)
+	} else {
+		sub = h.api.SubscribeExecutionData(stream.Context(), startBlockID, request.GetStartBlockHeight())
+	}
+
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion(), trailer))
 }
 
 // SubscribeExecutionDataFromStartBlockID handles subscription requests for
@@ -110,8 +148,14 @@ func (h *Handler) SubscribeExecutionData(request *executiondata.SubscribeExecuti
 // subscription and sends the subscribed information to the client via the
 // provided stream.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
+// If a register filter is provided in the RegisterOwnersMetadataKey and RegisterKeyPrefixesMetadataKey
+// metadata of the request, the execution data only contains the matching register updates.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument   - if request contains invalid startBlockID.
+// - codes.InvalidArgument   - if invalid resume token is provided, if request contains invalid startBlockID, if invalid register filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeExecutionDataFromStartBlockID(request *executiondata.SubscribeExecutionDataFromStartBlockIDRequest, stream executiondata.ExecutionDataAPI_SubscribeExecutionDataFromStartBlockIDServer) error {
@@ -127,9 +171,32 @@ func (h *Handler) SubscribeExecutionDataFromStartBlockID(request *executiondata.
 		return status.Errorf(codes.InvalidArgument, "could not convert start block ID: %v", err)
 	}
 
-	sub := h.api.SubscribeExecutionDataFromStartBlockID(stream.Context(), startBlockID)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
 
-	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion()))
+	filter, filtered, err := h.getRegisterFilter(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	switch {
+	case resumeToken != nil && filtered:
+		sub = h.api.SubscribeFilteredExecutionDataFromStartBlockHeight(stream.Context(), resumeToken.Height, filter)
+	case resumeToken != nil:
+		sub = h.api.SubscribeExecutionDataFromStartBlockHeight(stream.Context(), resumeToken.Height)
+	case filtered:
+		sub = h.api.SubscribeFilteredExecutionDataFromStartBlockID(stream.Context(), startBlockID, filter)
+	default:
+		sub = h.api.SubscribeExecutionDataFromStartBlockID(stream.Context(), startBlockID)
+	}
+
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion(), trailer))
 }
 
 // SubscribeExecutionDataFromStartBlockHeight handles subscription requests for
@@ -137,7 +204,14 @@ func (h *Handler) SubscribeExecutionDataFromStartBlockID(request *executiondata.
 // subscription and sends the subscribed information to the client via the
 // provided stream.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
+// If a register filter is provided in the RegisterOwnersMetadataKey and RegisterKeyPrefixesMetadataKey
+// metadata of the request, the execution data only contains the matching register updates.
+//
 // Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid resume token is provided, if invalid register filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeExecutionDataFromStartBlockHeight(request *executiondata.SubscribeExecutionDataFromStartBlockHeightRequest, stream executiondata.ExecutionDataAPI_SubscribeExecutionDataFromStartBlockHeightServer) error {
@@ -148,9 +222,32 @@ func (h *Handler) SubscribeExecutionDataFromStartBlockHeight(request *executiond
 	h.StreamCount.Add(1)
 	defer h.StreamCount.Add(-1)
 
-	sub := h.api.SubscribeExecutionDataFromStartBlockHeight(stream.Context(), request.GetStartBlockHeight())
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	filter, filtered, err := h.getRegisterFilter(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	if filtered {
+		sub = h.api.SubscribeFilteredExecutionDataFromStartBlockHeight(stream.Context(), startHeight, filter)
+	} else {
+		sub = h.api.SubscribeExecutionDataFromStartBlockHeight(stream.Context(), startHeight)
+	}
 
-	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion()))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion(), trailer))
 }
 
 // SubscribeExecutionDataFromLatest handles subscription requests for
@@ -158,7 +255,11 @@ func (h *Handler) SubscribeExecutionDataFromStartBlockHeight(request *executiond
 // subscription and sends the subscribed information to the client via the
 // provided stream.
 //
+// If a register filter is provided in the RegisterOwnersMetadataKey and RegisterKeyPrefixesMetadataKey
+// metadata of the request, the execution data only contains the matching register updates.
+//
 // Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid register filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeExecutionDataFromLatest(request *executiondata.SubscribeExecutionDataFromLatestRequest, stream executiondata.ExecutionDataAPI_SubscribeExecutionDataFromLatestServer) error {
@@ -169,9 +270,22 @@ func (h *Handler) SubscribeExecutionDataFromLatest(request *executiondata.Subscr
 	h.StreamCount.Add(1)
 	defer h.StreamCount.Add(-1)
 
-	sub := h.api.SubscribeExecutionDataFromLatest(stream.Context())
+	filter, filtered, err := h.getRegisterFilter(stream.Context())
+	if err != nil {
+		return err
+	}
 
-	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion()))
+	var sub subscription.Subscription
+	if filtered {
+		sub = h.api.SubscribeFilteredExecutionDataFromLatest(stream.Context(), filter)
+	} else {
+		sub = h.api.SubscribeExecutionDataFromLatest(stream.Context())
+	}
+
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, handleSubscribeExecutionData(stream.Send, request.GetEventEncodingVersion(), trailer))
 }
 
 // SubscribeEvents is deprecated and will be removed in a future version.
@@ -185,8 +299,11 @@ func (h *Handler) SubscribeExecutionDataFromLatest(request *executiondata.Subscr
 // clients to track which blocks were searched. Clients can use this
 // information to determine which block to start from when reconnecting.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument   - if provided both startBlockID and startHeight, if invalid startBlockID is provided, if invalid event filter is provided.
+// - codes.InvalidArgument   - if invalid resume token is provided, if provided both startBlockID and startHeight, if invalid startBlockID is provided, if invalid event filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeEvents(request *executiondata.SubscribeEventsRequest, stream executiondata.ExecutionDataAPI_SubscribeEventsServer) error {
@@ -206,14 +323,27 @@ func (h *Handler) SubscribeEvents(request *executiondata.SubscribeEventsRequest,
 		startBlockID = blockID
 	}
 
-	filter, err := h.getEventFilter(request.GetFilter())
+	filter, err := h.getEventFilter(stream.Context(), request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
 	if err != nil {
 		return err
 	}
 
-	sub := h.api.SubscribeEvents(stream.Context(), startBlockID, request.GetStartBlockHeight(), filter)
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeEventsFromStartHeight(stream.Context(), resumeToken.Height, filter)
+	} else {
+		sub = h.api.SubscribeEvents(stream.Context(), startBlockID, request.GetStartBlockHeight(), filter)
+	}
 
-	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion()))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion(), resumeToken, trailer))
 }
 
 // SubscribeEventsFromStartBlockID handles subscription requests for events starting at the specified block ID.
@@ -224,8 +354,11 @@ func (h *Handler) SubscribeEvents(request *executiondata.SubscribeEventsRequest,
 // clients to track which blocks were searched. Clients can use this
 // information to determine which block to start from when reconnecting.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument   - if invalid startBlockID is provided, if invalid event filter is provided.
+// - codes.InvalidArgument   - if invalid resume token is provided, if invalid startBlockID is provided, if invalid event filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeEventsFromStartBlockID(request *executiondata.SubscribeEventsFromStartBlockIDRequest, stream executiondata.ExecutionDataAPI_SubscribeEventsFromStartBlockIDServer) error {
@@ -241,14 +374,27 @@ func (h *Handler) SubscribeEventsFromStartBlockID(request *executiondata.Subscri
 		return status.Errorf(codes.InvalidArgument, "could not convert start block ID: %v", err)
 	}
 
-	filter, err := h.getEventFilter(request.GetFilter())
+	filter, err := h.getEventFilter(stream.Context(), request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
 	if err != nil {
 		return err
 	}
 
-	sub := h.api.SubscribeEventsFromStartBlockID(stream.Context(), startBlockID, filter)
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeEventsFromStartHeight(stream.Context(), resumeToken.Height, filter)
+	} else {
+		sub = h.api.SubscribeEventsFromStartBlockID(stream.Context(), startBlockID, filter)
+	}
+
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
 
-	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion()))
+	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion(), resumeToken, trailer))
 }
 
 // SubscribeEventsFromStartHeight handles subscription requests for events starting at the specified block height.
@@ -259,8 +405,11 @@ func (h *Handler) SubscribeEventsFromStartBlockID(request *executiondata.Subscri
 // clients to track which blocks were searched. Clients can use this
 // information to determine which block to start from when reconnecting.
 //
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
+//
 // Expected errors during normal operation:
-// - codes.InvalidArgument   - if invalid event filter is provided.
+// - codes.InvalidArgument   - if invalid resume token is provided, if invalid event filter is provided.
 // - codes.ResourceExhausted - if the maximum number of streams is reached.
 // - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
 func (h *Handler) SubscribeEventsFromStartHeight(request *executiondata.SubscribeEventsFromStartHeightRequest, stream executiondata.ExecutionDataAPI_SubscribeEventsFromStartHeightServer) error {
@@ -271,14 +420,27 @@ func (h *Handler) SubscribeEventsFromStartHeight(request *executiondata.Subscrib
 	h.StreamCount.Add(1)
 	defer h.StreamCount.Add(-1)
 
-	filter, err := h.getEventFilter(request.GetFilter())
+	filter, err := h.getEventFilter(stream.Context(), request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
 	if err != nil {
 		return err
 	}
 
-	sub := h.api.SubscribeEventsFromStartHeight(stream.Context(), request.GetStartBlockHeight(), filter)
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	sub := h.api.SubscribeEventsFromStartHeight(stream.Context(), startHeight, filter)
 
-	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion()))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion(), resumeToken, trailer))
 }
 
 // SubscribeEventsFromLatest handles subscription requests for events started from latest sealed block..
@@ -301,14 +463,17 @@ func (h *Handler) SubscribeEventsFromLatest(request *executiondata.SubscribeEven
 	h.StreamCount.Add(1)
 	defer h.StreamCount.Add(-1)
 
-	filter, err := h.getEventFilter(request.GetFilter())
+	filter, err := h.getEventFilter(stream.Context(), request.GetFilter())
 	if err != nil {
 		return err
 	}
 
 	sub := h.api.SubscribeEventsFromLatest(stream.Context(), filter)
 
-	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion()))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, h.handleEventsResponse(stream.Send, request.HeartbeatInterval, request.GetEventEncodingVersion(), nil, trailer))
 }
 
 // handleSubscribeExecutionData handles the subscription to execution data and sends it to the client via the provided stream.
@@ -316,12 +481,13 @@ func (h *Handler) SubscribeEventsFromLatest(request *executiondata.SubscribeEven
 //
 // Parameters:
 // - send: The function responsible for sending execution data in response to the client.
+// - trailer: Tracks the resume token of the responses sent to the client.
 //
 // Returns a function that can be used as a callback for execution data updates.
 //
 // Expected errors during normal operation:
 //   - codes.Internal - could not convert execution data to entity or could not convert execution data event payloads to JSON.
-func handleSubscribeExecutionData(send sendSubscribeExecutionDataResponseFunc, eventEncodingVersion entities.EventEncodingVersion) func(response *ExecutionDataResponse) error {
+func handleSubscribeExecutionData(send sendSubscribeExecutionDataResponseFunc, eventEncodingVersion entities.EventEncodingVersion, trailer *resumeTrailer) func(response *ExecutionDataResponse) error {
 	return func(resp *ExecutionDataResponse) error {
 		execData, err := convert.BlockExecutionDataToMessage(resp.ExecutionData)
 		if err != nil {
@@ -333,13 +499,20 @@ func handleSubscribeExecutionData(send sendSubscribeExecutionDataResponseFunc, e
 			return status.Errorf(codes.Internal, "could not convert execution data event payloads to JSON: %v", err)
 		}
 
-		err = send(&executiondata.SubscribeExecutionDataResponse{
+		msg := &executiondata.SubscribeExecutionDataResponse{
 			BlockHeight:        resp.Height,
 			BlockExecutionData: execData,
 			BlockTimestamp:     timestamppb.New(resp.BlockTimestamp),
-		})
+		}
+		subscription.SetMessageResumeToken(msg, resp.ResumeToken)
 
-		return err
+		err = send(msg)
+		if err != nil {
+			return err
+		}
+
+		trailer.update(resp.ResumeToken)
+		return nil
 	}
 }
 
@@ -348,13 +521,23 @@ func handleSubscribeExecutionData(send sendSubscribeExecutionDataResponseFunc, e
 // It takes a EventsResponse, processes it, and sends the corresponding response to the client using the provided send function.
 //
 // Parameters:
-// - send: The function responsible for sending events response to the client.
+// - send: The function responsible for sending events response to the client. The events of blocks with more than
+// maxEventsPerResponse events are sent in several messages, split at transaction boundaries.
+// - resumeToken: The resume token the subscription was resumed from, or nil. Events delivered before the
+// token was issued are not sent again.
+// - trailer: Tracks the resume token of the responses processed for the client.
 //
 // Returns a function that can be used as a callback for events updates.
 //
 // Expected errors during normal operation:
 //   - codes.Internal - could not convert events to entity or the stream could not send a response.
-func (h *Handler) handleEventsResponse(send sendSubscribeEventsResponseFunc, heartbeatInterval uint64, eventEncodingVersion entities.EventEncodingVersion) func(*EventsResponse) error {
+func (h *Handler) handleEventsResponse(
+	send sendSubscribeEventsResponseFunc,
+	heartbeatInterval uint64,
+	eventEncodingVersion entities.EventEncodingVersion,
+	resumeToken *subscription.ResumeToken,
+	trailer *resumeTrailer,
+) func(*EventsResponse) error {
 	if heartbeatInterval == 0 {
 		heartbeatInterval = h.defaultHeartbeatInterval
 	}
@@ -363,55 +546,71 @@ func (h *Handler) handleEventsResponse(send sendSubscribeEventsResponseFunc, hea
 	messageIndex := counters.NewMonotonousCounter(0)
 
 	return func(resp *EventsResponse) error {
+		resp.Events = resumeToken.FilterEvents(resp.Height, resp.Events)
+
 		// check if there are any events in the response. if not, do not send a message unless the last
 		// response was more than HeartbeatInterval blocks ago
 		if len(resp.Events) == 0 {
 			blocksSinceLastMessage++
 			if blocksSinceLastMessage < heartbeatInterval {
+				trailer.update(resp.ResumeToken)
 				return nil
 			}
 			blocksSinceLastMessage = 0
 		}
 
-		// BlockExecutionData contains CCF encoded events, and the Access API returns JSON-CDC events.
-		// convert event payload formats.
-		// This is a temporary solution until the Access API supports specifying the encoding in the request
-		events, err := convert.EventsToMessagesWithEncodingConversion(resp.Events, entities.EventEncodingVersion_CCF_V0, eventEncodingVersion)
-		if err != nil {
-			return status.Errorf(codes.Internal, "could not convert events to entity: %v", err)
-		}
+		// the events of large blocks are sent in several messages, each carrying its position in the block
+		for _, part := range subscription.SplitEvents(resp.Height, resp.Events, h.maxEventsPerResponse) {
+			// BlockExecutionData contains CCF encoded events, and the Access API returns JSON-CDC events.
+			// convert event payload formats.
+			// This is a temporary solution until the Access API supports specifying the encoding in the request
+			events, err := convert.EventsToMessagesWithEncodingConversion(part.Events, entities.EventEncodingVersion_CCF_V0, eventEncodingVersion)
+			if err != nil {
+				return status.Errorf(codes.Internal, "could not convert events to entity: %v", err)
+			}
 
-		index := messageIndex.Increment()
+			index := messageIndex.Increment()
+			token := part.ResumeToken.Encode()
 
-		err = send(&executiondata.SubscribeEventsResponse{
-			BlockHeight:    resp.Height,
-			BlockId:        convert.IdentifierToMessage(resp.BlockID),
-			Events:         events,
-			BlockTimestamp: timestamppb.New(resp.BlockTimestamp),
-			MessageIndex:   index,
-		})
-		if err != nil {
-			return rpc.ConvertError(err, "could not send response", codes.Internal)
-		}
+			msg := &executiondata.SubscribeEventsResponse{
+				BlockHeight:    resp.Height,
+				BlockId:        convert.IdentifierToMessage(resp.BlockID),
+				Events:         events,
+				BlockTimestamp: timestamppb.New(resp.BlockTimestamp),
+				MessageIndex:   index,
+			}
+			subscription.SetMessageResumeToken(msg, token)
+
+			err = send(msg)
+			if err != nil {
+				return rpc.ConvertError(err, "could not send response", codes.Internal)
+			}
 
+			trailer.update(token)
+		}
 		return nil
 	}
 }
 
 // getEventFilter returns an event filter based on the provided event filter configuration.
-// If the event filter is nil, it returns an empty filter.
+// If the event filter is nil and no field predicates are provided, it returns an empty filter.
 // Otherwise, it initializes a new event filter using the provided filter parameters,
 // including the event type, address, and contract. It then validates the filter configuration
 // and returns the constructed event filter or an error if the filter configuration is invalid.
 // The event filter is used for subscription to events.
 //
+// Since the event filter message has no field for them, event field predicates are read from the
+// EventFieldPredicatesMetadataKey metadata of the request, with one predicate per value.
+//
 // Parameters:
+// - ctx: Context of the stream, carrying the metadata of the request.
 // - eventFilter: executiondata.EventFilter object containing filter parameters.
 //
 // Expected errors during normal operation:
 // - codes.InvalidArgument - if the provided event filter is invalid.
-func (h *Handler) getEventFilter(eventFilter *executiondata.EventFilter) (state_stream.EventFilter, error) {
-	if eventFilter == nil {
+func (h *Handler) getEventFilter(ctx context.Context, eventFilter *executiondata.EventFilter) (state_stream.EventFilter, error) {
+	fieldPredicates := metadata.ValueFromIncomingContext(ctx, EventFieldPredicatesMetadataKey)
+	if eventFilter == nil && len(fieldPredicates) == 0 {
 		return state_stream.EventFilter{}, nil
 	}
 	filter, err := state_stream.NewEventFilter(
@@ -420,6 +619,7 @@ func (h *Handler) getEventFilter(eventFilter *executiondata.EventFilter) (state_
 		eventFilter.GetEventType(),
 		eventFilter.GetAddress(),
 		eventFilter.GetContract(),
+		fieldPredicates,
 	)
 	if err != nil {
 		return filter, status.Errorf(codes.InvalidArgument, "invalid event filter: %v", err)
@@ -427,6 +627,48 @@ func (h *Handler) getEventFilter(eventFilter *executiondata.EventFilter) (state_
 	return filter, nil
 }
 
+// getRegisterFilter returns the register filter of a filtered execution data subscription, read from the
+// RegisterOwnersMetadataKey and RegisterKeyPrefixesMetadataKey metadata of the request. The returned bool
+// is false if the request does not contain a register filter.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if the provided register filter is invalid.
+func (h *Handler) getRegisterFilter(ctx context.Context) (state_stream.RegisterFilter, bool, error) {
+	owners := metadata.ValueFromIncomingContext(ctx, RegisterOwnersMetadataKey)
+	keyPrefixes := metadata.ValueFromIncomingContext(ctx, RegisterKeyPrefixesMetadataKey)
+	if len(owners) == 0 && len(keyPrefixes) == 0 {
+		return state_stream.RegisterFilter{}, false, nil
+	}
+	filter, err := state_stream.NewRegisterFilter(h.eventFilterConfig, h.chain, owners, keyPrefixes)
+	if err != nil {
+		return filter, false, status.Errorf(codes.InvalidArgument, "invalid register filter: %v", err)
+	}
+	return filter, true, nil
+}
+
+// resumeTrailer keeps track of the resume token of the last response processed by a stream, and sets it
+// in the trailer of the stream once the subscription ends.
+type resumeTrailer struct {
+	stream grpc.ServerStream
+	token  string
+}
+
+func newResumeTrailer(stream grpc.ServerStream) *resumeTrailer {
+	return &resumeTrailer{stream: stream}
+}
+
+// update records the resume token of a processed response.
+func (t *resumeTrailer) update(token string) {
+	t.token = token
+}
+
+// set sets the last recorded resume token in the trailer of the stream, if any.
+func (t *resumeTrailer) set() {
+	if t.token != "" {
+		t.stream.SetTrailer(metadata.Pairs(ResumeTokenMetadataKey, t.token))
+	}
+}
+
 func (h *Handler) GetRegisterValues(_ context.Context, request *executiondata.GetRegisterValuesRequest) (*executiondata.GetRegisterValuesResponse, error) {
 	// Convert data
 	registerIDs, err := convert.MessagesToRegisterIDs(request.GetRegisterIds(), h.chain)
@@ -443,13 +685,13 @@ func (h *Handler) GetRegisterValues(_ context.Context, request *executiondata.Ge
 	return &executiondata.GetRegisterValuesResponse{Values: values}, nil
 }
 
-// convertAccountsStatusesResultsToMessage converts account status responses to the message
+// convertAccountsStatusesResultsToMessage converts the events of account status responses to the message
 func convertAccountsStatusesResultsToMessage(
 	eventVersion entities.EventEncodingVersion,
-	resp *AccountStatusesResponse,
+	accountEvents map[string]flow.EventsList,
 ) ([]*executiondata.SubscribeAccountStatusesResponse_Result, error) {
 	var results []*executiondata.SubscribeAccountStatusesResponse_Result
-	for address, events := range resp.AccountEvents {
+	for address, events := range accountEvents {
 		convertedEvent, err := convert.EventsToMessagesWithEncodingConversion(events, entities.EventEncodingVersion_CCF_V0, eventVersion)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "could not convert events to entity: %v", err)
@@ -467,10 +709,14 @@ func convertAccountsStatusesResultsToMessage(
 type sendSubscribeAccountStatusesResponseFunc func(*executiondata.SubscribeAccountStatusesResponse) error
 
 // handleAccountStatusesResponse handles account status responses by converting them to the message and sending them to the subscriber.
+// The events of blocks with more than maxEventsPerResponse events are sent in several messages, split at transaction boundaries.
+// If the subscription was resumed from a resume token, events delivered before the token was issued are not sent again.
 func (h *Handler) handleAccountStatusesResponse(
 	heartbeatInterval uint64,
 	evenVersion entities.EventEncodingVersion,
 	send sendSubscribeAccountStatusesResponseFunc,
+	resumeToken *subscription.ResumeToken,
+	trailer *resumeTrailer,
 ) func(resp *AccountStatusesResponse) error {
 	if heartbeatInterval == 0 {
 		heartbeatInterval = h.defaultHeartbeatInterval
@@ -480,33 +726,44 @@ func (h *Handler) handleAccountStatusesResponse(
 	messageIndex := counters.NewMonotonousCounter(0)
 
 	return func(resp *AccountStatusesResponse) error {
+		resp.AccountEvents = resumeToken.FilterAccountEvents(resp.Height, resp.AccountEvents)
+
 		// check if there are any events in the response. if not, do not send a message unless the last
 		// response was more than HeartbeatInterval blocks ago
 		if len(resp.AccountEvents) == 0 {
 			blocksSinceLastMessage++
 			if blocksSinceLastMessage < heartbeatInterval {
+				trailer.update(resp.ResumeToken)
 				return nil
 			}
 			blocksSinceLastMessage = 0
 		}
 
-		results, err := convertAccountsStatusesResultsToMessage(evenVersion, resp)
-		if err != nil {
-			return err
-		}
+		// the events of large blocks are sent in several messages, each carrying its position in the block
+		for _, part := range subscription.SplitAccountEvents(resp.Height, resp.AccountEvents, h.maxEventsPerResponse) {
+			results, err := convertAccountsStatusesResultsToMessage(evenVersion, part.AccountEvents)
+			if err != nil {
+				return err
+			}
 
-		index := messageIndex.Increment()
+			index := messageIndex.Increment()
+			token := part.ResumeToken.Encode()
 
-		err = send(&executiondata.SubscribeAccountStatusesResponse{
-			BlockId:      convert.IdentifierToMessage(resp.BlockID),
-			BlockHeight:  resp.Height,
-			Results:      results,
-			MessageIndex: index,
-		})
-		if err != nil {
-			return rpc.ConvertError(err, "could not send response", codes.Internal)
-		}
+			msg := &executiondata.SubscribeAccountStatusesResponse{
+				BlockId:      convert.IdentifierToMessage(resp.BlockID),
+				BlockHeight:  resp.Height,
+				Results:      results,
+				MessageIndex: index,
+			}
+			subscription.SetMessageResumeToken(msg, token)
+
+			err = send(msg)
+			if err != nil {
+				return rpc.ConvertError(err, "could not send response", codes.Internal)
+			}
 
+			trailer.update(token)
+		}
 		return nil
 	}
 }
@@ -515,6 +772,9 @@ func (h *Handler) handleAccountStatusesResponse(
 // start block ID, up until the latest available block. Once the latest is
 // reached, the stream will remain open and responses are sent for each new
 // block as it becomes available.
+//
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
 func (h *Handler) SubscribeAccountStatusesFromStartBlockID(
 	request *executiondata.SubscribeAccountStatusesFromStartBlockIDRequest,
 	stream executiondata.ExecutionDataAPI_SubscribeAccountStatusesFromStartBlockIDServer,
@@ -538,15 +798,31 @@ func (h *Handler) SubscribeAccountStatusesFromStartBlockID(
 		return status.Errorf(codes.InvalidArgument, "could not create account status filter: %v", err)
 	}
 
-	sub := h.api.SubscribeAccountStatusesFromStartBlockID(stream.Context(), startBlockID, filter)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	var sub subscription.Subscription
+	if resumeToken != nil {
+		sub = h.api.SubscribeAccountStatusesFromStartHeight(stream.Context(), resumeToken.Height, filter)
+	} else {
+		sub = h.api.SubscribeAccountStatusesFromStartBlockID(stream.Context(), startBlockID, filter)
+	}
+
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
 
-	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send))
+	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send, resumeToken, trailer))
 }
 
 // SubscribeAccountStatusesFromStartHeight streams account statuses for all blocks starting at the requested
 // start block height, up until the latest available block. Once the latest is
 // reached, the stream will remain open and responses are sent for each new
 // block as it becomes available.
+//
+// If a resume token is provided in the ResumeTokenMetadataKey metadata of the request, the subscription
+// resumes from the token instead of the requested start block.
 func (h *Handler) SubscribeAccountStatusesFromStartHeight(
 	request *executiondata.SubscribeAccountStatusesFromStartHeightRequest,
 	stream executiondata.ExecutionDataAPI_SubscribeAccountStatusesFromStartHeightServer,
@@ -565,9 +841,22 @@ func (h *Handler) SubscribeAccountStatusesFromStartHeight(
 		return status.Errorf(codes.InvalidArgument, "could not create account status filter: %v", err)
 	}
 
-	sub := h.api.SubscribeAccountStatusesFromStartHeight(stream.Context(), request.GetStartBlockHeight(), filter)
+	resumeToken, err := subscription.ResumeTokenFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	startHeight := request.GetStartBlockHeight()
+	if resumeToken != nil {
+		startHeight = resumeToken.Height
+	}
+
+	sub := h.api.SubscribeAccountStatusesFromStartHeight(stream.Context(), startHeight, filter)
 
-	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send, resumeToken, trailer))
 }
 
 // SubscribeAccountStatusesFromLatestBlock streams account statuses for all blocks starting
@@ -594,5 +883,8 @@ func (h *Handler) SubscribeAccountStatusesFromLatestBlock(
 
 	sub := h.api.SubscribeAccountStatusesFromLatestBlock(stream.Context(), filter)
 
-	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send))
+	trailer := newResumeTrailer(stream)
+	defer trailer.set()
+
+	return subscription.HandleSubscription(sub, h.handleAccountStatusesResponse(request.HeartbeatInterval, request.GetEventEncodingVersion(), stream.Send, nil, trailer))
 }
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"sync"
@@ -15,6 +16,7 @@ import (
 	pb "google.golang.org/genproto/googleapis/bytestream"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/cadence/encoding/ccf"
@@ -508,6 +510,181 @@ func TestEventStream(t *testing.T) {
 	}
 }
 
+// TestEventStreamResume tests that event subscriptions resume from the resume token provided in the
+// request metadata, that each response carries its resume token, and that the resume token of the last
+// response is returned in the trailer of the stream.
+func TestEventStreamResume(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockHeight := uint64(10)
+	ccfEvents, _ := generateEvents(t, 3)
+	resumeToken := subscription.NewResumeToken(blockHeight, ccfEvents[1].TransactionIndex)
+	nextToken := subscription.NewResumeToken(blockHeight+1, 0).Encode()
+
+	t.Run("resumes from token", func(t *testing.T) {
+		streamCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(ResumeTokenMetadataKey, resumeToken.Encode()))
+		stream := makeStreamMock[executiondata.SubscribeEventsFromStartHeightRequest, executiondata.SubscribeEventsResponse](streamCtx)
+
+		sub := subscription.NewSubscription(1)
+		api := ssmock.NewAPI(t)
+		api.On("SubscribeEventsFromStartHeight", mock.Anything, blockHeight, mock.Anything).Return(sub)
+		h := NewHandler(api, flow.Localnet.Chain(), makeConfig(1))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := h.SubscribeEventsFromStartHeight(&executiondata.SubscribeEventsFromStartHeightRequest{
+				StartBlockHeight:     1,
+				EventEncodingVersion: entities.EventEncodingVersion_CCF_V0,
+			}, stream)
+			require.NoError(t, err)
+		}()
+
+		err := sub.Send(ctx, &EventsResponse{
+			Height:      blockHeight,
+			Events:      ccfEvents,
+			ResumeToken: nextToken,
+		}, 100*time.Millisecond)
+		require.NoError(t, err)
+		sub.Close()
+
+		resp, err := stream.RecvToClient()
+		require.NoError(t, err)
+		// events of transactions before the index of the token were already delivered
+		assert.Equal(t, ccfEvents[1:], convert.MessagesToEvents(resp.GetEvents()))
+		token, ok := subscription.MessageResumeToken(resp)
+		require.True(t, ok)
+		assert.Equal(t, nextToken, token)
+
+		unittest.RequireCloseBefore(t, done, time.Second, "timed out waiting for stream to end")
+		assert.Equal(t, []string{nextToken}, stream.trailer.Get(ResumeTokenMetadataKey))
+	})
+
+	t.Run("splits large blocks", func(t *testing.T) {
+		stream := makeStreamMock[executiondata.SubscribeEventsFromStartHeightRequest, executiondata.SubscribeEventsResponse](ctx)
+
+		sub := subscription.NewSubscription(1)
+		api := ssmock.NewAPI(t)
+		api.On("SubscribeEventsFromStartHeight", mock.Anything, blockHeight, mock.Anything).Return(sub)
+		config := makeConfig(1)
+		config.MaxEventsPerResponse = 1
+		h := NewHandler(api, flow.Localnet.Chain(), config)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := h.SubscribeEventsFromStartHeight(&executiondata.SubscribeEventsFromStartHeightRequest{
+				StartBlockHeight:     blockHeight,
+				EventEncodingVersion: entities.EventEncodingVersion_CCF_V0,
+			}, stream)
+			require.NoError(t, err)
+		}()
+
+		err := sub.Send(ctx, &EventsResponse{
+			Height:      blockHeight,
+			Events:      ccfEvents,
+			ResumeToken: nextToken,
+		}, 100*time.Millisecond)
+		require.NoError(t, err)
+		sub.Close()
+
+		// each event is sent in its own response, which resumes from the transaction of the next event
+		for i, event := range ccfEvents {
+			resp, err := stream.RecvToClient()
+			require.NoError(t, err)
+			assert.Equal(t, ccfEvents[i:i+1], convert.MessagesToEvents(resp.GetEvents()))
+			assert.Equal(t, uint64(i+1), resp.GetMessageIndex())
+
+			expected := nextToken
+			if i < len(ccfEvents)-1 {
+				expected = subscription.NewResumeToken(blockHeight, ccfEvents[i+1].TransactionIndex).Encode()
+			}
+			token, ok := subscription.MessageResumeToken(resp)
+			require.True(t, ok)
+			assert.Equal(t, expected, token, "unexpected token after event of transaction %d", event.TransactionIndex)
+		}
+
+		unittest.RequireCloseBefore(t, done, time.Second, "timed out waiting for stream to end")
+		assert.Equal(t, []string{nextToken}, stream.trailer.Get(ResumeTokenMetadataKey))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		streamCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(ResumeTokenMetadataKey, "invalid"))
+		stream := makeStreamMock[executiondata.SubscribeEventsFromStartHeightRequest, executiondata.SubscribeEventsResponse](streamCtx)
+
+		h := NewHandler(ssmock.NewAPI(t), flow.Localnet.Chain(), makeConfig(1))
+		err := h.SubscribeEventsFromStartHeight(&executiondata.SubscribeEventsFromStartHeightRequest{}, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestExecutionDataStreamRegisterFilter tests that the register filter provided in the request metadata
+// is used to subscribe to filtered execution data.
+func TestExecutionDataStreamRegisterFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockHeight := uint64(10)
+	chain := flow.Localnet.Chain()
+	owner := chain.ServiceAddress().Hex()
+	keyPrefix := base64.StdEncoding.EncodeToString([]byte("public_key_"))
+
+	t.Run("subscribes to filtered execution data", func(t *testing.T) {
+		streamCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(
+			RegisterOwnersMetadataKey, owner,
+			RegisterKeyPrefixesMetadataKey, keyPrefix,
+		))
+		stream := makeStreamMock[executiondata.SubscribeExecutionDataFromStartBlockHeightRequest, executiondata.SubscribeExecutionDataResponse](streamCtx)
+
+		filter, err := state_stream.NewRegisterFilter(makeConfig(1).EventFilterConfig, chain, []string{owner}, []string{keyPrefix})
+		require.NoError(t, err)
+
+		sub := subscription.NewSubscription(1)
+		api := ssmock.NewAPI(t)
+		api.On("SubscribeFilteredExecutionDataFromStartBlockHeight", mock.Anything, blockHeight, filter).Return(sub)
+		h := NewHandler(api, chain, makeConfig(1))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := h.SubscribeExecutionDataFromStartBlockHeight(&executiondata.SubscribeExecutionDataFromStartBlockHeightRequest{
+				StartBlockHeight:     blockHeight,
+				EventEncodingVersion: entities.EventEncodingVersion_CCF_V0,
+			}, stream)
+			require.NoError(t, err)
+		}()
+
+		executionData := unittest.BlockExecutionDataFixture()
+		err = sub.Send(ctx, &ExecutionDataResponse{
+			Height:        blockHeight,
+			ExecutionData: executionData,
+		}, 100*time.Millisecond)
+		require.NoError(t, err)
+		sub.Close()
+
+		resp, err := stream.RecvToClient()
+		require.NoError(t, err)
+		assert.Equal(t, blockHeight, resp.GetBlockHeight())
+		assert.Equal(t, executionData.BlockID[:], resp.GetBlockExecutionData().GetBlockId())
+
+		unittest.RequireCloseBefore(t, done, time.Second, "timed out waiting for stream to end")
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		streamCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(RegisterKeyPrefixesMetadataKey, "public_key_"))
+		stream := makeStreamMock[executiondata.SubscribeExecutionDataFromLatestRequest, executiondata.SubscribeExecutionDataResponse](streamCtx)
+
+		h := NewHandler(ssmock.NewAPI(t), chain, makeConfig(1))
+		err := h.SubscribeExecutionDataFromLatest(&executiondata.SubscribeExecutionDataFromLatestRequest{}, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 // TestGetRegisterValues tests the register values.
 func TestGetRegisterValues(t *testing.T) {
 	t.Parallel()
@@ -636,11 +813,16 @@ type StreamMock[R, T any] struct {
 	ctx            context.Context
 	recvToServer   chan *R
 	sentFromServer chan *T
+	trailer        metadata.MD
 }
 
 func (m *StreamMock[R, T]) Context() context.Context {
 	return m.ctx
 }
+
+func (m *StreamMock[R, T]) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
 func (m *StreamMock[R, T]) Send(resp *T) error {
 	m.sentFromServer <- resp
 	return nil
@@ -0,0 +1,530 @@
+package state_stream
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// maxPredicateNesting is the maximum nesting depth of parentheses in an event field predicate.
+const maxPredicateNesting = 16
+
+// EventFieldPredicate is a predicate on the decoded fields of the events of a single type, e.g.
+//
+//	A.1654653399040a61.FlowToken.TokensDeposited where to == 0x8624b52f9ddcd04a and amount > 100.0
+//
+// Fields are compared to literals using ==, !=, <, <=, > and >=, or tested for membership in a set of
+// literals using `in [...]`. Comparisons can be combined using `and`, `or` and parentheses, where `and`
+// binds stronger than `or`. Literals are numbers, double-quoted strings, addresses, and the booleans
+// true and false. Numbers and strings can be ordered, addresses and booleans can only be tested for
+// equality.
+//
+// Comparisons on fields which are missing, nil, or of a type which does not match the literal never match.
+type EventFieldPredicate struct {
+	EventType flow.EventType
+
+	expr  predicateExpr
+	terms int
+}
+
+// ParseEventFieldPredicate parses an event field predicate of the form `<event type> where <expression>`.
+//
+// Expected errors during normal operation:
+//   - if the predicate is malformed, or the event type is not valid for the chain
+func ParseEventFieldPredicate(raw string, chain flow.Chain) (*EventFieldPredicate, error) {
+	rawType, rawExpr, ok := cutKeyword(strings.TrimSpace(raw), "where")
+	if !ok {
+		return nil, fmt.Errorf("invalid event field predicate %q: expected '<event type> where <expression>'", raw)
+	}
+
+	eventType := flow.EventType(rawType)
+	if err := validateEventType(eventType, chain); err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenizePredicate(rawExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event field predicate %q: %w", raw, err)
+	}
+
+	p := &predicateParser{tokens: tokens}
+	expr, err := p.parseOr(0)
+	if err == nil && !p.done() {
+		err = fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid event field predicate %q: %w", raw, err)
+	}
+
+	return &EventFieldPredicate{
+		EventType: eventType,
+		expr:      expr,
+		terms:     p.terms,
+	}, nil
+}
+
+// Terms returns the number of comparisons evaluated by the predicate, counting each element of a set
+// separately. It is used to limit the cost of evaluating predicates.
+func (p *EventFieldPredicate) Terms() int {
+	return p.terms
+}
+
+// Match returns true if the decoded event fields satisfy the predicate.
+func (p *EventFieldPredicate) Match(fields map[string]cadence.Value) bool {
+	return p.expr.eval(fields)
+}
+
+// cutKeyword splits s around the first occurrence of the whitespace separated keyword, ignoring case.
+func cutKeyword(s string, keyword string) (before string, after string, found bool) {
+	fields := strings.Fields(s)
+	if len(fields) < 3 || !strings.EqualFold(fields[1], keyword) {
+		return "", "", false
+	}
+	before = fields[0]
+	rest := strings.TrimSpace(strings.TrimPrefix(s, before))
+	after = strings.TrimSpace(rest[len(keyword):])
+	return before, after, true
+}
+
+type predicateExpr interface {
+	eval(fields map[string]cadence.Value) bool
+}
+
+type andExpr struct {
+	left, right predicateExpr
+}
+
+func (e *andExpr) eval(fields map[string]cadence.Value) bool {
+	return e.left.eval(fields) && e.right.eval(fields)
+}
+
+type orExpr struct {
+	left, right predicateExpr
+}
+
+func (e *orExpr) eval(fields map[string]cadence.Value) bool {
+	return e.left.eval(fields) || e.right.eval(fields)
+}
+
+type comparisonExpr struct {
+	field string
+	op    string
+	value predicateLiteral
+}
+
+func (e *comparisonExpr) eval(fields map[string]cadence.Value) bool {
+	cmp, ok := e.value.compare(fields[e.field])
+	if !ok {
+		return false
+	}
+
+	switch e.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+type inExpr struct {
+	field  string
+	values []predicateLiteral
+}
+
+func (e *inExpr) eval(fields map[string]cadence.Value) bool {
+	value := fields[e.field]
+	for _, literal := range e.values {
+		if cmp, ok := literal.compare(value); ok && cmp == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type literalKind int
+
+const (
+	numberLiteral literalKind = iota
+	stringLiteral
+	addressLiteral
+	boolLiteral
+)
+
+// predicateLiteral is a literal value of a predicate expression.
+type predicateLiteral struct {
+	kind    literalKind
+	number  *big.Rat
+	str     string
+	address flow.Address
+	boolean bool
+}
+
+// ordered returns true if values can be ordered relative to the literal.
+func (l predicateLiteral) ordered() bool {
+	return l.kind == numberLiteral || l.kind == stringLiteral
+}
+
+// compare compares the value to the literal, and returns -1, 0 or +1 if the value is less than, equal
+// to or greater than the literal. It returns false if the value is nil or its type does not match the
+// literal.
+func (l predicateLiteral) compare(value cadence.Value) (int, bool) {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if value == nil {
+		return 0, false
+	}
+
+	switch l.kind {
+	case numberLiteral:
+		if !isNumber(value) {
+			return 0, false
+		}
+		number, ok := new(big.Rat).SetString(value.String())
+		if !ok {
+			return 0, false
+		}
+		return number.Cmp(l.number), true
+	case stringLiteral:
+		str, ok := value.(cadence.String)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(string(str), l.str), true
+	case addressLiteral:
+		address, ok := value.(cadence.Address)
+		if !ok {
+			return 0, false
+		}
+		if flow.Address(address) == l.address {
+			return 0, true
+		}
+		return 1, true
+	case boolLiteral:
+		boolean, ok := value.(cadence.Bool)
+		if !ok {
+			return 0, false
+		}
+		if bool(boolean) == l.boolean {
+			return 0, true
+		}
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+// isNumber returns true if the value is a Cadence integer or fixed point number.
+func isNumber(value cadence.Value) bool {
+	switch value.(type) {
+	case cadence.Int, cadence.Int8, cadence.Int16, cadence.Int32, cadence.Int64, cadence.Int128, cadence.Int256,
+		cadence.UInt, cadence.UInt8, cadence.UInt16, cadence.UInt32, cadence.UInt64, cadence.UInt128, cadence.UInt256,
+		cadence.Word8, cadence.Word16, cadence.Word32, cadence.Word64, cadence.Word128, cadence.Word256,
+		cadence.Fix64, cadence.UFix64:
+		return true
+	default:
+		return false
+	}
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	numberToken
+	stringToken
+	addressToken
+	operatorToken
+)
+
+type predicateToken struct {
+	kind tokenKind
+	text string
+}
+
+// tokenizePredicate splits a predicate expression into tokens.
+func tokenizePredicate(s string) ([]predicateToken, error) {
+	var tokens []predicateToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case strings.ContainsRune("()[],", rune(c)):
+			tokens = append(tokens, predicateToken{kind: operatorToken, text: string(c)})
+			i++
+
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(s) && s[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q", op)
+			}
+			tokens = append(tokens, predicateToken{kind: operatorToken, text: op})
+			i += len(op)
+
+		case c == '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			str, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s: %w", s[i:end+1], err)
+			}
+			tokens = append(tokens, predicateToken{kind: stringToken, text: str})
+			i = end + 1
+
+		case c == '0' && i+1 < len(s) && (s[i+1] == 'x' || s[i+1] == 'X'):
+			end := i + 2
+			for end < len(s) && isIdentChar(s[end]) {
+				end++
+			}
+			tokens = append(tokens, predicateToken{kind: addressToken, text: strings.ToLower(s[i:end])})
+			i = end
+
+		case c == '-' || c == '.' || isDigit(c):
+			end := i + 1
+			for end < len(s) && (s[end] == '.' || isDigit(s[end])) {
+				end++
+			}
+			tokens = append(tokens, predicateToken{kind: numberToken, text: s[i:end]})
+			i = end
+
+		case isIdentChar(c):
+			end := i + 1
+			for end < len(s) && isIdentChar(s[end]) {
+				end++
+			}
+			tokens = append(tokens, predicateToken{kind: identToken, text: s[i:end]})
+			i = end
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || isDigit(c)
+}
+
+// predicateParser is a recursive descent parser of predicate expressions:
+//
+//	or         = and { "or" and }
+//	and        = primary { "and" primary }
+//	primary    = "(" or ")" | field op literal | field "in" "[" literal { "," literal } "]"
+type predicateParser struct {
+	tokens []predicateToken
+	pos    int
+	terms  int
+}
+
+func (p *predicateParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *predicateParser) peek() predicateToken {
+	if p.done() {
+		return predicateToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *predicateParser) next() (predicateToken, error) {
+	if p.done() {
+		return predicateToken{}, fmt.Errorf("unexpected end of expression")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token, nil
+}
+
+func (p *predicateParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return !p.done() && token.kind == identToken && strings.EqualFold(token.text, keyword)
+}
+
+func (p *predicateParser) isOperator(op string) bool {
+	token := p.peek()
+	return !p.done() && token.kind == operatorToken && token.text == op
+}
+
+func (p *predicateParser) expectOperator(op string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if token.kind != operatorToken || token.text != op {
+		return fmt.Errorf("expected %q, got %q", op, token.text)
+	}
+	return nil
+}
+
+func (p *predicateParser) parseOr(depth int) (predicateExpr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *predicateParser) parseAnd(depth int) (predicateExpr, error) {
+	left, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *predicateParser) parsePrimary(depth int) (predicateExpr, error) {
+	if p.isOperator("(") {
+		if depth >= maxPredicateNesting {
+			return nil, fmt.Errorf("expression is nested deeper than %d levels", maxPredicateNesting)
+		}
+		p.pos++
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if field.kind != identToken {
+		return nil, fmt.Errorf("expected field name, got %q", field.text)
+	}
+
+	if p.isKeyword("in") {
+		p.pos++
+		return p.parseSet(field.text)
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch op.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		return nil, fmt.Errorf("expected comparison operator, got %q", op.text)
+	}
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	if op.text != "==" && op.text != "!=" && !value.ordered() {
+		return nil, fmt.Errorf("operator %s is only supported for numbers and strings", op.text)
+	}
+
+	p.terms++
+	return &comparisonExpr{field: field.text, op: op.text, value: value}, nil
+}
+
+func (p *predicateParser) parseSet(field string) (predicateExpr, error) {
+	if err := p.expectOperator("["); err != nil {
+		return nil, err
+	}
+
+	expr := &inExpr{field: field}
+	for {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		expr.values = append(expr.values, value)
+		p.terms++
+
+		if p.isOperator(",") {
+			p.pos++
+			continue
+		}
+		if err := p.expectOperator("]"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+}
+
+func (p *predicateParser) parseLiteral() (predicateLiteral, error) {
+	token, err := p.next()
+	if err != nil {
+		return predicateLiteral{}, err
+	}
+
+	switch token.kind {
+	case numberToken:
+		number, ok := new(big.Rat).SetString(token.text)
+		if !ok {
+			return predicateLiteral{}, fmt.Errorf("invalid number %q", token.text)
+		}
+		return predicateLiteral{kind: numberLiteral, number: number}, nil
+	case stringToken:
+		return predicateLiteral{kind: stringLiteral, str: token.text}, nil
+	case addressToken:
+		address, err := flow.StringToAddress(token.text)
+		if err != nil {
+			return predicateLiteral{}, fmt.Errorf("invalid address %q: %w", token.text, err)
+		}
+		return predicateLiteral{kind: addressLiteral, address: address}, nil
+	case identToken:
+		switch strings.ToLower(token.text) {
+		case "true":
+			return predicateLiteral{kind: boolLiteral, boolean: true}, nil
+		case "false":
+			return predicateLiteral{kind: boolLiteral, boolean: false}, nil
+		}
+	}
+	return predicateLiteral{}, fmt.Errorf("expected literal, got %q", token.text)
+}
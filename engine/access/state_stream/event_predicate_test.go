@@ -0,0 +1,234 @@
+package state_stream_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const depositedEventType = "A.0000000000000001.Token.Deposited"
+
+// depositedEvent returns a CCF encoded Deposited event with the given fields.
+func depositedEvent(t *testing.T, to flow.Address, amount string, memo string, fee cadence.Value) flow.Event {
+	ufix, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+	str, err := cadence.NewString(memo)
+	require.NoError(t, err)
+
+	cadenceEvent := cadence.NewEvent([]cadence.Value{
+		cadence.NewAddress(to),
+		ufix,
+		str,
+		cadence.NewOptional(fee),
+	}).WithType(&cadence.EventType{
+		Location:            common.AddressLocation{Address: common.Address(flow.HexToAddress("0000000000000001")), Name: "Token"},
+		QualifiedIdentifier: "Token.Deposited",
+		Fields: []cadence.Field{
+			{Identifier: "to", Type: cadence.AddressType},
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "memo", Type: cadence.StringType},
+			{Identifier: "fee", Type: cadence.NewOptionalType(cadence.UInt64Type)},
+		},
+	})
+
+	payload, err := ccf.Encode(cadenceEvent)
+	require.NoError(t, err)
+
+	event := unittest.EventFixture(depositedEventType, 0, 0, unittest.IdentifierFixture(), 0)
+	event.Payload = payload
+	return event
+}
+
+func TestParseEventFieldPredicate(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+
+	tests := []struct {
+		name      string
+		predicate string
+		terms     int
+		err       bool
+	}{
+		{
+			name:      "equality",
+			predicate: depositedEventType + " where to == 0x0000000000000002",
+			terms:     1,
+		},
+		{
+			name:      "case insensitive keywords",
+			predicate: depositedEventType + " WHERE amount > 1.5 AND memo != \"x\" OR fee IN [1, 2]",
+			terms:     4,
+		},
+		{
+			name:      "parentheses",
+			predicate: depositedEventType + " where (amount >= 1 or amount <= 0.5) and memo == \"gift\"",
+			terms:     3,
+		},
+		{
+			name:      "missing where",
+			predicate: depositedEventType,
+			err:       true,
+		},
+		{
+			name:      "invalid event type",
+			predicate: "invalid where amount > 1",
+			err:       true,
+		},
+		{
+			name:      "missing operand",
+			predicate: depositedEventType + " where amount >",
+			err:       true,
+		},
+		{
+			name:      "unbalanced parentheses",
+			predicate: depositedEventType + " where (amount > 1",
+			err:       true,
+		},
+		{
+			name:      "unterminated string",
+			predicate: depositedEventType + " where memo == \"gift",
+			err:       true,
+		},
+		{
+			name:      "ordered address comparison",
+			predicate: depositedEventType + " where to > 0x0000000000000002",
+			err:       true,
+		},
+		{
+			name:      "trailing tokens",
+			predicate: depositedEventType + " where amount > 1 2",
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			predicate, err := state_stream.ParseEventFieldPredicate(test.predicate, chain)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, flow.EventType(depositedEventType), predicate.EventType)
+			assert.Equal(t, test.terms, predicate.Terms())
+		})
+	}
+}
+
+// TestEventFieldPredicateFilter tests that events with field predicates only match if their decoded
+// fields satisfy one of the predicates of their type.
+func TestEventFieldPredicateFilter(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	alice := flow.HexToAddress("0000000000000002")
+	bob := flow.HexToAddress("0000000000000003")
+
+	small := depositedEvent(t, alice, "1.5", "coffee", nil)
+	large := depositedEvent(t, alice, "250.0", "rent", cadence.UInt64(3))
+	other := depositedEvent(t, bob, "100.0", "gift", cadence.UInt64(1))
+
+	tests := []struct {
+		name       string
+		predicates []string
+		matches    []bool
+	}{
+		{
+			name:       "equality",
+			predicates: []string{depositedEventType + " where to == 0x0000000000000002"},
+			matches:    []bool{true, true, false},
+		},
+		{
+			name:       "numeric comparison",
+			predicates: []string{depositedEventType + " where amount >= 100"},
+			matches:    []bool{false, true, true},
+		},
+		{
+			name:       "set membership",
+			predicates: []string{depositedEventType + ` where memo in ["coffee", "gift"]`},
+			matches:    []bool{true, false, true},
+		},
+		{
+			name:       "optional field",
+			predicates: []string{depositedEventType + " where fee != 3"},
+			matches:    []bool{false, false, true},
+		},
+		{
+			name:       "and binds stronger than or",
+			predicates: []string{depositedEventType + " where to == 0x0000000000000003 or to == 0x0000000000000002 and amount < 10"},
+			matches:    []bool{true, false, true},
+		},
+		{
+			name:       "type mismatch never matches",
+			predicates: []string{depositedEventType + ` where amount == "250.0" or memo > 1`},
+			matches:    []bool{false, false, false},
+		},
+		{
+			name: "any predicate of a type matches",
+			predicates: []string{
+				depositedEventType + " where amount < 2",
+				depositedEventType + ` where memo == "gift"`,
+			},
+			matches: []bool{true, false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, nil, nil, nil, test.predicates)
+			require.NoError(t, err)
+
+			for i, event := range []flow.Event{small, large, other} {
+				assert.Equal(t, test.matches[i], filter.Match(event), "event %d", i)
+			}
+		})
+	}
+
+	t.Run("events of other types are filtered as usual", func(t *testing.T) {
+		predicates := []string{depositedEventType + " where amount > 1000"}
+		filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, []string{"flow.AccountCreated"}, nil, nil, predicates)
+		require.NoError(t, err)
+
+		assert.False(t, filter.Match(large))
+		assert.True(t, filter.Match(unittest.EventFixture("flow.AccountCreated", 0, 0, unittest.IdentifierFixture(), 0)))
+		assert.False(t, filter.Match(unittest.EventFixture("flow.AccountKeyAdded", 0, 0, unittest.IdentifierFixture(), 0)))
+	})
+}
+
+// TestEventFieldPredicateLimits tests that the number of predicates and their comparisons are limited.
+func TestEventFieldPredicateLimits(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	config := state_stream.DefaultEventFilterConfig
+	config.MaxFieldPredicates = 2
+	config.MaxFieldPredicateTerms = 3
+
+	_, err := state_stream.NewEventFilter(config, chain, nil, nil, nil, []string{
+		depositedEventType + " where amount > 1",
+		depositedEventType + " where amount in [1, 2]",
+	})
+	assert.NoError(t, err)
+
+	_, err = state_stream.NewEventFilter(config, chain, nil, nil, nil, []string{
+		depositedEventType + " where amount > 1",
+		depositedEventType + " where amount > 2",
+		depositedEventType + " where amount > 3",
+	})
+	assert.Error(t, err, "too many predicates")
+
+	_, err = state_stream.NewEventFilter(config, chain, nil, nil, nil, []string{
+		depositedEventType + " where amount > 1",
+		depositedEventType + " where amount in [1, 2, 3]",
+	})
+	assert.Error(t, err, "too many terms")
+}
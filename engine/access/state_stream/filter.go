@@ -23,41 +23,69 @@ const (
 
 	// DefaultMaxAccountAddresses specifies limitation for possible number of accounts that could be used in filter
 	DefaultMaxAccountAddresses = 100
+
+	// DefaultMaxRegisterOwners is the default maximum number of register owners that can be specified in a register filter
+	DefaultMaxRegisterOwners = 100
+
+	// DefaultMaxRegisterKeyPrefixes is the default maximum number of register key prefixes that can be specified in a register filter
+	DefaultMaxRegisterKeyPrefixes = 100
+
+	// DefaultMaxFieldPredicates is the default maximum number of event field predicates that can be specified in a filter
+	DefaultMaxFieldPredicates = 10
+
+	// DefaultMaxFieldPredicateTerms is the default maximum number of comparisons of all event field predicates of a filter
+	DefaultMaxFieldPredicateTerms = 100
 )
 
 // EventFilterConfig is used to configure the limits for EventFilters
 type EventFilterConfig struct {
-	MaxEventTypes     int
-	MaxAddresses      int
-	MaxContracts      int
-	MaxAccountAddress int
+	MaxEventTypes          int
+	MaxAddresses           int
+	MaxContracts           int
+	MaxAccountAddress      int
+	MaxRegisterOwners      int
+	MaxRegisterKeyPrefixes int
+	MaxFieldPredicates     int
+	MaxFieldPredicateTerms int
 }
 
 // DefaultEventFilterConfig is the default configuration for EventFilters
 var DefaultEventFilterConfig = EventFilterConfig{
-	MaxEventTypes:     DefaultMaxEventTypes,
-	MaxAddresses:      DefaultMaxAddresses,
-	MaxContracts:      DefaultMaxContracts,
-	MaxAccountAddress: DefaultMaxAccountAddresses,
+	MaxEventTypes:          DefaultMaxEventTypes,
+	MaxAddresses:           DefaultMaxAddresses,
+	MaxContracts:           DefaultMaxContracts,
+	MaxAccountAddress:      DefaultMaxAccountAddresses,
+	MaxRegisterOwners:      DefaultMaxRegisterOwners,
+	MaxRegisterKeyPrefixes: DefaultMaxRegisterKeyPrefixes,
+	MaxFieldPredicates:     DefaultMaxFieldPredicates,
+	MaxFieldPredicateTerms: DefaultMaxFieldPredicateTerms,
 }
 
 type FieldFilter map[string]map[string]struct{}
 
 // EventFilter represents a filter applied to events for a given subscription
 type EventFilter struct {
-	hasFilters        bool
-	EventTypes        map[flow.EventType]struct{}
-	Addresses         map[string]struct{}
-	Contracts         map[string]struct{}
-	EventFieldFilters map[flow.EventType]FieldFilter
+	hasFilters           bool
+	EventTypes           map[flow.EventType]struct{}
+	Addresses            map[string]struct{}
+	Contracts            map[string]struct{}
+	EventFieldFilters    map[flow.EventType]FieldFilter
+	EventFieldPredicates map[flow.EventType][]*EventFieldPredicate
 }
 
+// NewEventFilter creates an event filter matching events of the given types, or emitted by the given
+// addresses or contracts. Events with a type of one of the field predicates only match if their fields
+// satisfy any of the predicates for the type. See EventFieldPredicate for the predicate syntax.
+//
+// Expected errors during normal operation:
+//   - if any of the filters is invalid, or the filters exceed the limits of the config
 func NewEventFilter(
 	config EventFilterConfig,
 	chain flow.Chain,
 	eventTypes []string,
 	addresses []string,
 	contracts []string,
+	fieldPredicates []string,
 ) (EventFilter, error) {
 	// put some reasonable limits on the number of filters. Lookups use a map so they are fast,
 	// this just puts a cap on the memory consumed per filter.
@@ -73,11 +101,16 @@ func NewEventFilter(
 		return EventFilter{}, fmt.Errorf("too many contracts in filter (%d). use %d or fewer", len(contracts), config.MaxContracts)
 	}
 
+	if len(fieldPredicates) > config.MaxFieldPredicates {
+		return EventFilter{}, fmt.Errorf("too many field predicates in filter (%d). use %d or fewer", len(fieldPredicates), config.MaxFieldPredicates)
+	}
+
 	f := EventFilter{
-		EventTypes:        make(map[flow.EventType]struct{}, len(eventTypes)),
-		Addresses:         make(map[string]struct{}, len(addresses)),
-		Contracts:         make(map[string]struct{}, len(contracts)),
-		EventFieldFilters: make(map[flow.EventType]FieldFilter),
+		EventTypes:           make(map[flow.EventType]struct{}, len(eventTypes)),
+		Addresses:            make(map[string]struct{}, len(addresses)),
+		Contracts:            make(map[string]struct{}, len(contracts)),
+		EventFieldFilters:    make(map[flow.EventType]FieldFilter),
+		EventFieldPredicates: make(map[flow.EventType][]*EventFieldPredicate),
 	}
 
 	// Check all of the filters to ensure they are correctly formatted. This helps avoid searching
@@ -106,7 +139,21 @@ func NewEventFilter(
 		f.Contracts[contract] = struct{}{}
 	}
 
-	f.hasFilters = len(f.EventTypes) > 0 || len(f.Addresses) > 0 || len(f.Contracts) > 0
+	// every predicate is evaluated for each event of its type, so limit the total number of comparisons
+	terms := 0
+	for _, raw := range fieldPredicates {
+		predicate, err := ParseEventFieldPredicate(raw, chain)
+		if err != nil {
+			return EventFilter{}, err
+		}
+		terms += predicate.Terms()
+		if terms > config.MaxFieldPredicateTerms {
+			return EventFilter{}, fmt.Errorf("too many comparisons in field predicates. use %d or fewer", config.MaxFieldPredicateTerms)
+		}
+		f.EventFieldPredicates[predicate.EventType] = append(f.EventFieldPredicates[predicate.EventType], predicate)
+	}
+
+	f.hasFilters = len(f.EventTypes) > 0 || len(f.Addresses) > 0 || len(f.Contracts) > 0 || len(f.EventFieldPredicates) > 0
 	return f, nil
 }
 
@@ -128,6 +175,10 @@ func (f *EventFilter) Match(event flow.Event) bool {
 		return true
 	}
 
+	if predicates, ok := f.EventFieldPredicates[event.Type]; ok {
+		return f.matchFieldPredicates(&event, predicates)
+	}
+
 	if fieldFilter, ok := f.EventFieldFilters[event.Type]; ok {
 		return f.matchFieldFilter(&event, fieldFilter)
 	}
@@ -181,6 +232,22 @@ func (f *EventFilter) matchFieldFilter(event *flow.Event, fieldFilters FieldFilt
 	return false
 }
 
+// matchFieldPredicates checks if the fields of the given event satisfy any of the given predicates.
+func (f *EventFilter) matchFieldPredicates(event *flow.Event, predicates []*EventFieldPredicate) bool {
+	fields, err := getEventFields(event)
+	if err != nil {
+		return false
+	}
+
+	for _, predicate := range predicates {
+		if predicate.Match(fields) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // getEventFields extracts field values and field names from the payload of a flow event.
 // It decodes the event payload into a Cadence event, retrieves the field values and fields, and returns them.
 // Parameters:
@@ -64,7 +64,7 @@ func TestContructor(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, test.eventTypes, test.addresses, test.contracts)
+			filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, test.eventTypes, test.addresses, test.contracts, nil)
 			if test.err {
 				assert.Error(t, err)
 				assert.Equal(t, filter, state_stream.EventFilter{})
@@ -83,7 +83,7 @@ func TestFilter(t *testing.T) {
 
 	chain := flow.MonotonicEmulator.Chain()
 
-	filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, []string{"flow.AccountCreated", "A.0000000000000001.Contract1.EventA"}, nil, nil)
+	filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, []string{"flow.AccountCreated", "A.0000000000000001.Contract1.EventA"}, nil, nil, nil)
 	assert.NoError(t, err)
 
 	events := flow.EventsList{
@@ -175,6 +175,7 @@ func TestMatch(t *testing.T) {
 				test.eventTypes,
 				test.addresses,
 				test.contracts,
+				nil,
 			)
 			assert.NoError(t, err)
 			for _, event := range events {
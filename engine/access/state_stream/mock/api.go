@@ -300,6 +300,66 @@ func (_m *API) SubscribeExecutionDataFromStartBlockID(ctx context.Context, start
 	return r0
 }
 
+// SubscribeFilteredExecutionDataFromLatest provides a mock function with given fields: ctx, filter
+func (_m *API) SubscribeFilteredExecutionDataFromLatest(ctx context.Context, filter state_stream.RegisterFilter) subscription.Subscription {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeFilteredExecutionDataFromLatest")
+	}
+
+	var r0 subscription.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, state_stream.RegisterFilter) subscription.Subscription); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(subscription.Subscription)
+		}
+	}
+
+	return r0
+}
+
+// SubscribeFilteredExecutionDataFromStartBlockHeight provides a mock function with given fields: ctx, startBlockHeight, filter
+func (_m *API) SubscribeFilteredExecutionDataFromStartBlockHeight(ctx context.Context, startBlockHeight uint64, filter state_stream.RegisterFilter) subscription.Subscription {
+	ret := _m.Called(ctx, startBlockHeight, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeFilteredExecutionDataFromStartBlockHeight")
+	}
+
+	var r0 subscription.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, state_stream.RegisterFilter) subscription.Subscription); ok {
+		r0 = rf(ctx, startBlockHeight, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(subscription.Subscription)
+		}
+	}
+
+	return r0
+}
+
+// SubscribeFilteredExecutionDataFromStartBlockID provides a mock function with given fields: ctx, startBlockID, filter
+func (_m *API) SubscribeFilteredExecutionDataFromStartBlockID(ctx context.Context, startBlockID flow.Identifier, filter state_stream.RegisterFilter) subscription.Subscription {
+	ret := _m.Called(ctx, startBlockID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeFilteredExecutionDataFromStartBlockID")
+	}
+
+	var r0 subscription.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, state_stream.RegisterFilter) subscription.Subscription); ok {
+		r0 = rf(ctx, startBlockID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(subscription.Subscription)
+		}
+	}
+
+	return r0
+}
+
 // NewAPI creates a new instance of API. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAPI(t interface {
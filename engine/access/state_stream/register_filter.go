@@ -0,0 +1,143 @@
+package state_stream
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// RegisterFilter represents a filter applied to the register updates of execution data for a given
+// subscription. A register matches if its owner is one of the filtered owners, and its key starts with
+// one of the filtered key prefixes. An empty list of owners or key prefixes matches any owner or key.
+type RegisterFilter struct {
+	hasFilters  bool
+	Owners      map[string]struct{}
+	KeyPrefixes []string
+}
+
+// NewRegisterFilter creates a register filter matching the registers of the given addresses with keys
+// starting with one of the given prefixes. Key prefixes are base64 encoded, the same way register keys
+// are encoded in the responses.
+//
+// Expected errors during normal operation:
+//   - if too many addresses or key prefixes are provided, or they are not valid
+func NewRegisterFilter(
+	config EventFilterConfig,
+	chain flow.Chain,
+	addresses []string,
+	keyPrefixes []string,
+) (RegisterFilter, error) {
+	// put some reasonable limits on the number of filters, since every register update of the
+	// execution data is checked against the key prefixes.
+	if len(addresses) > config.MaxRegisterOwners {
+		return RegisterFilter{}, fmt.Errorf("too many addresses in filter (%d). use %d or fewer", len(addresses), config.MaxRegisterOwners)
+	}
+
+	if len(keyPrefixes) > config.MaxRegisterKeyPrefixes {
+		return RegisterFilter{}, fmt.Errorf("too many register key prefixes in filter (%d). use %d or fewer", len(keyPrefixes), config.MaxRegisterKeyPrefixes)
+	}
+
+	f := RegisterFilter{
+		Owners:      make(map[string]struct{}, len(addresses)),
+		KeyPrefixes: make([]string, 0, len(keyPrefixes)),
+	}
+
+	for _, address := range addresses {
+		addr := flow.HexToAddress(address)
+		if err := validateAddress(addr, chain); err != nil {
+			return RegisterFilter{}, err
+		}
+		f.Owners[flow.AddressToRegisterOwner(addr)] = struct{}{}
+	}
+
+	for _, prefix := range keyPrefixes {
+		decoded, err := base64.StdEncoding.DecodeString(prefix)
+		if err != nil {
+			return RegisterFilter{}, fmt.Errorf("invalid register key prefix %q: %w", prefix, err)
+		}
+		if len(decoded) == 0 {
+			return RegisterFilter{}, fmt.Errorf("register key prefix must not be empty")
+		}
+		f.KeyPrefixes = append(f.KeyPrefixes, string(decoded))
+	}
+
+	f.hasFilters = len(f.Owners) > 0 || len(f.KeyPrefixes) > 0
+	return f, nil
+}
+
+// Match returns true if the register matches the filter.
+func (f *RegisterFilter) Match(registerID flow.RegisterID) bool {
+	// No filters means all registers match
+	if !f.hasFilters {
+		return true
+	}
+
+	if len(f.Owners) > 0 {
+		if _, ok := f.Owners[registerID.Owner]; !ok {
+			return false
+		}
+	}
+
+	if len(f.KeyPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range f.KeyPrefixes {
+		if strings.HasPrefix(registerID.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns a copy of the execution data only containing the register updates matching the filter.
+// Chunks without matching register updates are omitted. The chunks of the copy contain the collection,
+// transaction results and matching register updates of the original chunk, but not its events.
+//
+// The provided execution data is not modified, so it is safe to use with cached execution data.
+//
+// No errors are expected during normal operation. An error is returned if a payload of the execution
+// data cannot be converted to a register, which indicates malformed execution data.
+func (f *RegisterFilter) Filter(executionData *execution_data.BlockExecutionData) (*execution_data.BlockExecutionData, error) {
+	filtered := &execution_data.BlockExecutionData{
+		BlockID:             executionData.BlockID,
+		ChunkExecutionDatas: make([]*execution_data.ChunkExecutionData, 0),
+	}
+
+	for chunkIndex, chunk := range executionData.ChunkExecutionDatas {
+		if chunk.TrieUpdate == nil {
+			continue
+		}
+
+		trieUpdate := &ledger.TrieUpdate{
+			RootHash: chunk.TrieUpdate.RootHash,
+		}
+		for i, payload := range chunk.TrieUpdate.Payloads {
+			registerID, _, err := convert.PayloadToRegister(payload)
+			if err != nil {
+				return nil, fmt.Errorf("could not convert payload %d of chunk %d to register: %w", i, chunkIndex, err)
+			}
+			if !f.Match(registerID) {
+				continue
+			}
+			trieUpdate.Paths = append(trieUpdate.Paths, chunk.TrieUpdate.Paths[i])
+			trieUpdate.Payloads = append(trieUpdate.Payloads, payload)
+		}
+
+		if trieUpdate.IsEmpty() {
+			continue
+		}
+
+		filtered.ChunkExecutionDatas = append(filtered.ChunkExecutionDatas, &execution_data.ChunkExecutionData{
+			Collection:         chunk.Collection,
+			TrieUpdate:         trieUpdate,
+			TransactionResults: chunk.TransactionResults,
+		})
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,240 @@
+package state_stream_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterFilterConstructor(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	config := state_stream.DefaultEventFilterConfig
+	config.MaxRegisterOwners = 2
+	config.MaxRegisterKeyPrefixes = 2
+
+	tests := []struct {
+		name        string
+		addresses   []string
+		keyPrefixes []string
+		err         bool
+	}{
+		{
+			name: "no filters",
+		},
+		{
+			name:        "valid filters",
+			addresses:   []string{"0000000000000001", "0000000000000002"},
+			keyPrefixes: []string{encodePrefix("public_key_"), encodePrefix("contract_names")},
+		},
+		{
+			name:      "invalid address",
+			addresses: []string{"invalid"},
+			err:       true,
+		},
+		{
+			name:        "empty key prefix",
+			keyPrefixes: []string{""},
+			err:         true,
+		},
+		{
+			name:        "key prefix not base64 encoded",
+			keyPrefixes: []string{"public_key_"},
+			err:         true,
+		},
+		{
+			name:      "too many addresses",
+			addresses: []string{"0000000000000001", "0000000000000002", "0000000000000003"},
+			err:       true,
+		},
+		{
+			name:        "too many key prefixes",
+			keyPrefixes: []string{encodePrefix("a"), encodePrefix("b"), encodePrefix("c")},
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := state_stream.NewRegisterFilter(config, chain, test.addresses, test.keyPrefixes)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegisterFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	owner := flow.HexToAddress("0000000000000001")
+	other := flow.HexToAddress("0000000000000002")
+
+	tests := []struct {
+		name        string
+		addresses   []string
+		keyPrefixes []string
+		matches     []flow.RegisterID
+		misses      []flow.RegisterID
+	}{
+		{
+			name: "no filters",
+			matches: []flow.RegisterID{
+				flow.NewRegisterID(owner, "public_key_0"),
+				flow.NewRegisterID(flow.EmptyAddress, "uuid"),
+			},
+		},
+		{
+			name:      "owner filter",
+			addresses: []string{owner.Hex()},
+			matches: []flow.RegisterID{
+				flow.NewRegisterID(owner, "public_key_0"),
+				flow.NewRegisterID(owner, "contract_names"),
+			},
+			misses: []flow.RegisterID{
+				flow.NewRegisterID(other, "public_key_0"),
+				flow.NewRegisterID(flow.EmptyAddress, "uuid"),
+			},
+		},
+		{
+			name:        "key prefix filter",
+			keyPrefixes: []string{encodePrefix("public_key_")},
+			matches: []flow.RegisterID{
+				flow.NewRegisterID(owner, "public_key_0"),
+				flow.NewRegisterID(other, "public_key_1"),
+			},
+			misses: []flow.RegisterID{
+				flow.NewRegisterID(owner, "contract_names"),
+			},
+		},
+		{
+			name:        "owner and key prefix filter",
+			addresses:   []string{owner.Hex()},
+			keyPrefixes: []string{encodePrefix("public_key_"), encodePrefix("code.")},
+			matches: []flow.RegisterID{
+				flow.NewRegisterID(owner, "public_key_0"),
+				flow.NewRegisterID(owner, "code.Contract"),
+			},
+			misses: []flow.RegisterID{
+				flow.NewRegisterID(owner, "contract_names"),
+				flow.NewRegisterID(other, "public_key_0"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := state_stream.NewRegisterFilter(state_stream.DefaultEventFilterConfig, chain, test.addresses, test.keyPrefixes)
+			require.NoError(t, err)
+
+			for _, registerID := range test.matches {
+				assert.True(t, filter.Match(registerID), "expected %s to match", registerID)
+			}
+			for _, registerID := range test.misses {
+				assert.False(t, filter.Match(registerID), "expected %s not to match", registerID)
+			}
+		})
+	}
+}
+
+// TestRegisterFilterFilter tests that only the matching register updates and the chunks containing them
+// are included in the filtered execution data, and that the original execution data is not modified.
+func TestRegisterFilterFilter(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	owner := flow.HexToAddress("0000000000000001")
+	other := flow.HexToAddress("0000000000000002")
+
+	chunkWithUpdates := func(registerIDs ...flow.RegisterID) *execution_data.ChunkExecutionData {
+		collection := unittest.CollectionFixture(2)
+		trieUpdate := &ledger.TrieUpdate{
+			RootHash: testutils.RootHashFixture(),
+			Paths:    testutils.RandomPaths(len(registerIDs)),
+		}
+		for _, registerID := range registerIDs {
+			key := convert.RegisterIDToLedgerKey(registerID)
+			trieUpdate.Payloads = append(trieUpdate.Payloads, ledger.NewPayload(key, unittest.RandomBytes(8)))
+		}
+		return &execution_data.ChunkExecutionData{
+			Collection: &collection,
+			Events:     unittest.EventsFixture(2),
+			TrieUpdate: trieUpdate,
+		}
+	}
+
+	matching := chunkWithUpdates(
+		flow.NewRegisterID(owner, "public_key_0"),
+		flow.NewRegisterID(other, "public_key_0"),
+		flow.NewRegisterID(owner, "contract_names"),
+	)
+	notMatching := chunkWithUpdates(
+		flow.NewRegisterID(other, "public_key_1"),
+	)
+	executionData := unittest.BlockExecutionDataFixture(
+		unittest.WithChunkExecutionDatas(notMatching, matching),
+	)
+
+	filter, err := state_stream.NewRegisterFilter(state_stream.DefaultEventFilterConfig, chain, []string{owner.Hex()}, []string{encodePrefix("public_key_")})
+	require.NoError(t, err)
+
+	filtered, err := filter.Filter(executionData)
+	require.NoError(t, err)
+	assert.Equal(t, executionData.BlockID, filtered.BlockID)
+	require.Len(t, filtered.ChunkExecutionDatas, 1)
+
+	chunk := filtered.ChunkExecutionDatas[0]
+	assert.Equal(t, matching.Collection, chunk.Collection)
+	assert.Empty(t, chunk.Events)
+	assert.Equal(t, matching.TrieUpdate.RootHash, chunk.TrieUpdate.RootHash)
+	assert.Equal(t, []ledger.Path{matching.TrieUpdate.Paths[0]}, chunk.TrieUpdate.Paths)
+	assert.Equal(t, []*ledger.Payload{matching.TrieUpdate.Payloads[0]}, chunk.TrieUpdate.Payloads)
+
+	// the original execution data is unchanged
+	assert.Len(t, executionData.ChunkExecutionDatas, 2)
+	assert.Len(t, matching.TrieUpdate.Payloads, 3)
+	assert.Len(t, matching.Events, 2)
+}
+
+// TestRegisterFilterFilter_InvalidPayload tests that an error is returned if a payload of the execution
+// data cannot be converted to a register.
+func TestRegisterFilterFilter_InvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	collection := unittest.CollectionFixture(1)
+	key := ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(ledger.KeyPartOwner, []byte("owner"))})
+	chunk := &execution_data.ChunkExecutionData{
+		Collection: &collection,
+		TrieUpdate: &ledger.TrieUpdate{
+			RootHash: testutils.RootHashFixture(),
+			Paths:    testutils.RandomPaths(1),
+			Payloads: []*ledger.Payload{ledger.NewPayload(key, unittest.RandomBytes(8))},
+		},
+	}
+	executionData := unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(chunk))
+
+	filter, err := state_stream.NewRegisterFilter(state_stream.DefaultEventFilterConfig, flow.MonotonicEmulator.Chain(), nil, nil)
+	require.NoError(t, err)
+
+	_, err = filter.Filter(executionData)
+	assert.Error(t, err)
+}
+
+// encodePrefix encodes the register key prefix the way clients provide it.
+func encodePrefix(prefix string) string {
+	return base64.StdEncoding.EncodeToString([]byte(prefix))
+}
@@ -28,6 +28,18 @@ type API interface {
 	SubscribeExecutionDataFromStartBlockHeight(ctx context.Context, startBlockHeight uint64) subscription.Subscription
 	// SubscribeExecutionDataFromLatest subscribes to execution data starting from latest block.
 	SubscribeExecutionDataFromLatest(ctx context.Context) subscription.Subscription
+	// SubscribeFilteredExecutionDataFromStartBlockID subscribes to execution data starting from a specific block id.
+	// Only the register updates matching the RegisterFilter are included, together with the transactions of
+	// the chunks containing them.
+	SubscribeFilteredExecutionDataFromStartBlockID(ctx context.Context, startBlockID flow.Identifier, filter RegisterFilter) subscription.Subscription
+	// SubscribeFilteredExecutionDataFromStartBlockHeight subscribes to execution data starting from a specific block height.
+	// Only the register updates matching the RegisterFilter are included, together with the transactions of
+	// the chunks containing them.
+	SubscribeFilteredExecutionDataFromStartBlockHeight(ctx context.Context, startBlockHeight uint64, filter RegisterFilter) subscription.Subscription
+	// SubscribeFilteredExecutionDataFromLatest subscribes to execution data starting from latest block.
+	// Only the register updates matching the RegisterFilter are included, together with the transactions of
+	// the chunks containing them.
+	SubscribeFilteredExecutionDataFromLatest(ctx context.Context, filter RegisterFilter) subscription.Subscription
 	// SubscribeEvents is deprecated and will be removed in a future version.
 	// Use SubscribeEventsFromStartBlockID, SubscribeEventsFromStartHeight or SubscribeEventsFromLatest.
 	//
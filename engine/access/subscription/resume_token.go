@@ -0,0 +1,253 @@
+package subscription
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// resumeTokenVersion is the version of the encoding of resume tokens. It is the first byte of every
+// encoded token, and allows changing the encoding without breaking tokens held by clients.
+const resumeTokenVersion = 1
+
+// resumeTokenLength is the length of an encoded resume token in bytes: the version, followed by the
+// big endian height and index.
+const resumeTokenLength = 1 + 8 + 4
+
+// ResumeToken is the position of a subscription in the stream of blocks, from which a subscription can
+// be resumed without skipping or repeating any data. It points to the first data not yet delivered: the
+// transaction at position Index of the block at Height. Responses covering a whole block resume from
+// index 0 of the next block.
+//
+// Clients receive resume tokens encoded as opaque strings, and must not depend on their content.
+type ResumeToken struct {
+	Height uint64
+	Index  uint32
+}
+
+// NewResumeToken creates a resume token resuming at the transaction with the given index of the block
+// at the given height.
+func NewResumeToken(height uint64, index uint32) ResumeToken {
+	return ResumeToken{
+		Height: height,
+		Index:  index,
+	}
+}
+
+// NewTransactionStatusResumeToken creates a resume token of a transaction status subscription, resuming
+// after the given status was delivered. Index holds the status, and Height is unused.
+func NewTransactionStatusResumeToken(status flow.TransactionStatus) ResumeToken {
+	return ResumeToken{
+		Index: uint32(status),
+	}
+}
+
+// ParseResumeToken decodes a resume token encoded using ResumeToken.Encode.
+//
+// Expected errors during normal operation:
+//   - if the token is malformed
+func ParseResumeToken(encoded string) (ResumeToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ResumeToken{}, fmt.Errorf("invalid resume token: %w", err)
+	}
+	if len(data) != resumeTokenLength || data[0] != resumeTokenVersion {
+		return ResumeToken{}, fmt.Errorf("invalid resume token")
+	}
+
+	return ResumeToken{
+		Height: binary.BigEndian.Uint64(data[1:9]),
+		Index:  binary.BigEndian.Uint32(data[9:13]),
+	}, nil
+}
+
+// Encode returns the opaque string representation of the token returned to clients.
+func (t ResumeToken) Encode() string {
+	data := make([]byte, resumeTokenLength)
+	data[0] = resumeTokenVersion
+	binary.BigEndian.PutUint64(data[1:9], t.Height)
+	binary.BigEndian.PutUint32(data[9:13], t.Index)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// FilterEvents returns the events of the block at the given height which were not delivered before the
+// token was issued. A nil token returns all events.
+func (t *ResumeToken) FilterEvents(height uint64, events flow.EventsList) flow.EventsList {
+	if t == nil || height != t.Height || t.Index == 0 {
+		return events
+	}
+
+	filtered := make(flow.EventsList, 0, len(events))
+	for _, event := range events {
+		if event.TransactionIndex >= t.Index {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// FilterAccountEvents returns the account events of the block at the given height which were not
+// delivered before the token was issued. Accounts without remaining events are omitted. A nil token
+// returns all account events.
+func (t *ResumeToken) FilterAccountEvents(height uint64, accountEvents map[string]flow.EventsList) map[string]flow.EventsList {
+	if t == nil || height != t.Height || t.Index == 0 {
+		return accountEvents
+	}
+
+	filtered := make(map[string]flow.EventsList, len(accountEvents))
+	for address, events := range accountEvents {
+		events = t.FilterEvents(height, events)
+		if len(events) > 0 {
+			filtered[address] = events
+		}
+	}
+	return filtered
+}
+
+// TransactionStatusDelivered returns true if the given transaction status was delivered before the token
+// of a transaction status subscription was issued. A nil token returns false.
+func (t *ResumeToken) TransactionStatusDelivered(status flow.TransactionStatus) bool {
+	return t != nil && uint32(status) <= t.Index
+}
+
+// DefaultMaxEventsPerResponse is the default max number of events of a single event or account status
+// response.
+const DefaultMaxEventsPerResponse = 1000
+
+// EventsPart is a part of the events of a block, sent to the client in a single response.
+type EventsPart struct {
+	Events flow.EventsList
+	// ResumeToken is the token of the position after the part.
+	ResumeToken ResumeToken
+}
+
+// SplitEvents splits the events of the block at the given height into parts of at most maxEvents events,
+// so the events of large blocks are sent in several responses which can each be resumed from. The events
+// must be ordered by transaction index.
+//
+// Parts are split at transaction boundaries, so a part exceeds maxEvents if a single transaction emitted
+// more events. The last part resumes from the next block, and the other parts from the first transaction
+// of the part following them. A single part is returned if there are no events, or maxEvents is zero.
+func SplitEvents(height uint64, events flow.EventsList, maxEvents uint) []EventsPart {
+	txIndices := make([]uint32, len(events))
+	for i, event := range events {
+		txIndices[i] = event.TransactionIndex
+	}
+
+	ends := splitByTransaction(txIndices, maxEvents)
+	parts := make([]EventsPart, len(ends))
+	start := 0
+	for i, end := range ends {
+		parts[i] = EventsPart{
+			Events:      events[start:end],
+			ResumeToken: partResumeToken(height, txIndices, end),
+		}
+		start = end
+	}
+	return parts
+}
+
+// AccountEventsPart is a part of the account events of a block, sent to the client in a single response.
+type AccountEventsPart struct {
+	AccountEvents map[string]flow.EventsList
+	// ResumeToken is the token of the position after the part.
+	ResumeToken ResumeToken
+}
+
+// SplitAccountEvents splits the account events of the block at the given height into parts of at most
+// maxEvents events in total, in the same way as SplitEvents. Accounts without events in a part are
+// omitted from it.
+func SplitAccountEvents(height uint64, accountEvents map[string]flow.EventsList, maxEvents uint) []AccountEventsPart {
+	type accountEvent struct {
+		address string
+		event   flow.Event
+	}
+
+	var all []accountEvent
+	for address, events := range accountEvents {
+		for _, event := range events {
+			all = append(all, accountEvent{address: address, event: event})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].event.TransactionIndex != all[j].event.TransactionIndex {
+			return all[i].event.TransactionIndex < all[j].event.TransactionIndex
+		}
+		return all[i].event.EventIndex < all[j].event.EventIndex
+	})
+
+	txIndices := make([]uint32, len(all))
+	for i, e := range all {
+		txIndices[i] = e.event.TransactionIndex
+	}
+
+	ends := splitByTransaction(txIndices, maxEvents)
+	parts := make([]AccountEventsPart, len(ends))
+	start := 0
+	for i, end := range ends {
+		part := make(map[string]flow.EventsList)
+		for _, e := range all[start:end] {
+			part[e.address] = append(part[e.address], e.event)
+		}
+		parts[i] = AccountEventsPart{
+			AccountEvents: part,
+			ResumeToken:   partResumeToken(height, txIndices, end),
+		}
+		start = end
+	}
+	return parts
+}
+
+// splitByTransaction returns the exclusive end offsets of the parts a list of events with the given
+// transaction indices is split into. Parts hold at most maxEvents events, unless a single transaction
+// has more events. At least one part is returned.
+func splitByTransaction(txIndices []uint32, maxEvents uint) []int {
+	if maxEvents == 0 || len(txIndices) <= int(maxEvents) {
+		return []int{len(txIndices)}
+	}
+
+	var ends []int
+	start := 0
+	for start < len(txIndices) {
+		end := start + int(maxEvents)
+		if end >= len(txIndices) {
+			ends = append(ends, len(txIndices))
+			break
+		}
+
+		// move the end back to the first event of its transaction
+		for end > start && txIndices[end] == txIndices[end-1] {
+			end--
+		}
+		// the transaction at the start of the part has more than maxEvents events, so keep them together
+		if end == start {
+			end = start + int(maxEvents)
+			for end < len(txIndices) && txIndices[end] == txIndices[end-1] {
+				end++
+			}
+		}
+
+		ends = append(ends, end)
+		start = end
+	}
+	return ends
+}
+
+// partResumeToken returns the resume token of a part ending at the given offset of the events of the
+// block at the given height.
+func partResumeToken(height uint64, txIndices []uint32, end int) ResumeToken {
+	if end == len(txIndices) {
+		return NewResumeToken(height+1, 0)
+	}
+	return NewResumeToken(height, txIndices[end])
+}
+
+// Resumable is implemented by subscription responses which carry the resume token of the position after
+// the response. The token is set by the Streamer before the response is sent to the subscription.
+type Resumable interface {
+	// SetResumeToken sets the resume token of the response.
+	SetResumeToken(token ResumeToken)
+}
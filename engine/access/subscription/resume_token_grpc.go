@@ -0,0 +1,81 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ResumeTokenMetadataKey is the gRPC metadata key of subscription resume tokens. Clients resume a
+// subscription by providing a token in the request metadata. The token of the last response processed
+// by a stream is also set in its trailer, which covers blocks without a response.
+const ResumeTokenMetadataKey = "x-resume-token"
+
+// ResumeTokenFieldNumber is the protobuf field number of the resume token added to each response of a
+// gRPC subscription. The response messages have no field for the token, so it is added as an unknown
+// field, which is kept by protobuf clients and read using MessageResumeToken. The largest valid field
+// number is used, so the token does not collide with fields added to the messages in the future.
+const ResumeTokenFieldNumber = protowire.MaxValidNumber
+
+// ResumeTokenFromContext returns the resume token provided in the ResumeTokenMetadataKey metadata of a
+// gRPC request, or nil if no token is provided.
+//
+// Expected errors during normal operation:
+//   - codes.InvalidArgument - if more than one resume token is provided, or the resume token is invalid.
+func ResumeTokenFromContext(ctx context.Context) (*ResumeToken, error) {
+	values := metadata.ValueFromIncomingContext(ctx, ResumeTokenMetadataKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if len(values) > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "only one resume token may be provided")
+	}
+
+	token, err := ParseResumeToken(values[0])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &token, nil
+}
+
+// SetMessageResumeToken adds the encoded resume token to a gRPC subscription response, as the unknown
+// field ResumeTokenFieldNumber.
+func SetMessageResumeToken(msg proto.Message, token string) {
+	m := proto.MessageReflect(msg)
+	unknown := m.GetUnknown()
+	unknown = protowire.AppendTag(unknown, ResumeTokenFieldNumber, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, token)
+	m.SetUnknown(unknown)
+}
+
+// MessageResumeToken returns the encoded resume token added to a gRPC subscription response using
+// SetMessageResumeToken. It returns false if the response has no resume token.
+func MessageResumeToken(msg proto.Message) (string, bool) {
+	unknown := proto.MessageReflect(msg).GetUnknown()
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return "", false
+		}
+		unknown = unknown[n:]
+
+		if num == ResumeTokenFieldNumber && typ == protowire.BytesType {
+			token, n := protowire.ConsumeString(unknown)
+			if n < 0 {
+				return "", false
+			}
+			return token, true
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return "", false
+		}
+		unknown = unknown[n:]
+	}
+	return "", false
+}
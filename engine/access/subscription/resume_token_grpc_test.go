@@ -0,0 +1,39 @@
+package subscription_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+
+	"github.com/onflow/flow-go/engine/access/subscription"
+)
+
+// TestMessageResumeToken tests that the resume token added to a response is kept when the response is
+// sent over the wire, and does not affect the fields of the response.
+func TestMessageResumeToken(t *testing.T) {
+	t.Parallel()
+
+	token := subscription.NewResumeToken(10, 2).Encode()
+
+	msg := &executiondata.SubscribeEventsResponse{BlockHeight: 10, MessageIndex: 3}
+	_, ok := subscription.MessageResumeToken(msg)
+	assert.False(t, ok)
+
+	subscription.SetMessageResumeToken(msg, token)
+
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+
+	var received executiondata.SubscribeEventsResponse
+	require.NoError(t, proto.Unmarshal(data, &received))
+	assert.Equal(t, uint64(10), received.GetBlockHeight())
+	assert.Equal(t, uint64(3), received.GetMessageIndex())
+
+	receivedToken, ok := subscription.MessageResumeToken(&received)
+	require.True(t, ok)
+	assert.Equal(t, token, receivedToken)
+}
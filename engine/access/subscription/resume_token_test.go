@@ -0,0 +1,93 @@
+package subscription_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestResumeTokenEncoding(t *testing.T) {
+	t.Parallel()
+
+	token := subscription.NewResumeToken(123456, 7)
+
+	decoded, err := subscription.ParseResumeToken(token.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+
+	for _, encoded := range []string{"", "invalid!", "AQ", token.Encode() + "AA"} {
+		_, err := subscription.ParseResumeToken(encoded)
+		assert.Error(t, err, "expected %q to be invalid", encoded)
+	}
+}
+
+// TestResumeTokenFilterEvents tests that only the events of the resumed block which were not delivered
+// before the token was issued are returned.
+func TestResumeTokenFilterEvents(t *testing.T) {
+	t.Parallel()
+
+	events := make(flow.EventsList, 4)
+	for i := range events {
+		events[i] = unittest.EventFixture(flow.EventAccountCreated, uint32(i), 0, unittest.IdentifierFixture(), 0)
+	}
+
+	var noToken *subscription.ResumeToken
+	assert.Equal(t, events, noToken.FilterEvents(10, events))
+
+	token := subscription.NewResumeToken(10, 2)
+	assert.Equal(t, events[2:], token.FilterEvents(10, events))
+	assert.Equal(t, events, token.FilterEvents(11, events))
+
+	accountEvents := map[string]flow.EventsList{
+		"a": events[:2],
+		"b": events[1:],
+	}
+	assert.Equal(t, map[string]flow.EventsList{"b": events[2:]}, token.FilterAccountEvents(10, accountEvents))
+	assert.Equal(t, accountEvents, token.FilterAccountEvents(11, accountEvents))
+}
+
+// TestSplitEvents tests that the events of a block are split at transaction boundaries, and that each part
+// resumes from the first transaction after it.
+func TestSplitEvents(t *testing.T) {
+	t.Parallel()
+
+	// transaction 0 has 3 events, transactions 1 and 2 have a single event each
+	txIndices := []uint32{0, 0, 0, 1, 2}
+	events := make(flow.EventsList, len(txIndices))
+	for i, txIndex := range txIndices {
+		events[i] = unittest.EventFixture(flow.EventAccountCreated, txIndex, uint32(i), unittest.IdentifierFixture(), 0)
+	}
+
+	parts := subscription.SplitEvents(10, events, 2)
+	require.Len(t, parts, 2)
+	// the events of transaction 0 are kept together, even if they exceed the max
+	assert.Equal(t, events[:3], parts[0].Events)
+	assert.Equal(t, subscription.NewResumeToken(10, 1), parts[0].ResumeToken)
+	assert.Equal(t, events[3:], parts[1].Events)
+	assert.Equal(t, subscription.NewResumeToken(11, 0), parts[1].ResumeToken)
+
+	parts = subscription.SplitEvents(10, events, 0)
+	require.Len(t, parts, 1)
+	assert.Equal(t, events, parts[0].Events)
+
+	parts = subscription.SplitEvents(10, nil, 2)
+	require.Len(t, parts, 1)
+	assert.Empty(t, parts[0].Events)
+	assert.Equal(t, subscription.NewResumeToken(11, 0), parts[0].ResumeToken)
+
+	accountEvents := map[string]flow.EventsList{
+		"a": {events[0], events[3]},
+		"b": {events[1], events[2], events[4]},
+	}
+	accountParts := subscription.SplitAccountEvents(10, accountEvents, 3)
+	require.Len(t, accountParts, 2)
+	assert.Equal(t, map[string]flow.EventsList{"a": {events[0]}, "b": {events[1], events[2]}}, accountParts[0].AccountEvents)
+	assert.Equal(t, subscription.NewResumeToken(10, 1), accountParts[0].ResumeToken)
+	assert.Equal(t, map[string]flow.EventsList{"a": {events[3]}, "b": {events[4]}}, accountParts[1].AccountEvents)
+	assert.Equal(t, subscription.NewResumeToken(11, 0), accountParts[1].ResumeToken)
+}
@@ -115,6 +115,12 @@ func (s *Streamer) sendAllAvailable(ctx context.Context) error {
 			s.log.Trace().
 				Uint64("next_height", ssub.nextHeight).
 				Msg("sending response")
+
+			// responses of height based subscriptions cover a whole block, so the subscription resumes
+			// from the start of the next block.
+			if resumable, ok := response.(Resumable); ok {
+				resumable.SetResumeToken(NewResumeToken(ssub.nextHeight, 0))
+			}
 		}
 
 		err = s.sub.Send(ctx, response, s.sendTimeout)
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/engine"
 	streammock "github.com/onflow/flow-go/engine/access/state_stream/mock"
@@ -151,3 +152,47 @@ func TestLongStreamRatelimited(t *testing.T) {
 	assert.LessOrEqual(t, sendCalls, target+diff)
 	assert.GreaterOrEqual(t, sendCalls, target-diff)
 }
+
+// resumableData is a test response carrying a resume token.
+type resumableData struct {
+	height uint64
+	token  subscription.ResumeToken
+}
+
+func (d *resumableData) SetResumeToken(token subscription.ResumeToken) {
+	d.token = token
+}
+
+// TestStreamResumeToken tests that the responses of height based subscriptions carry the resume token
+// of the start of the next block.
+func TestStreamResumeToken(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	firstHeight := uint64(10)
+	lastHeight := uint64(12)
+	sub := subscription.NewHeightBasedSubscription(10, firstHeight, func(_ context.Context, height uint64) (interface{}, error) {
+		if height > lastHeight {
+			return nil, subscription.ErrEndOfData
+		}
+		return &resumableData{height: height}, nil
+	})
+
+	broadcaster := engine.NewBroadcaster()
+	streamer := subscription.NewStreamer(unittest.Logger(), broadcaster, subscription.DefaultSendTimeout, subscription.DefaultResponseLimit, sub)
+	go streamer.Stream(ctx)
+
+	for height := firstHeight; height <= lastHeight; height++ {
+		var v interface{}
+		unittest.RequireReturnsBefore(t, func() {
+			v = <-sub.Channel()
+		}, time.Second, "timed out waiting for response")
+
+		data, ok := v.(*resumableData)
+		require.True(t, ok)
+		assert.Equal(t, height, data.height)
+		assert.Equal(t, subscription.NewResumeToken(height+1, 0), data.token)
+	}
+}
@@ -80,7 +80,7 @@ func ConvertIndexError(err error, height uint64, defaultMsg string) error {
 	}
 
 	if errors.Is(err, storage.ErrHeightNotIndexed) {
-		return status.Errorf(codes.OutOfRange, "data for block height %d is not available", height)
+		return status.Errorf(codes.OutOfRange, "data for block height %d is not available: %v", height, err)
 	}
 
 	if errors.Is(err, storage.ErrNotFound) {
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"context"
+)
+
+type computationLimitKey struct{}
+
+// WithComputationLimit returns a copy of the context which lowers the computation limit of the scripts
+// executed using the context to the given limit. Limits above the computation limit of the executor, or
+// above a limit already set on the context, are ignored. This allows callers to share a computation
+// budget between scripts.
+func WithComputationLimit(ctx context.Context, limit uint64) context.Context {
+	if current, ok := computationLimitFromContext(ctx); ok && current <= limit {
+		return ctx
+	}
+	return context.WithValue(ctx, computationLimitKey{}, limit)
+}
+
+// computationLimitFromContext returns the computation limit set using WithComputationLimit, if any.
+func computationLimitFromContext(ctx context.Context) (uint64, bool) {
+	limit, ok := ctx.Value(computationLimitKey{}).(uint64)
+	return limit, ok && limit > 0
+}
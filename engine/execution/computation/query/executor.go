@@ -61,6 +61,16 @@ type Executor interface {
 		error,
 	)
 
+	GetAccountAvailableBalance(
+		ctx context.Context,
+		addr flow.Address,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		uint64,
+		error,
+	)
+
 	GetAccountKeys(
 		ctx context.Context,
 		addr flow.Address,
@@ -70,6 +80,17 @@ type Executor interface {
 		[]flow.AccountPublicKey,
 		error,
 	)
+
+	GetAccountKey(
+		ctx context.Context,
+		addr flow.Address,
+		keyIndex uint64,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		*flow.AccountPublicKey,
+		error,
+	)
 }
 
 type QueryConfig struct {
@@ -125,6 +146,14 @@ func NewQueryExecutor(
 	}
 }
 
+// ComputationLimit returns the max computation used by a single script.
+func (e *QueryExecutor) ComputationLimit() uint64 {
+	if e.vmCtx.ComputationLimit == 0 {
+		return fvm.DefaultComputationLimit
+	}
+	return e.vmCtx.ComputationLimit
+}
+
 func (e *QueryExecutor) ExecuteScript(
 	ctx context.Context,
 	script []byte,
@@ -189,22 +218,28 @@ func (e *QueryExecutor) ExecuteScript(
 		}
 	}()
 
+	options := []fvm.Option{
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())),
+	}
+	if limit, ok := computationLimitFromContext(ctx); ok && limit < e.ComputationLimit() {
+		options = append(options, fvm.WithComputationLimit(limit))
+	}
+
 	var output fvm.ProcedureOutput
 	_, output, err = e.vm.Run(
-		fvm.NewContextFromParent(
-			e.vmCtx,
-			fvm.WithBlockHeader(blockHeader),
-			fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
-			fvm.WithDerivedBlockData(
-				e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID()))),
+		fvm.NewContextFromParent(e.vmCtx, options...),
 		fvm.NewScriptWithContextAndArgs(script, requestCtx, arguments...),
 		snapshot)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to execute script (internal error): %w", err)
 	}
 
+	// the computation used by failed scripts is returned, so callers can account for it
 	if output.Err != nil {
-		return nil, 0, errors.NewCodedError(
+		return nil, output.ComputationUsed, errors.NewCodedError(
 			output.Err.Code(),
 			"failed to execute script at block (%s): %s", blockHeader.ID(),
 			summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize),
@@ -294,6 +329,29 @@ func (e *QueryExecutor) GetAccountBalance(ctx context.Context, address flow.Addr
 	return accountBalance, nil
 }
 
+func (e *QueryExecutor) GetAccountAvailableBalance(ctx context.Context, address flow.Address, blockHeader *flow.Header, snapshot snapshot.StorageSnapshot) (uint64, error) {
+	// TODO(ramtin): utilize ctx
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())))
+
+	accountAvailableBalance, err := fvm.GetAccountAvailableBalance(
+		blockCtx,
+		address,
+		snapshot)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get account available balance (%s) at block (%s): %w",
+			address.String(),
+			blockHeader.ID(),
+			err)
+	}
+
+	return accountAvailableBalance, nil
+}
+
 func (e *QueryExecutor) GetAccountKeys(ctx context.Context, address flow.Address, blockHeader *flow.Header, snapshot snapshot.StorageSnapshot) ([]flow.AccountPublicKey, error) {
 	// TODO(ramtin): utilize ctx
 	blockCtx := fvm.NewContextFromParent(
@@ -315,3 +373,27 @@ func (e *QueryExecutor) GetAccountKeys(ctx context.Context, address flow.Address
 
 	return accountKeys, nil
 }
+
+func (e *QueryExecutor) GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint64, blockHeader *flow.Header, snapshot snapshot.StorageSnapshot) (*flow.AccountPublicKey, error) {
+	// TODO(ramtin): utilize ctx
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())))
+
+	accountKey, err := fvm.GetAccountKey(blockCtx,
+		address,
+		keyIndex,
+		snapshot)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get account key (%s) by index (%d) at block (%s): %w",
+			address.String(),
+			keyIndex,
+			blockHeader.ID(),
+			err)
+	}
+
+	return accountKey, nil
+}
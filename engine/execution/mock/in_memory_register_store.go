@@ -5,6 +5,8 @@ package mock
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/onflow/flow-go/storage"
 )
 
 // InMemoryRegisterStore is an autogenerated mock type for the InMemoryRegisterStore type
@@ -72,6 +74,43 @@ func (_m *InMemoryRegisterStore) GetUpdatedRegisters(height uint64, blockID flow
 	return r0, r1
 }
 
+// GetUpdatedRegistersByOwner provides a mock function with given fields: height, blockID, owner, keyPrefix, cursor
+func (_m *InMemoryRegisterStore) GetUpdatedRegistersByOwner(height uint64, blockID flow.Identifier, owner string, keyPrefix string, cursor storage.RegisterCursor) (map[flow.RegisterID][]byte, uint64, error) {
+	ret := _m.Called(height, blockID, owner, keyPrefix, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdatedRegistersByOwner")
+	}
+
+	var r0 map[flow.RegisterID][]byte
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor) (map[flow.RegisterID][]byte, uint64, error)); ok {
+		return rf(height, blockID, owner, keyPrefix, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor) map[flow.RegisterID][]byte); ok {
+		r0 = rf(height, blockID, owner, keyPrefix, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[flow.RegisterID][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor) uint64); ok {
+		r1 = rf(height, blockID, owner, keyPrefix, cursor)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor) error); ok {
+		r2 = rf(height, blockID, owner, keyPrefix, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // IsBlockExecuted provides a mock function with given fields: height, blockID
 func (_m *InMemoryRegisterStore) IsBlockExecuted(height uint64, blockID flow.Identifier) (bool, error) {
 	ret := _m.Called(height, blockID)
@@ -5,6 +5,8 @@ package mock
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/onflow/flow-go/storage"
 )
 
 // RegisterStore is an autogenerated mock type for the RegisterStore type
@@ -42,6 +44,45 @@ func (_m *RegisterStore) GetRegister(height uint64, blockID flow.Identifier, reg
 	return r0, r1
 }
 
+// GetRegistersByOwner provides a mock function with given fields: height, blockID, owner, keyPrefix, cursor, limit
+func (_m *RegisterStore) GetRegistersByOwner(height uint64, blockID flow.Identifier, owner string, keyPrefix string, cursor storage.RegisterCursor, limit int) (flow.RegisterEntries, storage.RegisterCursor, error) {
+	ret := _m.Called(height, blockID, owner, keyPrefix, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegistersByOwner")
+	}
+
+	var r0 flow.RegisterEntries
+	var r1 storage.RegisterCursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor, int) (flow.RegisterEntries, storage.RegisterCursor, error)); ok {
+		return rf(height, blockID, owner, keyPrefix, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor, int) flow.RegisterEntries); ok {
+		r0 = rf(height, blockID, owner, keyPrefix, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.RegisterEntries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor, int) storage.RegisterCursor); ok {
+		r1 = rf(height, blockID, owner, keyPrefix, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(storage.RegisterCursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(uint64, flow.Identifier, string, string, storage.RegisterCursor, int) error); ok {
+		r2 = rf(height, blockID, owner, keyPrefix, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // IsBlockExecuted provides a mock function with given fields: height, blockID
 func (_m *RegisterStore) IsBlockExecuted(height uint64, blockID flow.Identifier) (bool, error) {
 	ret := _m.Called(height, blockID)
@@ -161,3 +161,22 @@ func ImportRegistersFromCheckpoint(logger zerolog.Logger, checkpointFile string,
 	logger.Info().Msgf("finish importing registers from checkpoint file %s at height %d", checkpointFile, checkpointHeight)
 	return nil
 }
+
+// ImportRegistersFromSnapshot imports the registers of the register snapshot in the given directory at the
+// given height. An import interrupted by a crash resumes with the chunks which were not imported yet.
+func ImportRegistersFromSnapshot(logger zerolog.Logger, snapshotDir string, height uint64, rootHash ledger.RootHash, pdb *pebble.DB, workerCount int) error {
+	logger.Info().Msgf("importing registers from register snapshot %s at height %d with root hash: %v", snapshotDir, height, rootHash)
+
+	bootstrap, err := pStorage.NewRegisterSnapshotBootstrap(pdb, snapshotDir, height, rootHash, logger)
+	if err != nil {
+		return fmt.Errorf("could not create register snapshot bootstrapper: %w", err)
+	}
+
+	err = bootstrap.IndexSnapshot(context.Background(), workerCount)
+	if err != nil {
+		return fmt.Errorf("could not import register snapshot: %w", err)
+	}
+
+	logger.Info().Msgf("finish importing registers from register snapshot %s at height %d", snapshotDir, height)
+	return nil
+}
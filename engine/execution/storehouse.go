@@ -21,6 +21,25 @@ type RegisterStore interface {
 	//  - (nil, err) for any other exceptions
 	GetRegister(height uint64, blockID flow.Identifier, register flow.RegisterID) (flow.RegisterValue, error)
 
+	// GetRegistersByOwner returns a page of the registers of the given owner whose keys start with the
+	// given key prefix, with their values at the given block. Registers updated by executed blocks which
+	// are not yet saved in OnDiskRegisterStore are merged into the registers read from OnDiskRegisterStore.
+	// See storage.RegisterIndex.ByOwner for paging through the registers with the cursor.
+	// It returns:
+	//  - (registers, next cursor, nil) if the registers are read at the given block
+	//  - (nil, nil, storage.ErrHeightNotIndexed) if the height is below the first height that is indexed.
+	//  - (nil, nil, storehouse.ErrNotExecuted) if the block is not executed yet
+	//  - (nil, nil, storehouse.ErrNotExecuted) if the block is conflicting with finalized block
+	//  - (nil, nil, err) for any other exceptions
+	GetRegistersByOwner(
+		height uint64,
+		blockID flow.Identifier,
+		owner string,
+		keyPrefix string,
+		cursor storage.RegisterCursor,
+		limit int,
+	) (flow.RegisterEntries, storage.RegisterCursor, error)
+
 	// SaveRegisters saves to InMemoryRegisterStore first, then trigger the same check as OnBlockFinalized
 	// Depend on InMemoryRegisterStore.SaveRegisters
 	// It returns:
@@ -82,6 +101,19 @@ type InMemoryRegisterStore interface {
 	// It returns exception if internal index is inconsistent
 	GetRegister(height uint64, blockID flow.Identifier, register flow.RegisterID) (flow.RegisterValue, error)
 	GetUpdatedRegisters(height uint64, blockID flow.Identifier) (flow.RegisterEntries, error)
+
+	// GetUpdatedRegistersByOwner returns the latest updated values of the registers of the given owner
+	// whose keys start with the given key prefix, and which are positioned at or after the given cursor,
+	// updated from the pruned block to the given block, along with the pruned height.
+	// It returns PrunedError if the block is at or below the pruned height.
+	// It returns ErrNotExecuted if the block is not executed.
+	GetUpdatedRegistersByOwner(
+		height uint64,
+		blockID flow.Identifier,
+		owner string,
+		keyPrefix string,
+		cursor storage.RegisterCursor,
+	) (map[flow.RegisterID]flow.RegisterValue, uint64, error)
 	SaveRegisters(
 		height uint64,
 		blockID flow.Identifier,
@@ -1,12 +1,15 @@
 package storehouse
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 var _ execution.InMemoryRegisterStore = (*InMemoryRegisterStore)(nil)
@@ -39,6 +42,7 @@ func IsPrunedError(err error) (PrunedError, bool) {
 type InMemoryRegisterStore struct {
 	sync.RWMutex
 	registersByBlockID map[flow.Identifier]map[flow.RegisterID]flow.RegisterValue // for storing the registers
+	registersByOwner   map[flow.Identifier]map[string][]flow.RegisterID           // for reading the registers of an owner
 	parentByBlockID    map[flow.Identifier]flow.Identifier                        // for register updates to be fork-aware
 	blockIDsByHeight   map[uint64]map[flow.Identifier]struct{}                    // for pruning
 	prunedHeight       uint64                                                     // registers at pruned height are pruned (not saved in registersByBlockID)
@@ -48,6 +52,7 @@ type InMemoryRegisterStore struct {
 func NewInMemoryRegisterStore(lastHeight uint64, lastID flow.Identifier) *InMemoryRegisterStore {
 	return &InMemoryRegisterStore{
 		registersByBlockID: make(map[flow.Identifier]map[flow.RegisterID]flow.RegisterValue),
+		registersByOwner:   make(map[flow.Identifier]map[string][]flow.RegisterID),
 		parentByBlockID:    make(map[flow.Identifier]flow.Identifier),
 		blockIDsByHeight:   make(map[uint64]map[flow.Identifier]struct{}),
 		prunedHeight:       lastHeight,
@@ -65,7 +70,11 @@ func (s *InMemoryRegisterStore) SaveRegisters(
 ) error {
 	// preprocess data before acquiring the lock
 	regs := make(map[flow.RegisterID]flow.RegisterValue, len(registers))
+	byOwner := make(map[string][]flow.RegisterID)
 	for _, reg := range registers {
+		if _, ok := regs[reg.Key]; !ok {
+			byOwner[reg.Key.Owner] = append(byOwner[reg.Key.Owner], reg.Key)
+		}
 		regs[reg.Key] = reg.Value
 	}
 
@@ -92,6 +101,7 @@ func (s *InMemoryRegisterStore) SaveRegisters(
 
 	// update registers for the block
 	s.registersByBlockID[blockID] = regs
+	s.registersByOwner[blockID] = byOwner
 
 	// update index on parent
 	s.parentByBlockID[blockID] = parentID
@@ -161,6 +171,61 @@ func (s *InMemoryRegisterStore) GetRegister(height uint64, blockID flow.Identifi
 	}
 }
 
+// GetUpdatedRegistersByOwner returns the latest updated values of the registers of the given owner
+// whose keys start with the given key prefix, and which are positioned at or after the given cursor,
+// updated from the pruned block to the given block, along with the pruned height.
+// It returns PrunedError if the block is at or below the pruned height
+// It returns ErrNotExecuted if the block is not saved
+func (s *InMemoryRegisterStore) GetUpdatedRegistersByOwner(
+	height uint64,
+	blockID flow.Identifier,
+	owner string,
+	keyPrefix string,
+	cursor storage.RegisterCursor,
+) (map[flow.RegisterID]flow.RegisterValue, uint64, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if height <= s.prunedHeight {
+		return nil, 0, NewPrunedError(height, s.prunedHeight, s.prunedID)
+	}
+
+	_, ok := s.registersByBlockID[blockID]
+	if !ok {
+		return nil, 0, fmt.Errorf("cannot get registers at height %d, block %v is not saved: %w", height, blockID, ErrNotExecuted)
+	}
+
+	// traverse the fork down to the pruned block, registers updated by a block take
+	// precedence over the updates of its ancestors
+	updated := make(map[flow.RegisterID]flow.RegisterValue)
+	block := blockID
+	for block != s.prunedID {
+		registers := s.registersByBlockID[block]
+		for _, register := range s.registersByOwner[block][owner] {
+			if _, ok := updated[register]; ok {
+				continue
+			}
+			if !strings.HasPrefix(register.Key, keyPrefix) {
+				continue
+			}
+			if cursor != nil && bytes.Compare(storage.NewRegisterCursor(register.Key), cursor) < 0 {
+				continue
+			}
+			updated[register] = registers[register]
+		}
+
+		parent, ok := s.parentByBlockID[block]
+		if !ok {
+			return nil, 0,
+				fmt.Errorf("inconsistent parent block index in in-memory-register-store, ancient block %v is not found when getting registers at block %v",
+					block, blockID)
+		}
+		block = parent
+	}
+
+	return updated, s.prunedHeight, nil
+}
+
 func (s *InMemoryRegisterStore) readRegisterAtBlockID(blockID flow.Identifier, register flow.RegisterID) (flow.RegisterValue, bool) {
 	registers, ok := s.registersByBlockID[blockID]
 	if !ok {
@@ -327,6 +392,7 @@ func (s *InMemoryRegisterStore) pruneByHeight(height uint64, finalized flow.Iden
 
 func (s *InMemoryRegisterStore) removeBlock(height uint64, blockID flow.Identifier) {
 	delete(s.registersByBlockID, blockID)
+	delete(s.registersByOwner, blockID)
 	delete(s.parentByBlockID, blockID)
 	delete(s.blockIDsByHeight[height], blockID)
 }
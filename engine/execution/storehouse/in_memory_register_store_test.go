@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -678,6 +679,58 @@ func randBetween(min, max uint64) uint64 {
 	return uint64(rand.Intn(int(max)-int(min))) + min
 }
 
+// GetUpdatedRegistersByOwner should return the latest updates of the fork, only for the registers
+// of the owner matching the key prefix and cursor
+func TestInMemoryRegisterStoreGetUpdatedRegistersByOwner(t *testing.T) {
+	t.Parallel()
+	pruned := uint64(10)
+	lastID := unittest.IdentifierFixture()
+	store := NewInMemoryRegisterStore(pruned, lastID)
+
+	other := flow.RegisterEntry{Key: flow.RegisterID{Owner: "other", Key: "key_1"}, Value: []byte("1")}
+
+	// pruned <- A (key_1: 1, key_2: 2, other) <- B (key_2: 3, key_3: 4, prefix: 5)
+	//        ^- C (key_1: 6)
+	blockA := unittest.IdentifierFixture()
+	blockB := unittest.IdentifierFixture()
+	blockC := unittest.IdentifierFixture()
+	require.NoError(t, store.SaveRegisters(pruned+1, blockA, lastID,
+		flow.RegisterEntries{makeReg("key_1", "1"), makeReg("key_2", "2"), other}))
+	require.NoError(t, store.SaveRegisters(pruned+2, blockB, blockA,
+		flow.RegisterEntries{makeReg("key_2", "3"), makeReg("key_3", "4"), makeReg("prefix", "5")}))
+	require.NoError(t, store.SaveRegisters(pruned+1, blockC, lastID,
+		flow.RegisterEntries{makeReg("key_1", "6")}))
+
+	owner := makeReg("key_1", "").Key.Owner
+	toMap := func(entries ...flow.RegisterEntry) map[flow.RegisterID]flow.RegisterValue {
+		m := make(map[flow.RegisterID]flow.RegisterValue, len(entries))
+		for _, entry := range entries {
+			m[entry.Key] = entry.Value
+		}
+		return m
+	}
+
+	updated, prunedHeight, err := store.GetUpdatedRegistersByOwner(pruned+2, blockB, owner, "key_", nil)
+	require.NoError(t, err)
+	require.Equal(t, pruned, prunedHeight)
+	require.Equal(t, toMap(makeReg("key_1", "1"), makeReg("key_2", "3"), makeReg("key_3", "4")), updated)
+
+	updated, _, err = store.GetUpdatedRegistersByOwner(pruned+2, blockB, owner, "", storage.NewRegisterCursor("key_2"))
+	require.NoError(t, err)
+	require.Equal(t, toMap(makeReg("key_2", "3"), makeReg("key_3", "4"), makeReg("prefix", "5")), updated)
+
+	updated, _, err = store.GetUpdatedRegistersByOwner(pruned+1, blockC, owner, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, toMap(makeReg("key_1", "6")), updated)
+
+	_, _, err = store.GetUpdatedRegistersByOwner(pruned, lastID, owner, "", nil)
+	_, ok := IsPrunedError(err)
+	require.True(t, ok)
+
+	_, _, err = store.GetUpdatedRegistersByOwner(pruned+1, unknownBlock, owner, "", nil)
+	require.ErrorIs(t, err, ErrNotExecuted)
+}
+
 func makeReg(key string, value string) flow.RegisterEntry {
 	return unittest.MakeOwnerReg(key, value)
 }
@@ -1,8 +1,10 @@
 package storehouse
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"go.uber.org/atomic"
 
@@ -109,6 +111,20 @@ func (r *RegisterStore) GetRegister(height uint64, blockID flow.Identifier, regi
 		return r.getAndConvertNotFoundErr(register, prunedError.PrunedHeight)
 	}
 
+	err = r.checkFinalized(height, blockID, prunedError)
+	if err != nil {
+		return flow.RegisterValue{}, err
+	}
+	return r.getAndConvertNotFoundErr(register, height)
+}
+
+// checkFinalized checks that a block below or equal to the pruned height of the in memory store
+// is finalized, in which case its registers can be read from the on disk store.
+// It returns:
+//   - nil if the block is finalized
+//   - storehouse.ErrNotExecuted if the block is conflicting with finalized block
+//   - err for any other exceptions
+func (r *RegisterStore) checkFinalized(height uint64, blockID flow.Identifier, prunedError PrunedError) error {
 	// if the block is below or equal to the pruned height, then there are two cases:
 	// the block is a finalized block, or a conflicting block.
 	// In order to distinguish, we need to query the finalized block ID at that height
@@ -120,18 +136,19 @@ func (r *RegisterStore) GetRegister(height uint64, blockID flow.Identifier, regi
 		finalizedID = prunedError.PrunedID
 	} else {
 		// if the block is below the pruned height, we query the finalized ID from the finalized reader
+		var err error
 		finalizedID, err = r.finalized.FinalizedBlockIDAtHeight(height)
 		if err != nil {
-			return nil, fmt.Errorf("cannot get finalized block ID at height %d: %w", height, err)
+			return fmt.Errorf("cannot get finalized block ID at height %d: %w", height, err)
 		}
 	}
 
 	isConflictingBlock := blockID != finalizedID
 	if isConflictingBlock {
 		// conflicting blocks are considered as un-executed
-		return flow.RegisterValue{}, fmt.Errorf("getting registers from conflicting block %v at height %v: %w", blockID, height, ErrNotExecuted)
+		return fmt.Errorf("getting registers from conflicting block %v at height %v: %w", blockID, height, ErrNotExecuted)
 	}
-	return r.getAndConvertNotFoundErr(register, height)
+	return nil
 }
 
 // getAndConvertNotFoundErr returns nil if the register is not found from storage
@@ -144,6 +161,88 @@ func (r *RegisterStore) getAndConvertNotFoundErr(register flow.RegisterID, heigh
 	return val, err
 }
 
+// GetRegistersByOwner returns a page of the registers of the given owner whose keys start with the
+// given key prefix, with their values at the given block.
+// For blocks above the pruned height of InMemoryRegisterStore, the registers updated since the pruned
+// height are merged into the registers read from OnDiskRegisterStore at the pruned height.
+// It returns:
+//   - (registers, next cursor, nil) if the registers are read at the given block
+//   - (nil, nil, storage.ErrHeightNotIndexed) if the height is below the first height that is indexed.
+//   - (nil, nil, storehouse.ErrNotExecuted) if the block is not executed yet
+//   - (nil, nil, storehouse.ErrNotExecuted) if the block is conflicting iwth finalized block
+//   - (nil, nil, err) for any other exceptions
+func (r *RegisterStore) GetRegistersByOwner(
+	height uint64,
+	blockID flow.Identifier,
+	owner string,
+	keyPrefix string,
+	cursor storage.RegisterCursor,
+	limit int,
+) (flow.RegisterEntries, storage.RegisterCursor, error) {
+	updated, prunedHeight, err := r.memStore.GetUpdatedRegistersByOwner(height, blockID, owner, keyPrefix, cursor)
+	if err != nil {
+		prunedError, ok := IsPrunedError(err)
+		if !ok {
+			return nil, nil, fmt.Errorf("cannot get updated registers from memStore: %w", err)
+		}
+
+		err = r.checkFinalized(height, blockID, prunedError)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r.diskStore.ByOwner(owner, keyPrefix, height, cursor, limit)
+	}
+
+	registers, next, err := r.diskStore.ByOwner(owner, keyPrefix, prunedHeight, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registers, next = mergeUpdatedRegisters(registers, next, updated, limit)
+	return registers, next, nil
+}
+
+// mergeUpdatedRegisters merges the updated registers which are positioned before next into the page of
+// registers. The updated registers must match the owner, key prefix and cursor of the page.
+// It returns the merged page, with registers removed by the updates omitted, and its next cursor.
+func mergeUpdatedRegisters(
+	registers flow.RegisterEntries,
+	next storage.RegisterCursor,
+	updated map[flow.RegisterID]flow.RegisterValue,
+	limit int,
+) (flow.RegisterEntries, storage.RegisterCursor) {
+	values := make(map[flow.RegisterID]flow.RegisterValue, len(registers))
+	for _, register := range registers {
+		values[register.Key] = register.Value
+	}
+
+	for register, value := range updated {
+		if next != nil && bytes.Compare(storage.NewRegisterCursor(register.Key), next) >= 0 {
+			// the register is positioned in a later page
+			continue
+		}
+		values[register] = value
+	}
+
+	merged := make(flow.RegisterEntries, 0, len(values))
+	for register, value := range values {
+		if len(value) == 0 {
+			// the register was removed
+			continue
+		}
+		merged = append(merged, flow.RegisterEntry{Key: register, Value: value})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return bytes.Compare(storage.NewRegisterCursor(merged[i].Key.Key), storage.NewRegisterCursor(merged[j].Key.Key)) < 0
+	})
+
+	if len(merged) > limit {
+		next = storage.NewRegisterCursor(merged[limit].Key.Key)
+		merged = merged[:limit]
+	}
+	return merged, next
+}
+
 // SaveRegisters saves to InMemoryRegisterStore first, then trigger the same check as OnBlockFinalized
 // Depend on InMemoryRegisterStore.SaveRegisters
 // It returns:
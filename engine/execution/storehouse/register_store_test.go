@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/storehouse"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/pebble"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -331,6 +332,71 @@ func TestRegisterStoreReadingFromInMemStore(t *testing.T) {
 	})
 }
 
+// GetRegistersByOwner should merge the registers updated by executed blocks in memory
+// into the registers read from disk, and page through them in order.
+func TestRegisterStoreGetRegistersByOwner(t *testing.T) {
+	t.Parallel()
+	withRegisterStore(t, func(
+		t *testing.T,
+		rs *storehouse.RegisterStore,
+		diskStore execution.OnDiskRegisterStore,
+		finalized *testutil.MockFinalizedReader,
+		rootHeight uint64,
+		endHeight uint64,
+		headerByHeight map[uint64]*flow.Header,
+		n *notifier,
+	) {
+		// R <- 11 (X: 1, Y: 2, Z: 5) <- 12 (Y: 3, Z: removed, W: 6)
+		//   ^- 11 (X: 4)
+		err := rs.SaveRegisters(headerByHeight[rootHeight+1], flow.RegisterEntries{makeReg("X", "1"), makeReg("Y", "2"), makeReg("Z", "5")})
+		require.NoError(t, err)
+
+		err = rs.SaveRegisters(headerByHeight[rootHeight+2], flow.RegisterEntries{makeReg("Y", "3"), makeReg("Z", ""), makeReg("W", "6")})
+		require.NoError(t, err)
+
+		block11Fork := unittest.BlockWithParentFixture(headerByHeight[rootHeight]).Header
+		err = rs.SaveRegisters(block11Fork, flow.RegisterEntries{makeReg("X", "4")})
+		require.NoError(t, err)
+
+		// block 11 is finalized and saved on disk, block 12 is in memory
+		require.NoError(t, finalized.MockFinal(rootHeight+1))
+		require.NoError(t, rs.OnBlockFinalized())
+		require.Equal(t, rootHeight+1, n.height)
+
+		readAll := func(height uint64, blockID flow.Identifier, limit int) flow.RegisterEntries {
+			all := flow.RegisterEntries{}
+			var cursor storage.RegisterCursor
+			for {
+				registers, next, err := rs.GetRegistersByOwner(height, blockID, "owner", "", cursor, limit)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(registers), limit)
+				all = append(all, registers...)
+				if next == nil {
+					return all
+				}
+				cursor = next
+			}
+		}
+
+		for _, limit := range []int{1, 2, 10} {
+			require.Equal(t,
+				flow.RegisterEntries{makeReg("X", "1"), makeReg("Y", "2"), makeReg("Z", "5")},
+				readAll(rootHeight+1, headerByHeight[rootHeight+1].ID(), limit))
+
+			require.Equal(t,
+				flow.RegisterEntries{makeReg("W", "6"), makeReg("X", "1"), makeReg("Y", "3")},
+				readAll(rootHeight+2, headerByHeight[rootHeight+2].ID(), limit))
+		}
+
+		// pruned conflicting forks are considered not executed
+		_, _, err = rs.GetRegistersByOwner(rootHeight+1, block11Fork.ID(), "owner", "", nil, 10)
+		require.ErrorIs(t, err, storehouse.ErrNotExecuted)
+
+		_, _, err = rs.GetRegistersByOwner(rootHeight+3, headerByHeight[rootHeight+3].ID(), "owner", "", nil, 10)
+		require.ErrorIs(t, err, storehouse.ErrNotExecuted)
+	})
+}
+
 func TestRegisterStoreReadRegisterAtPrunedHeight(t *testing.T) {
 	t.Parallel()
 	withRegisterStore(t, func(
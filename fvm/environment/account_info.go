@@ -22,6 +22,7 @@ type AccountInfo interface {
 
 	GetAccount(address flow.Address) (*flow.Account, error)
 	GetAccountKeys(address flow.Address) ([]flow.AccountPublicKey, error)
+	GetAccountKeyByIndex(address flow.Address, index uint64) (*flow.AccountPublicKey, error)
 }
 
 type ParseRestrictedAccountInfo struct {
@@ -117,6 +118,22 @@ func (info ParseRestrictedAccountInfo) GetAccountKeys(
 		address)
 }
 
+func (info ParseRestrictedAccountInfo) GetAccountKeyByIndex(
+	address flow.Address,
+	index uint64,
+) (
+	*flow.AccountPublicKey,
+	error,
+) {
+	return parseRestrict2Arg1Ret(
+		info.txnState,
+		trace.FVMEnvGetAccountKeyByIndex,
+		info.impl.GetAccountKeyByIndex,
+		address,
+		index,
+	)
+}
+
 type accountInfo struct {
 	tracer tracing.TracerSpan
 	meter  Meter
@@ -285,3 +302,20 @@ func (info *accountInfo) GetAccountKeys(
 
 	return accountKeys, nil
 }
+
+func (info *accountInfo) GetAccountKeyByIndex(
+	address flow.Address,
+	index uint64,
+) (
+	*flow.AccountPublicKey,
+	error,
+) {
+	defer info.tracer.StartChildSpan(trace.FVMEnvGetAccountKeyByIndex).End()
+
+	accountKey, err := info.accounts.GetPublicKey(address, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountKey, nil
+}
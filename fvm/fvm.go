@@ -247,6 +247,25 @@ func GetAccountBalance(
 	return accountBalance, nil
 }
 
+// GetAccountAvailableBalance returns an account available balance by address or an error if none exists.
+func GetAccountAvailableBalance(
+	ctx Context,
+	address flow.Address,
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	uint64,
+	error,
+) {
+	env := getScriptEnvironment(ctx, storageSnapshot)
+
+	accountBalance, err := env.GetAccountAvailableBalance(common.MustBytesToAddress(address.Bytes()))
+
+	if err != nil {
+		return 0, fmt.Errorf("cannot get account available balance: %w", err)
+	}
+	return accountBalance, nil
+}
+
 // GetAccountKeys returns an account keys by address or an error if none exists.
 func GetAccountKeys(
 	ctx Context,
@@ -265,6 +284,25 @@ func GetAccountKeys(
 	return accountKeys, nil
 }
 
+// GetAccountKey returns an account key by address and index or an error if none exists.
+func GetAccountKey(
+	ctx Context,
+	address flow.Address,
+	keyIndex uint64,
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	*flow.AccountPublicKey,
+	error,
+) {
+	env := getScriptEnvironment(ctx, storageSnapshot)
+
+	accountKey, err := env.GetAccountKeyByIndex(address, keyIndex)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get account key: %w", err)
+	}
+	return accountKey, nil
+}
+
 // Helper function to initialize common components.
 func getScriptEnvironment(ctx Context, storageSnapshot snapshot.StorageSnapshot) environment.Environment {
 	blockDatabase := storage.NewBlockDatabase(
@@ -14,6 +14,8 @@ import (
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	realWAL "github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/lifecycle"
 	"github.com/onflow/flow-go/module/observable"
 )
@@ -49,7 +51,9 @@ type checkpointResult struct {
 type Compactor struct {
 	checkpointer                         *realWAL.Checkpointer
 	wal                                  realWAL.LedgerWAL
-	trieQueue                            *realWAL.TrieQueue
+	ledger                               *Ledger
+	checkpointCapacity                   uint
+	trieQueue                            *realWAL.TrieQueue // initialized when Compactor goroutine starts
 	logger                               zerolog.Logger
 	lm                                   *lifecycle.LifecycleManager
 	observers                            map[observable.Observer]struct{}
@@ -59,6 +63,34 @@ type Compactor struct {
 	trieUpdateCh                         <-chan *WALTrieUpdate
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool // to trigger checkpoint manually
 	metrics                              module.WALMetrics
+	consolidationInterval                uint                // incremental checkpoints between full checkpoints
+	previousCheckpoint                   *previousCheckpoint // only accessed by the checkpointing goroutine
+	signalerCtx                          context.Context     // irrecoverable errors are thrown to this context
+}
+
+var _ component.Component = (*Compactor)(nil)
+
+// previousCheckpoint is the last created checkpoint, which incremental checkpoints are based on.
+// Its tries aren't kept, since the base tries are identified by the root hashes stored in the
+// checkpoint file, and keeping them would prevent the tries evicted since then from being freed.
+type previousCheckpoint struct {
+	num int
+	// incrementals is the number of incremental checkpoints created since the last full checkpoint.
+	incrementals uint
+}
+
+// CompactorOption is an option for creating a Compactor.
+type CompactorOption func(*Compactor)
+
+// WithIncrementalCheckpoints configures the Compactor to create incremental checkpoints, which only
+// contain the trie nodes created since the previous checkpoint. After consolidationInterval
+// incremental checkpoints, a full checkpoint is created so that loading a checkpoint never requires
+// more than consolidationInterval prior checkpoints. Zero disables incremental checkpoints.
+// The first checkpoint created after startup is always a full checkpoint.
+func WithIncrementalCheckpoints(consolidationInterval uint) CompactorOption {
+	return func(c *Compactor) {
+		c.consolidationInterval = consolidationInterval
+	}
 }
 
 // NewCompactor creates new Compactor which writes WAL record and triggers
@@ -79,6 +111,7 @@ func NewCompactor(
 	checkpointsToKeep uint,
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool,
 	metrics module.WALMetrics,
+	opts ...CompactorOption,
 ) (*Compactor, error) {
 	if checkpointDistance < 1 {
 		checkpointDistance = 1
@@ -96,19 +129,11 @@ func NewCompactor(
 		return nil, errors.New("failed to get valid trie update channel from ledger")
 	}
 
-	// Get all tries from ledger state.
-	tries, err := l.Tries()
-	if err != nil {
-		return nil, err
-	}
-
-	// Create trieQueue with initial values from ledger state.
-	trieQueue := realWAL.NewTrieQueueWithValues(checkpointCapacity, tries)
-
-	return &Compactor{
+	c := &Compactor{
 		checkpointer:                         checkpointer,
 		wal:                                  w,
-		trieQueue:                            trieQueue,
+		ledger:                               l,
+		checkpointCapacity:                   checkpointCapacity,
 		logger:                               logger.With().Str("ledger_mod", "compactor").Logger(),
 		stopCh:                               make(chan chan struct{}),
 		trieUpdateCh:                         trieUpdateCh,
@@ -118,7 +143,12 @@ func NewCompactor(
 		checkpointsToKeep:                    checkpointsToKeep,
 		triggerCheckpointOnNextSegmentFinish: triggerCheckpointOnNextSegmentFinish,
 		metrics:                              metrics,
-	}, nil
+		signalerCtx:                          context.Background(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // Subscribe subscribes observer to Compactor.
@@ -132,6 +162,13 @@ func (c *Compactor) Unsubscribe(observer observable.Observer) {
 	delete(c.observers, observer)
 }
 
+// Start sets the context irrecoverable errors of the Compactor goroutine are thrown to.
+// It must be called before Ready. If the Compactor is not started, irrecoverable errors
+// terminate the process.
+func (c *Compactor) Start(ctx irrecoverable.SignalerContext) {
+	c.signalerCtx = irrecoverable.WithSignalerContext(ctx, ctx)
+}
+
 // Ready returns channel which would be closed when Compactor goroutine starts.
 func (c *Compactor) Ready() <-chan struct{} {
 	c.lm.OnStart(func() {
@@ -176,6 +213,28 @@ func (c *Compactor) run() {
 
 	checkpointResultCh := make(chan checkpointResult, 1)
 
+	// Create trieQueue with initial values from ledger state.
+	// Getting the tries waits until the ledger finishes loading the forest, which is
+	// done in the background if the ledger serves early reads.
+	// The checkpoints are created from the trieQueue, so the Compactor must not run without the
+	// ledger tries, as the next checkpoint would lose the state of the missing tries.
+	tries, err := c.ledger.Tries()
+	if err != nil {
+		// Wait for stop signal, so that Done doesn't block. This is deferred because
+		// throwing the error exits the goroutine.
+		defer func() {
+			doneCh := <-c.stopCh
+			close(doneCh)
+		}()
+
+		// The ledger stops loading the forest when it is shut down, which isn't an error.
+		if !errors.Is(err, errLoadStopped) {
+			irrecoverable.Throw(c.signalerCtx, fmt.Errorf("compactor failed to get tries from ledger: %w", err))
+		}
+		return
+	}
+	c.trieQueue = realWAL.NewTrieQueueWithValues(c.checkpointCapacity, tries)
+
 	// Get active segment number (opened segment that new records write to).
 	// activeSegmentNum is updated when record is written to a new segment.
 	_, activeSegmentNum, err := c.wal.Segments()
@@ -292,9 +351,21 @@ Loop:
 // Since this function is only for checkpointing, Compactor isn't affected by returned error.
 func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpointNum int) error {
 
-	err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
-	if err != nil {
-		return &createCheckpointError{num: checkpointNum, err: err}
+	base := c.previousCheckpoint
+	if base != nil && base.incrementals < c.consolidationInterval {
+		err := createIncrementalCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, base, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		c.previousCheckpoint = &previousCheckpoint{num: checkpointNum, incrementals: base.incrementals + 1}
+	} else {
+		err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		if c.consolidationInterval > 0 {
+			c.previousCheckpoint = &previousCheckpoint{num: checkpointNum}
+		}
 	}
 
 	// Return if context is canceled.
@@ -304,7 +375,7 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	default:
 	}
 
-	err = cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
+	err := cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
 	if err != nil {
 		return &removeCheckpointError{err: err}
 	}
@@ -354,7 +425,38 @@ func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger,
 	return nil
 }
 
+// createIncrementalCheckpoint creates incremental checkpoint with given checkpointNum and tries,
+// based on the given previous checkpoint.
+// Errors indicate that checkpoint file can't be created.
+// Caller should handle returned errors by retrying checkpointing when appropriate.
+func createIncrementalCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger, tries []*trie.MTrie, checkpointNum int, base *previousCheckpoint, metrics module.WALMetrics) error {
+
+	logger.Info().Msgf("serializing incremental checkpoint %d based on checkpoint %d with %v tries", checkpointNum, base.num, len(tries))
+
+	startTime := time.Now()
+
+	fileName := realWAL.NumberToFilename(checkpointNum)
+	err := realWAL.StoreIncrementalCheckpoint(tries, base.num, checkpointer.Dir(), fileName, logger)
+	if err != nil {
+		return fmt.Errorf("error serializing incremental checkpoint (%d): %w", checkpointNum, err)
+	}
+
+	size, err := realWAL.ReadCheckpointFileSize(checkpointer.Dir(), fileName)
+	if err != nil {
+		return fmt.Errorf("error reading checkpoint file size (%d): %w", checkpointNum, err)
+	}
+
+	metrics.ExecutionCheckpointSize(size)
+
+	duration := time.Since(startTime)
+	logger.Info().Float64("total_time_s", duration.Seconds()).Msgf("created incremental checkpoint %d", checkpointNum)
+
+	return nil
+}
+
 // cleanupCheckpoints deletes prior checkpoint files if needed.
+// Checkpoints which kept incremental checkpoints are based on are not deleted,
+// since incremental checkpoints can't be loaded without them.
 // Since the function is side-effect free, all failures are simply a no-op.
 func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep int) error {
 	// Don't list checkpoints if we keep them all
@@ -369,7 +471,15 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 		// if condition guarantees this never fails
 		checkpointsToRemove := checkpoints[:len(checkpoints)-int(checkpointsToKeep)]
 
+		required, err := baseCheckpoints(checkpointer, checkpoints[len(checkpoints)-int(checkpointsToKeep):])
+		if err != nil {
+			return err
+		}
+
 		for _, checkpoint := range checkpointsToRemove {
+			if _, ok := required[checkpoint]; ok {
+				continue
+			}
 			err := checkpointer.RemoveCheckpoint(checkpoint)
 			if err != nil {
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
@@ -379,6 +489,29 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 	return nil
 }
 
+// baseCheckpoints returns the checkpoints required to load the given checkpoints,
+// which are the chains of base checkpoints of incremental checkpoints.
+func baseCheckpoints(checkpointer *realWAL.Checkpointer, checkpoints []int) (map[int]struct{}, error) {
+	required := make(map[int]struct{})
+	for _, checkpoint := range checkpoints {
+		for {
+			base, incremental, err := checkpointer.CheckpointBase(checkpoint)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read base of checkpoint %d: %w", checkpoint, err)
+			}
+			if !incremental {
+				break
+			}
+			if _, ok := required[base]; ok {
+				break
+			}
+			required[base] = struct{}{}
+			checkpoint = base
+		}
+	}
+	return required, nil
+}
+
 // processTrieUpdate writes trie update to WAL, updates activeSegmentNum,
 // and returns tries for checkpointing if needed.
 // It sends WAL update result, receives updated trie, and pushes updated trie to trieQueue.
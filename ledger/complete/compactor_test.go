@@ -1,6 +1,8 @@
 package complete
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -22,6 +24,7 @@ import (
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	realWAL "github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -523,6 +526,177 @@ func TestCompactorAccuracy(t *testing.T) {
 	})
 }
 
+// TestCompactorIncrementalCheckpoints tests that the compactor creates incremental checkpoints
+// consolidated by full checkpoints, keeps the base checkpoints of kept incremental checkpoints,
+// and that the rebuilt ledger state at restart matches the checkpointed state.
+func TestCompactorIncrementalCheckpoints(t *testing.T) {
+
+	const (
+		numInsPerStep         = 2
+		pathByteSize          = 32
+		minPayloadByteSize    = 2<<11 - 256 // 3840 bytes
+		maxPayloadByteSize    = 2 << 11     // 4096 bytes
+		size                  = 20
+		checkpointDistance    = 2
+		checkpointsToKeep     = 2
+		consolidationInterval = 2
+		forestCapacity        = 500
+	)
+
+	metricsCollector := &metrics.NoopCollector{}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		lastCheckpointNum := -1
+
+		rootHash := trie.EmptyTrieRootHash()
+
+		// Create DiskWAL and Ledger repeatedly to test rebuilding ledger state from incremental checkpoints at restart.
+		for i := 0; i < 2; i++ {
+
+			wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, 32*1024)
+			require.NoError(t, err)
+
+			l, err := NewLedger(wal, forestCapacity, metricsCollector, zerolog.Logger{}, DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			// the ledger state is rebuilt from the latest checkpoint and the following segments
+			require.True(t, l.HasState(ledger.State(rootHash)))
+
+			compactor, err := NewCompactor(l, wal, unittest.Logger(), forestCapacity, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector(),
+				WithIncrementalCheckpoints(consolidationInterval))
+			require.NoError(t, err)
+
+			fromBound := lastCheckpointNum + (size / 2)
+
+			co := CompactorObserver{fromBound: fromBound, done: make(chan struct{})}
+			compactor.Subscribe(&co)
+
+			// Run Compactor in background.
+			<-compactor.Ready()
+
+			for i := 0; i < size+2; i++ {
+				time.Sleep(LedgerUpdateDelay)
+
+				payloads := testutils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+				keys := make([]ledger.Key, len(payloads))
+				values := make([]ledger.Value, len(payloads))
+				for i, p := range payloads {
+					k, err := p.Key()
+					require.NoError(t, err)
+					keys[i] = k
+					values[i] = p.Value()
+				}
+
+				update, err := ledger.NewUpdate(ledger.State(rootHash), keys, values)
+				require.NoError(t, err)
+
+				newState, _, err := l.Set(update)
+				require.NoError(t, err)
+
+				rootHash = ledger.RootHash(newState)
+			}
+
+			// wait for the bound-checking observer to confirm checkpoints have been made
+			select {
+			case <-co.done:
+				// continue
+			case <-time.After(60 * time.Second):
+				assert.FailNow(t, "timed out")
+			}
+
+			// Shutdown ledger and compactor
+			<-l.Done()
+			<-compactor.Done()
+
+			checkpointer, err := wal.NewCheckpointer()
+			require.NoError(t, err)
+
+			nums, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+
+			// the latest checkpoints are incremental, and their base checkpoints are kept
+			// back to the last full checkpoint.
+			incrementals := 0
+			for _, n := range nums {
+				base, incremental, err := checkpointer.CheckpointBase(n)
+				require.NoError(t, err)
+				if incremental {
+					incrementals++
+					require.Contains(t, nums, base)
+				}
+			}
+			require.Greater(t, incrementals, 0)
+
+			for _, n := range nums[len(nums)-checkpointsToKeep:] {
+				testCheckpointedTriesMatchReplayedTriesFromSegments(t, checkpointer, n, dir, false)
+			}
+
+			lastCheckpointNum = nums[len(nums)-1]
+		}
+	})
+}
+
+// TestCompactorStopsWithoutLedgerTries tests that the Compactor throws an irrecoverable error
+// instead of running with an empty trie queue when the ledger fails to load its tries.
+func TestCompactorStopsWithoutLedgerTries(t *testing.T) {
+	const (
+		pathByteSize   = 32
+		forestCapacity = 100
+		segmentSize    = 32 * 1024
+	)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		diskWAL, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		replayErr := errors.New("replay failed")
+		wal := &failingReplayWAL{LedgerWAL: diskWAL, err: replayErr}
+
+		l, err := NewLedger(wal, forestCapacity, metrics.NewNoopCollector(), unittest.Logger(), DefaultPathFinderVersion, WithEarlyReads())
+		require.NoError(t, err)
+
+		compactor, err := NewCompactor(l, wal, unittest.Logger(), forestCapacity, 1, 1, atomic.NewBool(false), metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+
+		compactor.Start(signalerCtx)
+		<-compactor.Ready()
+
+		select {
+		case err := <-errChan:
+			require.ErrorIs(t, err, replayErr)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "compactor did not throw an error")
+		}
+
+		unittest.RequireCloseBefore(t, compactor.Done(), 10*time.Second, "compactor did not stop")
+		<-l.Done()
+	})
+}
+
+// failingReplayWAL is a WAL whose replay fails with err after loading an empty checkpoint.
+type failingReplayWAL struct {
+	realWAL.LedgerWAL
+	err error
+}
+
+func (w *failingReplayWAL) Replay(
+	checkpointFn func(tries []*trie.MTrie) error,
+	updateFn func(update *ledger.TrieUpdate) error,
+	deleteFn func(ledger.RootHash) error,
+) error {
+	err := checkpointFn(nil)
+	if err != nil {
+		return err
+	}
+	return w.err
+}
+
 // TestCompactorTriggeredByAdminTool tests that the compactor will listen to the signal from admin tool
 // to trigger checkpoint when current segment file is finished.
 func TestCompactorTriggeredByAdminTool(t *testing.T) {
@@ -1,11 +1,14 @@
 package complete
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
@@ -23,6 +26,9 @@ const (
 	defaultTrieUpdateChanSize = 500
 )
 
+// errLoadStopped is returned by the replay of the WAL when the ledger is shut down while the forest is loading.
+var errLoadStopped = errors.New("ledger shut down while loading forest")
+
 // Ledger (complete) is a fast memory-efficient fork-aware thread-safe trie-based key/value storage.
 // Ledger holds an array of registers (key-value pairs) and keeps tracks of changes over a limited time.
 // Each register is referenced by an ID (key) and holds a value (byte slice).
@@ -41,6 +47,37 @@ type Ledger struct {
 	logger            zerolog.Logger
 	trieUpdateCh      chan *WALTrieUpdate
 	pathFinderVersion uint8
+	earlyReads        bool
+	// loaded is closed once the forest is loaded from the WAL, and loadErr is
+	// the error of loading it, if any.
+	loaded  chan struct{}
+	loadErr error
+	// trieAdded is closed and replaced whenever tries are added to the forest while it is loading,
+	// to wake up reads waiting for their trie.
+	trieAddedMu sync.Mutex
+	trieAdded   chan struct{}
+	// stop is closed when the ledger shuts down, to stop loading the forest.
+	stop     chan struct{}
+	stopOnce sync.Once
+	// checkpointTrie is the latest trie of the loaded checkpoint while the forest is loading with early
+	// reads. It is published before the tries of the checkpoint are added to the forest.
+	checkpointTrie atomic.Pointer[trie.MTrie]
+}
+
+// LedgerOption is an option for creating a Ledger.
+type LedgerOption func(*Ledger)
+
+// WithEarlyReads configures the Ledger to load the forest from the WAL in the background, instead of
+// blocking until the whole forest is loaded. NewLedger returns as soon as the latest checkpoint is read,
+// and the latest trie of the checkpoint is published first: GetSingleValue reads of it are served
+// before the older tries of the checkpoint are added to the forest and the WAL segments are replayed.
+// The other tries are then served as soon as they are added to the forest, from the checkpoint or from
+// the segments replayed so far. All other operations wait for the forest to finish loading.
+// Shutting down the ledger stops the replay.
+func WithEarlyReads() LedgerOption {
+	return func(l *Ledger) {
+		l.earlyReads = true
+	}
 }
 
 // NewLedger creates a new in-memory trie-backed ledger storage with persistence.
@@ -49,7 +86,9 @@ func NewLedger(
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	opts ...LedgerOption,
+) (*Ledger, error) {
 
 	logger := log.With().Str("ledger_mod", "complete").Logger()
 
@@ -65,6 +104,20 @@ func NewLedger(
 		logger:            logger,
 		pathFinderVersion: pathFinderVer,
 		trieUpdateCh:      make(chan *WALTrieUpdate, defaultTrieUpdateChanSize),
+		loaded:            make(chan struct{}),
+		trieAdded:         make(chan struct{}),
+		stop:              make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(storage)
+	}
+
+	if storage.earlyReads {
+		err = storage.loadWithEarlyReads()
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
 	}
 
 	// pause records to prevent double logging trie removals
@@ -77,6 +130,7 @@ func NewLedger(
 	}
 
 	wal.UnpauseRecord()
+	close(storage.loaded)
 
 	// TODO update to proper value once https://github.com/onflow/flow-go/pull/3720 is merged
 	metrics.ForestApproxMemorySize(0)
@@ -84,6 +138,109 @@ func NewLedger(
 	return storage, nil
 }
 
+// loadWithEarlyReads loads the forest from the WAL in the background. It returns once the tries of
+// the checkpoint are loaded, or once the forest is loaded if there is no checkpoint to load.
+// All returned errors indicate that the forest can't be loaded.
+func (l *Ledger) loadWithEarlyReads() error {
+	checkpointLoaded := make(chan struct{})
+	var once sync.Once
+
+	// pause records to prevent double logging trie removals
+	l.wal.PauseRecord()
+
+	go func() {
+		defer close(l.loaded)
+		defer l.checkpointTrie.Store(nil)
+		defer l.wal.UnpauseRecord()
+
+		err := l.wal.Replay(
+			func(tries []*trie.MTrie) error {
+				// publish the latest checkpointed trie before adding the older ones to the forest
+				if len(tries) > 0 {
+					l.checkpointTrie.Store(tries[len(tries)-1])
+				}
+				once.Do(func() { close(checkpointLoaded) })
+
+				err := l.forest.AddTries(tries)
+				if err != nil {
+					return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
+				}
+				l.notifyTrieAdded()
+				return nil
+			},
+			func(update *ledger.TrieUpdate) error {
+				select {
+				case <-l.stop:
+					return errLoadStopped
+				default:
+				}
+				_, err := l.forest.Update(update)
+				if err != nil {
+					return err
+				}
+				l.notifyTrieAdded()
+				return nil
+			},
+			func(rootHash ledger.RootHash) error {
+				return nil
+			},
+		)
+		if err != nil {
+			l.loadErr = fmt.Errorf("cannot restore LedgerWAL: %w", err)
+			if errors.Is(err, errLoadStopped) {
+				l.logger.Info().Int("forest_size", l.forest.Size()).Msg("stopped loading forest")
+				return
+			}
+			l.logger.Error().Err(err).Msg("failed to load forest")
+			return
+		}
+		l.logger.Info().Int("forest_size", l.forest.Size()).Msg("finished loading forest")
+	}()
+
+	select {
+	case <-checkpointLoaded:
+		l.logger.Info().Msg("checkpoint loaded, serving reads of the latest checkpointed trie while loading the forest")
+		return nil
+	case <-l.loaded:
+		return l.loadErr
+	}
+}
+
+// waitLoaded waits until the forest is loaded, and returns the error of loading it, if any.
+func (l *Ledger) waitLoaded() error {
+	<-l.loaded
+	return l.loadErr
+}
+
+// waitTrie waits until the trie with the given root hash is added to the forest, or the forest is loaded.
+// It returns the error of loading the forest, if the trie isn't loaded.
+func (l *Ledger) waitTrie(rootHash ledger.RootHash) error {
+	for {
+		// get the channel before checking the forest, so that tries added after the check wake us up
+		l.trieAddedMu.Lock()
+		added := l.trieAdded
+		l.trieAddedMu.Unlock()
+
+		if l.forest.HasTrie(rootHash) {
+			return nil
+		}
+
+		select {
+		case <-added:
+		case <-l.loaded:
+			return l.loadErr
+		}
+	}
+}
+
+// notifyTrieAdded wakes up the reads waiting for their trie to be added to the forest.
+func (l *Ledger) notifyTrieAdded() {
+	l.trieAddedMu.Lock()
+	defer l.trieAddedMu.Unlock()
+	close(l.trieAdded)
+	l.trieAdded = make(chan struct{})
+}
+
 // TrieUpdateChan returns a channel which is used to receive trie updates that needs to be logged in WALs.
 // This channel is closed when ledger component shutdowns down.
 func (l *Ledger) TrieUpdateChan() <-chan *WALTrieUpdate {
@@ -108,6 +265,10 @@ func (l *Ledger) Done() <-chan struct{} {
 	go func() {
 		defer close(done)
 
+		// stop loading the forest from the WAL, and wait for the replay to return
+		l.stopOnce.Do(func() { close(l.stop) })
+		<-l.loaded
+
 		// Ledger is responsible for closing trieUpdateCh channel,
 		// so Compactor can drain and process remaining updates.
 		close(l.trieUpdateCh)
@@ -123,6 +284,10 @@ func (l *Ledger) InitialState() ledger.State {
 // ValueSizes read the values of the given keys at the given state.
 // It returns value sizes in the same order as given registerIDs and errors (if any)
 func (l *Ledger) ValueSizes(query *ledger.Query) (valueSizes []int, err error) {
+	err = l.waitTrie(ledger.RootHash(query.State()))
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
 	if err != nil {
@@ -154,9 +319,21 @@ func (l *Ledger) GetSingleValue(query *ledger.QuerySingleValue) (value ledger.Va
 		return nil, err
 	}
 	trieRead := &ledger.TrieReadSingleValue{RootHash: ledger.RootHash(query.State()), Path: path}
-	value, err = l.forest.ReadSingleValue(trieRead)
-	if err != nil {
-		return nil, err
+
+	// with early reads, the latest checkpointed trie is read before it is added to the forest,
+	// and other tries are read once they are added while the forest is loading
+	if checkpointTrie := l.checkpointTrie.Load(); checkpointTrie != nil && checkpointTrie.RootHash() == trieRead.RootHash {
+		value = checkpointTrie.ReadSinglePayload(path).Value().DeepCopy()
+	} else {
+		err = l.waitTrie(trieRead.RootHash)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = l.forest.ReadSingleValue(trieRead)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	l.metrics.ReadValuesNumber(1)
@@ -172,6 +349,10 @@ func (l *Ledger) GetSingleValue(query *ledger.QuerySingleValue) (value ledger.Va
 // Get read the values of the given keys at the given state
 // it returns the values in the same order as given registerIDs and errors (if any)
 func (l *Ledger) Get(query *ledger.Query) (values []ledger.Value, err error) {
+	err = l.waitTrie(ledger.RootHash(query.State()))
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
 	if err != nil {
@@ -198,6 +379,11 @@ func (l *Ledger) Get(query *ledger.Query) (values []ledger.Value, err error) {
 // Set updates the ledger given an update.
 // It returns the state after update and errors (if any)
 func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, trieUpdate *ledger.TrieUpdate, err error) {
+	err = l.waitLoaded()
+	if err != nil {
+		return ledger.State(hash.DummyHash), nil, err
+	}
+
 	if update.Size() == 0 {
 		return update.State(),
 			&ledger.TrieUpdate{
@@ -287,6 +473,10 @@ func (l *Ledger) set(trieUpdate *ledger.TrieUpdate) (newState ledger.State, err
 // In the current implementation, proofs are sorted in a deterministic order specified by the
 // forest and mtrie implementation.
 func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
+	err = l.waitTrie(ledger.RootHash(query.State()))
+	if err != nil {
+		return nil, err
+	}
 
 	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
 	if err != nil {
@@ -321,11 +511,19 @@ func (l *Ledger) ForestSize() int {
 
 // Tries returns the tries stored in the forest
 func (l *Ledger) Tries() ([]*trie.MTrie, error) {
+	err := l.waitLoaded()
+	if err != nil {
+		return nil, err
+	}
 	return l.forest.GetTries()
 }
 
 // Trie returns the trie stored in the forest
 func (l *Ledger) Trie(rootHash ledger.RootHash) (*trie.MTrie, error) {
+	err := l.waitLoaded()
+	if err != nil {
+		return nil, err
+	}
 	return l.forest.GetTrie(rootHash)
 }
 
@@ -348,6 +546,11 @@ func (l *Ledger) MigrateAt(
 		state.String(),
 	)
 
+	err := l.waitLoaded()
+	if err != nil {
+		return nil, err
+	}
+
 	// get trie
 	t, err := l.forest.GetTrie(ledger.RootHash(state))
 	if err != nil {
@@ -414,17 +617,28 @@ func (l *Ledger) MigrateAt(
 
 // MostRecentTouchedState returns a state which is most recently touched.
 func (l *Ledger) MostRecentTouchedState() (ledger.State, error) {
+	err := l.waitLoaded()
+	if err != nil {
+		return ledger.State(hash.DummyHash), err
+	}
 	root, err := l.forest.MostRecentTouchedRootHash()
 	return ledger.State(root), err
 }
 
 // HasState returns true if the given state exists inside the ledger
 func (l *Ledger) HasState(state ledger.State) bool {
+	if l.waitTrie(ledger.RootHash(state)) != nil {
+		return false
+	}
 	return l.forest.HasTrie(ledger.RootHash(state))
 }
 
 // DumpTrieAsJSON export trie at specific state as JSONL (each line is JSON encoding of a payload)
 func (l *Ledger) DumpTrieAsJSON(state ledger.State, writer io.Writer) error {
+	err := l.waitLoaded()
+	if err != nil {
+		return err
+	}
 	fmt.Println(ledger.RootHash(state))
 	trie, err := l.forest.GetTrie(ledger.RootHash(state))
 	if err != nil {
@@ -435,6 +649,10 @@ func (l *Ledger) DumpTrieAsJSON(state ledger.State, writer io.Writer) error {
 
 // this operation should only be used for exporting
 func (l *Ledger) keepOnlyOneTrie(state ledger.State) error {
+	err := l.waitLoaded()
+	if err != nil {
+		return err
+	}
 	// don't write things to WALs
 	l.wal.PauseRecord()
 	defer l.wal.UnpauseRecord()
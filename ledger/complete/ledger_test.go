@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -18,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/proof"
 	"github.com/onflow/flow-go/ledger/common/testutils"
 	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
 	"github.com/onflow/flow-go/ledger/partial/ptrie"
@@ -585,6 +587,174 @@ func Test_WAL(t *testing.T) {
 	})
 }
 
+// TestLedger_EarlyReads tests that a ledger loaded with early reads serves reads of the checkpointed
+// tries and of the tries replayed from WAL segments after the checkpoint.
+func TestLedger_EarlyReads(t *testing.T) {
+	const (
+		numInsPerStep      = 2
+		keyNumberOfParts   = 10
+		keyPartMinByteSize = 1
+		keyPartMaxByteSize = 100
+		valueMaxByteSize   = 2 << 11 // 4kB
+		size               = 20
+		segmentSize        = 32 * 1024
+		checkpointDistance = math.MaxInt // A large number to prevent checkpoint creation.
+		checkpointsToKeep  = 1
+	)
+
+	metricsCollector := &metrics.NoopCollector{}
+	logger := zerolog.Logger{}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		diskWal, err := wal.NewDiskWAL(zerolog.Nop(), nil, metricsCollector, dir, size, pathfinder.PathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		led, err := complete.NewLedger(diskWal, size, metricsCollector, logger, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		compactor, err := complete.NewCompactor(led, diskWal, zerolog.Nop(), size, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		<-compactor.Ready()
+
+		state := led.InitialState()
+		states := make([]ledger.State, 0, size)
+		updates := make([]*ledger.Update, 0, size)
+
+		for i := 0; i < size; i++ {
+			keys := testutils.RandomUniqueKeys(numInsPerStep, keyNumberOfParts, keyPartMinByteSize, keyPartMaxByteSize)
+			values := testutils.RandomValues(numInsPerStep, valueMaxByteSize/2, valueMaxByteSize)
+			update, err := ledger.NewUpdate(state, keys, values)
+			require.NoError(t, err)
+			state, _, err = led.Set(update)
+			require.NoError(t, err)
+
+			states = append(states, state)
+			updates = append(updates, update)
+		}
+
+		<-led.Done()
+		<-compactor.Done()
+
+		// checkpoint all but the last segment, so the ledger is loaded from both a checkpoint and segments
+		_, last, err := diskWal.Segments()
+		require.NoError(t, err)
+		require.Greater(t, last, 1)
+
+		checkpointer, err := diskWal.NewCheckpointer()
+		require.NoError(t, err)
+		require.NoError(t, checkpointer.Checkpoint(last-1))
+
+		// the latest checkpointed trie is read while the segments are not replayed yet
+		checkpointRoots, err := wal.ReadTriesRootHash(logger, dir, wal.NumberToFilename(last-1))
+		require.NoError(t, err)
+		latestCheckpointed := slices.Index(states, ledger.State(checkpointRoots[len(checkpointRoots)-1]))
+		require.GreaterOrEqual(t, latestCheckpointed, 0)
+		require.Less(t, latestCheckpointed, size-1)
+
+		diskWalBlocked, err := wal.NewDiskWAL(zerolog.Nop(), nil, metricsCollector, dir, size, pathfinder.PathByteSize, segmentSize)
+		require.NoError(t, err)
+		blockedWal := &blockingReplayWAL{LedgerWAL: diskWalBlocked, release: make(chan struct{})}
+
+		ledBlocked, err := complete.NewLedger(blockedWal, size, metricsCollector, logger, complete.DefaultPathFinderVersion, complete.WithEarlyReads())
+		require.NoError(t, err)
+
+		for j, key := range updates[latestCheckpointed].Keys() {
+			query, err := ledger.NewQuerySingleValue(states[latestCheckpointed], key)
+			require.NoError(t, err)
+
+			value, err := ledBlocked.GetSingleValue(query)
+			require.NoError(t, err)
+			require.True(t, updates[latestCheckpointed].Values()[j].Equals(value))
+		}
+
+		// the reads above returned before any segment was replayed, so the forest finishes loading now
+		close(blockedWal.release)
+		tries, err := ledBlocked.Tries()
+		require.NoError(t, err)
+		require.Len(t, tries, size)
+		<-ledBlocked.Done()
+		<-diskWalBlocked.Done()
+
+		diskWal2, err := wal.NewDiskWAL(zerolog.Nop(), nil, metricsCollector, dir, size, pathfinder.PathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		led2, err := complete.NewLedger(diskWal2, size, metricsCollector, logger, complete.DefaultPathFinderVersion, complete.WithEarlyReads())
+		require.NoError(t, err)
+
+		compactor2, err := complete.NewCompactor(led2, diskWal2, zerolog.Nop(), size, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		<-compactor2.Ready()
+
+		for i, update := range updates {
+			require.True(t, led2.HasState(states[i]))
+
+			for j, key := range update.Keys() {
+				query, err := ledger.NewQuerySingleValue(states[i], key)
+				require.NoError(t, err)
+
+				value, err := led2.GetSingleValue(query)
+				require.NoError(t, err)
+				require.True(t, update.Values()[j].Equals(value))
+			}
+
+			query, err := ledger.NewQuery(states[i], update.Keys())
+			require.NoError(t, err)
+
+			values, err := led2.Get(query)
+			require.NoError(t, err)
+			for j, value := range values {
+				require.True(t, update.Values()[j].Equals(value))
+			}
+		}
+
+		tries, err = led2.Tries()
+		require.NoError(t, err)
+		require.Len(t, tries, size)
+
+		<-led2.Done()
+		<-compactor2.Done()
+
+		// shutting down the ledger stops replaying the segments
+		diskWal3, err := wal.NewDiskWAL(zerolog.Nop(), nil, metricsCollector, dir, size, pathfinder.PathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		led3, err := complete.NewLedger(diskWal3, size, metricsCollector, logger, complete.DefaultPathFinderVersion, complete.WithEarlyReads())
+		require.NoError(t, err)
+		<-led3.Done()
+
+		// the replay either completed or stopped, and reads don't wait for it
+		if !led3.HasState(states[size-1]) {
+			_, err = led3.MostRecentTouchedState()
+			require.Error(t, err)
+		}
+		<-diskWal3.Done()
+	})
+}
+
+// blockingReplayWAL is a WAL whose replay of segment updates blocks until release is closed.
+type blockingReplayWAL struct {
+	wal.LedgerWAL
+	release chan struct{}
+}
+
+func (w *blockingReplayWAL) Replay(
+	checkpointFn func(tries []*trie.MTrie) error,
+	updateFn func(update *ledger.TrieUpdate) error,
+	deleteFn func(ledger.RootHash) error,
+) error {
+	return w.LedgerWAL.Replay(
+		checkpointFn,
+		func(update *ledger.TrieUpdate) error {
+			<-w.release
+			return updateFn(update)
+		},
+		deleteFn,
+	)
+}
+
 func TestLedgerFunctionality(t *testing.T) {
 	const (
 		checkpointDistance = math.MaxInt // A large number to prevent checkpoint creation.
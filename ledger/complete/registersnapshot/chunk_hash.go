@@ -0,0 +1,184 @@
+package registersnapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+)
+
+// encSubtrieSize is the size of an encoded subtrie: its path, height and hash.
+const encSubtrieSize = ledger.PathLen + 2 + hash.HashLen
+
+// ChunkHash is the part of the root hash of a register snapshot computed from the payloads of a single
+// chunk: the roots of the subtries of the trie of the snapshot which hold only payloads of the chunk,
+// and can't be completed without the payloads of the other chunks.
+//
+// Chunk hashes are computed independently with HashChunk, so chunks can be verified in parallel, and
+// are combined into the root hash of the snapshot with VerifyChunkHashes.
+type ChunkHash struct {
+	subtries []subtrie
+}
+
+// HashChunk computes the chunk hash of the payloads of the chunk at the given index of the manifest.
+// The neighboring chunks are only used through the paths of their first and last payloads listed in
+// the manifest, so their payloads don't need to be read.
+//
+// Expected errors during normal operation:
+//   - if the payloads are not sorted by path, or don't match the paths of the manifest
+func HashChunk(manifest *Manifest, index int, payloads []*ledger.Payload) (*ChunkHash, error) {
+	chunk := manifest.Chunks[index]
+	firstPath, lastPath, err := chunkPaths(chunk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chunk %d: %w", index, err)
+	}
+
+	firstPrefixLen := -1
+	if index > 0 {
+		_, prevLastPath, err := chunkPaths(manifest.Chunks[index-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk %d: %w", index-1, err)
+		}
+		if bytes.Compare(prevLastPath[:], firstPath[:]) >= 0 {
+			return nil, fmt.Errorf("chunks %d and %d are not sorted by path", index-1, index)
+		}
+		firstPrefixLen = commonPrefixLen(prevLastPath, firstPath)
+	}
+
+	lastPrefixLen := -1
+	if index < len(manifest.Chunks)-1 {
+		nextFirstPath, _, err := chunkPaths(manifest.Chunks[index+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk %d: %w", index+1, err)
+		}
+		if bytes.Compare(lastPath[:], nextFirstPath[:]) >= 0 {
+			return nil, fmt.Errorf("chunks %d and %d are not sorted by path", index, index+1)
+		}
+		lastPrefixLen = commonPrefixLen(lastPath, nextFirstPath)
+	}
+
+	if len(payloads) == 0 {
+		return nil, fmt.Errorf("chunk %d holds no payloads", index)
+	}
+
+	hasher := newRangeHasher(firstPrefixLen)
+	for i, payload := range payloads {
+		key, err := payload.Key()
+		if err != nil {
+			return nil, fmt.Errorf("could not get key of payload %d of chunk %d: %w", i, index, err)
+		}
+		path, err := pathfinder.KeyToPath(key, pathFinderVersion)
+		if err != nil {
+			return nil, fmt.Errorf("could not get path of payload %d of chunk %d: %w", i, index, err)
+		}
+
+		if i == 0 && path != firstPath || i == len(payloads)-1 && path != lastPath {
+			return nil, fmt.Errorf("path of payload %d of chunk %d does not match the manifest", i, index)
+		}
+
+		err = hasher.add(path, payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload %d of chunk %d: %w", i, index, err)
+		}
+	}
+
+	return &ChunkHash{subtries: hasher.subtries(lastPrefixLen)}, nil
+}
+
+// VerifyChunkHashes verifies that the chunk hashes of all chunks of the manifest, in order, combine into
+// the given root hash, which must be the state commitment of the snapshot.
+func VerifyChunkHashes(manifest *Manifest, chunkHashes []*ChunkHash, rootHash ledger.RootHash) error {
+	commitment, err := manifest.RootHash()
+	if err != nil {
+		return err
+	}
+	if commitment != rootHash {
+		return fmt.Errorf("state commitment of snapshot %v does not match expected root hash %v", commitment, rootHash)
+	}
+	if len(chunkHashes) != len(manifest.Chunks) {
+		return fmt.Errorf("got %d chunk hashes for %d chunks", len(chunkHashes), len(manifest.Chunks))
+	}
+
+	var subtries []subtrie
+	for _, chunkHash := range chunkHashes {
+		subtries = append(subtries, chunkHash.subtries...)
+	}
+
+	hasher := newRootHasher()
+	for i, s := range subtries {
+		nextPrefixLen := -1
+		if i < len(subtries)-1 {
+			next := subtries[i+1]
+			if bytes.Compare(s.path[:], next.path[:]) >= 0 {
+				return fmt.Errorf("chunk hashes are not sorted by path")
+			}
+			nextPrefixLen = commonPrefixLen(s.path, next.path)
+		}
+		hasher.push(s, nextPrefixLen)
+	}
+
+	computed := hasher.rootHash()
+	if computed != rootHash {
+		return fmt.Errorf("root hash of snapshot payloads %v does not match state commitment %v", computed, rootHash)
+	}
+	return nil
+}
+
+// Encode returns the encoded chunk hash, consisting of the path (32 bytes), height (2 bytes) and hash
+// (32 bytes) of each of its subtries.
+func (c *ChunkHash) Encode() []byte {
+	buf := make([]byte, 0, len(c.subtries)*encSubtrieSize)
+	for _, s := range c.subtries {
+		buf = append(buf, s.path[:]...)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(s.height))
+		buf = append(buf, s.hash[:]...)
+	}
+	return buf
+}
+
+// DecodeChunkHash decodes a chunk hash encoded with ChunkHash.Encode.
+func DecodeChunkHash(data []byte) (*ChunkHash, error) {
+	if len(data) == 0 || len(data)%encSubtrieSize != 0 {
+		return nil, fmt.Errorf("invalid chunk hash length %d", len(data))
+	}
+
+	subtries := make([]subtrie, 0, len(data)/encSubtrieSize)
+	for ; len(data) > 0; data = data[encSubtrieSize:] {
+		var s subtrie
+		copy(s.path[:], data[:ledger.PathLen])
+		s.height = int(binary.BigEndian.Uint16(data[ledger.PathLen:]))
+		copy(s.hash[:], data[ledger.PathLen+2:encSubtrieSize])
+		if s.height > ledger.NodeMaxHeight {
+			return nil, fmt.Errorf("invalid subtrie height %d", s.height)
+		}
+		subtries = append(subtries, s)
+	}
+	return &ChunkHash{subtries: subtries}, nil
+}
+
+// chunkPaths returns the decoded paths of the first and last payloads of the chunk.
+func chunkPaths(chunk ChunkInfo) (ledger.Path, ledger.Path, error) {
+	first, err := hex.DecodeString(chunk.FirstPath)
+	if err != nil {
+		return ledger.Path{}, ledger.Path{}, fmt.Errorf("could not decode first path: %w", err)
+	}
+	firstPath, err := ledger.ToPath(first)
+	if err != nil {
+		return ledger.Path{}, ledger.Path{}, fmt.Errorf("invalid first path: %w", err)
+	}
+
+	last, err := hex.DecodeString(chunk.LastPath)
+	if err != nil {
+		return ledger.Path{}, ledger.Path{}, fmt.Errorf("could not decode last path: %w", err)
+	}
+	lastPath, err := ledger.ToPath(last)
+	if err != nil {
+		return ledger.Path{}, ledger.Path{}, fmt.Errorf("invalid last path: %w", err)
+	}
+
+	return firstPath, lastPath, nil
+}
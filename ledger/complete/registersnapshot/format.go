@@ -0,0 +1,206 @@
+// Package registersnapshot implements the register snapshot format, a standalone format for the
+// registers of a single ledger state, which doesn't require the mtrie to be read.
+//
+// A register snapshot is a directory holding a manifest file and chunk files. Each chunk file
+// holds a range of the payloads of the state, sorted by their ledger paths. The manifest lists the
+// chunks in order, along with the hash of each chunk file and the state commitment of the snapshot.
+// Since the payloads are sorted by path, the root hash of the trie holding them is computed while
+// streaming through the chunks, which verifies the snapshot against the state commitment.
+package registersnapshot
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/crypto/hash"
+
+	"github.com/onflow/flow-go/ledger"
+)
+
+const (
+	// Version is the version of the register snapshot format.
+	Version uint16 = 1
+
+	// ManifestFileName is the name of the manifest file in a register snapshot directory.
+	ManifestFileName = "manifest.json"
+
+	// DefaultChunkSize is the default number of payloads in a chunk.
+	DefaultChunkSize = 100_000
+
+	// pathFinderVersion is the version of the path finder used to compute the ledger paths of the
+	// payload keys, which is complete.DefaultPathFinderVersion.
+	pathFinderVersion = 1
+
+	chunkMagicBytes uint16 = 0x5253 // "RS"
+
+	encMagicBytesSize   = 2
+	encVersionSize      = 2
+	encPayloadCountSize = 8
+	encPayloadLenSize   = 4
+	chunkHeaderSize     = encMagicBytesSize + encVersionSize + encPayloadCountSize
+)
+
+// Manifest describes a register snapshot.
+type Manifest struct {
+	// Version is the version of the register snapshot format.
+	Version uint16 `json:"version"`
+	// StateCommitment is the hex encoded root hash of the trie holding the payloads of the snapshot.
+	StateCommitment string `json:"state_commitment"`
+	// RegisterCount is the total number of payloads in the snapshot.
+	RegisterCount uint64 `json:"register_count"`
+	// Chunks are the chunks of the snapshot, in the order of their payload paths.
+	Chunks []ChunkInfo `json:"chunks"`
+}
+
+// ChunkInfo describes a chunk of a register snapshot.
+type ChunkInfo struct {
+	// File is the name of the chunk file in the snapshot directory.
+	File string `json:"file"`
+	// RegisterCount is the number of payloads in the chunk.
+	RegisterCount uint64 `json:"register_count"`
+	// FirstPath and LastPath are the hex encoded paths of the first and last payloads of the chunk.
+	FirstPath string `json:"first_path"`
+	LastPath  string `json:"last_path"`
+	// Hash is the hex encoded SHA3-256 hash of the chunk file.
+	Hash string `json:"hash"`
+}
+
+// RootHash returns the state commitment of the snapshot.
+func (m *Manifest) RootHash() (ledger.RootHash, error) {
+	decoded, err := hex.DecodeString(m.StateCommitment)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not decode state commitment: %w", err)
+	}
+	return ledger.ToRootHash(decoded)
+}
+
+// ChunkFileName returns the name of the file of the chunk at the given index.
+func ChunkFileName(index int) string {
+	return fmt.Sprintf("chunk-%06d.registers", index)
+}
+
+// ReadManifest reads the manifest of the register snapshot in the given directory.
+// A snapshot without manifest is incomplete, as the manifest is written last.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+
+	if manifest.Version != Version {
+		return nil, fmt.Errorf("unsupported register snapshot version %d, expected %d", manifest.Version, Version)
+	}
+
+	var count uint64
+	for _, chunk := range manifest.Chunks {
+		count += chunk.RegisterCount
+	}
+	if count != manifest.RegisterCount {
+		return nil, fmt.Errorf("inconsistent manifest: chunks hold %d registers, expected %d", count, manifest.RegisterCount)
+	}
+
+	return &manifest, nil
+}
+
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+
+	// write to a temporary file first, so an existing manifest always describes a complete snapshot
+	tmpPath := filepath.Join(dir, ManifestFileName+".tmp")
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+	return os.Rename(tmpPath, filepath.Join(dir, ManifestFileName))
+}
+
+// encodeChunk encodes the given payloads into a chunk, consisting of:
+// - magic bytes (2 bytes)
+// - version (2 bytes)
+// - payload count (8 bytes)
+// - for each payload, its encoded length (4 bytes) followed by the encoded payload
+func encodeChunk(payloads []*ledger.Payload) []byte {
+	size := chunkHeaderSize
+	for _, payload := range payloads {
+		size += encPayloadLenSize + ledger.EncodedPayloadLengthWithoutPrefix(payload, ledger.PayloadVersion)
+	}
+
+	buf := make([]byte, 0, size)
+	buf = binary.BigEndian.AppendUint16(buf, chunkMagicBytes)
+	buf = binary.BigEndian.AppendUint16(buf, Version)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(payloads)))
+	for _, payload := range payloads {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(ledger.EncodedPayloadLengthWithoutPrefix(payload, ledger.PayloadVersion)))
+		buf = ledger.EncodeAndAppendPayloadWithoutPrefix(buf, payload, ledger.PayloadVersion)
+	}
+	return buf
+}
+
+// decodeChunk decodes the payloads of an encoded chunk.
+func decodeChunk(data []byte) ([]*ledger.Payload, error) {
+	if len(data) < chunkHeaderSize {
+		return nil, fmt.Errorf("chunk too short: %d bytes", len(data))
+	}
+
+	magicBytes := binary.BigEndian.Uint16(data)
+	if magicBytes != chunkMagicBytes {
+		return nil, fmt.Errorf("wrong magic bytes %x, expected %x", magicBytes, chunkMagicBytes)
+	}
+	version := binary.BigEndian.Uint16(data[encMagicBytesSize:])
+	if version != Version {
+		return nil, fmt.Errorf("unsupported chunk version %d, expected %d", version, Version)
+	}
+	count := binary.BigEndian.Uint64(data[encMagicBytesSize+encVersionSize:])
+	data = data[chunkHeaderSize:]
+
+	// each payload takes at least the size of its length
+	if count > uint64(len(data)/encPayloadLenSize) {
+		return nil, fmt.Errorf("chunk too short for %d payloads", count)
+	}
+
+	payloads := make([]*ledger.Payload, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if len(data) < encPayloadLenSize {
+			return nil, fmt.Errorf("chunk too short for payload %d", i)
+		}
+		size := binary.BigEndian.Uint32(data)
+		data = data[encPayloadLenSize:]
+		if uint64(len(data)) < uint64(size) {
+			return nil, fmt.Errorf("chunk too short for payload %d of %d bytes", i, size)
+		}
+
+		payload, err := ledger.DecodePayloadWithoutPrefix(data[:size], false, ledger.PayloadVersion)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode payload %d: %w", i, err)
+		}
+		if payload == nil {
+			return nil, fmt.Errorf("empty payload %d", i)
+		}
+		payloads = append(payloads, payload)
+		data = data[size:]
+	}
+
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after %d payloads", len(data), count)
+	}
+
+	return payloads, nil
+}
+
+// chunkHash returns the hex encoded SHA3-256 hash of an encoded chunk.
+func chunkHash(data []byte) string {
+	return hex.EncodeToString(hash.NewSHA3_256().ComputeHash(data))
+}
@@ -0,0 +1,54 @@
+package registersnapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-go/ledger"
+)
+
+// ReadChunk reads the payloads of the given chunk of the register snapshot in the given directory,
+// once the chunk file is verified to match the hash and register count of the chunk.
+func ReadChunk(dir string, chunk ChunkInfo) ([]*ledger.Payload, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.Base(chunk.File)))
+	if err != nil {
+		return nil, fmt.Errorf("could not read chunk file %v: %w", chunk.File, err)
+	}
+
+	if h := chunkHash(data); h != chunk.Hash {
+		return nil, fmt.Errorf("hash of chunk file %v is %v, expected %v", chunk.File, h, chunk.Hash)
+	}
+
+	payloads, err := decodeChunk(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode chunk file %v: %w", chunk.File, err)
+	}
+
+	if uint64(len(payloads)) != chunk.RegisterCount {
+		return nil, fmt.Errorf("chunk file %v holds %d registers, expected %d", chunk.File, len(payloads), chunk.RegisterCount)
+	}
+
+	return payloads, nil
+}
+
+// Verify verifies that the payloads of the register snapshot in the given directory are held by the
+// trie with the given root hash, which must be the state commitment of the snapshot.
+// All chunks are read, so verification takes about as long as reading the whole snapshot. Importers
+// reading the chunks anyway should hash them using HashChunk instead.
+func Verify(dir string, manifest *Manifest, rootHash ledger.RootHash) error {
+	chunkHashes := make([]*ChunkHash, len(manifest.Chunks))
+	for i, chunk := range manifest.Chunks {
+		payloads, err := ReadChunk(dir, chunk)
+		if err != nil {
+			return fmt.Errorf("could not read chunk %d: %w", i, err)
+		}
+
+		chunkHashes[i], err = HashChunk(manifest, i, payloads)
+		if err != nil {
+			return err
+		}
+	}
+
+	return VerifyChunkHashes(manifest, chunkHashes, rootHash)
+}
@@ -0,0 +1,208 @@
+package registersnapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// randomTrie returns a trie holding n random payloads at the paths of their keys.
+func randomTrie(t *testing.T, n int) *trie.MTrie {
+	keys := testutils.RandomUniqueKeys(n, 2, 1, 10)
+	values := testutils.RandomValues(n, 1, 32)
+
+	paths := make([]ledger.Path, n)
+	payloads := make([]ledger.Payload, n)
+	for i, key := range keys {
+		path, err := pathfinder.KeyToPath(key, pathFinderVersion)
+		require.NoError(t, err)
+		paths[i] = path
+		payloads[i] = *ledger.NewPayload(key, values[i])
+	}
+
+	updated, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return updated
+}
+
+// TestRootHasher tests that the root hash computed from sorted payloads matches the root hash of the mtrie.
+func TestRootHasher(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		tr := randomTrie(t, n)
+		hasher := newRootHasher()
+		paths, payloads := sortedLeaves(tr)
+		for i := range paths {
+			require.NoError(t, hasher.add(paths[i], payloads[i]))
+		}
+		require.Equal(t, tr.RootHash(), hasher.rootHash(), "root hash of trie with %d payloads", n)
+	}
+
+	t.Run("unsorted payloads", func(t *testing.T) {
+		paths, payloads := sortedLeaves(randomTrie(t, 2))
+		hasher := newRootHasher()
+		require.NoError(t, hasher.add(paths[1], payloads[1]))
+		require.Error(t, hasher.add(paths[0], payloads[0]))
+		require.Error(t, hasher.add(paths[1], payloads[1]))
+	})
+}
+
+func TestExportAndVerify(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tr := randomTrie(t, 1000)
+		snapshotDir := filepath.Join(dir, "snapshot")
+
+		manifest, err := ExportTrie(tr, snapshotDir, 64)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1000), manifest.RegisterCount)
+		require.Len(t, manifest.Chunks, 16)
+
+		read, err := ReadManifest(snapshotDir)
+		require.NoError(t, err)
+		require.Equal(t, manifest, read)
+
+		require.NoError(t, Verify(snapshotDir, read, tr.RootHash()))
+
+		// the chunks hold the payloads of the trie, in order
+		_, expected := sortedLeaves(tr)
+		var payloads []*ledger.Payload
+		for _, chunk := range read.Chunks {
+			chunkPayloads, err := ReadChunk(snapshotDir, chunk)
+			require.NoError(t, err)
+			payloads = append(payloads, chunkPayloads...)
+		}
+		require.Equal(t, len(expected), len(payloads))
+		for i := range expected {
+			require.True(t, expected[i].Equals(payloads[i]))
+		}
+
+		// the snapshot is verified against the expected root hash
+		require.Error(t, Verify(snapshotDir, read, randomTrie(t, 10).RootHash()))
+
+		// the directory must be empty
+		_, err = ExportTrie(tr, snapshotDir, 64)
+		require.Error(t, err)
+	})
+}
+
+// TestChunkHashes tests that chunk hashes computed independently, in any order, combine into the root hash.
+func TestChunkHashes(t *testing.T) {
+	tr := randomTrie(t, 300)
+	for _, chunkSize := range []int{1, 3, 7, 64, 1000} {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest, err := ExportTrie(tr, dir, chunkSize)
+			require.NoError(t, err)
+
+			chunkHashes := make([]*ChunkHash, len(manifest.Chunks))
+			for i := len(manifest.Chunks) - 1; i >= 0; i-- {
+				payloads, err := ReadChunk(dir, manifest.Chunks[i])
+				require.NoError(t, err)
+				chunkHash, err := HashChunk(manifest, i, payloads)
+				require.NoError(t, err)
+
+				// chunk hashes are persisted by importers
+				chunkHashes[i], err = DecodeChunkHash(chunkHash.Encode())
+				require.NoError(t, err)
+				require.Equal(t, chunkHash, chunkHashes[i])
+			}
+			require.NoError(t, VerifyChunkHashes(manifest, chunkHashes, tr.RootHash()), "chunk size %d", chunkSize)
+
+			if len(chunkHashes) > 1 {
+				chunkHashes[0], chunkHashes[1] = chunkHashes[1], chunkHashes[0]
+				require.Error(t, VerifyChunkHashes(manifest, chunkHashes, tr.RootHash()))
+				require.Error(t, VerifyChunkHashes(manifest, chunkHashes[1:], tr.RootHash()))
+			}
+		})
+	}
+
+	_, err := DecodeChunkHash(make([]byte, encSubtrieSize+1))
+	require.Error(t, err)
+}
+
+func TestExportEmptyTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tr := trie.NewEmptyMTrie()
+		manifest, err := ExportTrie(tr, dir, DefaultChunkSize)
+		require.NoError(t, err)
+		require.Empty(t, manifest.Chunks)
+
+		read, err := ReadManifest(dir)
+		require.NoError(t, err)
+		require.NoError(t, Verify(dir, read, tr.RootHash()))
+	})
+}
+
+// TestVerifyTamperedSnapshot tests that modified chunks and manifests fail the verification.
+func TestVerifyTamperedSnapshot(t *testing.T) {
+	tr := randomTrie(t, 100)
+
+	export := func(t *testing.T, dir string) *Manifest {
+		manifest, err := ExportTrie(tr, dir, 10)
+		require.NoError(t, err)
+		return manifest
+	}
+
+	t.Run("modified chunk", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest := export(t, dir)
+			chunkPath := filepath.Join(dir, manifest.Chunks[3].File)
+			data, err := os.ReadFile(chunkPath)
+			require.NoError(t, err)
+			data[len(data)-1] ^= 0xff
+			require.NoError(t, os.WriteFile(chunkPath, data, 0644))
+
+			_, err = ReadChunk(dir, manifest.Chunks[3])
+			require.Error(t, err)
+			require.Error(t, Verify(dir, manifest, tr.RootHash()))
+		})
+	})
+
+	t.Run("missing chunk", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest := export(t, dir)
+			manifest.RegisterCount -= manifest.Chunks[3].RegisterCount
+			manifest.Chunks = append(manifest.Chunks[:3], manifest.Chunks[4:]...)
+			require.Error(t, Verify(dir, manifest, tr.RootHash()))
+		})
+	})
+
+	t.Run("reordered chunks", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest := export(t, dir)
+			manifest.Chunks[3], manifest.Chunks[4] = manifest.Chunks[4], manifest.Chunks[3]
+			require.Error(t, Verify(dir, manifest, tr.RootHash()))
+		})
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest := export(t, dir)
+			manifest.Version = Version + 1
+			require.NoError(t, writeManifest(dir, manifest))
+			_, err := ReadManifest(dir)
+			require.Error(t, err)
+		})
+	})
+}
+
+// sortedLeaves returns the paths and payloads of the leaves of the given trie, sorted by path.
+func sortedLeaves(tr *trie.MTrie) ([]ledger.Path, []*ledger.Payload) {
+	var paths []ledger.Path
+	var payloads []*ledger.Payload
+	for itr := flattener.NewNodeIterator(tr.RootNode()); itr.Next(); {
+		n := itr.Value()
+		if n.IsLeaf() {
+			paths = append(paths, *n.Path())
+			payloads = append(payloads, n.Payload())
+		}
+	}
+	return paths, payloads
+}
@@ -0,0 +1,154 @@
+package registersnapshot
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+)
+
+// subtrie is the root of a completed subtrie, holding the payload at path.
+type subtrie struct {
+	path   ledger.Path
+	height int
+	hash   hash.Hash
+}
+
+// rootHasher computes the root hash of the trie holding payloads added in the order of their paths,
+// without building the trie.
+//
+// A payload is held by a compact leaf right below the deepest node its path shares with the paths of
+// the previous and next payloads, so the leaf of a payload is created once the next payload is added.
+// Completed subtries are kept on a stack, and merged once no later payload can be held by them.
+// Since the hash of a compact leaf is the hash of the fully expanded leaf, the root hash matches the
+// root hash of the mtrie holding the same payloads.
+//
+// A hasher may also process a range of the payloads of a trie, given the paths of the payloads
+// before and after the range, in which case only the subtries holding payloads of the range are
+// completed. The subtries of consecutive ranges are combined by pushing them into another hasher.
+type rootHasher struct {
+	stack []subtrie
+	// pending is the last added payload, whose leaf isn't created yet
+	pending     *ledger.Payload
+	pendingPath ledger.Path
+	// prevPrefixLen is the length of the common prefix of the paths of the pending and previous payloads,
+	// or -1 if there is no previous payload
+	prevPrefixLen int
+	// firstPrefixLen is the length of the common prefix of the path of the first payload and the path
+	// of the payload before the range processed by the hasher, or -1 if there is none. Subtries are only
+	// merged into nodes deeper than this prefix, since shallower nodes also hold payloads before the range.
+	firstPrefixLen int
+}
+
+func newRootHasher() *rootHasher {
+	return newRangeHasher(-1)
+}
+
+// newRangeHasher returns a hasher for a range of payloads, given the length of the common prefix of
+// the path of the first payload of the range and the path of the payload before it, or -1 if there is
+// no payload before the range.
+func newRangeHasher(firstPrefixLen int) *rootHasher {
+	return &rootHasher{
+		prevPrefixLen:  firstPrefixLen,
+		firstPrefixLen: firstPrefixLen,
+	}
+}
+
+// add adds a payload with a path greater than the paths of all previously added payloads.
+func (h *rootHasher) add(path ledger.Path, payload *ledger.Payload) error {
+	if h.pending != nil {
+		if bytes.Compare(path[:], h.pendingPath[:]) <= 0 {
+			return fmt.Errorf("payloads are not sorted by path: %x is not greater than %x", path, h.pendingPath)
+		}
+		h.complete(commonPrefixLen(h.pendingPath, path))
+	}
+	h.pending = payload
+	h.pendingPath = path
+	return nil
+}
+
+// rootHash returns the root hash of the trie holding all added payloads.
+func (h *rootHasher) rootHash() ledger.RootHash {
+	if h.pending != nil {
+		h.complete(-1)
+	}
+	if len(h.stack) == 0 {
+		return ledger.RootHash(ledger.GetDefaultHashForHeight(ledger.NodeMaxHeight))
+	}
+	return ledger.RootHash(lift(h.stack[0], ledger.NodeMaxHeight))
+}
+
+// subtries returns the roots of the completed subtries holding the added payloads, given the length of
+// the common prefix of the path of the last payload and the path of the payload after the range
+// processed by the hasher, or -1 if there is none.
+func (h *rootHasher) subtries(nextPrefixLen int) []subtrie {
+	if h.pending != nil {
+		h.complete(nextPrefixLen)
+	}
+	return h.stack
+}
+
+// complete creates the leaf of the pending payload, given the length of the common prefix of its path
+// and the path of the next payload, or -1 if there is no next payload.
+func (h *rootHasher) complete(nextPrefixLen int) {
+	height := ledger.NodeMaxHeight - max(h.prevPrefixLen, nextPrefixLen) - 1
+	leaf := subtrie{
+		path:   h.pendingPath,
+		height: height,
+		hash:   ledger.ComputeCompactValue(hash.Hash(h.pendingPath), h.pending.Value(), height),
+	}
+	h.pending = nil
+	h.prevPrefixLen = nextPrefixLen
+
+	h.push(leaf, nextPrefixLen)
+}
+
+// push adds a completed subtrie holding payloads with paths greater than the paths of all previous
+// subtries, given the length of the common prefix of its path and the path of the next payload, or -1
+// if there is no next payload.
+func (h *rootHasher) push(s subtrie, nextPrefixLen int) {
+	h.stack = append(h.stack, s)
+
+	// merge the subtries which the next payloads don't share a node with
+	for len(h.stack) >= 2 {
+		left, right := h.stack[len(h.stack)-2], h.stack[len(h.stack)-1]
+		prefixLen := commonPrefixLen(left.path, right.path)
+		if prefixLen <= nextPrefixLen || prefixLen <= h.firstPrefixLen {
+			break
+		}
+
+		parentHeight := ledger.NodeMaxHeight - prefixLen
+		h.stack = h.stack[:len(h.stack)-2]
+		h.stack = append(h.stack, subtrie{
+			path:   left.path,
+			height: parentHeight,
+			hash:   hash.HashInterNode(lift(left, parentHeight-1), lift(right, parentHeight-1)),
+		})
+	}
+}
+
+// lift returns the hash of the node at the given height holding only the given subtrie.
+func lift(s subtrie, height int) hash.Hash {
+	out := s.hash
+	for h := s.height + 1; h <= height; h++ {
+		if bitutils.ReadBit(s.path[:], ledger.NodeMaxHeight-h) == 1 {
+			out = hash.HashInterNode(ledger.GetDefaultHashForHeight(h-1), out)
+		} else {
+			out = hash.HashInterNode(out, ledger.GetDefaultHashForHeight(h-1))
+		}
+	}
+	return out
+}
+
+// commonPrefixLen returns the number of leading bits shared by the given different paths.
+func commonPrefixLen(a ledger.Path, b ledger.Path) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return i*8 + bits.LeadingZeros8(a[i]^b[i])
+		}
+	}
+	return ledger.NodeMaxHeight
+}
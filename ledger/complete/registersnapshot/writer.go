@@ -0,0 +1,143 @@
+package registersnapshot
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// Writer writes a register snapshot from payloads written in the order of their paths.
+// Writer is not safe for concurrent use.
+type Writer struct {
+	dir        string
+	commitment ledger.RootHash
+	chunkSize  int
+	manifest   *Manifest
+	hasher     *rootHasher
+
+	// payloads and paths of the chunk being written
+	payloads []*ledger.Payload
+	paths    []ledger.Path
+}
+
+// NewWriter returns a writer of a register snapshot with the given state commitment into the given
+// directory, which is created if it doesn't exist, and must be empty otherwise.
+func NewWriter(dir string, commitment ledger.RootHash, chunkSize int) (*Writer, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create snapshot directory %v: %w", dir, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot directory %v: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return nil, fmt.Errorf("snapshot directory %v is not empty", dir)
+	}
+
+	return &Writer{
+		dir:        dir,
+		commitment: commitment,
+		chunkSize:  chunkSize,
+		manifest: &Manifest{
+			Version:         Version,
+			StateCommitment: commitment.String(),
+		},
+		hasher:   newRootHasher(),
+		payloads: make([]*ledger.Payload, 0, chunkSize),
+		paths:    make([]ledger.Path, 0, chunkSize),
+	}, nil
+}
+
+// Write adds a payload with a path greater than the paths of all previously written payloads.
+func (w *Writer) Write(path ledger.Path, payload *ledger.Payload) error {
+	err := w.hasher.add(path, payload)
+	if err != nil {
+		return err
+	}
+
+	w.payloads = append(w.payloads, payload)
+	w.paths = append(w.paths, path)
+	if len(w.payloads) == w.chunkSize {
+		return w.flushChunk()
+	}
+	return nil
+}
+
+// Close writes the remaining payloads and the manifest, once the root hash of the trie holding the
+// written payloads is verified to match the state commitment of the snapshot.
+func (w *Writer) Close() (*Manifest, error) {
+	if len(w.payloads) > 0 {
+		err := w.flushChunk()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rootHash := w.hasher.rootHash()
+	if rootHash != w.commitment {
+		return nil, fmt.Errorf("root hash of written payloads %v does not match state commitment %v", rootHash, w.commitment)
+	}
+
+	err := writeManifest(w.dir, w.manifest)
+	if err != nil {
+		return nil, err
+	}
+	return w.manifest, nil
+}
+
+func (w *Writer) flushChunk() error {
+	index := len(w.manifest.Chunks)
+	fileName := ChunkFileName(index)
+
+	data := encodeChunk(w.payloads)
+	err := os.WriteFile(filepath.Join(w.dir, fileName), data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write chunk %d: %w", index, err)
+	}
+
+	w.manifest.Chunks = append(w.manifest.Chunks, ChunkInfo{
+		File:          fileName,
+		RegisterCount: uint64(len(w.payloads)),
+		FirstPath:     hex.EncodeToString(w.paths[0][:]),
+		LastPath:      hex.EncodeToString(w.paths[len(w.paths)-1][:]),
+		Hash:          chunkHash(data),
+	})
+	w.manifest.RegisterCount += uint64(len(w.payloads))
+
+	w.payloads = w.payloads[:0]
+	w.paths = w.paths[:0]
+	return nil
+}
+
+// ExportTrie writes the payloads of the given trie as a register snapshot into the given directory,
+// and returns its manifest.
+func ExportTrie(t *trie.MTrie, dir string, chunkSize int) (*Manifest, error) {
+	writer, err := NewWriter(dir, t.RootHash(), chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// the descendants-first iteration visits the leaves in the order of their paths
+	for itr := flattener.NewNodeIterator(t.RootNode()); itr.Next(); {
+		n := itr.Value()
+		if !n.IsLeaf() || n.Payload() == nil {
+			continue
+		}
+		err = writer.Write(*n.Path(), n.Payload())
+		if err != nil {
+			return nil, fmt.Errorf("could not write payload: %w", err)
+		}
+	}
+
+	return writer.Close()
+}
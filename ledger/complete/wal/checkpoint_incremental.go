@@ -0,0 +1,595 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// Incremental checkpoints (version 7) only contain the trie nodes created since a base checkpoint.
+// Nodes shared with the tries of the base checkpoint are stored as references to base nodes,
+// which are resolved by loading the base checkpoint when the incremental checkpoint is loaded.
+// The base tries are identified by the root hashes stored in the base checkpoint file, so that
+// the tries of the base checkpoint don't need to be kept in memory until the next checkpoint.
+// Since the base can itself be an incremental checkpoint, loading an incremental checkpoint
+// loads the chain of checkpoints back to the last full checkpoint.
+//
+// An incremental checkpoint is stored in a single file containing:
+//   - header: magic (2 bytes) + version (2 bytes) + base checkpoint number (8 bytes)
+//   - reference count (8 bytes) + references to base nodes
+//   - node count (8 bytes) + new nodes in descendants-first order (see EncodeNode())
+//   - tries (see EncodeTrie())
+//   - footer: trie count (2 bytes)
+//   - CRC32 sum of the file (4 bytes)
+//
+// A reference contains the index of the base trie containing the node (2 bytes), the height of
+// the node (2 bytes), the path from the trie root to the node (32 bytes, only the bits above
+// the node are set), and the hash of the node (32 bytes) to detect a mismatching base.
+// Node index 0 means nil, references are indexed from 1, followed by the new nodes.
+
+const (
+	encBaseCheckpointSize   = 8
+	encNodeReferenceSize    = 2 + 2 + ledger.PathLen + hash.HashLen
+	incrementalHeaderSize   = headerSize + encBaseCheckpointSize
+	incrementalFooterSize   = encTrieCountSize
+	incrementalFooterOffset = incrementalFooterSize + crc32SumSize
+)
+
+// nodeReference identifies a node of a base trie by its position in the trie.
+type nodeReference struct {
+	trieIndex uint16
+	height    uint16
+	path      ledger.Path
+	hash      hash.Hash
+}
+
+// baseNode is a node of a base trie, at the position of a node of a new trie.
+type baseNode struct {
+	node      *node.Node
+	trieIndex uint16
+}
+
+// incrementalEncoder collects the nodes of the tries of an incremental checkpoint, and
+// replaces the nodes shared with the base tries by references.
+//
+// New tries are compared with all base tries at once, since a new trie can be created by
+// updating any base trie, e.g. when blocks are executed on different forks. Nodes are compared
+// position by position, so that the position of shared nodes in the base trie is known.
+// Base tries mostly share their nodes, so the number of distinct base nodes at a position
+// quickly decreases with the depth of the position.
+type incrementalEncoder struct {
+	baseRoots  []baseNode
+	references []nodeReference
+	nodes      []*node.Node
+	// references and new nodes are indexed separately, since new node indices follow
+	// the references, which are only all known once all tries are added.
+	referenceIndices map[*node.Node]uint64
+	nodeIndices      map[*node.Node]uint64
+}
+
+// newIncrementalEncoder creates an encoder for the given tries, based on the tries with the given
+// root hashes of the base checkpoint. Only the base tries which are among the given tries are
+// compared with, so nodes only shared with base tries evicted since the base checkpoint are
+// stored as new nodes.
+func newIncrementalEncoder(tries []*trie.MTrie, baseRootHashes []ledger.RootHash) *incrementalEncoder {
+	baseIndices := make(map[ledger.RootHash]uint16, len(baseRootHashes))
+	for i, rootHash := range baseRootHashes {
+		if _, ok := baseIndices[rootHash]; !ok {
+			baseIndices[rootHash] = uint16(i)
+		}
+	}
+	roots := make([]baseNode, 0, len(baseRootHashes))
+	for _, t := range tries {
+		if trieIndex, ok := baseIndices[t.RootHash()]; ok {
+			roots = append(roots, baseNode{node: t.RootNode(), trieIndex: trieIndex})
+		}
+	}
+	return &incrementalEncoder{
+		baseRoots:        distinctBaseNodes(roots),
+		referenceIndices: make(map[*node.Node]uint64),
+		nodeIndices:      make(map[*node.Node]uint64),
+	}
+}
+
+// addTrie adds the nodes of the trie which aren't already added.
+func (e *incrementalEncoder) addTrie(t *trie.MTrie) {
+	e.addNode(t.RootNode(), e.baseRoots, ledger.Path{}, 0)
+}
+
+// addNode adds the node n and its descendants, where base holds the distinct nodes at the same
+// position in the base tries, path is the path of the node and depth is the number of edges from the root.
+func (e *incrementalEncoder) addNode(n *node.Node, base []baseNode, path ledger.Path, depth int) {
+	if n == nil {
+		return
+	}
+	if _, ok := e.referenceIndices[n]; ok {
+		return
+	}
+	if _, ok := e.nodeIndices[n]; ok {
+		return
+	}
+	for _, b := range base {
+		if n == b.node {
+			e.addReference(n, b.trieIndex, path)
+			return
+		}
+	}
+
+	if !n.IsLeaf() {
+		// children of interim nodes are always one level below their parent, so the
+		// children of the base nodes are at the same position as the children of n.
+		var baseLeft, baseRight []baseNode
+		for _, b := range base {
+			if !b.node.IsLeaf() {
+				baseLeft = append(baseLeft, baseNode{node: b.node.LeftChild(), trieIndex: b.trieIndex})
+				baseRight = append(baseRight, baseNode{node: b.node.RightChild(), trieIndex: b.trieIndex})
+			}
+		}
+		e.addNode(n.LeftChild(), distinctBaseNodes(baseLeft), path, depth+1)
+		rightPath := path
+		bitutils.SetBit(rightPath[:], depth)
+		e.addNode(n.RightChild(), distinctBaseNodes(baseRight), rightPath, depth+1)
+	}
+
+	e.nodes = append(e.nodes, n)
+	e.nodeIndices[n] = uint64(len(e.nodes))
+}
+
+// distinctBaseNodes removes nil and duplicate nodes from the given base nodes, in place.
+func distinctBaseNodes(base []baseNode) []baseNode {
+	distinct := base[:0]
+	// most positions only have a few distinct base nodes, which are cheaper to compare than to hash
+	var seen map[*node.Node]struct{}
+	if len(base) > 16 {
+		seen = make(map[*node.Node]struct{}, len(base))
+	}
+	for _, b := range base {
+		if b.node == nil || containsBaseNode(distinct, seen, b.node) {
+			continue
+		}
+		if seen != nil {
+			seen[b.node] = struct{}{}
+		}
+		distinct = append(distinct, b)
+	}
+	return distinct
+}
+
+func containsBaseNode(distinct []baseNode, seen map[*node.Node]struct{}, n *node.Node) bool {
+	if seen != nil {
+		_, ok := seen[n]
+		return ok
+	}
+	for _, b := range distinct {
+		if b.node == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *incrementalEncoder) addReference(n *node.Node, trieIndex uint16, path ledger.Path) {
+	e.references = append(e.references, nodeReference{
+		trieIndex: trieIndex,
+		height:    uint16(n.Height()),
+		path:      path,
+		hash:      n.Hash(),
+	})
+	e.referenceIndices[n] = uint64(len(e.references))
+}
+
+// index returns the index of the added node in the checkpoint.
+func (e *incrementalEncoder) index(n *node.Node) uint64 {
+	if n == nil {
+		return 0
+	}
+	if index, ok := e.referenceIndices[n]; ok {
+		return index
+	}
+	return uint64(len(e.references)) + e.nodeIndices[n]
+}
+
+func encodeNodeReference(ref nodeReference, scratch []byte) []byte {
+	buf := scratch[:encNodeReferenceSize]
+	binary.BigEndian.PutUint16(buf, ref.trieIndex)
+	binary.BigEndian.PutUint16(buf[2:], ref.height)
+	copy(buf[4:], ref.path[:])
+	copy(buf[4+ledger.PathLen:], ref.hash[:])
+	return buf
+}
+
+func decodeNodeReference(encoded []byte) nodeReference {
+	var ref nodeReference
+	ref.trieIndex = binary.BigEndian.Uint16(encoded)
+	ref.height = binary.BigEndian.Uint16(encoded[2:])
+	copy(ref.path[:], encoded[4:])
+	copy(ref.hash[:], encoded[4+ledger.PathLen:])
+	return ref
+}
+
+// StoreIncrementalCheckpoint stores the tries as an incremental checkpoint, which only contains
+// the trie nodes not shared with the tries of the base checkpoint numbered baseCheckpoint in the
+// same directory, which is required to load the incremental checkpoint.
+// Nodes are shared with the base tries which are still among the given tries.
+func StoreIncrementalCheckpoint(
+	tries []*trie.MTrie,
+	baseCheckpoint int,
+	dir string,
+	fileName string,
+	logger zerolog.Logger,
+) (
+	errToReturn error,
+) {
+	if len(tries) > math.MaxUint16 {
+		return fmt.Errorf("too many tries for checkpoint: %d", len(tries))
+	}
+	if baseCheckpoint < 0 {
+		return fmt.Errorf("invalid base checkpoint number: %d", baseCheckpoint)
+	}
+
+	baseRootHashes, err := ReadTriesRootHash(logger, dir, NumberToFilename(baseCheckpoint))
+	if err != nil {
+		return fmt.Errorf("cannot read root hashes of base checkpoint %d: %w", baseCheckpoint, err)
+	}
+	if len(baseRootHashes) > math.MaxUint16 {
+		return fmt.Errorf("too many base tries for checkpoint: %d", len(baseRootHashes))
+	}
+
+	encoder := newIncrementalEncoder(tries, baseRootHashes)
+	for _, t := range tries {
+		encoder.addTrie(t)
+	}
+
+	lg := logger.With().Str("checkpoint_file", fileName).Int("base_checkpoint", baseCheckpoint).Logger()
+	lg.Info().
+		Int("reference_count", len(encoder.references)).
+		Int("node_count", len(encoder.nodes)).
+		Msgf("storing incremental checkpoint with %v tries", len(tries))
+
+	writer, err := CreateCheckpointWriterForFile(dir, fileName, logger)
+	if err != nil {
+		return fmt.Errorf("could not create writer: %w", err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(writer, errToReturn)
+	}()
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	// Scratch buffer is used as temporary buffer that node can encode into.
+	// Data in scratch buffer should be copied or used before scratch buffer is used again.
+	scratch := make([]byte, 1024*4)
+
+	header := scratch[:incrementalHeaderSize]
+	binary.BigEndian.PutUint16(header, MagicBytesCheckpointHeader)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV7)
+	binary.BigEndian.PutUint64(header[headerSize:], uint64(baseCheckpoint))
+	_, err = crc32Writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	_, err = crc32Writer.Write(encodeNodeCount(uint64(len(encoder.references))))
+	if err != nil {
+		return fmt.Errorf("cannot write reference count: %w", err)
+	}
+	for _, ref := range encoder.references {
+		_, err = crc32Writer.Write(encodeNodeReference(ref, scratch))
+		if err != nil {
+			return fmt.Errorf("cannot write node reference: %w", err)
+		}
+	}
+
+	_, err = crc32Writer.Write(encodeNodeCount(uint64(len(encoder.nodes))))
+	if err != nil {
+		return fmt.Errorf("cannot write node count: %w", err)
+	}
+	for _, n := range encoder.nodes {
+		encoded := flattener.EncodeNode(n, encoder.index(n.LeftChild()), encoder.index(n.RightChild()), scratch)
+		_, err = crc32Writer.Write(encoded)
+		if err != nil {
+			return fmt.Errorf("cannot write node: %w", err)
+		}
+	}
+
+	for _, t := range tries {
+		encoded := flattener.EncodeTrie(t, encoder.index(t.RootNode()), scratch)
+		_, err = crc32Writer.Write(encoded)
+		if err != nil {
+			return fmt.Errorf("cannot write trie: %w", err)
+		}
+	}
+
+	footer := scratch[:incrementalFooterSize]
+	binary.BigEndian.PutUint16(footer, uint16(len(tries)))
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	// Write CRC32 sum
+	_, err = writer.Write(encodeCRC32Sum(crc32Writer.Crc32()))
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return nil
+}
+
+// readIncrementalCheckpoint decodes an incremental checkpoint file (version 7) and returns its tries.
+// The base checkpoint is loaded from the directory of the file.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readIncrementalCheckpoint(f *os.File, logger zerolog.Logger, config checkpointReadConfig) ([]*trie.MTrie, error) {
+	dir, fileName := filepath.Split(f.Name())
+	lg := logger.With().Str("checkpoint_file", f.Name()).Logger()
+	lg.Info().Msgf("reading incremental checkpoint file")
+
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+
+	triesCount, err := readIncrementalFooter(f)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	header := scratch[:incrementalHeaderSize]
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", err)
+	}
+	baseCheckpoint := binary.BigEndian.Uint64(header[headerSize:])
+
+	// a checkpoint can only be based on a prior checkpoint, which also prevents reference cycles.
+	if num, err := strconv.Atoi(strings.TrimPrefix(fileName, checkpointFilenamePrefix)); err == nil && baseCheckpoint >= uint64(num) {
+		return nil, fmt.Errorf("base checkpoint %d is not prior to checkpoint %d", baseCheckpoint, num)
+	}
+
+	lg.Info().Uint64("base_checkpoint", baseCheckpoint).Msg("loading base checkpoint")
+
+	baseTries, err := loadCheckpoint(filepath.Join(dir, NumberToFilename(int(baseCheckpoint))), logger, config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load base checkpoint %d: %w", baseCheckpoint, err)
+	}
+
+	referenceCount, err := readNodeCount(reader, scratch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read reference count: %w", err)
+	}
+
+	// nodes's element at index 0 is a special, meaning nil.
+	nodes := make([]*node.Node, 1, referenceCount+1)
+	for i := uint64(0); i < referenceCount; i++ {
+		_, err = io.ReadFull(reader, scratch[:encNodeReferenceSize])
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node reference %d: %w", i, err)
+		}
+		n, err := resolveNodeReference(baseTries, decodeNodeReference(scratch[:encNodeReferenceSize]))
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve node reference %d: %w", i, err)
+		}
+		nodes = append(nodes, n)
+	}
+
+	nodesCount, err := readNodeCount(reader, scratch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read node count: %w", err)
+	}
+
+	logging := logProgress("reading trie nodes", int(nodesCount), lg)
+
+	for i := uint64(1); i <= nodesCount; i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		nodes = append(nodes, n)
+		logging(i)
+	}
+
+	tries := make([]*trie.MTrie, triesCount)
+	for i := uint16(0); i < triesCount; i++ {
+		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	// Read footer again for crc32 computation
+	// No action is needed.
+	_, err = io.ReadFull(reader, scratch[:incrementalFooterSize])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	crc32buf := scratch[:crc32SumSize]
+	_, err = io.ReadFull(bufReader, crc32buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+	calculatedCrc32 := crcReader.Crc32()
+	if calculatedCrc32 != readCrc32 {
+		return nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	lg.Info().
+		Uint64("reference_count", referenceCount).
+		Uint64("node_count", nodesCount).
+		Msgf("finished reading incremental checkpoint, trie root count: %v", len(tries))
+
+	return tries, nil
+}
+
+// resolveNodeReference returns the base trie node identified by the reference.
+func resolveNodeReference(baseTries []*trie.MTrie, ref nodeReference) (*node.Node, error) {
+	if int(ref.trieIndex) >= len(baseTries) {
+		return nil, fmt.Errorf("base trie index %d out of range, base checkpoint has %d tries", ref.trieIndex, len(baseTries))
+	}
+	if int(ref.height) > ledger.NodeMaxHeight {
+		return nil, fmt.Errorf("invalid node height %d", ref.height)
+	}
+
+	n := baseTries[ref.trieIndex].RootNode()
+	depth := ledger.NodeMaxHeight - int(ref.height)
+	for i := 0; i < depth; i++ {
+		if n == nil || n.IsLeaf() {
+			break
+		}
+		if bitutils.ReadBit(ref.path[:], i) == 0 {
+			n = n.LeftChild()
+		} else {
+			n = n.RightChild()
+		}
+	}
+
+	if n == nil || n.Height() != int(ref.height) {
+		return nil, fmt.Errorf("base trie %d has no node at height %d on path %x", ref.trieIndex, ref.height, ref.path)
+	}
+	if n.Hash() != ref.hash {
+		return nil, fmt.Errorf("base trie %d node at height %d on path %x has hash %v, expected %v",
+			ref.trieIndex, ref.height, ref.path, n.Hash(), ref.hash)
+	}
+	return n, nil
+}
+
+func readNodeCount(reader io.Reader, scratch []byte) (uint64, error) {
+	buf := scratch[:encNodeCountSize]
+	_, err := io.ReadFull(reader, buf)
+	if err != nil {
+		return 0, err
+	}
+	return decodeNodeCount(buf)
+}
+
+// readIncrementalFooter returns the trie count of the incremental checkpoint file.
+func readIncrementalFooter(f *os.File) (uint16, error) {
+	_, err := f.Seek(-incrementalFooterOffset, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := make([]byte, incrementalFooterSize)
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read footer: %w", err)
+	}
+	return binary.BigEndian.Uint16(footer), nil
+}
+
+// readIncrementalTriesRootHash returns the root hashes of the tries of the incremental checkpoint file.
+func readIncrementalTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
+	trieRootsToReturn []ledger.RootHash,
+	errToReturn error,
+) {
+	errToReturn = withFile(logger, filepath.Join(dir, fileName), func(file *os.File) error {
+		triesCount, err := readIncrementalFooter(file)
+		if err != nil {
+			return err
+		}
+
+		trieRootOffset := incrementalFooterOffset + flattener.EncodedTrieSize*int(triesCount)
+		_, err = file.Seek(int64(-trieRootOffset), io.SeekEnd)
+		if err != nil {
+			return fmt.Errorf("could not seek to trie roots: %w", err)
+		}
+
+		reader := bufio.NewReaderSize(file, defaultBufioReadSize)
+		trieRoots := make([]ledger.RootHash, 0, triesCount)
+		scratch := make([]byte, 1024*4) // must not be less than 1024
+		for i := 0; i < int(triesCount); i++ {
+			trieRootNode, err := flattener.ReadEncodedTrie(reader, scratch)
+			if err != nil {
+				return fmt.Errorf("could not read trie root node: %w", err)
+			}
+			trieRoots = append(trieRoots, ledger.RootHash(trieRootNode.RootHash))
+		}
+
+		trieRootsToReturn = trieRoots
+		return nil
+	})
+	return trieRootsToReturn, errToReturn
+}
+
+// readCheckpointVersion returns the version of the checkpoint file.
+func readCheckpointVersion(logger zerolog.Logger, dir string, fileName string) (version uint16, errToReturn error) {
+	errToReturn = withFile(logger, filepath.Join(dir, fileName), func(file *os.File) error {
+		magic, v, err := readFileHeader(file)
+		if err != nil {
+			return err
+		}
+		if magic != MagicBytesCheckpointHeader {
+			return fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magic, MagicBytesCheckpointHeader)
+		}
+		version = v
+		return nil
+	})
+	return version, errToReturn
+}
+
+// ReadCheckpointBase returns the number of the base checkpoint of the checkpoint file,
+// and whether the checkpoint is an incremental checkpoint.
+// Full checkpoints have no base checkpoint, in which case (-1, false) is returned.
+func ReadCheckpointBase(dir string, fileName string) (base int, incremental bool, errToReturn error) {
+	file, err := os.Open(filepath.Join(dir, fileName))
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot open checkpoint file %s: %w", fileName, err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(file, errToReturn)
+	}()
+
+	header := make([]byte, incrementalHeaderSize)
+	_, err = io.ReadFull(file, header[:headerSize])
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot read header: %w", err)
+	}
+	magic := binary.BigEndian.Uint16(header)
+	version := binary.BigEndian.Uint16(header[encMagicSize:])
+	if magic != MagicBytesCheckpointHeader {
+		return -1, false, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magic, MagicBytesCheckpointHeader)
+	}
+	if version != VersionV7 {
+		return -1, false, nil
+	}
+
+	_, err = io.ReadFull(file, header[headerSize:])
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot read base checkpoint: %w", err)
+	}
+	return int(binary.BigEndian.Uint64(header[headerSize:])), true, nil
+}
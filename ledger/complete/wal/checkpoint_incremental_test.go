@@ -0,0 +1,242 @@
+package wal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// updateRandomTries returns the last keep tries of the given tries, followed by n tries
+// created by updating random registers, some of which are updated more than once.
+func updateRandomTries(t *testing.T, tries []*trie.MTrie, keep int, n int) []*trie.MTrie {
+	updated := append([]*trie.MTrie{}, tries[len(tries)-keep:]...)
+	activeTrie := tries[len(tries)-1]
+	sharedPaths, _ := randNPathPayloads(5)
+
+	var err error
+	for i := 0; i < n; i++ {
+		paths, payloads := randNPathPayloads(20)
+		copy(paths, sharedPaths)
+		activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, payloads, false)
+		require.NoError(t, err, "update registers")
+		updated = append(updated, activeTrie)
+	}
+	return updated
+}
+
+func TestWriteAndReadIncrementalCheckpoint(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateRandomTries(t, baseTries, 50, 20)
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+
+		base, incremental, err := ReadCheckpointBase(dir, fileName)
+		require.NoError(t, err)
+		require.True(t, incremental)
+		require.Equal(t, 1, base)
+
+		_, incremental, err = ReadCheckpointBase(dir, NumberToFilename(1))
+		require.NoError(t, err)
+		require.False(t, incremental)
+
+		// only the nodes created since the base checkpoint are stored
+		baseSize, err := ReadCheckpointFileSize(dir, NumberToFilename(1))
+		require.NoError(t, err)
+		size, err := ReadCheckpointFileSize(dir, fileName)
+		require.NoError(t, err)
+		require.Less(t, size, baseSize)
+	})
+}
+
+func TestWriteAndReadIncrementalCheckpointEmptyTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := []*trie.MTrie{trie.NewEmptyMTrie()}
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := append([]*trie.MTrie{trie.NewEmptyMTrie()}, createSimpleTrie(t)...)
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+// TestReadIncrementalCheckpointChain tests that incremental checkpoints based on incremental
+// checkpoints are loaded from the chain of checkpoints back to the full checkpoint.
+func TestReadIncrementalCheckpointChain(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		tries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, NumberToFilename(1), logger))
+
+		for num := 2; num <= 5; num++ {
+			updated := updateRandomTries(t, tries, 3, 5)
+			require.NoError(t, StoreIncrementalCheckpoint(updated, num-1, dir, NumberToFilename(num), logger))
+			tries = updated
+		}
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(5)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+
+		trieRoots, err := ReadTriesRootHash(logger, dir, NumberToFilename(5))
+		require.NoError(t, err)
+		require.Equal(t, len(tries), len(trieRoots))
+		for i, root := range trieRoots {
+			require.Equal(t, tries[i].RootHash(), root)
+		}
+	})
+}
+
+func TestReadIncrementalCheckpointMismatchingBase(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateRandomTries(t, baseTries, 3, 5)
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+
+		// replace the base checkpoint by a checkpoint of other tries
+		require.NoError(t, deleteCheckpointFiles(dir, NumberToFilename(1)))
+		require.NoError(t, StoreCheckpointV6Concurrently(createMultipleRandomTriesMini(t), dir, NumberToFilename(1), logger))
+
+		_, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.Error(t, err)
+	})
+}
+
+func TestWriteIncrementalCheckpointMissingBase(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+
+		tries := updateRandomTries(t, baseTries, 3, 5)
+		require.Error(t, StoreIncrementalCheckpoint(tries, 1, dir, NumberToFilename(2), logger))
+	})
+}
+
+// TestIncrementalCheckpointEvictedBaseTries tests that the nodes of base tries which aren't
+// among the checkpointed tries anymore are stored as new nodes.
+func TestIncrementalCheckpointEvictedBaseTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateRandomTries(t, baseTries, 0, 5)
+		baseRootHashes, err := ReadTriesRootHash(logger, dir, NumberToFilename(1))
+		require.NoError(t, err)
+		encoder := newIncrementalEncoder(tries, baseRootHashes)
+		for _, tr := range tries {
+			encoder.addTrie(tr)
+		}
+		require.Empty(t, encoder.references)
+
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+		decoded, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+func TestReadIncrementalCheckpointBaseNotPrior(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(2), logger))
+
+		tries := updateRandomTries(t, baseTries, 3, 5)
+		fileName := NumberToFilename(1)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 2, dir, fileName, logger))
+
+		_, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.Error(t, err)
+	})
+}
+
+// TestIncrementalCheckpointForkedTries tests that nodes shared with any base trie are stored as
+// references, not only the nodes shared with the last base trie.
+func TestIncrementalCheckpointForkedTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		// fork from each of the base tries, which are still checkpointed
+		tries := append([]*trie.MTrie{}, baseTries...)
+		for _, base := range baseTries {
+			tries = append(tries, updateRandomTries(t, []*trie.MTrie{base}, 0, 2)...)
+		}
+
+		baseNodes := make(map[*node.Node]struct{})
+		for _, base := range baseTries {
+			require.NoError(t, trie.TraverseNodes(base, func(n *node.Node) error {
+				baseNodes[n] = struct{}{}
+				return nil
+			}))
+		}
+		baseRootHashes, err := ReadTriesRootHash(logger, dir, NumberToFilename(1))
+		require.NoError(t, err)
+		encoder := newIncrementalEncoder(tries, baseRootHashes)
+		for _, tr := range tries {
+			encoder.addTrie(tr)
+		}
+		for _, n := range encoder.nodes {
+			_, ok := baseNodes[n]
+			require.False(t, ok, "node of base trie is stored")
+		}
+
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+		decoded, err := LoadCheckpoint(filepath.Join(dir, fileName), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+// TestReadIncrementalCheckpointLeafNodes tests that the leaf nodes of an incremental checkpoint are read
+// from the chain of base checkpoints, like the leaf nodes of a full checkpoint.
+func TestReadIncrementalCheckpointLeafNodes(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTriesMini(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		// the root of the only trie is a reference to the last base trie
+		tries := updateRandomTries(t, baseTries, 1, 0)
+		fileName := NumberToFilename(2)
+		require.NoError(t, StoreIncrementalCheckpoint(tries, 1, dir, fileName, logger))
+
+		leafNodesCh := make(chan *LeafNode, 10)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- OpenAndReadLeafNodesFromCheckpointV6(leafNodesCh, dir, fileName, tries[0].RootHash(), logger)
+		}()
+		var payloads []*ledger.Payload
+		for leafNode := range leafNodesCh {
+			payloads = append(payloads, leafNode.Payload)
+		}
+		require.NoError(t, <-errCh)
+		require.ElementsMatch(t, tries[0].AllPayloads(), payloads)
+	})
+}
@@ -0,0 +1,142 @@
+package wal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/atomic"
+	"golang.org/x/exp/mmap"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// CheckpointReadOption is an option for reading checkpoint files.
+type CheckpointReadOption func(*checkpointReadConfig)
+
+type checkpointReadConfig struct {
+	// workers is the max number of V6 subtrie part files read concurrently.
+	workers int
+	// memoryMapped reads part files through memory mapping instead of file reads.
+	memoryMapped bool
+	// metrics receives the progress of loading checkpoints, if not nil.
+	metrics module.WALMetrics
+}
+
+func newCheckpointReadConfig(opts []CheckpointReadOption) checkpointReadConfig {
+	config := checkpointReadConfig{
+		// read all subtrie part files concurrently by default
+		workers: subtrieCount,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithReadWorkers limits the number of V6 subtrie part files read concurrently.
+// Fewer workers reduce the memory used while loading a checkpoint, at the cost of loading time.
+// Values less than 1 are ignored.
+func WithReadWorkers(workers int) CheckpointReadOption {
+	return func(config *checkpointReadConfig) {
+		if workers > 0 {
+			config.workers = workers
+		}
+	}
+}
+
+// WithMemoryMappedRead reads checkpoint part files through memory mapping, which
+// avoids a read system call for every buffered chunk of the file.
+func WithMemoryMappedRead(enabled bool) CheckpointReadOption {
+	return func(config *checkpointReadConfig) {
+		config.memoryMapped = enabled
+	}
+}
+
+// WithLoadProgressMetrics reports the progress of loading checkpoints to the given metrics.
+func WithLoadProgressMetrics(metrics module.WALMetrics) CheckpointReadOption {
+	return func(config *checkpointReadConfig) {
+		config.metrics = metrics
+	}
+}
+
+// loadProgress tracks the number of bytes read while loading a checkpoint from concurrently read part files.
+// A nil *loadProgress doesn't track anything.
+type loadProgress struct {
+	read    *atomic.Uint64
+	total   uint64
+	metrics module.WALMetrics
+}
+
+// newLoadProgress returns the progress of loading a checkpoint of the given size in bytes,
+// or nil if the progress isn't reported.
+func newLoadProgress(metrics module.WALMetrics, total uint64) *loadProgress {
+	if metrics == nil {
+		return nil
+	}
+	metrics.ExecutionCheckpointLoadProgress(0, total)
+	return &loadProgress{
+		read:    atomic.NewUint64(0),
+		total:   total,
+		metrics: metrics,
+	}
+}
+
+// reader returns a reader tracking the bytes read from r.
+func (p *loadProgress) reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+	return &progressReader{reader: r, progress: p}
+}
+
+// done reports the checkpoint as fully read.
+func (p *loadProgress) done() {
+	if p == nil {
+		return
+	}
+	p.metrics.ExecutionCheckpointLoadProgress(p.total, p.total)
+}
+
+type progressReader struct {
+	reader   io.Reader
+	progress *loadProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		read := r.progress.read.Add(uint64(n))
+		r.progress.metrics.ExecutionCheckpointLoadProgress(read, r.progress.total)
+	}
+	return n, err
+}
+
+// newPartFileReader returns a buffered reader of the part file f from its start, which reads
+// through memory mapping if configured. The returned close function must be called once
+// reading is done, before f is closed.
+func newPartFileReader(f *os.File, config checkpointReadConfig, progress *loadProgress) (io.Reader, func(), error) {
+	var source io.Reader
+	closeFn := func() {}
+
+	if config.memoryMapped {
+		mapped, err := mmap.Open(f.Name())
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot memory map file %v: %w", f.Name(), err)
+		}
+		source = io.NewSectionReader(mapped, 0, int64(mapped.Len()))
+		closeFn = func() {
+			// the mapping is read only, so unmapping can't lose data
+			_ = mapped.Close()
+		}
+	} else {
+		_, err := f.Seek(0, io.SeekStart)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot seek to start of file: %w", err)
+		}
+		source = f
+	}
+
+	return bufio.NewReaderSize(progress.reader(source), defaultBufioReadSize), closeFn, nil
+}
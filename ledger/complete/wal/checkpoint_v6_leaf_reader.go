@@ -3,6 +3,7 @@ package wal
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/rs/zerolog"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
 
 type LeafNode struct {
@@ -30,6 +32,8 @@ func nodeToLeaf(leaf *node.Node) *LeafNode {
 // the given checkpoint file specified by dir and fileName.
 // It returns when finish reading the checkpoint file and the input channel can be closed.
 // It requires the checkpoint file only has one trie.
+// Incremental checkpoints (version 7) are also supported, in which case the chain of base checkpoints
+// in the same directory is loaded to resolve the nodes shared with the base tries.
 func OpenAndReadLeafNodesFromCheckpointV6(
 	allLeafNodesCh chan<- *LeafNode,
 	dir string,
@@ -47,6 +51,14 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 		return fmt.Errorf("fail to check checkpoint has single root hash: %w", err)
 	}
 
+	_, incremental, err := ReadCheckpointBase(dir, fileName)
+	if err != nil {
+		return fmt.Errorf("could not read checkpoint version: %w", err)
+	}
+	if incremental {
+		return readIncrementalCheckpointLeafNodes(allLeafNodesCh, dir, fileName, logger)
+	}
+
 	filepath := filePathCheckpointHeader(dir, fileName)
 
 	f, err := os.Open(filepath)
@@ -81,7 +93,7 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 }
 
 func readCheckpointSubTrieLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) error {
-	return processCheckpointSubTrie(dir, fileName, index, checksum, logger,
+	return processCheckpointSubTrie(dir, fileName, index, checksum, newCheckpointReadConfig(nil), nil, logger,
 		func(reader *Crc32Reader, nodesCount uint64) error {
 			scratch := make([]byte, 1024*4) // must not be less than 1024
 
@@ -106,3 +118,20 @@ func readCheckpointSubTrieLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fi
 			return nil
 		})
 }
+
+// readIncrementalCheckpointLeafNodes pushes the leaf nodes of the single trie of the incremental checkpoint.
+// Unlike full checkpoints, incremental checkpoints can't be streamed, since their nodes reference the nodes
+// of the base tries, so the checkpoint is loaded before its leaf nodes are pushed.
+func readIncrementalCheckpointLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fileName string, logger zerolog.Logger) error {
+	tries, err := loadCheckpoint(filepath.Join(dir, fileName), logger, newCheckpointReadConfig(nil))
+	if err != nil {
+		return fmt.Errorf("could not load incremental checkpoint: %w", err)
+	}
+
+	return trie.TraverseNodes(tries[0], func(n *node.Node) error {
+		if n.IsLeaf() {
+			leafNodesCh <- nodeToLeaf(n)
+		}
+		return nil
+	})
+}
@@ -24,7 +24,15 @@ func ReadTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
 	[]ledger.RootHash,
 	error,
 ) {
-	err := validateCheckpointFile(logger, dir, fileName)
+	version, err := readCheckpointVersion(logger, dir, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if version == VersionV7 {
+		return readIncrementalTriesRootHash(logger, dir, fileName)
+	}
+
+	err = validateCheckpointFile(logger, dir, fileName)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +54,9 @@ var CheckpointHasSingleRootHash = checkpointHasSingleRootHash
 // it returns (nil, os.ErrNotExist) if a certain file is missing, use (os.IsNotExist to check)
 // it returns (nil, ErrEOFNotReached) if a certain part file is malformed
 // it returns (nil, err) if running into any exception
-func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
+//
+// the subtrie part files are read concurrently by at most config.workers goroutines.
+func readCheckpointV6(headerFile *os.File, logger zerolog.Logger, config checkpointReadConfig) ([]*trie.MTrie, error) {
 	// the full path of header file
 	headerPath := headerFile.Name()
 	dir, fileName := filepath.Split(headerPath)
@@ -66,9 +76,16 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 		return nil, fmt.Errorf("fail to check all checkpoint part file exist: %w", err)
 	}
 
-	// TODO making number of goroutine configable for reading subtries, which can help us
-	// test the code on machines that don't have as much RAM as EN by using fewer goroutines.
-	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, subtrieChecksums, lg)
+	var progress *loadProgress
+	if config.metrics != nil {
+		size, err := ReadCheckpointFileSize(dir, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("could not read checkpoint file size: %w", err)
+		}
+		progress = newLoadProgress(config.metrics, size)
+	}
+
+	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, subtrieChecksums, config, progress, lg)
 	if err != nil {
 		return nil, fmt.Errorf("could not read subtrie from dir: %w", err)
 	}
@@ -76,10 +93,11 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 	lg.Info().Uint32("topsum", topTrieChecksum).
 		Msg("finish reading all v6 subtrie files, start reading top level tries")
 
-	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, lg)
+	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, config, progress, lg)
 	if err != nil {
 		return nil, fmt.Errorf("could not read top level nodes or tries: %w", err)
 	}
+	progress.done()
 
 	lg.Info().Msgf("finish reading all trie roots, trie root count: %v", len(tries))
 
@@ -98,14 +116,14 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 }
 
 // OpenAndReadCheckpointV6 open the checkpoint file and read it with readCheckpointV6
-func OpenAndReadCheckpointV6(dir string, fileName string, logger zerolog.Logger) (
+func OpenAndReadCheckpointV6(dir string, fileName string, logger zerolog.Logger, opts ...CheckpointReadOption) (
 	triesToReturn []*trie.MTrie,
 	errToReturn error,
 ) {
 
 	filepath := filePathCheckpointHeader(dir, fileName)
 	errToReturn = withFile(logger, filepath, func(file *os.File) error {
-		tries, err := readCheckpointV6(file, logger)
+		tries, err := readCheckpointV6(file, logger, newCheckpointReadConfig(opts))
 		if err != nil {
 			return err
 		}
@@ -119,6 +137,15 @@ func OpenAndReadCheckpointV6(dir string, fileName string, logger zerolog.Logger)
 // ReadCheckpointFileSize returns the total size of the checkpoint file
 func ReadCheckpointFileSize(dir string, fileName string) (uint64, error) {
 	paths := allFilePaths(dir, fileName)
+	_, incremental, err := ReadCheckpointBase(dir, fileName)
+	if err != nil {
+		return 0, err
+	}
+	if incremental {
+		// incremental checkpoints are stored in a single file
+		paths = paths[:1]
+	}
+
 	totalSize := uint64(0)
 	for _, path := range paths {
 		fileInfo, err := os.Stat(path)
@@ -322,7 +349,14 @@ type resultReadSubTrie struct {
 	Err   error
 }
 
-func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []uint32, logger zerolog.Logger) ([][]*node.Node, error) {
+func readSubTriesConcurrently(
+	dir string,
+	fileName string,
+	subtrieChecksums []uint32,
+	config checkpointReadConfig,
+	progress *loadProgress,
+	logger zerolog.Logger,
+) ([][]*node.Node, error) {
 
 	numOfSubTries := len(subtrieChecksums)
 	jobs := make(chan jobReadSubtrie, numOfSubTries)
@@ -330,7 +364,8 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 
 	// push all jobs into the channel
 	for i, checksum := range subtrieChecksums {
-		resultCh := make(chan *resultReadSubTrie)
+		// buffered, so that workers don't block if reading results stops at the first error
+		resultCh := make(chan *resultReadSubTrie, 1)
 		resultChs[i] = resultCh
 		jobs <- jobReadSubtrie{
 			Index:    i,
@@ -340,12 +375,14 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 	}
 	close(jobs)
 
-	// TODO: make nWorker configable
-	nWorker := numOfSubTries // use as many worker as the jobs to read subtries concurrently
+	nWorker := config.workers
+	if nWorker > numOfSubTries {
+		nWorker = numOfSubTries
+	}
 	for i := 0; i < nWorker; i++ {
 		go func() {
 			for job := range jobs {
-				nodes, err := readCheckpointSubTrie(dir, fileName, job.Index, job.Checksum, logger)
+				nodes, err := readCheckpointSubTrie(dir, fileName, job.Index, job.Checksum, config, progress, logger)
 				job.Result <- &resultReadSubTrie{
 					Nodes: nodes,
 					Err:   err,
@@ -369,12 +406,20 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 	return nodesGroups, nil
 }
 
-func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) (
+func readCheckpointSubTrie(
+	dir string,
+	fileName string,
+	index int,
+	checksum uint32,
+	config checkpointReadConfig,
+	progress *loadProgress,
+	logger zerolog.Logger,
+) (
 	[]*node.Node,
 	error,
 ) {
 	var nodes []*node.Node
-	err := processCheckpointSubTrie(dir, fileName, index, checksum, logger,
+	err := processCheckpointSubTrie(dir, fileName, index, checksum, config, progress, logger,
 		func(reader *Crc32Reader, nodesCount uint64) error {
 			scratch := make([]byte, 1024*4) // must not be less than 1024
 
@@ -416,6 +461,8 @@ func processCheckpointSubTrie(
 	fileName string,
 	index int,
 	checksum uint32,
+	config checkpointReadConfig,
+	progress *loadProgress,
 	logger zerolog.Logger,
 	processNode func(*Crc32Reader, uint64) error,
 ) error {
@@ -443,12 +490,13 @@ func processCheckpointSubTrie(
 
 		// restart from the beginning of the file, make sure Crc32Reader has seen all the bytes
 		// in order to compute the correct checksum
-		_, err = f.Seek(0, io.SeekStart)
+		partReader, closePartReader, err := newPartFileReader(f, config, progress)
 		if err != nil {
-			return fmt.Errorf("cannot seek to start of file: %w", err)
+			return err
 		}
+		defer closePartReader()
 
-		reader := NewCRC32Reader(bufio.NewReaderSize(f, defaultBufioReadSize))
+		reader := NewCRC32Reader(partReader)
 
 		// read version again for calculating checksum
 		_, _, err = readFileHeader(reader)
@@ -530,7 +578,15 @@ func readSubTriesFooter(f *os.File) (uint64, uint32, error) {
 // 5. node count
 // 6. trie count
 // 7. checksum
-func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node, topTrieChecksum uint32, logger zerolog.Logger) (
+func readTopLevelTries(
+	dir string,
+	fileName string,
+	subtrieNodes [][]*node.Node,
+	topTrieChecksum uint32,
+	config checkpointReadConfig,
+	progress *loadProgress,
+	logger zerolog.Logger,
+) (
 	rootTriesToReturn []*trie.MTrie,
 	errToReturn error,
 ) {
@@ -556,12 +612,13 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 
 		// restart from the beginning of the file, make sure CRC32Reader has seen all the bytes
 		// in order to compute the correct checksum
-		_, err = file.Seek(0, io.SeekStart)
+		partReader, closePartReader, err := newPartFileReader(file, config, progress)
 		if err != nil {
-			return fmt.Errorf("could not seek to 0: %w", err)
+			return err
 		}
+		defer closePartReader()
 
-		reader := NewCRC32Reader(bufio.NewReaderSize(file, defaultBufioReadSize))
+		reader := NewCRC32Reader(partReader)
 
 		// read version again for calculating checksum
 		_, _, err = readFileHeader(reader)
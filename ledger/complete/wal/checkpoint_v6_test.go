@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -22,6 +23,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/testutils"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -206,7 +208,7 @@ func TestEncodeSubTrie(t *testing.T) {
 				uniqueIndices, nodeCount, checksum)
 
 			// all the nodes
-			nodes, err := readCheckpointSubTrie(dir, file, index, checksum, logger)
+			nodes, err := readCheckpointSubTrie(dir, file, index, checksum, newCheckpointReadConfig(nil), nil, logger)
 			require.NoError(t, err)
 
 			for _, root := range roots {
@@ -294,6 +296,51 @@ func TestWriteAndReadCheckpointV6MultipleTries(t *testing.T) {
 	})
 }
 
+// loadProgressRecorder records the last checkpoint load progress reported.
+type loadProgressRecorder struct {
+	metrics.NoopCollector
+	mu      sync.Mutex
+	reports int
+	read    uint64
+	total   uint64
+}
+
+func (r *loadProgressRecorder) ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports++
+	r.read = bytesRead
+	r.total = bytesTotal
+}
+
+func TestWriteAndReadCheckpointV6WithReadOptions(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-multi-file"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		size, err := ReadCheckpointFileSize(dir, fileName)
+		require.NoError(t, err)
+
+		for _, memoryMapped := range []bool{false, true} {
+			recorder := &loadProgressRecorder{}
+			decoded, err := OpenAndReadCheckpointV6(dir, fileName, logger,
+				WithReadWorkers(1),
+				WithMemoryMappedRead(memoryMapped),
+				WithLoadProgressMetrics(recorder),
+			)
+			require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+			requireTriesEqual(t, tries, decoded)
+
+			// progress is reported while reading, and the checkpoint is reported as fully read at the end
+			require.Greater(t, recorder.reports, 2)
+			require.Equal(t, size, recorder.total)
+			require.Equal(t, size, recorder.read)
+		}
+	})
+}
+
 // test running checkpointing twice will produce the same checkpoint file
 func TestCheckpointV6IsDeterminstic(t *testing.T) {
 	unittest.RunWithTempDir(t, func(dir string) {
@@ -60,9 +60,14 @@ const VersionV5 uint16 = 0x05
 //     file name extension
 const VersionV6 uint16 = 0x06
 
+// Version 7 is an incremental checkpoint, which only contains the trie nodes created since
+// a base checkpoint, and references to the nodes of the base checkpoint tries.
+// See StoreIncrementalCheckpoint() for more details.
+const VersionV7 uint16 = 0x07
+
 // MaxVersion is the latest checkpoint version we support.
 // Need to update MaxVersion when creating a newer version.
-const MaxVersion = VersionV6
+const MaxVersion = VersionV7
 
 const (
 	encMagicSize        = 2
@@ -618,12 +623,12 @@ func getNodesAtLevel(root *node.Node, level uint) []*node.Node {
 
 func (c *Checkpointer) LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
-	return LoadCheckpoint(filepath, c.wal.log)
+	return LoadCheckpoint(filepath, c.wal.log, c.wal.checkpointReadOptions...)
 }
 
 func (c *Checkpointer) LoadRootCheckpoint() ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, bootstrap.FilenameWALRootCheckpoint)
-	return LoadCheckpoint(filepath, c.wal.log)
+	return LoadCheckpoint(filepath, c.wal.log, c.wal.checkpointReadOptions...)
 }
 
 func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
@@ -640,12 +645,23 @@ func HasRootCheckpoint(dir string) (bool, error) {
 	}
 }
 
+// CheckpointBase returns the number of the base checkpoint of the given checkpoint,
+// and whether the checkpoint is an incremental checkpoint.
+func (c *Checkpointer) CheckpointBase(checkpoint int) (int, bool, error) {
+	return ReadCheckpointBase(c.dir, NumberToFilename(checkpoint))
+}
+
 func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
 	name := NumberToFilename(checkpoint)
 	return deleteCheckpointFiles(c.dir, name)
 }
 
-func LoadCheckpoint(filepath string, logger zerolog.Logger) (
+// LoadCheckpoint loads the tries of the checkpoint file, reading it with the given options.
+func LoadCheckpoint(filepath string, logger zerolog.Logger, opts ...CheckpointReadOption) ([]*trie.MTrie, error) {
+	return loadCheckpoint(filepath, logger, newCheckpointReadConfig(opts))
+}
+
+func loadCheckpoint(filepath string, logger zerolog.Logger, config checkpointReadConfig) (
 	tries []*trie.MTrie,
 	errToReturn error) {
 	file, err := os.Open(filepath)
@@ -662,10 +678,10 @@ func LoadCheckpoint(filepath string, logger zerolog.Logger) (
 		errToReturn = closeAndMergeError(file, errToReturn)
 	}()
 
-	return readCheckpoint(file, logger)
+	return readCheckpoint(file, logger, config)
 }
 
-func readCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
+func readCheckpoint(f *os.File, logger zerolog.Logger, config checkpointReadConfig) ([]*trie.MTrie, error) {
 
 	// Read header: magic (2 bytes) + version (2 bytes)
 	header := make([]byte, headerSize)
@@ -696,7 +712,9 @@ func readCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
 	case VersionV5:
 		return readCheckpointV5(f, logger)
 	case VersionV6:
-		return readCheckpointV6(f, logger)
+		return readCheckpointV6(f, logger, config)
+	case VersionV7:
+		return readIncrementalCheckpoint(f, logger, config)
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
 	}
@@ -23,10 +23,23 @@ type DiskWAL struct {
 	pathByteSize   int
 	log            zerolog.Logger
 	dir            string
+	// checkpointReadOptions are the options for loading checkpoints
+	checkpointReadOptions []CheckpointReadOption
 }
 
+// NewDiskWAL creates a DiskWAL storing segments in dir. Checkpoints are loaded with the given options,
+// and report their loading progress to the given metrics.
 // TODO use real logger and metrics, but that would require passing them to Trie storage
-func NewDiskWAL(logger zerolog.Logger, reg prometheus.Registerer, metrics module.WALMetrics, dir string, forestCapacity int, pathByteSize int, segmentSize int) (*DiskWAL, error) {
+func NewDiskWAL(
+	logger zerolog.Logger,
+	reg prometheus.Registerer,
+	metrics module.WALMetrics,
+	dir string,
+	forestCapacity int,
+	pathByteSize int,
+	segmentSize int,
+	opts ...CheckpointReadOption,
+) (*DiskWAL, error) {
 	w, err := prometheusWAL.NewSize(logger, reg, dir, segmentSize, false)
 	if err != nil {
 		return nil, fmt.Errorf("could not create disk wal from dir %v, segmentSize %v: %w", dir, segmentSize, err)
@@ -38,6 +51,8 @@ func NewDiskWAL(logger zerolog.Logger, reg prometheus.Registerer, metrics module
 		pathByteSize:   pathByteSize,
 		log:            logger.With().Str("ledger_mod", "diskwal").Logger(),
 		dir:            dir,
+		// options given by the caller take precedence over the default metrics
+		checkpointReadOptions: append([]CheckpointReadOption{WithLoadProgressMetrics(metrics)}, opts...),
 	}, nil
 }
 
@@ -0,0 +1,60 @@
+package flow
+
+// TransactionRole describes how an account was involved in a transaction.
+type TransactionRole uint8
+
+const (
+	// TransactionRoleAuthorizer is the role of accounts that authorized the transaction.
+	TransactionRoleAuthorizer TransactionRole = iota + 1
+	// TransactionRolePayer is the role of the account that paid for the transaction.
+	TransactionRolePayer
+	// TransactionRoleProposer is the role of the account whose key was used as the proposal key.
+	TransactionRoleProposer
+	// TransactionRoleInteraction is the role of accounts whose registers were updated by the chunk
+	// that executed the transaction.
+	// This role is chunk-granular: execution data records register updates per chunk, so these
+	// accounts are associated with all transactions of the chunk, including transactions which
+	// didn't update any of their registers.
+	TransactionRoleInteraction
+)
+
+// String returns the string representation of the transaction role.
+func (r TransactionRole) String() string {
+	switch r {
+	case TransactionRoleAuthorizer:
+		return "authorizer"
+	case TransactionRolePayer:
+		return "payer"
+	case TransactionRoleProposer:
+		return "proposer"
+	case TransactionRoleInteraction:
+		return "interaction"
+	default:
+		return "unknown"
+	}
+}
+
+// AccountTransaction is an entry of the transaction history of an account.
+type AccountTransaction struct {
+	Address          Address
+	BlockID          Identifier
+	BlockHeight      uint64
+	TransactionID    Identifier
+	TransactionIndex uint32
+	// Roles are the roles of the account in the transaction, in ascending order.
+	Roles []TransactionRole
+}
+
+// Cursor returns the position of the entry in the transaction history of the account.
+func (t *AccountTransaction) Cursor() *AccountTransactionCursor {
+	return &AccountTransactionCursor{
+		BlockHeight:      t.BlockHeight,
+		TransactionIndex: t.TransactionIndex,
+	}
+}
+
+// AccountTransactionCursor identifies a position in the transaction history of an account.
+type AccountTransactionCursor struct {
+	BlockHeight      uint64
+	TransactionIndex uint32
+}
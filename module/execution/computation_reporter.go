@@ -0,0 +1,26 @@
+package execution
+
+import (
+	"context"
+)
+
+// ComputationReporter is called with the computation used by each script executed for a request.
+// It may be called concurrently.
+type ComputationReporter func(computationUsed uint64)
+
+type computationReporterKey struct{}
+
+// WithComputationReporter returns a copy of the context with the given reporter, which is called with the
+// computation used by the scripts executed using the context. This allows components serving requests to
+// account for the computation used by a request without changing the APIs between them.
+func WithComputationReporter(ctx context.Context, reporter ComputationReporter) context.Context {
+	return context.WithValue(ctx, computationReporterKey{}, reporter)
+}
+
+// ReportComputation reports the computation used by a script executed using the context to the reporter
+// of the context, if any.
+func ReportComputation(ctx context.Context, computationUsed uint64) {
+	if reporter, ok := ctx.Value(computationReporterKey{}).(ComputationReporter); ok && reporter != nil {
+		reporter(computationUsed)
+	}
+}
@@ -5,6 +5,8 @@ package mock
 import (
 	context "context"
 
+	execution "github.com/onflow/flow-go/module/execution"
+
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
@@ -45,6 +47,36 @@ func (_m *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byt
 	return r0, r1
 }
 
+// ExecuteBatchAtBlockHeight provides a mock function with given fields: ctx, scripts, height, computationLimit
+func (_m *ScriptExecutor) ExecuteBatchAtBlockHeight(ctx context.Context, scripts []execution.Script, height uint64, computationLimit uint64) ([]execution.ScriptResult, error) {
+	ret := _m.Called(ctx, scripts, height, computationLimit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteBatchAtBlockHeight")
+	}
+
+	var r0 []execution.ScriptResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, uint64, uint64) ([]execution.ScriptResult, error)); ok {
+		return rf(ctx, scripts, height, computationLimit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, uint64, uint64) []execution.ScriptResult); ok {
+		r0 = rf(ctx, scripts, height, computationLimit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]execution.ScriptResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []execution.Script, uint64, uint64) error); ok {
+		r1 = rf(ctx, scripts, height, computationLimit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountAtBlockHeight provides a mock function with given fields: ctx, address, height
 func (_m *ScriptExecutor) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
 	ret := _m.Called(ctx, address, height)
@@ -75,6 +107,34 @@ func (_m *ScriptExecutor) GetAccountAtBlockHeight(ctx context.Context, address f
 	return r0, r1
 }
 
+// GetAccountAvailableBalance provides a mock function with given fields: ctx, address, height
+func (_m *ScriptExecutor) GetAccountAvailableBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	ret := _m.Called(ctx, address, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountAvailableBalance")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (uint64, error)); ok {
+		return rf(ctx, address, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) uint64); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountBalance provides a mock function with given fields: ctx, address, height
 func (_m *ScriptExecutor) GetAccountBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
 	ret := _m.Called(ctx, address, height)
@@ -103,6 +163,36 @@ func (_m *ScriptExecutor) GetAccountBalance(ctx context.Context, address flow.Ad
 	return r0, r1
 }
 
+// GetAccountKey provides a mock function with given fields: ctx, address, keyIndex, height
+func (_m *ScriptExecutor) GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountKey")
+	}
+
+	var r0 *flow.AccountPublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64) (*flow.AccountPublicKey, error)); ok {
+		return rf(ctx, address, keyIndex, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, keyIndex, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountKeys provides a mock function with given fields: ctx, address, height
 func (_m *ScriptExecutor) GetAccountKeys(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error) {
 	ret := _m.Called(ctx, address, height)
@@ -133,6 +223,36 @@ func (_m *ScriptExecutor) GetAccountKeys(ctx context.Context, address flow.Addre
 	return r0, r1
 }
 
+// SimulateTransactionAtBlockHeight provides a mock function with given fields: ctx, tx, height, skipSignatureVerification
+func (_m *ScriptExecutor) SimulateTransactionAtBlockHeight(ctx context.Context, tx *flow.TransactionBody, height uint64, skipSignatureVerification bool) (*execution.TransactionSimulation, error) {
+	ret := _m.Called(ctx, tx, height, skipSignatureVerification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransactionAtBlockHeight")
+	}
+
+	var r0 *execution.TransactionSimulation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) (*execution.TransactionSimulation, error)); ok {
+		return rf(ctx, tx, height, skipSignatureVerification)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) *execution.TransactionSimulation); ok {
+		r0 = rf(ctx, tx, height, skipSignatureVerification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.TransactionSimulation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64, bool) error); ok {
+		r1 = rf(ctx, tx, height, skipSignatureVerification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewScriptExecutor creates a new instance of ScriptExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewScriptExecutor(t interface {
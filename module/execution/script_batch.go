@@ -0,0 +1,167 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BatchConcurrency is the max number of scripts of a batch executed concurrently.
+const BatchConcurrency = 8
+
+// ErrBatchComputationLimitExceeded is returned for scripts of a batch which were not executed because
+// the computation used by the batch reached its limit.
+var ErrBatchComputationLimitExceeded = errors.New("batch computation limit exceeded")
+
+// Script is a Cadence script with its arguments, executed as part of a batch.
+type Script struct {
+	Source    []byte
+	Arguments [][]byte
+}
+
+// ScriptResult is the result of a script executed as part of a batch.
+type ScriptResult struct {
+	// Value is the encoded result of the script, or nil if the script failed.
+	Value []byte
+	// ComputationUsed is the computation used by the script.
+	ComputationUsed uint64
+	// Err is the error returned while executing the script, or nil if the script succeeded.
+	Err error
+}
+
+// ExecuteScriptFunc executes a single script of a batch, returning the encoded result and the
+// computation used by the script. The script must not use more than computationLimit, where 0 means
+// no limit. The computation used by failed scripts must be returned as well.
+type ExecuteScriptFunc func(ctx context.Context, script Script, computationLimit uint64) ([]byte, uint64, error)
+
+// ExecuteBatch executes the scripts of a batch using the provided function, running up to
+// BatchConcurrency scripts concurrently. Results are returned in the order of the scripts.
+//
+// The computation used by all scripts is limited by computationLimit, where 0 means no limit. Each
+// running script reserves the computation it may use out of the remaining budget: scriptComputationLimit,
+// or less if less remains, which is passed to the script as its computation limit. Once a script
+// completes, its reservation is replaced by the computation it actually used, including the
+// computation used by failed scripts. Scripts are started in order, and wait for running scripts to
+// complete if the whole remaining budget is reserved. Once the budget is used, the remaining scripts
+// are not executed and fail with ErrBatchComputationLimitExceeded.
+func ExecuteBatch(
+	ctx context.Context,
+	scripts []Script,
+	computationLimit uint64,
+	scriptComputationLimit uint64,
+	execute ExecuteScriptFunc,
+) []ScriptResult {
+	results := make([]ScriptResult, len(scripts))
+
+	mu := sync.Mutex{}
+	completed := sync.NewCond(&mu)
+	var computationUsed, computationReserved uint64
+
+	// reserve returns the computation limit of the next script, or false if the budget is used.
+	reserve := func() (uint64, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if computationLimit == 0 {
+			return scriptComputationLimit, true
+		}
+		for {
+			if computationUsed >= computationLimit {
+				return 0, false
+			}
+			remaining := computationLimit - computationUsed
+			if remaining > computationReserved {
+				limit := remaining - computationReserved
+				if scriptComputationLimit > 0 && limit > scriptComputationLimit {
+					limit = scriptComputationLimit
+				}
+				computationReserved += limit
+				return limit, true
+			}
+			// the remaining budget is reserved by running scripts
+			completed.Wait()
+		}
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, BatchConcurrency)
+	for i, script := range scripts {
+		workers <- struct{}{}
+
+		limit, ok := reserve()
+		if !ok {
+			<-workers
+			results[i] = ScriptResult{Err: fmt.Errorf("%w (%d)", ErrBatchComputationLimitExceeded, computationLimit)}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, script Script) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			value, used, err := execute(ctx, script, limit)
+			results[i] = ScriptResult{
+				Value:           value,
+				ComputationUsed: used,
+				Err:             err,
+			}
+
+			mu.Lock()
+			if computationLimit > 0 {
+				computationReserved -= limit
+			}
+			computationUsed += used
+			mu.Unlock()
+			completed.Broadcast()
+		}(i, script)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// sharedStorageSnapshot is a storage snapshot caching the register values read from the underlying
+// snapshot. It is safe for concurrent use, so the scripts of a batch executed at the same block only
+// read each register once.
+type sharedStorageSnapshot struct {
+	snapshot snapshot.StorageSnapshot
+
+	mu     sync.RWMutex
+	values map[flow.RegisterID]flow.RegisterValue
+}
+
+var _ snapshot.StorageSnapshot = (*sharedStorageSnapshot)(nil)
+
+func newSharedStorageSnapshot(snapshot snapshot.StorageSnapshot) *sharedStorageSnapshot {
+	return &sharedStorageSnapshot{
+		snapshot: snapshot,
+		values:   make(map[flow.RegisterID]flow.RegisterValue),
+	}
+}
+
+// Get returns the value of the register with the given ID.
+// Any error returned by the underlying snapshot is returned.
+func (s *sharedStorageSnapshot) Get(id flow.RegisterID) (flow.RegisterValue, error) {
+	s.mu.RLock()
+	value, ok := s.values[id]
+	s.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err := s.snapshot.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.values[id] = value
+	s.mu.Unlock()
+
+	return value, nil
+}
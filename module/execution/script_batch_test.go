@@ -0,0 +1,119 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestExecuteBatch(t *testing.T) {
+	scripts := make([]Script, 20)
+	for i := range scripts {
+		scripts[i] = Script{Source: []byte(fmt.Sprintf("script %d", i))}
+	}
+
+	// scripts use up to 30 computation, and the failing script 3 uses its computation as well
+	execute := func(ctx context.Context, script Script, computationLimit uint64) ([]byte, uint64, error) {
+		used := uint64(30)
+		if computationLimit > 0 && computationLimit < used {
+			used = computationLimit
+		}
+		if string(script.Source) == "script 3" {
+			return nil, used, fmt.Errorf("failed")
+		}
+		return script.Source, used, nil
+	}
+
+	t.Run("results are returned in order", func(t *testing.T) {
+		results := ExecuteBatch(context.Background(), scripts, 0, 0, execute)
+		require.Len(t, results, len(scripts))
+
+		for i, result := range results {
+			assert.Equal(t, uint64(30), result.ComputationUsed)
+			if i == 3 {
+				assert.Error(t, result.Err)
+				assert.Nil(t, result.Value)
+				continue
+			}
+			assert.NoError(t, result.Err)
+			assert.Equal(t, scripts[i].Source, result.Value)
+		}
+	})
+
+	t.Run("computation limit", func(t *testing.T) {
+		var mu sync.Mutex
+		executed := 0
+		counting := func(ctx context.Context, script Script, computationLimit uint64) ([]byte, uint64, error) {
+			// each script is limited by the script limit and the remaining budget
+			assert.LessOrEqual(t, computationLimit, uint64(25))
+			assert.NotZero(t, computationLimit)
+
+			mu.Lock()
+			executed++
+			mu.Unlock()
+			return execute(ctx, script, computationLimit)
+		}
+
+		// scripts run concurrently, but never use more than the remaining budget
+		results := ExecuteBatch(context.Background(), scripts, 110, 25, counting)
+		require.Len(t, results, len(scripts))
+
+		var used uint64
+		skipped := 0
+		for _, result := range results {
+			if errors.Is(result.Err, ErrBatchComputationLimitExceeded) {
+				assert.Zero(t, result.ComputationUsed)
+				skipped++
+				continue
+			}
+			used += result.ComputationUsed
+		}
+		assert.Equal(t, uint64(110), used)
+		assert.Equal(t, 5, executed)
+		assert.Equal(t, len(scripts)-executed, skipped)
+	})
+}
+
+func TestSharedStorageSnapshot(t *testing.T) {
+	registerID := flow.NewRegisterID(flow.Address{1}, "key")
+	value := flow.RegisterValue("value")
+
+	var mu sync.Mutex
+	reads := 0
+	shared := newSharedStorageSnapshot(snapshot.NewReadFuncStorageSnapshot(func(id flow.RegisterID) (flow.RegisterValue, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reads++
+		return value, nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actual, err := shared.Get(registerID)
+			assert.NoError(t, err)
+			assert.Equal(t, value, actual)
+		}()
+	}
+	wg.Wait()
+
+	// concurrent reads of an uncached register may all hit the underlying snapshot, later reads don't
+	actual, err := shared.Get(registerID)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	before := reads
+	_, err = shared.Get(registerID)
+	require.NoError(t, err)
+	require.Equal(t, before, reads)
+}
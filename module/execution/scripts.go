@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/rs/zerolog"
 
@@ -37,6 +38,33 @@ type ScriptExecutor interface {
 		height uint64,
 	) ([]byte, error)
 
+	// ExecuteBatchAtBlockHeight executes the provided scripts against the block height, sharing the
+	// register reads of all scripts. Results are returned in the order of the scripts, and failures of
+	// individual scripts are returned in their result.
+	// The computation used by all scripts is limited by computationLimit, where 0 means no limit.
+	// Expected errors:
+	// - storage.ErrNotFound if block at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	ExecuteBatchAtBlockHeight(
+		ctx context.Context,
+		scripts []Script,
+		height uint64,
+		computationLimit uint64,
+	) ([]ScriptResult, error)
+
+	// SimulateTransactionAtBlockHeight executes the provided transaction against the block height
+	// without committing any of its changes. Signature and sequence number checks are skipped if
+	// skipSignatureVerification is set. Failures of the transaction are returned in the result.
+	// Expected errors:
+	// - storage.ErrNotFound if block at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	SimulateTransactionAtBlockHeight(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		height uint64,
+		skipSignatureVerification bool,
+	) (*TransactionSimulation, error)
+
 	// GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
@@ -47,10 +75,20 @@ type ScriptExecutor interface {
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
 	GetAccountBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error)
 
+	// GetAccountAvailableBalance returns the available balance of a Flow account by the provided address and block height.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	GetAccountAvailableBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error)
+
 	// GetAccountKeys returns
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
 	GetAccountKeys(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error)
+
+	// GetAccountKey returns the public key of a Flow account by the provided address, key index and block height.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error)
 }
 
 var _ ScriptExecutor = (*Scripts)(nil)
@@ -59,6 +97,12 @@ type Scripts struct {
 	executor         *query.QueryExecutor
 	headers          storage.Headers
 	registerAtHeight RegisterAtHeight
+
+	// used to simulate transactions
+	chainID flow.ChainID
+	vm      fvm.VM
+	txCtx   fvm.Context
+	entropy query.EntropyProviderPerBlock
 }
 
 func NewScripts(
@@ -71,6 +115,7 @@ func NewScripts(
 	queryConf query.QueryConfig,
 	derivedChainData *derived.DerivedChainData,
 	enableProgramCacheWrites bool,
+	transactionOptions []fvm.Option,
 ) *Scripts {
 	vm := fvm.NewVirtualMachine()
 
@@ -81,6 +126,9 @@ func NewScripts(
 	options = append(options, fvm.WithAllowProgramCacheWritesInScriptsEnabled(enableProgramCacheWrites))
 	vmCtx := fvm.NewContext(options...)
 
+	// transactions are simulated using the options used by execution nodes to execute transactions
+	txCtx := fvm.NewContextFromParent(vmCtx, transactionOptions...)
+
 	queryExecutor := query.NewQueryExecutor(
 		queryConf,
 		log,
@@ -95,6 +143,10 @@ func NewScripts(
 		executor:         queryExecutor,
 		headers:          header,
 		registerAtHeight: registerAtHeight,
+		chainID:          chainID,
+		vm:               vm,
+		txCtx:            txCtx,
+		entropy:          entropy,
 	}
 }
 
@@ -118,10 +170,73 @@ func (s *Scripts) ExecuteAtBlockHeight(
 
 	value, compUsage, err := s.executor.ExecuteScript(ctx, script, arguments, header, snap)
 	// TODO: return compUsage when upstream can handle it
-	_ = compUsage
+	ReportComputation(ctx, compUsage)
 	return value, err
 }
 
+// ExecuteBatchAtBlockHeight executes the provided scripts against the block height, sharing the
+// register reads of all scripts. Results are returned in the order of the scripts, and failures of
+// individual scripts are returned in their result.
+// The computation used by all scripts is limited by computationLimit, where 0 means no limit.
+// Expected errors:
+// - storage.ErrNotFound if block at height was not found.
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) ExecuteBatchAtBlockHeight(
+	ctx context.Context,
+	scripts []Script,
+	height uint64,
+	computationLimit uint64,
+) ([]ScriptResult, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	shared := newSharedStorageSnapshot(snap)
+
+	return ExecuteBatch(ctx, scripts, computationLimit, s.executor.ComputationLimit(), func(ctx context.Context, script Script, limit uint64) ([]byte, uint64, error) {
+		ctx = query.WithComputationLimit(ctx, limit)
+		value, compUsage, err := s.executor.ExecuteScript(ctx, script.Source, script.Arguments, header, shared)
+		ReportComputation(ctx, compUsage)
+		return value, compUsage, err
+	}), nil
+}
+
+// SimulateTransactionAtBlockHeight executes the provided transaction against the block height
+// without committing any of its changes. Signature and sequence number checks are skipped if
+// skipSignatureVerification is set. Failures of the transaction are returned in the result.
+// Expected errors:
+// - storage.ErrNotFound if block at height was not found.
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) SimulateTransactionAtBlockHeight(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	height uint64,
+	skipSignatureVerification bool,
+) (*TransactionSimulation, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	// transactions update the derived data of the block, so the cached derived data used by
+	// scripts must not be shared.
+	txCtx := fvm.NewContextFromParent(
+		s.txCtx,
+		fvm.WithBlockHeader(header),
+		fvm.WithEntropyProvider(s.entropy.AtBlockID(header.ID())),
+		fvm.WithDerivedBlockData(derived.NewEmptyDerivedBlockData(0)),
+		fvm.WithAuthorizationChecksEnabled(!skipSignatureVerification),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(!skipSignatureVerification),
+	)
+
+	executionSnapshot, output, err := s.vm.Run(txCtx, fvm.Transaction(tx, 0), snap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction (internal error): %w", err)
+	}
+
+	return newTransactionSimulation(s.chainID, snap, executionSnapshot, output)
+}
+
 // GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 // Expected errors:
 // - Script execution related errors
@@ -148,6 +263,19 @@ func (s *Scripts) GetAccountBalance(ctx context.Context, address flow.Address, h
 	return s.executor.GetAccountBalance(ctx, address, header, snap)
 }
 
+// GetAccountAvailableBalance returns an available balance of Flow account by the provided address and block height.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) GetAccountAvailableBalance(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.executor.GetAccountAvailableBalance(ctx, address, header, snap)
+}
+
 // GetAccountKeys returns a public keys of Flow account by the provided address and block height.
 // Expected errors:
 // - Script execution related errors
@@ -161,6 +289,19 @@ func (s *Scripts) GetAccountKeys(ctx context.Context, address flow.Address, heig
 	return s.executor.GetAccountKeys(ctx, address, header, snap)
 }
 
+// GetAccountKey returns a public key of Flow account by the provided address, key index and block height.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.GetAccountKey(ctx, address, keyIndex, header, snap)
+}
+
 // snapshotWithBlock is a common function for executing scripts and get account functionality.
 // It creates a storage snapshot that is needed by the FVM to execute scripts.
 func (s *Scripts) snapshotWithBlock(height uint64) (snapshot.StorageSnapshot, *flow.Header, error) {
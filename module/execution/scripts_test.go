@@ -112,6 +112,93 @@ func (s *scriptTestSuite) TestScriptExecution() {
 	})
 }
 
+func (s *scriptTestSuite) TestScriptBatchExecution() {
+	s.Run("Results are returned in order", func() {
+		arg, err := jsoncdc.Encode(cadence.NewInt(2))
+		s.Require().NoError(err)
+
+		scripts := []Script{
+			{Source: []byte("access(all) fun main(): Int { return 42 }")},
+			{Source: []byte("access(all) fun main(foo: Int): Int { return foo }"), Arguments: [][]byte{arg}},
+			{Source: []byte("access(all) fun main() { panic(\"!!\") }")},
+		}
+
+		results, err := s.scripts.ExecuteBatchAtBlockHeight(context.Background(), scripts, s.height, 0)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		val, err := jsoncdc.Decode(nil, results[0].Value)
+		s.Require().NoError(err)
+		s.Assert().Equal(int64(42), val.(cadence.Int).Value.Int64())
+		s.Assert().NotZero(results[0].ComputationUsed)
+
+		s.Assert().Equal(arg, results[1].Value)
+
+		s.Assert().Nil(results[2].Value)
+		s.Assert().Error(results[2].Err)
+	})
+
+	s.Run("Scripts are limited by the remaining computation", func() {
+		loop := []byte(`access(all) fun main() {
+			var i = 0
+			while i < 100000 { i = i + 1 }
+		}`)
+		scripts := []Script{{Source: loop}, {Source: loop}}
+
+		results, err := s.scripts.ExecuteBatchAtBlockHeight(context.Background(), scripts, s.height, 50)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		// the failed script is charged for the computation it used
+		var coded errors.CodedError
+		s.Require().True(errors.As(results[0].Err, &coded))
+		s.Assert().Equal(errors.ErrCodeComputationLimitExceededError, coded.Code())
+		s.Assert().NotZero(results[0].ComputationUsed)
+
+		s.Assert().ErrorIs(results[1].Err, ErrBatchComputationLimitExceeded)
+	})
+}
+
+func (s *scriptTestSuite) TestSimulateTransaction() {
+	const createAccountTransaction = `
+		transaction {
+		  prepare(signer: auth(Storage, Capabilities) &Account) {
+			let account = Account(payer: signer)
+		  }
+		}`
+
+	txBody := flow.NewTransactionBody().
+		SetScript([]byte(createAccountTransaction)).
+		SetPayer(s.chain.ServiceAddress()).
+		SetProposalKey(s.chain.ServiceAddress(), 0, 0).
+		AddAuthorizer(s.chain.ServiceAddress())
+
+	s.Run("Signature verification skipped", func() {
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), txBody, s.height, true)
+		s.Require().NoError(err)
+		s.Require().NoError(result.Err)
+
+		var accountCreated bool
+		for _, event := range result.Events {
+			accountCreated = accountCreated || event.Type == flow.EventAccountCreated
+		}
+		s.Assert().True(accountCreated)
+		s.Assert().NotZero(result.ComputationUsed)
+		s.Assert().NotZero(result.MemoryEstimate)
+		s.Assert().NotEmpty(result.StorageDelta)
+
+		// fees are not enabled on the test chain
+		s.Assert().Equal(TransactionFees{}, result.Fees)
+	})
+
+	s.Run("Missing signatures", func() {
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), txBody, s.height, false)
+		s.Require().NoError(err)
+		s.Require().Error(result.Err)
+		s.Assert().Empty(result.StorageDelta)
+	})
+}
+
 func (s *scriptTestSuite) TestGetAccount() {
 	s.Run("Get Service Account", func() {
 		address := s.chain.ServiceAddress()
@@ -154,6 +241,32 @@ func (s *scriptTestSuite) TestGetAccountKeys() {
 
 }
 
+func (s *scriptTestSuite) TestGetAccountAvailableBalance() {
+	address := s.createAccount()
+	var transferAmount uint64 = 100000000
+	s.transferTokens(address, transferAmount)
+	availableBalance, err := s.scripts.GetAccountAvailableBalance(context.Background(), address, s.height)
+	s.Require().NoError(err)
+	s.Assert().NotZero(availableBalance)
+	s.Assert().LessOrEqual(availableBalance, transferAmount)
+}
+
+func (s *scriptTestSuite) TestGetAccountKey() {
+	address := s.createAccount()
+	publicKey := s.addAccountKey(address, accountKeyAPIVersionV2)
+
+	accountKey, err := s.scripts.GetAccountKey(context.Background(), address, 0, s.height)
+	s.Require().NoError(err)
+	s.Assert().Equal(publicKey.PublicKey, accountKey.PublicKey)
+	s.Assert().Equal(publicKey.SignAlgo, accountKey.SignAlgo)
+	s.Assert().Equal(publicKey.HashAlgo, accountKey.HashAlgo)
+	s.Assert().Equal(publicKey.Weight, accountKey.Weight)
+
+	_, err = s.scripts.GetAccountKey(context.Background(), address, 1, s.height)
+	s.Require().Error(err)
+	s.Assert().True(errors.IsAccountPublicKeyNotFoundError(err))
+}
+
 func (s *scriptTestSuite) SetupTest() {
 	logger := unittest.LoggerForTest(s.Suite.T(), zerolog.InfoLevel)
 	entropyProvider := testutil.EntropyProviderFixture(nil)
@@ -211,6 +324,7 @@ func (s *scriptTestSuite) SetupTest() {
 		query.NewDefaultConfig(),
 		derivedChainData,
 		true,
+		nil,
 	)
 
 	s.bootstrap()
@@ -0,0 +1,166 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/environment"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionSimulation is the result of a transaction executed against the execution state
+// without committing any of its changes.
+type TransactionSimulation struct {
+	// Events are the events emitted by the transaction. If the transaction failed, this only
+	// contains the fee deduction events.
+	Events flow.EventsList
+	// Err is the error returned by the transaction, or nil if the transaction succeeded.
+	Err fvmerrors.CodedError
+	// ComputationUsed is the computation used by the transaction.
+	ComputationUsed uint64
+	// MemoryEstimate is the estimated memory used by the transaction.
+	MemoryEstimate uint64
+	// StorageDelta is the change of the storage used by each account whose storage was updated
+	// by the transaction, in bytes.
+	StorageDelta map[flow.Address]int64
+	// Fees are the fees deducted for the transaction.
+	Fees TransactionFees
+}
+
+// TransactionFees is the breakdown of the fees deducted for a transaction. All values are
+// UFix64 encoded.
+type TransactionFees struct {
+	Amount          uint64
+	InclusionEffort uint64
+	ExecutionEffort uint64
+}
+
+// newTransactionSimulation creates the result of a simulated transaction from the output of the
+// FVM and the execution snapshot of the transaction.
+//
+// No errors are expected during normal operation.
+func newTransactionSimulation(
+	chainID flow.ChainID,
+	storageSnapshot snapshot.StorageSnapshot,
+	executionSnapshot *snapshot.ExecutionSnapshot,
+	output fvm.ProcedureOutput,
+) (*TransactionSimulation, error) {
+	storageDelta, err := storageDeltas(storageSnapshot, executionSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := deductedFees(chainID, output.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionSimulation{
+		Events:          output.Events,
+		Err:             output.Err,
+		ComputationUsed: output.ComputationUsed,
+		MemoryEstimate:  output.MemoryEstimate,
+		StorageDelta:    storageDelta,
+		Fees:            fees,
+	}, nil
+}
+
+// storageDeltas returns the change of the storage used by each account whose status register
+// was updated, comparing the status before and after the transaction.
+//
+// No errors are expected during normal operation.
+func storageDeltas(
+	storageSnapshot snapshot.StorageSnapshot,
+	executionSnapshot *snapshot.ExecutionSnapshot,
+) (map[flow.Address]int64, error) {
+	deltas := make(map[flow.Address]int64)
+	for id, value := range executionSnapshot.WriteSet {
+		if id.Key != flow.AccountStatusKey {
+			continue
+		}
+
+		after, err := storageUsed(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode updated status of account %x: %w", id.Owner, err)
+		}
+
+		oldValue, err := storageSnapshot.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of account %x: %w", id.Owner, err)
+		}
+
+		before, err := storageUsed(oldValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode status of account %x: %w", id.Owner, err)
+		}
+
+		if delta := int64(after) - int64(before); delta != 0 {
+			deltas[flow.BytesToAddress([]byte(id.Owner))] = delta
+		}
+	}
+
+	return deltas, nil
+}
+
+// storageUsed returns the storage used by an account from its encoded status. Accounts without
+// a status (e.g. accounts created by the transaction) use no storage.
+func storageUsed(status flow.RegisterValue) (uint64, error) {
+	if len(status) == 0 {
+		return 0, nil
+	}
+
+	accountStatus, err := environment.AccountStatusFromBytes(status)
+	if err != nil {
+		return 0, err
+	}
+
+	return accountStatus.StorageUsed(), nil
+}
+
+// deductedFees returns the fees deducted for a transaction from its FeesDeducted event. If fees
+// are disabled for the chain, no event is emitted and empty fees are returned.
+//
+// No errors are expected during normal operation.
+func deductedFees(chainID flow.ChainID, events flow.EventsList) (TransactionFees, error) {
+	sc := systemcontracts.SystemContractsForChain(chainID)
+	feesDeductedType := flow.EventType(fmt.Sprintf("A.%s.%s.FeesDeducted", sc.FlowFees.Address.Hex(), systemcontracts.ContractNameFlowFees))
+
+	for _, event := range events {
+		if event.Type != feesDeductedType {
+			continue
+		}
+
+		data, err := ccf.Decode(nil, event.Payload)
+		if err != nil {
+			return TransactionFees{}, fmt.Errorf("failed to decode fees deducted event: %w", err)
+		}
+
+		cadenceEvent, ok := data.(cadence.Event)
+		if !ok {
+			return TransactionFees{}, fmt.Errorf("unexpected fees deducted event payload type: %T", data)
+		}
+
+		var fees TransactionFees
+		for name, field := range map[string]*uint64{
+			"amount":          &fees.Amount,
+			"inclusionEffort": &fees.InclusionEffort,
+			"executionEffort": &fees.ExecutionEffort,
+		} {
+			value, ok := cadence.SearchFieldByName(cadenceEvent, name).(cadence.UFix64)
+			if !ok {
+				return TransactionFees{}, fmt.Errorf("fees deducted event has no %s field of type UFix64", name)
+			}
+			*field = uint64(value)
+		}
+
+		return fees, nil
+	}
+
+	return TransactionFees{}, nil
+}
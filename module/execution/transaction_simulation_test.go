@@ -0,0 +1,91 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestDeductedFees(t *testing.T) {
+	chainID := flow.Testnet
+	sc := systemcontracts.SystemContractsForChain(chainID)
+
+	feesDeductedType := &cadence.EventType{
+		Location:            common.NewAddressLocation(nil, common.Address(sc.FlowFees.Address), systemcontracts.ContractNameFlowFees),
+		QualifiedIdentifier: "FlowFees.FeesDeducted",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "inclusionEffort", Type: cadence.UFix64Type},
+			{Identifier: "executionEffort", Type: cadence.UFix64Type},
+		},
+	}
+	payload, err := ccf.Encode(cadence.NewEvent([]cadence.Value{
+		cadence.UFix64(1_000),
+		cadence.UFix64(100_000_000),
+		cadence.UFix64(2_000),
+	}).WithType(feesDeductedType))
+	require.NoError(t, err)
+
+	events := flow.EventsList{
+		unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0),
+		{
+			Type:    flow.EventType(feesDeductedType.ID()),
+			Payload: payload,
+		},
+	}
+
+	t.Run("fees deducted", func(t *testing.T) {
+		fees, err := deductedFees(chainID, events)
+		require.NoError(t, err)
+		assert.Equal(t, TransactionFees{
+			Amount:          1_000,
+			InclusionEffort: 100_000_000,
+			ExecutionEffort: 2_000,
+		}, fees)
+	})
+
+	t.Run("fees disabled", func(t *testing.T) {
+		fees, err := deductedFees(chainID, events[:1])
+		require.NoError(t, err)
+		assert.Equal(t, TransactionFees{}, fees)
+	})
+}
+
+func TestStorageDeltas(t *testing.T) {
+	existing := unittest.RandomAddressFixture()
+	created := unittest.RandomAddressFixture()
+
+	accountStatus := func(storageUsed uint64) flow.RegisterValue {
+		status := environment.NewAccountStatus()
+		status.SetStorageUsed(storageUsed)
+		return status.ToBytes()
+	}
+
+	storageSnapshot := snapshot.MapStorageSnapshot{
+		flow.AccountStatusRegisterID(existing): accountStatus(100),
+	}
+	executionSnapshot := &snapshot.ExecutionSnapshot{
+		WriteSet: map[flow.RegisterID]flow.RegisterValue{
+			flow.AccountStatusRegisterID(existing): accountStatus(80),
+			flow.AccountStatusRegisterID(created):  accountStatus(50),
+			flow.NewRegisterID(existing, "key"):    []byte("value"),
+		},
+	}
+
+	deltas, err := storageDeltas(storageSnapshot, executionSnapshot)
+	require.NoError(t, err)
+	assert.Equal(t, map[flow.Address]int64{
+		existing: -20,
+		created:  50,
+	}, deltas)
+}
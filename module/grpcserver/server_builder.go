@@ -29,6 +29,19 @@ func WithStreamInterceptor() Option {
 	}
 }
 
+// WithServerInterceptors adds unary and stream interceptors to the grpc server, which are called after the
+// metrics and rate limit interceptors. Either interceptor may be nil.
+func WithServerInterceptors(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) Option {
+	return func(c *GrpcServerBuilder) {
+		if unary != nil {
+			c.unaryInterceptors = append(c.unaryInterceptors, unary)
+		}
+		if stream != nil {
+			c.streamInterceptors = append(c.streamInterceptors, stream)
+		}
+	}
+}
+
 // GrpcServerBuilder created for separating the creation and starting GrpcServer,
 // cause services need to be registered before the server starts.
 type GrpcServerBuilder struct {
@@ -39,6 +52,8 @@ type GrpcServerBuilder struct {
 
 	transportCredentials         credentials.TransportCredentials // the GRPC credentials
 	stateStreamInterceptorEnable bool
+	unaryInterceptors            []grpc.UnaryServerInterceptor  // additional unary interceptors
+	streamInterceptors           []grpc.StreamServerInterceptor // additional stream interceptors
 }
 
 // NewGrpcServerBuilder creates a new builder for configuring and initializing a gRPC server.
@@ -84,7 +99,8 @@ func NewGrpcServerBuilder(log zerolog.Logger,
 		grpc.MaxRecvMsgSize(int(maxMsgSize)),
 		grpc.MaxSendMsgSize(int(maxMsgSize)),
 	}
-	var interceptors []grpc.UnaryServerInterceptor // ordered list of interceptors
+	var interceptors []grpc.UnaryServerInterceptor        // ordered list of interceptors
+	var streamInterceptors []grpc.StreamServerInterceptor // ordered list of stream interceptors
 	// This interceptor is responsible for ensuring that irrecoverable errors are properly propagated using
 	// the irrecoverable.SignalerContext. It replaces the original gRPC context with a new one that includes
 	// the irrecoverable.SignalerContextKey if available, allowing the server to handle error conditions indicating
@@ -112,7 +128,7 @@ func NewGrpcServerBuilder(log zerolog.Logger,
 			// rate limiting is done in the handler, and we don't need log events for every message as
 			// that would be too noisy.
 			log.Info().Msg("stateStreamInterceptorEnable true")
-			grpcOpts = append(grpcOpts, grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor))
+			streamInterceptors = append(streamInterceptors, grpc_prometheus.StreamServerInterceptor)
 		} else {
 			log.Info().Msg("stateStreamInterceptorEnable false")
 		}
@@ -123,6 +139,11 @@ func NewGrpcServerBuilder(log zerolog.Logger,
 		// append the rate limit interceptor to the list of interceptors
 		interceptors = append(interceptors, rateLimitInterceptor)
 	}
+	interceptors = append(interceptors, grpcServerBuilder.unaryInterceptors...)
+	streamInterceptors = append(streamInterceptors, grpcServerBuilder.streamInterceptors...)
+	if len(streamInterceptors) > 0 {
+		grpcOpts = append(grpcOpts, grpc.ChainStreamInterceptor(streamInterceptors...))
+	}
 	// add the logging interceptor, ensure it is innermost wrapper
 	interceptors = append(interceptors, rpc.LoggingInterceptor(log)...)
 	// create a chained unary interceptor
@@ -756,6 +756,10 @@ type LedgerMetrics interface {
 type WALMetrics interface {
 	// ExecutionCheckpointSize reports the size of a checkpoint in bytes
 	ExecutionCheckpointSize(bytes uint64)
+
+	// ExecutionCheckpointLoadProgress reports the number of bytes read of the checkpoint
+	// being loaded, and the total size of the checkpoint in bytes
+	ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64)
 }
 
 type RateLimitedBlockstoreMetrics interface {
@@ -1000,6 +1004,42 @@ type ExecutionMetrics interface {
 	UpdateCollectionMaxHeight(height uint64)
 }
 
+// NodeSelectionMetrics tracks the health of upstream nodes used to select the nodes requests are sent to.
+type NodeSelectionMetrics interface {
+	// NodeStatsUpdated records the moving averages of the latency and error rate of an upstream node
+	NodeStatsUpdated(nodeID flow.Identifier, latency time.Duration, errorRate float64)
+
+	// NodeCircuitBreakerOpen records a request to an upstream node rejected by its open circuit breaker
+	NodeCircuitBreakerOpen(nodeID flow.Identifier)
+}
+
+// RequestHedgingMetrics tracks the hedging of read-only requests to upstream nodes.
+type RequestHedgingMetrics interface {
+	// HedgedRequestIssued records a request sent to a second node because the first node was slow
+	HedgedRequestIssued()
+
+	// HedgedRequestWon records a hedged request which completed before the original request
+	HedgedRequestWon()
+
+	// HedgingBudgetExhausted records a request which was not hedged because the hedging budget was exhausted
+	HedgingBudgetExhausted()
+}
+
+// ClientQuotaMetrics tracks the usage of the per-client quotas of the access API, labeled by quota tier.
+type ClientQuotaMetrics interface {
+	// QuotaRequestAllowed records a request of a client of the tier which was within its quotas
+	QuotaRequestAllowed(tier string)
+
+	// QuotaRequestRejected records a request of a client of the tier rejected because the given quota was exceeded
+	QuotaRequestRejected(tier string, quota string)
+
+	// QuotaStreamsChanged records a change of the number of streams opened by clients of the tier
+	QuotaStreamsChanged(tier string, delta int)
+
+	// QuotaScriptComputationUsed records the computation used by scripts executed by clients of the tier
+	QuotaScriptComputationUsed(tier string, computation uint64)
+}
+
 type BackendScriptsMetrics interface {
 	// ScriptExecuted records the round trip time while executing a script
 	ScriptExecuted(dur time.Duration, size int)
@@ -1029,6 +1069,12 @@ type BackendScriptsMetrics interface {
 	// ScriptExecutionNotIndexed records script execution matches where data for the block is not
 	// indexed locally yet
 	ScriptExecutionNotIndexed()
+
+	// ScriptResultCacheHit records a script execution served from the script result cache
+	ScriptResultCacheHit()
+
+	// ScriptResultCacheMiss records a script execution that was not found in the script result cache
+	ScriptResultCacheMiss()
 }
 
 type TransactionMetrics interface {
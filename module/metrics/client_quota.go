@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// ClientQuotaCollector tracks the usage of the per-client quotas of the access API by quota tier.
+type ClientQuotaCollector struct {
+	requestsAllowed   *prometheus.CounterVec
+	requestsRejected  *prometheus.CounterVec
+	activeStreams     *prometheus.GaugeVec
+	scriptComputation *prometheus.CounterVec
+}
+
+var _ module.ClientQuotaMetrics = (*ClientQuotaCollector)(nil)
+
+func NewClientQuotaCollector() *ClientQuotaCollector {
+	return &ClientQuotaCollector{
+		requestsAllowed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_allowed_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientQuota,
+			Help:      "counter for the number of requests of clients which were within their quotas",
+		}, []string{LabelQuotaTier}),
+		requestsRejected: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_rejected_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientQuota,
+			Help:      "counter for the number of requests of clients rejected because a quota was exceeded",
+		}, []string{LabelQuotaTier, LabelQuota}),
+		activeStreams: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "active_streams",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientQuota,
+			Help:      "the number of streams currently opened by clients",
+		}, []string{LabelQuotaTier}),
+		scriptComputation: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "script_computation_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientQuota,
+			Help:      "counter for the computation used by scripts executed by clients",
+		}, []string{LabelQuotaTier}),
+	}
+}
+
+func (qc *ClientQuotaCollector) QuotaRequestAllowed(tier string) {
+	qc.requestsAllowed.WithLabelValues(tier).Inc()
+}
+
+func (qc *ClientQuotaCollector) QuotaRequestRejected(tier string, quota string) {
+	qc.requestsRejected.WithLabelValues(tier, quota).Inc()
+}
+
+func (qc *ClientQuotaCollector) QuotaStreamsChanged(tier string, delta int) {
+	qc.activeStreams.WithLabelValues(tier).Add(float64(delta))
+}
+
+func (qc *ClientQuotaCollector) QuotaScriptComputationUsed(tier string, computation uint64) {
+	qc.scriptComputation.WithLabelValues(tier).Add(float64(computation))
+}
@@ -23,6 +23,8 @@ type ExecutionCollector struct {
 	stateStorageDiskTotal                   prometheus.Gauge
 	storageStateCommitment                  prometheus.Gauge
 	checkpointSize                          prometheus.Gauge
+	checkpointLoadReadBytes                 prometheus.Gauge
+	checkpointLoadTotalBytes                prometheus.Gauge
 	forestApproxMemorySize                  prometheus.Gauge
 	forestNumberOfTrees                     prometheus.Gauge
 	latestTrieRegCount                      prometheus.Gauge
@@ -666,6 +668,20 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 			Help:      "the size of a checkpoint in bytes",
 		}),
 
+		checkpointLoadReadBytes: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "checkpoint_load_read_bytes",
+			Help:      "the number of bytes read of the checkpoint being loaded",
+		}),
+
+		checkpointLoadTotalBytes: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "checkpoint_load_total_bytes",
+			Help:      "the size in bytes of the checkpoint being loaded",
+		}),
+
 		stateSyncActive: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemIngestion,
@@ -805,6 +821,13 @@ func (ec *ExecutionCollector) ExecutionCheckpointSize(bytes uint64) {
 	ec.checkpointSize.Set(float64(bytes))
 }
 
+// ExecutionCheckpointLoadProgress reports the number of bytes read of the checkpoint being loaded,
+// and the total size of the checkpoint in bytes
+func (ec *ExecutionCollector) ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64) {
+	ec.checkpointLoadReadBytes.Set(float64(bytesRead))
+	ec.checkpointLoadTotalBytes.Set(float64(bytesTotal))
+}
+
 // ExecutionLastExecutedBlockHeight reports last executed block height
 func (ec *ExecutionCollector) ExecutionLastExecutedBlockHeight(height uint64) {
 	ec.lastExecutedBlockHeightGauge.Set(float64(height))
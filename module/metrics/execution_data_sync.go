@@ -217,11 +217,25 @@ type ExecutionDataPrunerCollector struct {
 }
 
 func NewExecutionDataPrunerCollector() *ExecutionDataPrunerCollector {
+	return newPrunerCollector(namespaceExecutionDataSync, subsystemExeDataPruner)
+}
+
+// NewRegistersPrunerCollector returns a pruner collector for the access node's register db pruner.
+func NewRegistersPrunerCollector() *ExecutionDataPrunerCollector {
+	return newPrunerCollector(namespaceAccess, subsystemRegistersPruner)
+}
+
+// NewBlockDataPrunerCollector returns a pruner collector for the protocol database block data pruner.
+func NewBlockDataPrunerCollector() *ExecutionDataPrunerCollector {
+	return newPrunerCollector(namespaceStorage, subsystemBlockDataPruner)
+}
+
+func newPrunerCollector(namespace string, subsystem string) *ExecutionDataPrunerCollector {
 	return &ExecutionDataPrunerCollector{
 		pruneDurations: promauto.NewSummary(prometheus.SummaryOpts{
 			Name:      "prune_durations_ms",
-			Namespace: namespaceExecutionDataSync,
-			Subsystem: subsystemExeDataPruner,
+			Namespace: namespace,
+			Subsystem: subsystem,
 			Help:      "the durations of pruning in milliseconds",
 			Objectives: map[float64]float64{
 				0.01: 0.001,
@@ -235,8 +249,8 @@ func NewExecutionDataPrunerCollector() *ExecutionDataPrunerCollector {
 		}),
 		latestHeightPruned: promauto.NewGauge(prometheus.GaugeOpts{
 			Name:      "latest_height_pruned",
-			Namespace: namespaceExecutionDataSync,
-			Subsystem: subsystemExeDataPruner,
+			Namespace: namespace,
+			Subsystem: subsystem,
 			Help:      "the latest height pruned",
 		}),
 	}
@@ -159,3 +159,5 @@ const ExecutionDataRequestRetryable = "retryable"
 
 const LabelViolationReason = "reason"
 const LabelRateLimitReason = "reason"
+const LabelQuotaTier = "tier"
+const LabelQuota = "quota"
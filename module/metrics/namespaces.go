@@ -45,6 +45,9 @@ const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
 	subsystemConnectionPool        = "connection_pool"
+	subsystemNodeSelection         = "node_selection"
+	subsystemRequestHedging        = "request_hedging"
+	subsystemClientQuota           = "client_quota"
 	subsystemHTTP                  = "http"
 )
 
@@ -93,6 +96,8 @@ const (
 	subsystemExeDataPruner          = "pruner"
 	subsystemExecutionDataRequester = "execution_data_requester"
 	subsystemExecutionStateIndexer  = "execution_state_indexer"
+	subsystemRegistersPruner        = "registers_pruner"
+	subsystemBlockDataPruner        = "block_data_pruner"
 	subsystemExeDataBlobstore       = "blobstore"
 )
 
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// NodeSelectionCollector tracks the health of the upstream nodes requests are sent to.
+type NodeSelectionCollector struct {
+	nodeLatency            *prometheus.GaugeVec
+	nodeErrorRate          *prometheus.GaugeVec
+	nodeCircuitBreakerOpen *prometheus.CounterVec
+}
+
+var _ module.NodeSelectionMetrics = (*NodeSelectionCollector)(nil)
+
+func NewNodeSelectionCollector() *NodeSelectionCollector {
+	return &NodeSelectionCollector{
+		nodeLatency: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "node_latency_seconds",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemNodeSelection,
+			Help:      "moving average of the latency of successful requests to an upstream node",
+		}, []string{LabelNodeID}),
+		nodeErrorRate: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "node_error_rate",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemNodeSelection,
+			Help:      "moving average of the ratio of failed requests to an upstream node",
+		}, []string{LabelNodeID}),
+		nodeCircuitBreakerOpen: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "node_circuit_breaker_open_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemNodeSelection,
+			Help:      "counter for the number of requests to an upstream node rejected by its open circuit breaker",
+		}, []string{LabelNodeID}),
+	}
+}
+
+func (nc *NodeSelectionCollector) NodeStatsUpdated(nodeID flow.Identifier, latency time.Duration, errorRate float64) {
+	nc.nodeLatency.WithLabelValues(nodeID.String()).Set(latency.Seconds())
+	nc.nodeErrorRate.WithLabelValues(nodeID.String()).Set(errorRate)
+}
+
+func (nc *NodeSelectionCollector) NodeCircuitBreakerOpen(nodeID flow.Identifier) {
+	nc.nodeCircuitBreakerOpen.WithLabelValues(nodeID.String()).Inc()
+}
@@ -36,6 +36,9 @@ func (nc *NoopCollector) BlockProposalDuration(duration time.Duration)
 
 // interface check
 var _ module.BackendScriptsMetrics = (*NoopCollector)(nil)
+var _ module.NodeSelectionMetrics = (*NoopCollector)(nil)
+var _ module.RequestHedgingMetrics = (*NoopCollector)(nil)
+var _ module.ClientQuotaMetrics = (*NoopCollector)(nil)
 var _ module.TransactionMetrics = (*NoopCollector)(nil)
 var _ module.HotstuffMetrics = (*NoopCollector)(nil)
 var _ module.EngineMetrics = (*NoopCollector)(nil)
@@ -162,6 +165,7 @@ func (nc *NoopCollector) FinishBlockReceivedToExecuted(blockID flow.Identifier)
 func (nc *NoopCollector) ExecutionComputationUsedPerBlock(computation uint64)                  {}
 func (nc *NoopCollector) ExecutionStorageStateCommitment(bytes int64)                          {}
 func (nc *NoopCollector) ExecutionCheckpointSize(bytes uint64)                                 {}
+func (nc *NoopCollector) ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64)  {}
 func (nc *NoopCollector) ExecutionLastExecutedBlockHeight(height uint64)                       {}
 func (nc *NoopCollector) ExecutionLastFinalizedExecutedBlockHeight(height uint64)              {}
 func (nc *NoopCollector) ExecutionBlockExecuted(_ time.Duration, _ module.BlockExecutionResultStats) {
@@ -207,6 +211,17 @@ func (nc *NoopCollector) ScriptExecutionResultMatch()
 func (nc *NoopCollector) ScriptExecutionErrorMismatch()                                         {}
 func (nc *NoopCollector) ScriptExecutionErrorMatch()                                            {}
 func (nc *NoopCollector) ScriptExecutionNotIndexed()                                            {}
+func (nc *NoopCollector) ScriptResultCacheHit()                                                 {}
+func (nc *NoopCollector) ScriptResultCacheMiss()                                                {}
+func (nc *NoopCollector) NodeStatsUpdated(flow.Identifier, time.Duration, float64)              {}
+func (nc *NoopCollector) NodeCircuitBreakerOpen(flow.Identifier)                                {}
+func (nc *NoopCollector) HedgedRequestIssued()                                                  {}
+func (nc *NoopCollector) HedgedRequestWon()                                                     {}
+func (nc *NoopCollector) HedgingBudgetExhausted()                                               {}
+func (nc *NoopCollector) QuotaRequestAllowed(string)                                            {}
+func (nc *NoopCollector) QuotaRequestRejected(string, string)                                   {}
+func (nc *NoopCollector) QuotaStreamsChanged(string, int)                                       {}
+func (nc *NoopCollector) QuotaScriptComputationUsed(string, uint64)                             {}
 func (nc *NoopCollector) TransactionResultFetched(dur time.Duration, size int)                  {}
 func (nc *NoopCollector) TransactionReceived(txID flow.Identifier, when time.Time)              {}
 func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Time)             {}
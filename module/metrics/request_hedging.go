@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// RequestHedgingCollector tracks the hedging of read-only requests to upstream nodes.
+type RequestHedgingCollector struct {
+	hedgesIssued    prometheus.Counter
+	hedgesWon       prometheus.Counter
+	budgetExhausted prometheus.Counter
+}
+
+var _ module.RequestHedgingMetrics = (*RequestHedgingCollector)(nil)
+
+func NewRequestHedgingCollector() *RequestHedgingCollector {
+	return &RequestHedgingCollector{
+		hedgesIssued: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "hedges_issued_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRequestHedging,
+			Help:      "counter for the number of requests sent to a second upstream node because the first node was slow",
+		}),
+		hedgesWon: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "hedges_won_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRequestHedging,
+			Help:      "counter for the number of hedged requests which completed before the original request",
+		}),
+		budgetExhausted: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "budget_exhausted_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRequestHedging,
+			Help:      "counter for the number of requests which were not hedged because the hedging budget was exhausted",
+		}),
+	}
+}
+
+func (rc *RequestHedgingCollector) HedgedRequestIssued() {
+	rc.hedgesIssued.Inc()
+}
+
+func (rc *RequestHedgingCollector) HedgedRequestWon() {
+	rc.hedgesWon.Inc()
+}
+
+func (rc *RequestHedgingCollector) HedgingBudgetExhausted() {
+	rc.budgetExhausted.Inc()
+}
@@ -26,6 +26,7 @@ type TransactionCollector struct {
 	scriptExecutedDuration         *prometheus.HistogramVec
 	scriptExecutionErrorOnExecutor *prometheus.CounterVec
 	scriptExecutionComparison      *prometheus.CounterVec
+	scriptResultCache              *prometheus.CounterVec
 	scriptSize                     prometheus.Histogram
 	transactionResultDuration      *prometheus.HistogramVec
 }
@@ -116,6 +117,12 @@ func NewTransactionCollector(
 			Subsystem: subsystemTransactionSubmission,
 			Help:      "counter for the comparison outcomes of executing a script locally and on execution node",
 		}, []string{"outcome"}),
+		scriptResultCache: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "script_result_cache",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemTransactionSubmission,
+			Help:      "counter for the hits/misses of the cache of locally executed script results",
+		}, []string{"result"}),
 		transactionResultDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
 			Name:      "transaction_result_fetched_duration",
 			Namespace: namespaceAccess,
@@ -185,6 +192,16 @@ func (tc *TransactionCollector) ScriptExecutionNotIndexed() {
 	tc.scriptExecutionComparison.WithLabelValues("not_indexed").Inc()
 }
 
+// ScriptResultCacheHit records a script execution served from the script result cache
+func (tc *TransactionCollector) ScriptResultCacheHit() {
+	tc.scriptResultCache.WithLabelValues("hit").Inc()
+}
+
+// ScriptResultCacheMiss records a script execution that was not found in the script result cache
+func (tc *TransactionCollector) ScriptResultCacheMiss() {
+	tc.scriptResultCache.WithLabelValues("miss").Inc()
+}
+
 // TransactionResult metrics
 
 func (tc *TransactionCollector) TransactionResultFetched(dur time.Duration, size int) {
@@ -108,6 +108,16 @@ func (_m *AccessMetrics) ScriptExecutionResultMismatch() {
 	_m.Called()
 }
 
+// ScriptResultCacheHit provides a mock function with given fields:
+func (_m *AccessMetrics) ScriptResultCacheHit() {
+	_m.Called()
+}
+
+// ScriptResultCacheMiss provides a mock function with given fields:
+func (_m *AccessMetrics) ScriptResultCacheMiss() {
+	_m.Called()
+}
+
 // TotalConnectionsInPool provides a mock function with given fields: connectionCount, connectionPoolSize
 func (_m *AccessMetrics) TotalConnectionsInPool(connectionCount uint, connectionPoolSize uint) {
 	_m.Called(connectionCount, connectionPoolSize)
@@ -53,6 +53,16 @@ func (_m *BackendScriptsMetrics) ScriptExecutionResultMismatch() {
 	_m.Called()
 }
 
+// ScriptResultCacheHit provides a mock function with given fields:
+func (_m *BackendScriptsMetrics) ScriptResultCacheHit() {
+	_m.Called()
+}
+
+// ScriptResultCacheMiss provides a mock function with given fields:
+func (_m *BackendScriptsMetrics) ScriptResultCacheMiss() {
+	_m.Called()
+}
+
 // NewBackendScriptsMetrics creates a new instance of BackendScriptsMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBackendScriptsMetrics(t interface {
@@ -46,6 +46,11 @@ func (_m *ExecutionMetrics) ExecutionBlockExecutionEffortVectorComponent(_a0 str
 	_m.Called(_a0, _a1)
 }
 
+// ExecutionCheckpointLoadProgress provides a mock function with given fields: bytesRead, bytesTotal
+func (_m *ExecutionMetrics) ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64) {
+	_m.Called(bytesRead, bytesTotal)
+}
+
 // ExecutionCheckpointSize provides a mock function with given fields: bytes
 func (_m *ExecutionMetrics) ExecutionCheckpointSize(bytes uint64) {
 	_m.Called(bytes)
@@ -9,6 +9,11 @@ type WALMetrics struct {
 	mock.Mock
 }
 
+// ExecutionCheckpointLoadProgress provides a mock function with given fields: bytesRead, bytesTotal
+func (_m *WALMetrics) ExecutionCheckpointLoadProgress(bytesRead uint64, bytesTotal uint64) {
+	_m.Called(bytesRead, bytesTotal)
+}
+
 // ExecutionCheckpointSize provides a mock function with given fields: bytes
 func (_m *WALMetrics) ExecutionCheckpointSize(bytes uint64) {
 	_m.Called(bytes)
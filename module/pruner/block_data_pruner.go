@@ -0,0 +1,343 @@
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+)
+
+const (
+	// DefaultHeightRangeTarget is the default number of most recent sealed heights to keep block data for.
+	DefaultHeightRangeTarget = uint64(1_000_000)
+
+	// DefaultThreshold is the default number of heights the retained range may exceed the height range
+	// target by before pruning is triggered.
+	DefaultThreshold = uint64(100_000)
+
+	// DefaultRetainedEpochs is the default number of most recent epochs (including the current epoch)
+	// whose block data is never pruned.
+	DefaultRetainedEpochs = uint64(1)
+
+	// DefaultPruneInterval is the default interval at which the pruner checks whether pruning is needed.
+	DefaultPruneInterval = 10 * time.Minute
+)
+
+// LowestHeightReporter reports the lowest block height for which block data is available.
+type LowestHeightReporter interface {
+	// LowestAvailableHeight returns the lowest height for which block data is available.
+	// Block data for lower heights has either been pruned, or was never available on this node.
+	LowestAvailableHeight() uint64
+}
+
+// BlockDataPruner is a component responsible for pruning block data from the protocol database of
+// non-consensus nodes. Block data consists of block payloads, collections, transactions, transaction
+// results and events. Headers and all data required by the protocol state are never pruned.
+// It is configured with the following parameters:
+//   - Height range target: The target number of most recent sealed heights to keep block data for.
+//   - Threshold: The number of heights that the retained range can exceed the height range target by
+//     before pruning is triggered. This controls the frequency of pruning.
+//   - Retained epochs: The number of most recent epochs (including the current epoch) whose block data
+//     is never pruned, regardless of the height range target. 0 disables this bound.
+//   - Processed heights: The heights processed by the consumers of block data, such as the collection
+//     syncing and the execution state indexer. Block data above the height processed by any consumer
+//     is never pruned, so it is not written again after it was pruned.
+//
+// Block data of blocks included in the node's root snapshot is never pruned.
+//
+// Pruning is done one height at a time, and the pruned height is persisted atomically with the
+// removal of each block's data, so pruning resumes where it left off after a restart.
+type BlockDataPruner struct {
+	component.Component
+
+	log     zerolog.Logger
+	metrics module.ExecutionDataPrunerMetrics
+	db      *badger.DB
+	state   protocol.State
+
+	// rootHeight is the height of the highest block in the root snapshot. Data at or below this
+	// height is never pruned.
+	rootHeight uint64
+	// sealedRootHeight is the height of the lowest block with block data in the root snapshot.
+	sealedRootHeight uint64
+	// prunedHeight is the height of the highest block whose block data has been pruned.
+	prunedHeight *atomic.Uint64
+
+	heightRangeTarget uint64
+	threshold         uint64
+	retainedEpochs    uint64
+	pruneInterval     time.Duration
+	processedHeights  []processedHeight
+}
+
+// ProcessedHeightReader returns the highest height processed by a consumer of block data.
+// Expected errors during normal operation:
+//   - if the processed height is not available yet, in which case nothing is pruned
+type ProcessedHeightReader func() (uint64, error)
+
+// processedHeight is a named ProcessedHeightReader.
+type processedHeight struct {
+	name string
+	read ProcessedHeightReader
+}
+
+var _ component.Component = (*BlockDataPruner)(nil)
+var _ LowestHeightReporter = (*BlockDataPruner)(nil)
+
+type BlockDataPrunerOption func(*BlockDataPruner)
+
+// WithHeightRangeTarget is used to configure the pruner with a custom height range target.
+func WithHeightRangeTarget(heightRangeTarget uint64) BlockDataPrunerOption {
+	return func(p *BlockDataPruner) {
+		p.heightRangeTarget = heightRangeTarget
+	}
+}
+
+// WithThreshold is used to configure the pruner with a custom threshold.
+func WithThreshold(threshold uint64) BlockDataPrunerOption {
+	return func(p *BlockDataPruner) {
+		p.threshold = threshold
+	}
+}
+
+// WithRetainedEpochs is used to configure the number of most recent epochs that are never pruned.
+func WithRetainedEpochs(retainedEpochs uint64) BlockDataPrunerOption {
+	return func(p *BlockDataPruner) {
+		p.retainedEpochs = retainedEpochs
+	}
+}
+
+// WithPruneInterval is used to configure how often the pruner checks whether pruning is needed.
+func WithPruneInterval(interval time.Duration) BlockDataPrunerOption {
+	return func(p *BlockDataPruner) {
+		p.pruneInterval = interval
+	}
+}
+
+// WithProcessedHeight is used to configure the pruner to never prune block data above the height
+// processed by the given consumer. It may be used multiple times to register several consumers.
+func WithProcessedHeight(name string, reader ProcessedHeightReader) BlockDataPrunerOption {
+	return func(p *BlockDataPruner) {
+		p.processedHeights = append(p.processedHeights, processedHeight{name: name, read: reader})
+	}
+}
+
+// NewBlockDataPruner creates a new BlockDataPruner.
+// No errors are expected during normal operation.
+func NewBlockDataPruner(
+	log zerolog.Logger,
+	metrics module.ExecutionDataPrunerMetrics,
+	db *badger.DB,
+	state protocol.State,
+	opts ...BlockDataPrunerOption,
+) (*BlockDataPruner, error) {
+	rootHeight := state.Params().FinalizedRoot().Height
+
+	var prunedHeight uint64
+	err := db.View(operation.RetrievePrunedBlockDataHeight(&prunedHeight))
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("could not retrieve pruned height: %w", err)
+		}
+
+		// block data has never been pruned. all data up to the root block is part of the root snapshot.
+		prunedHeight = rootHeight
+		err = db.Update(operation.InsertPrunedBlockDataHeight(prunedHeight))
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize pruned height: %w", err)
+		}
+	}
+
+	p := &BlockDataPruner{
+		log:               log.With().Str("component", "block_data_pruner").Logger(),
+		metrics:           metrics,
+		db:                db,
+		state:             state,
+		rootHeight:        rootHeight,
+		sealedRootHeight:  state.Params().SealedRoot().Height,
+		prunedHeight:      atomic.NewUint64(prunedHeight),
+		heightRangeTarget: DefaultHeightRangeTarget,
+		threshold:         DefaultThreshold,
+		retainedEpochs:    DefaultRetainedEpochs,
+		pruneInterval:     DefaultPruneInterval,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.Component = component.NewComponentManagerBuilder().
+		AddWorker(p.loop).
+		Build()
+
+	return p, nil
+}
+
+// LowestAvailableHeight returns the lowest height for which block data is available.
+func (p *BlockDataPruner) LowestAvailableHeight() uint64 {
+	prunedHeight := p.prunedHeight.Load()
+	if prunedHeight == p.rootHeight {
+		// nothing was pruned yet
+		return p.sealedRootHeight
+	}
+	return prunedHeight + 1
+}
+
+func (p *BlockDataPruner) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(p.pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		// check immediately on startup, then once per interval
+		err := p.checkPrune(ctx)
+		if err != nil {
+			ctx.Throw(err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPrune prunes block data if the retained height range exceeds height range target + threshold.
+// No errors are expected during normal operation.
+func (p *BlockDataPruner) checkPrune(ctx irrecoverable.SignalerContext) error {
+	pruneHeight, err := p.pruneHeight()
+	if err != nil {
+		return err
+	}
+
+	prunedHeight := p.prunedHeight.Load()
+	if pruneHeight <= prunedHeight+p.threshold {
+		return nil
+	}
+
+	p.log.Info().
+		Uint64("pruned_height", prunedHeight).
+		Uint64("prune_height", pruneHeight).
+		Msg("pruning block data")
+	start := time.Now()
+
+	for height := prunedHeight + 1; height <= pruneHeight; height++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		err := p.pruneBlockData(height)
+		if err != nil {
+			return fmt.Errorf("could not prune block data at height %d: %w", height, err)
+		}
+		p.prunedHeight.Store(height)
+	}
+
+	duration := time.Since(start)
+	p.log.Info().Dur("duration", duration).Msg("pruned block data")
+
+	p.metrics.Pruned(pruneHeight, duration)
+
+	return nil
+}
+
+// pruneHeight returns the highest height whose block data may be pruned, taking the height range
+// target, the retained epochs, the processed heights and the root snapshot into account.
+// No errors are expected during normal operation.
+func (p *BlockDataPruner) pruneHeight() (uint64, error) {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get sealed header: %w", err)
+	}
+
+	if sealed.Height <= p.rootHeight+p.heightRangeTarget {
+		return p.rootHeight, nil
+	}
+	pruneHeight := sealed.Height - p.heightRangeTarget
+
+	if p.retainedEpochs > 0 {
+		epochFirstHeight, err := p.retainedEpochsFirstHeight()
+		if err != nil {
+			return 0, err
+		}
+		if pruneHeight >= epochFirstHeight {
+			pruneHeight = epochFirstHeight - 1
+		}
+	}
+
+	for _, processed := range p.processedHeights {
+		processedHeight, err := processed.read()
+		if err != nil {
+			p.log.Debug().Err(err).Str("consumer", processed.name).Msg("processed height not available, skipping pruning")
+			return p.rootHeight, nil
+		}
+		if processedHeight < pruneHeight {
+			pruneHeight = processedHeight
+		}
+	}
+
+	if pruneHeight < p.rootHeight {
+		return p.rootHeight, nil
+	}
+	return pruneHeight, nil
+}
+
+// retainedEpochsFirstHeight returns the first height of the oldest retained epoch.
+// No errors are expected during normal operation.
+func (p *BlockDataPruner) retainedEpochsFirstHeight() (uint64, error) {
+	epoch := p.state.Final().Epochs().Current()
+	for i := uint64(1); ; i++ {
+		firstHeight, err := epoch.FirstHeight()
+		if err != nil {
+			if errors.Is(err, protocol.ErrUnknownEpochBoundary) {
+				// the epoch started before the node's root block, so nothing can be pruned yet
+				return p.rootHeight, nil
+			}
+			return 0, fmt.Errorf("could not get epoch first height: %w", err)
+		}
+
+		if i >= p.retainedEpochs || firstHeight <= p.rootHeight+1 {
+			return firstHeight, nil
+		}
+
+		// the previous epoch is the epoch of the block before the first block of this epoch
+		epoch = p.state.AtHeight(firstHeight - 1).Epochs().Current()
+	}
+}
+
+// pruneBlockData removes the block data of the finalized block at the given height, and updates
+// the pruned height in the same transaction.
+// No errors are expected during normal operation.
+func (p *BlockDataPruner) pruneBlockData(height uint64) error {
+	return operation.RetryOnConflict(p.db.Update, func(tx *badger.Txn) error {
+		var blockID flow.Identifier
+		err := operation.LookupBlockHeight(height, &blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up block: %w", err)
+		}
+
+		err = procedure.PruneBlockData(blockID, height)(tx)
+		if err != nil {
+			return err
+		}
+
+		return operation.UpdatePrunedBlockDataHeight(height)(tx)
+	})
+}
@@ -0,0 +1,160 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestBlockDataPruner_Prune tests that the pruner removes the block data of all finalized blocks
+// below sealed height - height range target once the threshold is exceeded, and persists the
+// pruned height.
+func TestBlockDataPruner_Prune(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		rootBlock := unittest.BlockFixture()
+		rootBlock.Header.Height = 0
+		blocks := append([]*flow.Block{&rootBlock}, unittest.ChainFixtureFrom(30, rootBlock.Header)...)
+
+		blockIDs := make(map[uint64]flow.Identifier)
+		require.NoError(t, db.Update(func(tx *badger.Txn) error {
+			for _, block := range blocks {
+				blockID := block.ID()
+				blockIDs[block.Header.Height] = blockID
+				require.NoError(t, operation.IndexBlockHeight(block.Header.Height, blockID)(tx))
+				require.NoError(t, procedure.InsertIndex(blockID, block.Payload.Index())(tx))
+			}
+			return nil
+		}))
+
+		rootHeader := blocks[0].Header
+		sealed := blocks[len(blocks)-1].Header
+
+		params := protocolmock.NewParams(t)
+		params.On("FinalizedRoot").Return(rootHeader)
+		params.On("SealedRoot").Return(rootHeader)
+		sealedSnapshot := protocolmock.NewSnapshot(t)
+		sealedSnapshot.On("Head").Return(sealed, nil)
+		state := protocolmock.NewState(t)
+		state.On("Params").Return(params)
+		state.On("Sealed").Return(sealedSnapshot)
+
+		pruner, err := NewBlockDataPruner(
+			zerolog.Nop(),
+			metrics.NewNoopCollector(),
+			db,
+			state,
+			WithHeightRangeTarget(10),
+			WithThreshold(5),
+			WithRetainedEpochs(0),
+			WithPruneInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		require.Equal(t, rootHeader.Height, pruner.LowestAvailableHeight())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "timed out waiting for pruner to be ready")
+
+		pruneHeight := sealed.Height - 10
+		require.Eventually(t, func() bool {
+			return pruner.LowestAvailableHeight() == pruneHeight+1
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "timed out waiting for pruner to stop")
+
+		require.NoError(t, db.View(func(tx *badger.Txn) error {
+			for height, blockID := range blockIDs {
+				var index flow.Index
+				err := procedure.RetrieveIndex(blockID, &index)(tx)
+				if height > rootHeader.Height && height <= pruneHeight {
+					require.ErrorIs(t, err, storage.ErrNotFound, "block data at height %d should be pruned", height)
+				} else {
+					require.NoError(t, err, "block data at height %d should be kept", height)
+				}
+			}
+
+			var prunedHeight uint64
+			require.NoError(t, operation.RetrievePrunedBlockDataHeight(&prunedHeight)(tx))
+			require.Equal(t, pruneHeight, prunedHeight)
+			return nil
+		}))
+
+		// the pruned height is restored after a restart
+		restarted, err := NewBlockDataPruner(zerolog.Nop(), metrics.NewNoopCollector(), db, state)
+		require.NoError(t, err)
+		require.Equal(t, pruneHeight+1, restarted.LowestAvailableHeight())
+	})
+}
+
+// TestBlockDataPruner_ProcessedHeight tests that the pruner does not prune block data above the height
+// processed by its consumers, and does not prune at all while a processed height is not available.
+func TestBlockDataPruner_ProcessedHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		rootHeader := unittest.BlockHeaderFixture()
+		rootHeader.Height = 0
+		sealed := unittest.BlockHeaderFixture()
+		sealed.Height = 30
+
+		params := protocolmock.NewParams(t)
+		params.On("FinalizedRoot").Return(rootHeader)
+		params.On("SealedRoot").Return(rootHeader)
+		sealedSnapshot := protocolmock.NewSnapshot(t)
+		sealedSnapshot.On("Head").Return(sealed, nil)
+		state := protocolmock.NewState(t)
+		state.On("Params").Return(params)
+		state.On("Sealed").Return(sealedSnapshot)
+
+		indexedHeight := uint64(12)
+		var indexedErr error
+
+		pruner, err := NewBlockDataPruner(
+			zerolog.Nop(),
+			metrics.NewNoopCollector(),
+			db,
+			state,
+			WithHeightRangeTarget(10),
+			WithRetainedEpochs(0),
+			WithProcessedHeight("last full block height", func() (uint64, error) {
+				return 25, nil
+			}),
+			WithProcessedHeight("indexer", func() (uint64, error) {
+				return indexedHeight, indexedErr
+			}),
+		)
+		require.NoError(t, err)
+
+		// bounded by the lowest processed height
+		pruneHeight, err := pruner.pruneHeight()
+		require.NoError(t, err)
+		require.Equal(t, indexedHeight, pruneHeight)
+
+		// bounded by the height range target
+		indexedHeight = 28
+		pruneHeight, err = pruner.pruneHeight()
+		require.NoError(t, err)
+		require.Equal(t, sealed.Height-10, pruneHeight)
+
+		// nothing is pruned if a processed height is not available
+		indexedErr = fmt.Errorf("not initialized")
+		pruneHeight, err = pruner.pruneHeight()
+		require.NoError(t, err)
+		require.Equal(t, rootHeader.Height, pruneHeight)
+	})
+}
@@ -0,0 +1,40 @@
+package pruner
+
+import "time"
+
+// Config defines the configuration of the BlockDataPruner.
+type Config struct {
+	// Enabled determines whether block data is pruned. Pruning is opt-in.
+	Enabled bool
+	// HeightRangeTarget is the number of most recent sealed heights to keep block data for.
+	HeightRangeTarget uint64
+	// Threshold is the number of heights the retained range may exceed HeightRangeTarget by
+	// before pruning is triggered.
+	Threshold uint64
+	// RetainedEpochs is the number of most recent epochs (including the current epoch) whose
+	// block data is never pruned. 0 disables this bound.
+	RetainedEpochs uint64
+	// PruneInterval is the interval at which the pruner checks whether pruning is needed.
+	PruneInterval time.Duration
+}
+
+// DefaultConfig returns the default configuration of the BlockDataPruner. Pruning is disabled by default.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:           false,
+		HeightRangeTarget: DefaultHeightRangeTarget,
+		Threshold:         DefaultThreshold,
+		RetainedEpochs:    DefaultRetainedEpochs,
+		PruneInterval:     DefaultPruneInterval,
+	}
+}
+
+// Options returns the BlockDataPruner options for the config.
+func (c Config) Options() []BlockDataPrunerOption {
+	return []BlockDataPrunerOption{
+		WithHeightRangeTarget(c.HeightRangeTarget),
+		WithThreshold(c.Threshold),
+		WithRetainedEpochs(c.RetainedEpochs),
+		WithPruneInterval(c.PruneInterval),
+	}
+}
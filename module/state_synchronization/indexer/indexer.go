@@ -104,9 +104,8 @@ func (i *Indexer) Start(ctx irrecoverable.SignalerContext) {
 
 // LowestIndexedHeight returns the lowest height indexed by the execution indexer.
 func (i *Indexer) LowestIndexedHeight() (uint64, error) {
-	// TODO: use a separate value to track the lowest indexed height. We're using the registers db's
-	// value here to start because it's convenient. When pruning support is added, this will need to
-	// be updated.
+	// The registers db's first height is advanced when registers are pruned, so it always reflects
+	// the lowest height with complete register data.
 	return i.registers.FirstHeight(), nil
 }
 
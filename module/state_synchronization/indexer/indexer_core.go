@@ -32,12 +32,34 @@ type IndexerCore struct {
 	results      storage.LightTransactionResults
 	batcher      bstorage.BatchBuilder
 
+	// eventTypeIndex is nil if the event type index is disabled
+	eventTypeIndex storage.EventTypeIndex
+	// accountTxs is nil if the account transaction index is disabled
+	accountTxs storage.AccountTransactions
+
 	collectionExecutedMetric module.CollectionExecutedMetric
 
 	derivedChainData *derived.DerivedChainData
 	serviceAddress   flow.Address
 }
 
+type IndexerCoreOption func(*IndexerCore)
+
+// WithEventTypeIndex is used to configure the indexer to also index events by event type.
+func WithEventTypeIndex(eventTypeIndex storage.EventTypeIndex) IndexerCoreOption {
+	return func(c *IndexerCore) {
+		c.eventTypeIndex = eventTypeIndex
+	}
+}
+
+// WithAccountTransactions is used to configure the indexer to also index the transactions each
+// account was involved in.
+func WithAccountTransactions(accountTxs storage.AccountTransactions) IndexerCoreOption {
+	return func(c *IndexerCore) {
+		c.accountTxs = accountTxs
+	}
+}
+
 // New execution state indexer used to ingest block execution data and index it by height.
 // The passed RegisterIndex storage must be populated to include the first and last height otherwise the indexer
 // won't be initialized to ensure we have bootstrapped the storage first.
@@ -54,6 +76,7 @@ func New(
 	chain flow.Chain,
 	derivedChainData *derived.DerivedChainData,
 	collectionExecutedMetric module.CollectionExecutedMetric,
+	opts ...IndexerCoreOption,
 ) (*IndexerCore, error) {
 	log = log.With().Str("component", "execution_indexer").Logger()
 	metrics.InitializeLatestHeight(registers.LatestHeight())
@@ -63,7 +86,7 @@ func New(
 		Uint64("latest_height", registers.LatestHeight()).
 		Msg("indexer initialized")
 
-	return &IndexerCore{
+	c := &IndexerCore{
 		log:              log,
 		metrics:          metrics,
 		batcher:          batcher,
@@ -77,7 +100,13 @@ func New(
 		derivedChainData: derivedChainData,
 
 		collectionExecutedMetric: collectionExecutedMetric,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
 // RegisterValue retrieves register values by the register IDs at the provided block height.
@@ -152,11 +181,30 @@ func (c *IndexerCore) IndexBlockData(data *execution_data.BlockExecutionDataEnti
 			return fmt.Errorf("could not index events at height %d: %w", header.Height, err)
 		}
 
+		if c.eventTypeIndex != nil {
+			err = c.eventTypeIndex.BatchIndex(data.BlockID, header.Height, events, batch)
+			if err != nil {
+				return fmt.Errorf("could not index events by type at height %d: %w", header.Height, err)
+			}
+		}
+
 		err = c.results.BatchStore(data.BlockID, results, batch)
 		if err != nil {
 			return fmt.Errorf("could not index transaction results at height %d: %w", header.Height, err)
 		}
 
+		if c.accountTxs != nil {
+			accountTxs, err := findAccountTransactions(data.BlockID, header.Height, data.ChunkExecutionDatas)
+			if err != nil {
+				return fmt.Errorf("could not find account transactions at height %d: %w", header.Height, err)
+			}
+
+			err = c.accountTxs.BatchIndex(accountTxs, batch)
+			if err != nil {
+				return fmt.Errorf("could not index account transactions at height %d: %w", header.Height, err)
+			}
+		}
+
 		batch.Flush()
 		if err != nil {
 			return fmt.Errorf("batch flush error: %w", err)
@@ -35,6 +35,7 @@ type indexCoreTest struct {
 	indexer          *IndexerCore
 	registers        *storagemock.RegisterIndex
 	events           *storagemock.Events
+	eventTypeIndex   *storagemock.EventTypeIndex
 	collection       *flow.Collection
 	collections      *storagemock.Collections
 	transactions     *storagemock.Transactions
@@ -141,6 +142,17 @@ func (i *indexCoreTest) setStoreEvents(f func(*testing.T, flow.Identifier, []flo
 	return i
 }
 
+func (i *indexCoreTest) setIndexEventTypes(f func(*testing.T, flow.Identifier, uint64, []flow.Event) error) *indexCoreTest {
+	i.eventTypeIndex = storagemock.NewEventTypeIndex(i.t)
+	i.eventTypeIndex.
+		On("BatchIndex", mock.AnythingOfType("flow.Identifier"), mock.AnythingOfType("uint64"), mock.AnythingOfType("[]flow.Event"), mock.Anything).
+		Return(func(blockID flow.Identifier, height uint64, events []flow.Event, batch storage.BatchStorage) error {
+			require.NotNil(i.t, batch)
+			return f(i.t, blockID, height, events)
+		})
+	return i
+}
+
 func (i *indexCoreTest) setStoreTransactionResults(f func(*testing.T, flow.Identifier, []flow.LightTransactionResult) error) *indexCoreTest {
 	i.results.
 		On("BatchStore", mock.AnythingOfType("flow.Identifier"), mock.AnythingOfType("[]flow.LightTransactionResult"), mock.Anything).
@@ -215,6 +227,11 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 	derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
 	require.NoError(i.t, err)
 
+	var opts []IndexerCoreOption
+	if i.eventTypeIndex != nil {
+		opts = append(opts, WithEventTypeIndex(i.eventTypeIndex))
+	}
+
 	indexer, err := New(
 		log,
 		metrics.NewNoopCollector(),
@@ -228,6 +245,7 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 		flow.Testnet.Chain(),
 		derivedChainData,
 		collectionExecutedMetric,
+		opts...,
 	)
 	require.NoError(i.t, err)
 	i.indexer = indexer
@@ -385,6 +403,44 @@ func TestExecutionState_IndexBlockData(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Index Events By Type", func(t *testing.T) {
+		expectedEvents := unittest.EventsFixture(20)
+		ed := &execution_data.BlockExecutionData{
+			BlockID: block.ID(),
+			ChunkExecutionDatas: []*execution_data.ChunkExecutionData{
+				// split events into 2 chunks
+				{
+					Collection: &collection,
+					Events:     expectedEvents[:10],
+				},
+				{
+					Collection: &collection,
+					Events:     expectedEvents[10:],
+				},
+			},
+		}
+		execData := execution_data.NewBlockExecutionDataEntity(block.ID(), ed)
+
+		err := newIndexCoreTest(t, blocks, execData).
+			initIndexer().
+			useDefaultStorageMocks().
+			useDefaultEvents().
+			useDefaultTransactionResults().
+			// make sure all events are indexed by type at the block's height
+			setIndexEventTypes(func(t *testing.T, actualBlockID flow.Identifier, height uint64, actualEvents []flow.Event) error {
+				assert.Equal(t, block.ID(), actualBlockID)
+				assert.Equal(t, block.Header.Height, height)
+				assert.Equal(t, expectedEvents, actualEvents)
+				return nil
+			}).
+			setStoreRegisters(func(t *testing.T, entries flow.RegisterEntries, height uint64) error {
+				return nil
+			}).
+			runIndexBlockData()
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("Index Tx Results", func(t *testing.T) {
 		expectedResults := unittest.LightTransactionResultsFixture(20)
 		ed := &execution_data.BlockExecutionData{
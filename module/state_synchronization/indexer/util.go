@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/ccf"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/ledger/common/convert"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 )
 
 var (
@@ -33,6 +36,99 @@ func hasAuthorizedTransaction(collections []*flow.Collection, address flow.Addre
 	return false
 }
 
+// findAccountTransactions returns the account transactions of all accounts involved in the transactions
+// of the provided chunks, ordered by transaction index.
+// The payer, proposer and authorizers of a transaction are taken from the transaction body. Register
+// updates are only available per chunk, so accounts whose registers were updated by a chunk are
+// associated with all transactions of that chunk using TransactionRoleInteraction.
+// No errors are expected during normal operation and indicate invalid execution data was encountered
+func findAccountTransactions(
+	blockID flow.Identifier,
+	height uint64,
+	chunks []*execution_data.ChunkExecutionData,
+) ([]flow.AccountTransaction, error) {
+	accountTxs := make([]flow.AccountTransaction, 0)
+
+	txIndex := uint32(0)
+	for _, chunk := range chunks {
+		interactions, err := findUpdatedAccounts(chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range chunk.Collection.Transactions {
+			roles := make(map[flow.Address]map[flow.TransactionRole]struct{})
+			addRole := func(address flow.Address, role flow.TransactionRole) {
+				if _, ok := roles[address]; !ok {
+					roles[address] = make(map[flow.TransactionRole]struct{})
+				}
+				roles[address][role] = struct{}{}
+			}
+
+			for _, authorizer := range tx.Authorizers {
+				addRole(authorizer, flow.TransactionRoleAuthorizer)
+			}
+			addRole(tx.Payer, flow.TransactionRolePayer)
+			addRole(tx.ProposalKey.Address, flow.TransactionRoleProposer)
+			for address := range interactions {
+				addRole(address, flow.TransactionRoleInteraction)
+			}
+
+			txID := tx.ID()
+			for address, addressRoles := range roles {
+				accountTx := flow.AccountTransaction{
+					Address:          address,
+					BlockID:          blockID,
+					BlockHeight:      height,
+					TransactionID:    txID,
+					TransactionIndex: txIndex,
+					Roles:            make([]flow.TransactionRole, 0, len(addressRoles)),
+				}
+				for role := range addressRoles {
+					accountTx.Roles = append(accountTx.Roles, role)
+				}
+				sort.Slice(accountTx.Roles, func(i, j int) bool {
+					return accountTx.Roles[i] < accountTx.Roles[j]
+				})
+				accountTxs = append(accountTxs, accountTx)
+			}
+
+			txIndex++
+		}
+	}
+
+	return accountTxs, nil
+}
+
+// findUpdatedAccounts returns the addresses of all accounts with registers updated by the provided chunk.
+// No errors are expected during normal operation and indicate invalid execution data was encountered
+func findUpdatedAccounts(chunk *execution_data.ChunkExecutionData) (map[flow.Address]struct{}, error) {
+	accounts := make(map[flow.Address]struct{})
+	if chunk.TrieUpdate == nil {
+		return accounts, nil
+	}
+
+	for _, payload := range chunk.TrieUpdate.Payloads {
+		key, err := payload.Key()
+		if err != nil {
+			return nil, fmt.Errorf("could not get payload key: %w", err)
+		}
+
+		id, err := convert.LedgerKeyToRegisterID(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert payload key to register ID: %w", err)
+		}
+
+		// global registers are not owned by an account
+		if id.Owner == "" {
+			continue
+		}
+		accounts[flow.BytesToAddress([]byte(id.Owner))] = struct{}{}
+	}
+
+	return accounts, nil
+}
+
 // findContractUpdates returns a map of common.AddressLocation for all contracts updated within the
 // provided events.
 // No errors are expected during normal operation and indicate an invalid protocol event was encountered
@@ -12,7 +12,10 @@ import (
 
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/generator"
 )
@@ -57,6 +60,88 @@ func TestFindContractUpdates(t *testing.T) {
 	assert.Truef(t, ok, "could not find %s", expected2.ID())
 }
 
+// TestFindAccountTransactions tests the findAccountTransactions function returns the roles of all
+// accounts involved in each transaction
+func TestFindAccountTransactions(t *testing.T) {
+	t.Parallel()
+
+	blockID := unittest.IdentifierFixture()
+	height := uint64(10)
+
+	payer := unittest.RandomAddressFixture()
+	authorizer := unittest.RandomAddressFixture()
+	updated := unittest.RandomAddressFixture()
+
+	tx1 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = payer
+		tx.ProposalKey.Address = payer
+		tx.Authorizers = []flow.Address{authorizer}
+	})
+	tx2 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = payer
+		tx.ProposalKey.Address = payer
+		tx.Authorizers = []flow.Address{payer}
+	})
+	otherPayer := unittest.RandomAddressFixture()
+	tx3 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = otherPayer
+		tx.ProposalKey.Address = otherPayer
+		tx.Authorizers = []flow.Address{otherPayer}
+	})
+
+	registerPayload := func(owner flow.Address) *ledger.Payload {
+		key := convert.RegisterIDToLedgerKey(flow.NewRegisterID(owner, "key"))
+		return ledger.NewPayload(key, []byte{1})
+	}
+	globalPayload := ledger.NewPayload(convert.RegisterIDToLedgerKey(flow.NewRegisterID(flow.EmptyAddress, "key")), []byte{1})
+
+	chunks := []*execution_data.ChunkExecutionData{
+		{
+			Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx1, &tx3}},
+			TrieUpdate: &ledger.TrieUpdate{
+				Payloads: []*ledger.Payload{registerPayload(updated), registerPayload(authorizer), globalPayload},
+			},
+		},
+		{
+			Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx2}},
+		},
+	}
+
+	accountTxs, err := findAccountTransactions(blockID, height, chunks)
+	require.NoError(t, err)
+
+	roles := make(map[flow.Identifier]map[flow.Address][]flow.TransactionRole)
+	for _, accountTx := range accountTxs {
+		assert.Equal(t, blockID, accountTx.BlockID)
+		assert.Equal(t, height, accountTx.BlockHeight)
+		if _, ok := roles[accountTx.TransactionID]; !ok {
+			roles[accountTx.TransactionID] = make(map[flow.Address][]flow.TransactionRole)
+		}
+		roles[accountTx.TransactionID][accountTx.Address] = accountTx.Roles
+	}
+
+	assert.Equal(t, map[flow.Address][]flow.TransactionRole{
+		payer:      {flow.TransactionRolePayer, flow.TransactionRoleProposer},
+		authorizer: {flow.TransactionRoleAuthorizer, flow.TransactionRoleInteraction},
+		updated:    {flow.TransactionRoleInteraction},
+	}, roles[tx1.ID()])
+	assert.Equal(t, map[flow.Address][]flow.TransactionRole{
+		payer: {flow.TransactionRoleAuthorizer, flow.TransactionRolePayer, flow.TransactionRoleProposer},
+	}, roles[tx2.ID()])
+	// register updates are only known per chunk, so they are associated with all transactions of the chunk
+	assert.Equal(t, map[flow.Address][]flow.TransactionRole{
+		otherPayer: {flow.TransactionRoleAuthorizer, flow.TransactionRolePayer, flow.TransactionRoleProposer},
+		authorizer: {flow.TransactionRoleInteraction},
+		updated:    {flow.TransactionRoleInteraction},
+	}, roles[tx3.ID()])
+
+	// transaction indexes are counted across chunks
+	txIndexes := map[flow.Identifier]uint32{tx1.ID(): 0, tx3.ID(): 1, tx2.ID(): 2}
+	for _, accountTx := range accountTxs {
+		assert.Equal(t, txIndexes[accountTx.TransactionID], accountTx.TransactionIndex)
+	}
+}
+
 func contractUpdatedFixture(t *testing.T, address common.Address, contractName string) flow.Event {
 	contractUpdateEventType := &cadence.EventType{
 		Location:            stdlib.AccountContractAddedEventType.Location,
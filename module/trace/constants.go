@@ -163,6 +163,7 @@ const (
 	FVMEnvGetAccountBalance           SpanName = "fvm.env.getAccountBalance"
 	FVMEnvGetAccountAvailableBalance  SpanName = "fvm.env.getAccountAvailableBalance"
 	FVMEnvGetAccountKeys              SpanName = "fvm.env.getAccountKeys"
+	FVMEnvGetAccountKeyByIndex        SpanName = "fvm.env.getAccountKeyByIndex"
 	FVMEnvResolveLocation             SpanName = "fvm.env.resolveLocation"
 	FVMEnvGetCode                     SpanName = "fvm.env.getCode"
 	FVMEnvGetAccountContractNames     SpanName = "fvm.env.getAccountContractNames"
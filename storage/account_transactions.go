@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountTransactions represents a persistent index of the transactions each account was involved in,
+// keyed by address, block height and transaction index.
+type AccountTransactions interface {
+	// BatchIndex indexes the given account transactions in the provided batch.
+	// No errors are expected during normal operation.
+	BatchIndex(txs []flow.AccountTransaction, batch BatchStorage) error
+
+	// ByAddress returns up to limit transactions of the given address, most recent first.
+	// The cursor is inclusive, i.e. the transaction at the cursor position is the first returned
+	// transaction if it exists. A nil cursor starts at the most recent transaction.
+	// No errors are expected during normal operation.
+	ByAddress(address flow.Address, cursor *flow.AccountTransactionCursor, limit uint32) ([]flow.AccountTransaction, error)
+}
@@ -24,4 +24,6 @@ type All struct {
 	ProtocolKVStore           ProtocolKVStore
 	VersionBeacons            VersionBeacons
 	RegisterIndex             RegisterIndex
+	EventTypeIndex            EventTypeIndex
+	AccountTransactions       AccountTransactions
 }
@@ -0,0 +1,59 @@
+package badger
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// AccountTransactions implements storage.AccountTransactions on top of badger.
+type AccountTransactions struct {
+	db *badger.DB
+}
+
+var _ storage.AccountTransactions = (*AccountTransactions)(nil)
+
+func NewAccountTransactions(db *badger.DB) *AccountTransactions {
+	return &AccountTransactions{
+		db: db,
+	}
+}
+
+// BatchIndex indexes the given account transactions in the provided batch.
+// No errors are expected during normal operation.
+func (a *AccountTransactions) BatchIndex(txs []flow.AccountTransaction, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for _, tx := range txs {
+		err := operation.BatchIndexAccountTransaction(tx)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not index transaction %v of account %v: %w", tx.TransactionID, tx.Address, err)
+		}
+	}
+
+	return nil
+}
+
+// ByAddress returns up to limit transactions of the given address, most recent first.
+// The cursor is inclusive, i.e. the transaction at the cursor position is the first returned
+// transaction if it exists. A nil cursor starts at the most recent transaction.
+// No errors are expected during normal operation.
+func (a *AccountTransactions) ByAddress(address flow.Address, cursor *flow.AccountTransactionCursor, limit uint32) ([]flow.AccountTransaction, error) {
+	height, txIndex := uint64(math.MaxUint64), uint32(math.MaxUint32)
+	if cursor != nil {
+		height, txIndex = cursor.BlockHeight, cursor.TransactionIndex
+	}
+
+	txs := make([]flow.AccountTransaction, 0)
+	err := a.db.View(operation.LookupAccountTransactions(address, height, txIndex, limit, &txs))
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup transactions of account %v: %w", address, err)
+	}
+
+	return txs, nil
+}
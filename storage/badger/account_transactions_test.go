@@ -0,0 +1,83 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		accountTxs := badgerstorage.NewAccountTransactions(db)
+
+		address := unittest.RandomAddressFixture()
+		other := unittest.RandomAddressFixture()
+
+		// two transactions of the address at each height 10 to 12
+		var expected []flow.AccountTransaction
+		for height := uint64(10); height <= 12; height++ {
+			blockID := unittest.IdentifierFixture()
+			txs := make([]flow.AccountTransaction, 0)
+			for txIndex := uint32(0); txIndex < 2; txIndex++ {
+				tx := flow.AccountTransaction{
+					Address:          address,
+					BlockID:          blockID,
+					BlockHeight:      height,
+					TransactionID:    unittest.IdentifierFixture(),
+					TransactionIndex: txIndex,
+					Roles:            []flow.TransactionRole{flow.TransactionRoleAuthorizer, flow.TransactionRolePayer},
+				}
+				txs = append(txs, tx)
+				expected = append([]flow.AccountTransaction{tx}, expected...)
+			}
+			txs = append(txs, flow.AccountTransaction{
+				Address:          other,
+				BlockID:          blockID,
+				BlockHeight:      height,
+				TransactionID:    unittest.IdentifierFixture(),
+				TransactionIndex: 2,
+				Roles:            []flow.TransactionRole{flow.TransactionRoleInteraction},
+			})
+
+			batch := badgerstorage.NewBatch(db)
+			require.NoError(t, accountTxs.BatchIndex(txs, batch))
+			require.NoError(t, batch.Flush())
+		}
+
+		t.Run("most recent first", func(t *testing.T) {
+			actual, err := accountTxs.ByAddress(address, nil, 100)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+
+		t.Run("limit", func(t *testing.T) {
+			actual, err := accountTxs.ByAddress(address, nil, 3)
+			require.NoError(t, err)
+			require.Equal(t, expected[:3], actual)
+		})
+
+		t.Run("cursor is inclusive", func(t *testing.T) {
+			actual, err := accountTxs.ByAddress(address, expected[3].Cursor(), 100)
+			require.NoError(t, err)
+			require.Equal(t, expected[3:], actual)
+		})
+
+		t.Run("cursor between transactions", func(t *testing.T) {
+			cursor := &flow.AccountTransactionCursor{BlockHeight: 11, TransactionIndex: 5}
+			actual, err := accountTxs.ByAddress(address, cursor, 100)
+			require.NoError(t, err)
+			require.Equal(t, expected[2:], actual)
+		})
+
+		t.Run("unknown address", func(t *testing.T) {
+			actual, err := accountTxs.ByAddress(unittest.RandomAddressFixture(), nil, 100)
+			require.NoError(t, err)
+			require.Empty(t, actual)
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// EventTypeIndex implements storage.EventTypeIndex on top of the badger database that holds the
+// indexed events.
+type EventTypeIndex struct {
+	db *badger.DB
+
+	mu sync.RWMutex
+	// initialized is false until the first block was indexed
+	initialized  bool
+	latestHeight uint64
+	// the first indexed height is not cached, since it is advanced by the block data pruner
+}
+
+var _ storage.EventTypeIndex = (*EventTypeIndex)(nil)
+
+// NewEventTypeIndex creates a new EventTypeIndex, loading the indexed height range from the database.
+// No errors are expected during normal operation.
+func NewEventTypeIndex(db *badger.DB) (*EventTypeIndex, error) {
+	index := &EventTypeIndex{
+		db: db,
+	}
+
+	err := db.View(operation.RetrieveEventTypeIndexLatestHeight(&index.latestHeight))
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("could not retrieve indexed height range: %w", err)
+		}
+		// nothing was indexed yet
+		return index, nil
+	}
+	index.initialized = true
+
+	return index, nil
+}
+
+// BatchIndex indexes the given events of the block with the given ID and height by event type in the
+// provided batch.
+// Blocks are expected to be indexed in ascending height order. If a height is skipped, the indexed
+// height range is restarted at the given height.
+// No errors are expected during normal operation.
+func (i *EventTypeIndex) BatchIndex(blockID flow.Identifier, height uint64, events []flow.Event, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	i.mu.RLock()
+	latestHeight := i.latestHeight
+	restart := !i.initialized || height > i.latestHeight+1
+	i.mu.RUnlock()
+
+	for _, event := range events {
+		err := operation.BatchIndexEventType(blockID, height, event)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not index event: %w", err)
+		}
+	}
+
+	if restart {
+		// either nothing was indexed yet, or blocks were skipped. in both cases the index only
+		// covers heights starting at this block.
+		err := operation.BatchUpdateEventTypeIndexFirstHeight(height)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not update first indexed height: %w", err)
+		}
+	}
+
+	// re-indexing an already indexed height does not change the latest height
+	if restart || height > latestHeight {
+		latestHeight = height
+		err := operation.BatchUpdateEventTypeIndexLatestHeight(latestHeight)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not update latest indexed height: %w", err)
+		}
+	}
+
+	batch.OnSucceed(func() {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		i.initialized = true
+		i.latestHeight = latestHeight
+	})
+
+	return nil
+}
+
+// ByEventType returns all events of the given type emitted in blocks within the given height range
+// (inclusive), grouped by block in ascending height order. Events of a block are in execution order.
+// Blocks without events of the given type are omitted, and the BlockTimestamp of the returned block
+// events is not set. At most limit events are loaded.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the height range is not fully within the indexed height range
+//   - storage.ErrLimitExceeded if more than limit events of the given type are in the height range
+func (i *EventTypeIndex) ByEventType(eventType flow.EventType, startHeight uint64, endHeight uint64, limit uint) ([]flow.BlockEvents, error) {
+	i.mu.RLock()
+	initialized, latestHeight := i.initialized, i.latestHeight
+	i.mu.RUnlock()
+
+	if !initialized || endHeight > latestHeight {
+		return nil, fmt.Errorf("height range [%d, %d] is not within indexed range: %w",
+			startHeight, endHeight, storage.ErrHeightNotIndexed)
+	}
+
+	blockEvents := make([]flow.BlockEvents, 0)
+	err := i.db.View(func(tx *badger.Txn) error {
+		// the first height is read in the same transaction as the events, so events removed by the
+		// pruner are never silently missing from the result
+		var firstHeight uint64
+		err := operation.RetrieveEventTypeIndexFirstHeight(&firstHeight)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve first indexed height: %w", err)
+		}
+		if startHeight < firstHeight {
+			return fmt.Errorf("height range [%d, %d] is not within indexed range [%d, %d]: %w",
+				startHeight, endHeight, firstHeight, latestHeight, storage.ErrHeightNotIndexed)
+		}
+
+		return operation.LookupEventsByEventType(eventType, startHeight, endHeight, limit, &blockEvents)(tx)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) || errors.Is(err, storage.ErrLimitExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("could not lookup events by event type: %w", err)
+	}
+
+	return blockEvents, nil
+}
@@ -0,0 +1,132 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEventTypeIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		events := badgerstorage.NewEvents(metrics.NewNoopCollector(), db)
+		index, err := badgerstorage.NewEventTypeIndex(db)
+		require.NoError(t, err)
+
+		// a sparse event type is only emitted at heights 11 and 13
+		sparseType := flow.EventType("A.0000000000000001.Contract.Sparse")
+		commonType := flow.EventType("A.0000000000000001.Contract.SparseCommon")
+
+		blockIDs := make(map[uint64]flow.Identifier)
+		expected := make(map[uint64][]flow.Event)
+		for height := uint64(10); height <= 14; height++ {
+			blockID := unittest.IdentifierFixture()
+			blockIDs[height] = blockID
+
+			tx1ID := unittest.IdentifierFixture()
+			tx2ID := unittest.IdentifierFixture()
+			blockEvents := []flow.Event{
+				unittest.EventFixture(commonType, 0, 0, tx1ID, 0),
+				unittest.EventFixture(commonType, 1, 0, tx2ID, 0),
+			}
+			if height == 11 || height == 13 {
+				sparse := []flow.Event{
+					unittest.EventFixture(sparseType, 0, 1, tx1ID, 0),
+					unittest.EventFixture(sparseType, 1, 2, tx2ID, 0),
+				}
+				blockEvents = append(blockEvents, sparse...)
+				expected[height] = sparse
+			}
+
+			batch := badgerstorage.NewBatch(db)
+			require.NoError(t, events.BatchStore(blockID, []flow.EventsList{blockEvents}, batch))
+			require.NoError(t, index.BatchIndex(blockID, height, blockEvents, batch))
+			require.NoError(t, batch.Flush())
+		}
+
+		t.Run("skips blocks without matching events", func(t *testing.T) {
+			actual, err := index.ByEventType(sparseType, 10, 14, 100)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+
+			for i, height := range []uint64{11, 13} {
+				require.Equal(t, blockIDs[height], actual[i].BlockID)
+				require.Equal(t, height, actual[i].BlockHeight)
+				require.Equal(t, expected[height], actual[i].Events)
+			}
+		})
+
+		t.Run("event types sharing a prefix are not mixed", func(t *testing.T) {
+			actual, err := index.ByEventType(commonType, 12, 12, 100)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			require.Len(t, actual[0].Events, 2)
+			for _, event := range actual[0].Events {
+				require.Equal(t, commonType, event.Type)
+			}
+		})
+
+		t.Run("unknown event type", func(t *testing.T) {
+			actual, err := index.ByEventType("A.0000000000000001.Contract.Unknown", 10, 14, 100)
+			require.NoError(t, err)
+			require.Empty(t, actual)
+		})
+
+		t.Run("heights outside the indexed range", func(t *testing.T) {
+			_, err := index.ByEventType(sparseType, 9, 14, 100)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+			_, err = index.ByEventType(sparseType, 10, 15, 100)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+		})
+
+		t.Run("too many events", func(t *testing.T) {
+			_, err := index.ByEventType(commonType, 10, 14, 9)
+			require.ErrorIs(t, err, storage.ErrLimitExceeded)
+
+			actual, err := index.ByEventType(commonType, 10, 14, 10)
+			require.NoError(t, err)
+			require.Len(t, actual, 5)
+		})
+
+		t.Run("indexed range is loaded on startup", func(t *testing.T) {
+			reloaded, err := badgerstorage.NewEventTypeIndex(db)
+			require.NoError(t, err)
+
+			actual, err := reloaded.ByEventType(sparseType, 10, 14, 100)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+		})
+
+		t.Run("pruned heights are not indexed", func(t *testing.T) {
+			require.NoError(t, db.Update(operation.UpdateEventTypeIndexFirstHeight(11)))
+
+			_, err := index.ByEventType(sparseType, 10, 14, 100)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+			actual, err := index.ByEventType(sparseType, 11, 14, 100)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+		})
+
+		t.Run("skipped heights restart the indexed range", func(t *testing.T) {
+			batch := badgerstorage.NewBatch(db)
+			require.NoError(t, index.BatchIndex(unittest.IdentifierFixture(), 20, nil, batch))
+			require.NoError(t, batch.Flush())
+
+			_, err := index.ByEventType(sparseType, 14, 20, 100)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+			actual, err := index.ByEventType(sparseType, 20, 20, 100)
+			require.NoError(t, err)
+			require.Empty(t, actual)
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package operation
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BatchIndexAccountTransaction indexes the given account transaction by address, block height and
+// transaction index.
+func BatchIndexAccountTransaction(tx flow.AccountTransaction) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeAccountTransaction, tx.Address, tx.BlockHeight, tx.TransactionIndex), tx)
+}
+
+// LookupAccountTransactions retrieves up to limit transactions of the given address, starting at the
+// given height and transaction index (inclusive) and going backwards, most recent transactions first.
+// No errors are expected during normal operation.
+func LookupAccountTransactions(
+	address flow.Address,
+	height uint64,
+	txIndex uint32,
+	limit uint32,
+	txs *[]flow.AccountTransaction,
+) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeAccountTransaction, address)
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.Reverse = true
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(makePrefix(codeAccountTransaction, address, height, txIndex)); it.Valid(); it.Next() {
+			if uint32(len(*txs)) >= limit {
+				break
+			}
+
+			var accountTx flow.AccountTransaction
+			err := it.Item().Value(func(val []byte) error {
+				return msgpack.Unmarshal(val, &accountTx)
+			})
+			if err != nil {
+				return fmt.Errorf("could not decode account transaction: %w", err)
+			}
+			*txs = append(*txs, accountTx)
+		}
+
+		return nil
+	}
+}
@@ -42,3 +42,8 @@ func IndexCollectionByTransaction(txID flow.Identifier, collectionID flow.Identi
 func RetrieveCollectionID(txID flow.Identifier, collectionID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeIndexCollectionByTransaction, txID), collectionID)
 }
+
+// RemoveCollectionByTransactionIndex removes the collection id indexed by the given transaction id
+func RemoveCollectionByTransactionIndex(txID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexCollectionByTransaction, txID))
+}
@@ -0,0 +1,140 @@
+package operation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// eventTypeIndexEntry is the value stored in the event type index. It contains everything needed
+// to look up the indexed event in the events storage.
+type eventTypeIndexEntry struct {
+	BlockID          flow.Identifier
+	Height           uint64
+	TransactionID    flow.Identifier
+	TransactionIndex uint32
+	EventIndex       uint32
+}
+
+// eventTypeID returns the identifier used in the keys of the event type index for the given event type.
+// Event types have variable length, so they are hashed to guarantee that the keys of one event type
+// never share a prefix with the keys of another event type.
+func eventTypeID(eventType flow.EventType) flow.Identifier {
+	return flow.MakeIDFromFingerPrint([]byte(eventType))
+}
+
+func eventTypeIndexKey(height uint64, event flow.Event) []byte {
+	return makePrefix(codeEventTypeIndex, eventTypeID(event.Type), height, event.TransactionIndex, event.EventIndex)
+}
+
+// BatchIndexEventType indexes the given event of the block with the given ID and height by its event type.
+func BatchIndexEventType(blockID flow.Identifier, height uint64, event flow.Event) func(*badger.WriteBatch) error {
+	return batchWrite(eventTypeIndexKey(height, event), eventTypeIndexEntry{
+		BlockID:          blockID,
+		Height:           height,
+		TransactionID:    event.TransactionID,
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+	})
+}
+
+// RemoveEventTypeIndex removes the event type index entry of the given event at the given height.
+// No errors are expected during normal operation, even if the entry does not exist.
+func RemoveEventTypeIndex(height uint64, event flow.Event) func(*badger.Txn) error {
+	return SkipNonExist(remove(eventTypeIndexKey(height, event)))
+}
+
+// LookupEventsByEventType retrieves all indexed events of the given type emitted in blocks within
+// the given height range (inclusive), grouped by block in ascending height order. Events of a block
+// are in execution order.
+// Blocks without events of the given type are omitted, and the BlockTimestamp of the returned block
+// events is not set. The lookup is aborted once more than limit events are found.
+// Expected errors:
+//   - storage.ErrLimitExceeded if more than limit events of the given type are in the height range
+func LookupEventsByEventType(eventType flow.EventType, startHeight uint64, endHeight uint64, limit uint, blockEvents *[]flow.BlockEvents) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		typeID := eventTypeID(eventType)
+
+		var entries []eventTypeIndexEntry
+		iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				return true
+			}
+			var val eventTypeIndexEntry
+			create := func() interface{} {
+				return &val
+			}
+			handle := func() error {
+				if uint(len(entries)) >= limit {
+					return storage.ErrLimitExceeded
+				}
+				entries = append(entries, val)
+				return nil
+			}
+			return check, create, handle
+		}
+
+		start := makePrefix(codeEventTypeIndex, typeID, startHeight)
+		end := makePrefix(codeEventTypeIndex, typeID, endHeight)
+		err := iterate(start, end, iterationFunc)(tx)
+		if err != nil {
+			if errors.Is(err, storage.ErrLimitExceeded) {
+				return fmt.Errorf("more than %d events of type %s: %w", limit, eventType, storage.ErrLimitExceeded)
+			}
+			return fmt.Errorf("could not iterate event type index: %w", err)
+		}
+
+		for _, entry := range entries {
+			var event flow.Event
+			key := makePrefix(codeEvent, entry.BlockID, entry.TransactionID, entry.TransactionIndex, entry.EventIndex)
+			err := retrieve(key, &event)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve event %d of transaction %v in block %v: %w",
+					entry.EventIndex, entry.TransactionID, entry.BlockID, err)
+			}
+
+			last := len(*blockEvents) - 1
+			if last < 0 || (*blockEvents)[last].BlockID != entry.BlockID {
+				*blockEvents = append(*blockEvents, flow.BlockEvents{
+					BlockID:     entry.BlockID,
+					BlockHeight: entry.Height,
+				})
+				last++
+			}
+			(*blockEvents)[last].Events = append((*blockEvents)[last].Events, event)
+		}
+
+		return nil
+	}
+}
+
+// BatchUpdateEventTypeIndexFirstHeight sets the lowest height indexed by the event type index.
+func BatchUpdateEventTypeIndexFirstHeight(height uint64) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEventTypeIndexFirstHeight), height)
+}
+
+// UpdateEventTypeIndexFirstHeight sets the lowest height indexed by the event type index.
+func UpdateEventTypeIndexFirstHeight(height uint64) func(*badger.Txn) error {
+	return upsert(makePrefix(codeEventTypeIndexFirstHeight), height)
+}
+
+// RetrieveEventTypeIndexFirstHeight retrieves the lowest height indexed by the event type index.
+// Returns storage.ErrNotFound if no block was indexed yet.
+func RetrieveEventTypeIndexFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEventTypeIndexFirstHeight), height)
+}
+
+// BatchUpdateEventTypeIndexLatestHeight sets the highest height indexed by the event type index.
+func BatchUpdateEventTypeIndexLatestHeight(height uint64) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEventTypeIndexLatestHeight), height)
+}
+
+// RetrieveEventTypeIndexLatestHeight retrieves the highest height indexed by the event type index.
+// Returns storage.ErrNotFound if no block was indexed yet.
+func RetrieveEventTypeIndexLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEventTypeIndexLatestHeight), height)
+}
@@ -18,6 +18,14 @@ func IndexPayloadGuarantees(blockID flow.Identifier, guarIDs []flow.Identifier)
 	return insert(makePrefix(codePayloadGuarantees, blockID), guarIDs)
 }
 
+func RemoveGuarantee(collID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeGuarantee, collID))
+}
+
 func LookupPayloadGuarantees(blockID flow.Identifier, guarIDs *[]flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codePayloadGuarantees, blockID), guarIDs)
 }
+
+func RemovePayloadGuarantees(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePayloadGuarantees, blockID))
+}
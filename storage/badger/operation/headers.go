@@ -53,6 +53,11 @@ func LookupCollectionBlock(collID flow.Identifier, blockID *flow.Identifier) fun
 	return retrieve(makePrefix(codeCollectionBlock, collID), blockID)
 }
 
+// RemoveCollectionBlock removes the block indexed by a collection within that block.
+func RemoveCollectionBlock(collID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeCollectionBlock, collID))
+}
+
 // FindHeaders iterates through all headers, calling `filter` on each, and adding
 // them to the `found` slice if `filter` returned true
 func FindHeaders(filter func(header *flow.Header) bool, found *[]flow.Header) func(*badger.Txn) error {
@@ -89,3 +89,19 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+// InsertPrunedBlockDataHeight inserts the height of the highest block whose block data has been pruned.
+func InsertPrunedBlockDataHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codePrunedBlockDataHeight), height)
+}
+
+// UpdatePrunedBlockDataHeight updates the height of the highest block whose block data has been pruned.
+func UpdatePrunedBlockDataHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codePrunedBlockDataHeight), height)
+}
+
+// RetrievePrunedBlockDataHeight retrieves the height of the highest block whose block data has been pruned.
+// Returns storage.ErrNotFound if block data has never been pruned.
+func RetrievePrunedBlockDataHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codePrunedBlockDataHeight), height)
+}
@@ -32,6 +32,7 @@ const (
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
 	codeEpochFirstHeight        = 26 // the height of the first block in a given epoch
 	codeSealedRootHeight        = 27 // the height of the highest sealed block contained in the root snapshot
+	codePrunedBlockDataHeight   = 28 // the height of the highest block whose block data has been pruned
 
 	// codes for single entity storage
 	codeHeader               = 30
@@ -88,6 +89,17 @@ const (
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for the event type index
+	codeEventTypeIndex             = 80 // index mapping event type and height to event locations
+	codeEventTypeIndexFirstHeight  = 81 // the lowest height indexed by the event type index
+	codeEventTypeIndexLatestHeight = 82 // the highest height indexed by the event type index
+
+	// codes for the account transaction index
+	codeAccountTransaction = 85 // index mapping address, height and transaction index to account transactions
+
+	// codes for the transaction relay
+	codeRelayTransaction = 87 // pending transactions of the transaction relay, keyed by transaction ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -139,6 +151,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
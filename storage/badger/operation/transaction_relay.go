@@ -0,0 +1,38 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertRelayTransaction stores a pending transaction of the transaction relay. If the transaction
+// is already stored, it is overwritten.
+func InsertRelayTransaction(tx *flow.TransactionBody) func(*badger.Txn) error {
+	return upsert(makePrefix(codeRelayTransaction, tx.ID()), tx)
+}
+
+// RemoveRelayTransaction removes a pending transaction of the transaction relay.
+// Error returns:
+//   - storage.ErrNotFound if the transaction is not stored
+func RemoveRelayTransaction(txID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeRelayTransaction, txID))
+}
+
+// RetrieveRelayTransactions retrieves all pending transactions of the transaction relay.
+func RetrieveRelayTransactions(txs *[]flow.TransactionBody) func(*badger.Txn) error {
+	return traverse(makePrefix(codeRelayTransaction), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var tx flow.TransactionBody
+		create := func() interface{} {
+			return &tx
+		}
+		handle := func() error {
+			*txs = append(*txs, tx)
+			return nil
+		}
+		return check, create, handle
+	})
+}
@@ -120,3 +120,23 @@ func LookupLightTransactionResultsByBlockIDUsingIndex(blockID flow.Identifier, t
 
 	return traverse(makePrefix(codeLightTransactionResultIndex, blockID), txErrIterFunc)
 }
+
+// RemoveLightTransactionResultsByBlockID removes the light transaction results and their tx_index index
+// for the given blockID.
+// No errors are expected during normal operation, but it may return generic error
+// if badger fails to process request
+func RemoveLightTransactionResultsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return func(txn *badger.Txn) error {
+		err := removeByPrefix(makePrefix(codeLightTransactionResult, blockID))(txn)
+		if err != nil {
+			return fmt.Errorf("could not remove light transaction results for block %v: %w", blockID, err)
+		}
+
+		err = removeByPrefix(makePrefix(codeLightTransactionResultIndex, blockID))(txn)
+		if err != nil {
+			return fmt.Errorf("could not remove light transaction result index for block %v: %w", blockID, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+package procedure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// PruneBlockData removes the block data of the given finalized block at the given height from the database. This includes
+// the guarantee index of the payload, the collection guarantees and collections referenced by the payload, the
+// transactions within those collections, and all transaction results and events for the block,
+// including their event type index entries.
+// The block header, the seal, receipt and result indices of the payload, and all data required by the
+// protocol state are kept. Since the guarantees are removed, the full block can no longer be retrieved.
+//
+// Pruning a block whose data was already (partially) pruned is a no-op for the removed parts,
+// so it is safe to call this function again after an interrupted pruning operation.
+//
+// No errors are expected during normal operation.
+func PruneBlockData(blockID flow.Identifier, height uint64) func(tx *badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var collIDs []flow.Identifier
+		err := operation.LookupPayloadGuarantees(blockID, &collIDs)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve guarantee index: %w", err)
+		}
+
+		for _, collID := range collIDs {
+			err = pruneCollection(collID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune collection %v: %w", collID, err)
+			}
+		}
+
+		err = operation.SkipNonExist(operation.RemovePayloadGuarantees(blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove guarantee index: %w", err)
+		}
+
+		err = pruneEventTypeIndex(blockID, height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not prune event type index: %w", err)
+		}
+		err = operation.RemoveEventsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove events: %w", err)
+		}
+		err = operation.RemoveServiceEventsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove service events: %w", err)
+		}
+		err = operation.RemoveTransactionResultsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction results: %w", err)
+		}
+		err = operation.RemoveLightTransactionResultsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove light transaction results: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// pruneCollection removes the guarantee, the light collection and the contained transactions
+// of the given collection, together with their indices.
+func pruneCollection(collID flow.Identifier) func(tx *badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var light flow.LightCollection
+		err := operation.RetrieveCollection(collID, &light)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve collection: %w", err)
+		}
+
+		for _, txID := range light.Transactions {
+			err = operation.SkipNonExist(operation.RemoveTransaction(txID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove transaction %v: %w", txID, err)
+			}
+			err = operation.SkipNonExist(operation.RemoveCollectionByTransactionIndex(txID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove collection index for transaction %v: %w", txID, err)
+			}
+		}
+
+		err = operation.SkipNonExist(operation.RemoveCollection(collID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove collection: %w", err)
+		}
+		err = operation.SkipNonExist(operation.RemoveGuarantee(collID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove guarantee: %w", err)
+		}
+		err = operation.SkipNonExist(operation.RemoveCollectionBlock(collID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove collection block index: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// pruneEventTypeIndex removes the event type index entries of all events of the given block, and
+// advances the first height of the event type index past the block.
+func pruneEventTypeIndex(blockID flow.Identifier, height uint64) func(tx *badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var events []flow.Event
+		err := operation.LookupEventsByBlockID(blockID, &events)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve events: %w", err)
+		}
+
+		for _, event := range events {
+			err = operation.RemoveEventTypeIndex(height, event)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove event type index entry: %w", err)
+			}
+		}
+
+		var firstHeight uint64
+		err = operation.RetrieveEventTypeIndexFirstHeight(&firstHeight)(tx)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				// the event type index is disabled, or nothing was indexed yet
+				return nil
+			}
+			return fmt.Errorf("could not retrieve first height of event type index: %w", err)
+		}
+		if firstHeight <= height {
+			err = operation.UpdateEventTypeIndexFirstHeight(height + 1)(tx)
+			if err != nil {
+				return fmt.Errorf("could not update first height of event type index: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
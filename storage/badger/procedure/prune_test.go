@@ -0,0 +1,123 @@
+package procedure
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPruneBlockData(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		header := unittest.BlockHeaderFixture()
+		blockID := header.ID()
+		otherHeader := unittest.BlockHeaderWithParentFixture(header)
+		otherBlockID := otherHeader.ID()
+
+		collection := unittest.CollectionFixture(2)
+		light := collection.Light()
+		collID := collection.ID()
+		guarantee := unittest.CollectionGuaranteeFixture(func(g *flow.CollectionGuarantee) {
+			g.CollectionID = collID
+		})
+
+		index := unittest.IndexFixture()
+		index.CollectionIDs = []flow.Identifier{collID}
+
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, light.Transactions[0], 0)
+		txResult := flow.LightTransactionResult{TransactionID: light.Transactions[0]}
+
+		require.NoError(t, db.Update(func(tx *badger.Txn) error {
+			require.NoError(t, InsertIndex(blockID, index)(tx))
+			require.NoError(t, InsertIndex(otherBlockID, index)(tx))
+			require.NoError(t, operation.InsertGuarantee(collID, guarantee)(tx))
+			require.NoError(t, operation.InsertCollection(&light)(tx))
+			require.NoError(t, operation.IndexCollectionBlock(collID, blockID)(tx))
+			for _, txBody := range collection.Transactions {
+				require.NoError(t, operation.InsertTransaction(txBody.ID(), txBody)(tx))
+				require.NoError(t, operation.IndexCollectionByTransaction(txBody.ID(), collID)(tx))
+			}
+			require.NoError(t, operation.InsertEvent(blockID, event)(tx))
+			require.NoError(t, operation.InsertEvent(otherBlockID, event)(tx))
+			require.NoError(t, operation.InsertLightTransactionResult(blockID, &txResult)(tx))
+			return nil
+		}))
+
+		writeBatch := db.NewWriteBatch()
+		require.NoError(t, operation.BatchIndexEventType(blockID, header.Height, event)(writeBatch))
+		require.NoError(t, operation.BatchIndexEventType(otherBlockID, otherHeader.Height, event)(writeBatch))
+		require.NoError(t, operation.BatchUpdateEventTypeIndexFirstHeight(header.Height)(writeBatch))
+		require.NoError(t, writeBatch.Flush())
+
+		require.NoError(t, db.Update(PruneBlockData(blockID, header.Height)))
+
+		// pruning again is a no-op
+		require.NoError(t, db.Update(PruneBlockData(blockID, header.Height)))
+
+		require.NoError(t, db.View(func(tx *badger.Txn) error {
+			var collIDs []flow.Identifier
+			require.ErrorIs(t, operation.LookupPayloadGuarantees(blockID, &collIDs)(tx), storage.ErrNotFound)
+
+			// the seal, receipt and result indices are kept
+			var sealIDs []flow.Identifier
+			require.NoError(t, operation.LookupPayloadSeals(blockID, &sealIDs)(tx))
+			require.Equal(t, index.SealIDs, sealIDs)
+			var receiptIDs []flow.Identifier
+			require.NoError(t, operation.LookupPayloadReceipts(blockID, &receiptIDs)(tx))
+			require.Equal(t, index.ReceiptIDs, receiptIDs)
+			var resultIDs []flow.Identifier
+			require.NoError(t, operation.LookupPayloadResults(blockID, &resultIDs)(tx))
+			require.Equal(t, index.ResultIDs, resultIDs)
+
+			var retrievedGuarantee flow.CollectionGuarantee
+			require.ErrorIs(t, operation.RetrieveGuarantee(collID, &retrievedGuarantee)(tx), storage.ErrNotFound)
+			var retrievedCollection flow.LightCollection
+			require.ErrorIs(t, operation.RetrieveCollection(collID, &retrievedCollection)(tx), storage.ErrNotFound)
+			var retrievedBlockID flow.Identifier
+			require.ErrorIs(t, operation.LookupCollectionBlock(collID, &retrievedBlockID)(tx), storage.ErrNotFound)
+
+			for _, txID := range light.Transactions {
+				var txBody flow.TransactionBody
+				require.ErrorIs(t, operation.RetrieveTransaction(txID, &txBody)(tx), storage.ErrNotFound)
+				var retrievedCollID flow.Identifier
+				require.ErrorIs(t, operation.RetrieveCollectionID(txID, &retrievedCollID)(tx), storage.ErrNotFound)
+			}
+
+			var events []flow.Event
+			require.NoError(t, operation.LookupEventsByBlockID(blockID, &events)(tx))
+			require.Empty(t, events)
+
+			var retrievedResult flow.LightTransactionResult
+			require.ErrorIs(t, operation.RetrieveLightTransactionResult(blockID, txResult.TransactionID, &retrievedResult)(tx), storage.ErrNotFound)
+
+			// the protocol state ID of the payload is kept
+			var stateID flow.Identifier
+			require.NoError(t, operation.LookupPayloadProtocolStateID(blockID, &stateID)(tx))
+			require.Equal(t, index.ProtocolStateID, stateID)
+
+			// data of other blocks is not affected
+			var otherIndex flow.Index
+			require.NoError(t, RetrieveIndex(otherBlockID, &otherIndex)(tx))
+			events = nil
+			require.NoError(t, operation.LookupEventsByBlockID(otherBlockID, &events)(tx))
+			require.Len(t, events, 1)
+
+			// only the event type index entries of the pruned block are removed
+			var blockEvents []flow.BlockEvents
+			require.NoError(t, operation.LookupEventsByEventType(event.Type, header.Height, otherHeader.Height, 10, &blockEvents)(tx))
+			require.Len(t, blockEvents, 1)
+			require.Equal(t, otherBlockID, blockEvents[0].BlockID)
+
+			// the event type index no longer covers the pruned block
+			var firstHeight uint64
+			require.NoError(t, operation.RetrieveEventTypeIndexFirstHeight(&firstHeight)(tx))
+			require.Equal(t, header.Height+1, firstHeight)
+			return nil
+		}))
+	})
+}
@@ -26,6 +26,9 @@ var (
 	// and that data is unavailable.
 	ErrHeightNotIndexed = errors.New("data for block height not available")
 
+	// ErrLimitExceeded is returned when a query would return more results than the given limit.
+	ErrLimitExceeded = errors.New("result limit exceeded")
+
 	// ErrNotBootstrapped is returned when the database has not been bootstrapped.
 	ErrNotBootstrapped = errors.New("pebble database not bootstrapped")
 )
@@ -44,3 +44,24 @@ type ServiceEvents interface {
 	// If Badger unexpectedly fails to process the request, the error is wrapped in a generic error and returned.
 	BatchRemoveByBlockID(blockID flow.Identifier, batch BatchStorage) error
 }
+
+// EventTypeIndex represents a persistent secondary index of events by event type and block height.
+// It allows looking up the events of a given type within a height range without loading the events
+// of blocks that do not contain any events of that type.
+type EventTypeIndex interface {
+	// BatchIndex indexes the given events of the block with the given ID and height by event type in the
+	// provided batch. The events must also be stored in the Events storage.
+	// Blocks are expected to be indexed in ascending height order. If a height is skipped, the indexed
+	// height range is restarted at the given height.
+	// No errors are expected during normal operation.
+	BatchIndex(blockID flow.Identifier, height uint64, events []flow.Event, batch BatchStorage) error
+
+	// ByEventType returns all events of the given type emitted in blocks within the given height range
+	// (inclusive), grouped by block in ascending height order. Events of a block are in execution order.
+	// Blocks without events of the given type are omitted, and the BlockTimestamp of the returned block
+	// events is not set. At most limit events are loaded.
+	// Expected errors:
+	//   - storage.ErrHeightNotIndexed if the height range is not fully within the indexed height range
+	//   - storage.ErrLimitExceeded if more than limit events of the given type are in the height range
+	ByEventType(eventType flow.EventType, startHeight uint64, endHeight uint64, limit uint) ([]flow.BlockEvents, error)
+}
@@ -5,6 +5,8 @@ package mock
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/onflow/flow-go/storage"
 )
 
 // RegisterIndex is an autogenerated mock type for the RegisterIndex type
@@ -12,6 +14,45 @@ type RegisterIndex struct {
 	mock.Mock
 }
 
+// ByOwner provides a mock function with given fields: owner, keyPrefix, height, cursor, limit
+func (_m *RegisterIndex) ByOwner(owner string, keyPrefix string, height uint64, cursor storage.RegisterCursor, limit int) (flow.RegisterEntries, storage.RegisterCursor, error) {
+	ret := _m.Called(owner, keyPrefix, height, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByOwner")
+	}
+
+	var r0 flow.RegisterEntries
+	var r1 storage.RegisterCursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, uint64, storage.RegisterCursor, int) (flow.RegisterEntries, storage.RegisterCursor, error)); ok {
+		return rf(owner, keyPrefix, height, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, uint64, storage.RegisterCursor, int) flow.RegisterEntries); ok {
+		r0 = rf(owner, keyPrefix, height, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.RegisterEntries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, uint64, storage.RegisterCursor, int) storage.RegisterCursor); ok {
+		r1 = rf(owner, keyPrefix, height, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(storage.RegisterCursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, uint64, storage.RegisterCursor, int) error); ok {
+		r2 = rf(owner, keyPrefix, height, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FirstHeight provides a mock function with given fields:
 func (_m *RegisterIndex) FirstHeight() uint64 {
 	ret := _m.Called()
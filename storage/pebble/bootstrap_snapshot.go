@@ -0,0 +1,263 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete/registersnapshot"
+)
+
+// RegisterSnapshotBootstrap bootstraps a pebble instance from a register snapshot, which is an
+// alternative to bootstrapping from a checkpoint file with RegisterBootstrap.
+type RegisterSnapshotBootstrap struct {
+	log           zerolog.Logger
+	db            *pebble.DB
+	snapshotDir   string
+	manifest      *registersnapshot.Manifest
+	rootHeight    uint64
+	rootHash      ledger.RootHash
+	registerCount *atomic.Uint64
+	skippedChunks *atomic.Uint64
+}
+
+// NewRegisterSnapshotBootstrap creates the bootstrap object for importing the register snapshot in the
+// given directory and the height tracker in pebble.
+// RegisterSnapshotBootstrap.IndexSnapshot must be run to have the pebble db instance in the correct
+// state to initialize a Registers store.
+//
+// A db holding a partial import of the same snapshot can be bootstrapped again, in which case the
+// import resumes where it left off. It returns an error if the db holds a partial import of a different
+// snapshot, or if the state commitment of the snapshot doesn't match the given root hash.
+func NewRegisterSnapshotBootstrap(
+	db *pebble.DB,
+	snapshotDir string,
+	rootHeight uint64,
+	rootHash ledger.RootHash,
+	log zerolog.Logger,
+) (*RegisterSnapshotBootstrap, error) {
+	isBootstrapped, err := IsBootstrapped(db)
+	if err != nil {
+		return nil, err
+	}
+	if isBootstrapped {
+		return nil, ErrAlreadyBootstrapped
+	}
+
+	manifest, err := registersnapshot.ReadManifest(snapshotDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read register snapshot manifest: %w", err)
+	}
+
+	commitment, err := manifest.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if commitment != rootHash {
+		return nil, fmt.Errorf("state commitment of register snapshot %v does not match root hash %v", commitment, rootHash)
+	}
+
+	// a previous import must have been of the same snapshot to be resumed
+	imported, closer, err := db.Get(snapshotCommitmentKey)
+	if err == nil {
+		defer closer.Close()
+		if !bytes.Equal(imported, rootHash[:]) {
+			return nil, fmt.Errorf("db holds a partial import of a register snapshot with state commitment %x", imported)
+		}
+	} else if !errors.Is(err, pebble.ErrNotFound) {
+		return nil, fmt.Errorf("could not read imported register snapshot: %w", err)
+	}
+
+	return &RegisterSnapshotBootstrap{
+		log:           log.With().Str("module", "register_snapshot_bootstrap").Logger(),
+		db:            db,
+		snapshotDir:   snapshotDir,
+		manifest:      manifest,
+		rootHeight:    rootHeight,
+		rootHash:      rootHash,
+		registerCount: atomic.NewUint64(0),
+		skippedChunks: atomic.NewUint64(0),
+	}, nil
+}
+
+// IndexSnapshot imports the registers of the snapshot at the root height, with the given number of
+// workers importing chunks in parallel. The workers hash the chunks they import, and the chunk hashes
+// are combined to verify the snapshot against the root hash once all chunks are imported. The db is only
+// marked as bootstrapped once the verification succeeds.
+// Chunks imported by a previous, interrupted import of the snapshot are skipped, using the chunk hashes
+// stored when they were imported.
+func (b *RegisterSnapshotBootstrap) IndexSnapshot(ctx context.Context, workerCount int) error {
+	start := time.Now()
+	b.log.Info().
+		Int("chunk_count", len(b.manifest.Chunks)).
+		Uint64("register_count", b.manifest.RegisterCount).
+		Msgf("importing register snapshot with %v workers", workerCount)
+
+	err := b.db.Set(snapshotCommitmentKey, b.rootHash[:], pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("could not store imported register snapshot: %w", err)
+	}
+
+	// the chunk hashes are computed by the workers and combined into the root hash once all chunks are imported
+	chunkHashes := make([]*registersnapshot.ChunkHash, len(b.manifest.Chunks))
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	chunks := make(chan int)
+	g.Go(func() error {
+		defer close(chunks)
+		for i := range b.manifest.Chunks {
+			select {
+			case chunks <- i:
+			case <-gCtx.Done():
+				return gCtx.Err()
+			}
+		}
+		return nil
+	})
+
+	for i := 0; i < workerCount; i++ {
+		g.Go(func() error {
+			for index := range chunks {
+				chunkHash, err := b.indexChunk(index)
+				if err != nil {
+					return fmt.Errorf("could not import chunk %d: %w", index, err)
+				}
+				chunkHashes[index] = chunkHash
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("failed to import register snapshot: %w", err)
+	}
+
+	err = registersnapshot.VerifyChunkHashes(b.manifest, chunkHashes, b.rootHash)
+	if err != nil {
+		return fmt.Errorf("could not verify register snapshot: %w", err)
+	}
+
+	err = initHeights(b.db, b.rootHeight)
+	if err != nil {
+		return fmt.Errorf("could not index latest height: %w", err)
+	}
+
+	err = b.removeProgress()
+	if err != nil {
+		return err
+	}
+
+	b.log.Info().
+		Uint64("root_height", b.rootHeight).
+		Uint64("register_count", b.registerCount.Load()).
+		Uint64("skipped_chunks", b.skippedChunks.Load()).
+		// note: not using Dur() since default units are ms and this duration is long
+		Str("duration", fmt.Sprintf("%v", time.Since(start))).
+		Msg("register snapshot import complete")
+
+	return nil
+}
+
+// indexChunk imports the registers of the chunk at the given index and returns its chunk hash, unless
+// it is already imported, in which case the chunk hash stored with the chunk is returned.
+func (b *RegisterSnapshotBootstrap) indexChunk(index int) (*registersnapshot.ChunkHash, error) {
+	chunkKey := snapshotChunkKey(index)
+	encoded, closer, err := b.db.Get(chunkKey)
+	if err == nil {
+		chunkHash, err := registersnapshot.DecodeChunkHash(encoded)
+		_ = closer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not decode hash of imported chunk: %w", err)
+		}
+		b.skippedChunks.Inc()
+		return chunkHash, nil
+	}
+	if !errors.Is(err, pebble.ErrNotFound) {
+		return nil, fmt.Errorf("could not read imported chunk: %w", err)
+	}
+
+	payloads, err := registersnapshot.ReadChunk(b.snapshotDir, b.manifest.Chunks[index])
+	if err != nil {
+		return nil, err
+	}
+
+	chunkHash, err := registersnapshot.HashChunk(b.manifest, index, payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := b.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, payload := range payloads {
+		key, err := payload.Key()
+		if err != nil {
+			return nil, fmt.Errorf("could not get key from register payload: %w", err)
+		}
+
+		registerID, err := convert.LedgerKeyToRegisterID(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not get register ID from key: %w", err)
+		}
+
+		err = batch.Set(newLookupKey(b.rootHeight, registerID).Bytes(), payload.Value(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set key: %w", err)
+		}
+
+		if batch.Count() >= snapshotImportBatchLen {
+			// registers are written again if the import is interrupted before the chunk is marked as imported
+			err = batch.Commit(pebble.NoSync)
+			if err != nil {
+				return nil, fmt.Errorf("failed to commit batch: %w", err)
+			}
+			_ = batch.Close()
+			batch = b.db.NewBatch()
+		}
+	}
+
+	// mark the chunk as imported along with its last registers
+	err = batch.Set(chunkKey, chunkHash.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark chunk as imported: %w", err)
+	}
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	b.registerCount.Add(uint64(len(payloads)))
+	return chunkHash, nil
+}
+
+// removeProgress removes the keys tracking the progress of the import.
+func (b *RegisterSnapshotBootstrap) removeProgress() error {
+	batch := b.db.NewBatch()
+	defer batch.Close()
+
+	err := batch.DeleteRange(snapshotChunkKey(0), snapshotChunkKey(len(b.manifest.Chunks)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove imported chunks: %w", err)
+	}
+	err = batch.Delete(snapshotCommitmentKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove imported register snapshot: %w", err)
+	}
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package pebble
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/registersnapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterSnapshotBootstrap_Happy(t *testing.T) {
+	t.Parallel()
+	log := zerolog.New(io.Discard)
+	rootHeight := uint64(10000)
+	unittest.RunWithTempDir(t, func(dir string) {
+		tr, registers := snapshotTrie(t, 1000)
+		_, err := registersnapshot.ExportTrie(tr, dir, 64)
+		require.NoError(t, err)
+		pb, dbDir := createPebbleForTest(t)
+
+		bootstrap, err := NewRegisterSnapshotBootstrap(pb, dir, rootHeight, tr.RootHash(), log)
+		require.NoError(t, err)
+		require.NoError(t, bootstrap.IndexSnapshot(context.Background(), workerCount))
+		require.Equal(t, uint64(len(registers)), bootstrap.registerCount.Load())
+
+		reg, err := NewRegisters(pb)
+		require.NoError(t, err)
+		require.Equal(t, rootHeight, reg.FirstHeight())
+		require.Equal(t, rootHeight, reg.LatestHeight())
+
+		for _, register := range registers {
+			val, err := reg.Get(register.Key, rootHeight)
+			require.NoError(t, err)
+			require.Equal(t, register.Value, val)
+		}
+
+		// the progress of the import is removed
+		_, _, err = pb.Get(snapshotCommitmentKey)
+		require.Error(t, err)
+		_, _, err = pb.Get(snapshotChunkKey(0))
+		require.Error(t, err)
+
+		_, err = NewRegisterSnapshotBootstrap(pb, dir, rootHeight, tr.RootHash(), log)
+		require.ErrorIs(t, err, ErrAlreadyBootstrapped)
+
+		require.NoError(t, pb.Close())
+		require.NoError(t, os.RemoveAll(dbDir))
+	})
+}
+
+// TestRegisterSnapshotBootstrap_Resume tests that an interrupted import resumes with the chunks
+// which were not imported yet.
+func TestRegisterSnapshotBootstrap_Resume(t *testing.T) {
+	t.Parallel()
+	log := zerolog.New(io.Discard)
+	rootHeight := uint64(10000)
+	unittest.RunWithTempDir(t, func(dir string) {
+		tr, registers := snapshotTrie(t, 1000)
+		manifest, err := registersnapshot.ExportTrie(tr, dir, 64)
+		require.NoError(t, err)
+		pb, dbDir := createPebbleForTest(t)
+
+		// import some of the chunks, as if the import was interrupted
+		interrupted, err := NewRegisterSnapshotBootstrap(pb, dir, rootHeight, tr.RootHash(), log)
+		require.NoError(t, err)
+		rootHash := tr.RootHash()
+		require.NoError(t, pb.Set(snapshotCommitmentKey, rootHash[:], nil))
+		for i := 0; i < len(manifest.Chunks); i += 2 {
+			_, err := interrupted.indexChunk(i)
+			require.NoError(t, err)
+
+			// imported chunks are neither imported nor verified again, so their files are not read
+			require.NoError(t, os.Remove(filepath.Join(dir, manifest.Chunks[i].File)))
+		}
+
+		isBootstrapped, err := IsBootstrapped(pb)
+		require.NoError(t, err)
+		require.False(t, isBootstrapped)
+
+		// a different snapshot can't be imported into the db
+		otherDir := filepath.Join(dir, "other")
+		other, _ := snapshotTrie(t, 10)
+		_, err = registersnapshot.ExportTrie(other, otherDir, 64)
+		require.NoError(t, err)
+		_, err = NewRegisterSnapshotBootstrap(pb, otherDir, rootHeight, other.RootHash(), log)
+		require.Error(t, err)
+
+		bootstrap, err := NewRegisterSnapshotBootstrap(pb, dir, rootHeight, tr.RootHash(), log)
+		require.NoError(t, err)
+		require.NoError(t, bootstrap.IndexSnapshot(context.Background(), workerCount))
+		require.Equal(t, uint64((len(manifest.Chunks)+1)/2), bootstrap.skippedChunks.Load())
+
+		reg, err := NewRegisters(pb)
+		require.NoError(t, err)
+		for _, register := range registers {
+			val, err := reg.Get(register.Key, rootHeight)
+			require.NoError(t, err)
+			require.Equal(t, register.Value, val)
+		}
+
+		require.NoError(t, pb.Close())
+		require.NoError(t, os.RemoveAll(dbDir))
+	})
+}
+
+func TestRegisterSnapshotBootstrap_InvalidSnapshot(t *testing.T) {
+	t.Parallel()
+	log := zerolog.New(io.Discard)
+	rootHeight := uint64(10000)
+
+	t.Run("mismatching root hash", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tr, _ := snapshotTrie(t, 100)
+			_, err := registersnapshot.ExportTrie(tr, dir, 64)
+			require.NoError(t, err)
+			pb, dbDir := createPebbleForTest(t)
+
+			_, err = NewRegisterSnapshotBootstrap(pb, dir, rootHeight, ledger.RootHash(unittest.StateCommitmentFixture()), log)
+			require.Error(t, err)
+
+			require.NoError(t, pb.Close())
+			require.NoError(t, os.RemoveAll(dbDir))
+		})
+	})
+
+	t.Run("modified chunk", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tr, _ := snapshotTrie(t, 100)
+			manifest, err := registersnapshot.ExportTrie(tr, dir, 64)
+			require.NoError(t, err)
+			chunkPath := filepath.Join(dir, manifest.Chunks[1].File)
+			data, err := os.ReadFile(chunkPath)
+			require.NoError(t, err)
+			data[len(data)-1] ^= 0xff
+			require.NoError(t, os.WriteFile(chunkPath, data, 0644))
+			pb, dbDir := createPebbleForTest(t)
+
+			bootstrap, err := NewRegisterSnapshotBootstrap(pb, dir, rootHeight, tr.RootHash(), log)
+			require.NoError(t, err)
+			require.Error(t, bootstrap.IndexSnapshot(context.Background(), workerCount))
+
+			isBootstrapped, err := IsBootstrapped(pb)
+			require.NoError(t, err)
+			require.False(t, isBootstrapped)
+
+			require.NoError(t, pb.Close())
+			require.NoError(t, os.RemoveAll(dbDir))
+		})
+	})
+}
+
+// snapshotTrie returns a trie holding n registers at the paths of their keys, and the registers.
+func snapshotTrie(t *testing.T, n int) (*trie.MTrie, flow.RegisterEntries) {
+	registers := make(flow.RegisterEntries, 0, n)
+	paths := make([]ledger.Path, 0, n)
+	payloads := make([]ledger.Payload, 0, n)
+	for i := 0; i < n; i++ {
+		register := flow.RegisterEntry{
+			Key:   flow.NewRegisterID(unittest.RandomAddressFixture(), "key"),
+			Value: unittest.RandomBytes(10),
+		}
+		key := convert.RegisterIDToLedgerKey(register.Key)
+		path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		registers = append(registers, register)
+		paths = append(paths, path)
+		payloads = append(payloads, *ledger.NewPayload(key, register.Value))
+	}
+
+	tr, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return tr, registers
+}
@@ -11,6 +11,13 @@ const (
 	// register bootstrap process
 	pebbleBootstrapRegisterBatchLen = 1000
 
+	// snapshotImportBatchLen is the batch size of register values written to pebble when importing
+	// a register snapshot
+	snapshotImportBatchLen = 10_000
+
+	// pruneBatchLen is the number of register values deleted in a single batch by the register pruning process
+	pruneBatchLen = 1000
+
 	// placeHolderHeight is an element of the height lookup keys of length HeightSuffixLen
 	// 10 bits per key yields a filter with <1% false positive rate.
 	placeHolderHeight = uint64(0)
@@ -36,4 +43,10 @@ const (
 	// codeFirstBlockHeight and codeLatestBlockHeight are keys for the range of block heights in the register store
 	codeFirstBlockHeight  byte = 3
 	codeLatestBlockHeight byte = 4
+	// codeSnapshotCommitment and codeSnapshotChunk are keys tracking the progress of importing
+	// a register snapshot, which are removed once the import is complete
+	codeSnapshotCommitment byte = 5
+	codeSnapshotChunk      byte = 6
+	// codePrunedHeight is the key of the height up to which register values have been pruned
+	codePrunedHeight byte = 7
 )
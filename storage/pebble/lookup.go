@@ -21,6 +21,24 @@ var latestHeightKey = binary.BigEndian.AppendUint64(
 var firstHeightKey = binary.BigEndian.AppendUint64(
 	[]byte{codeFirstBlockHeight, byte('/'), byte('/')}, placeHolderHeight)
 
+// prunedHeightKey is a special case of a lookupKey with codePrunedHeight as key, no owner and a
+// placeholder height of 0.
+var prunedHeightKey = binary.BigEndian.AppendUint64(
+	[]byte{codePrunedHeight, byte('/'), byte('/')}, placeHolderHeight)
+
+// snapshotCommitmentKey is a special case of a lookupKey with codeSnapshotCommitment as key,
+// no owner and a placeholder height of 0.
+var snapshotCommitmentKey = binary.BigEndian.AppendUint64(
+	[]byte{codeSnapshotCommitment, byte('/'), byte('/')}, placeHolderHeight)
+
+// snapshotChunkKey returns the key marking the chunk at the given index of a register snapshot
+// as imported. It is a special case of a lookupKey with codeSnapshotChunk as key, no owner and
+// the chunk index in place of the height.
+func snapshotChunkKey(index int) []byte {
+	return binary.BigEndian.AppendUint64(
+		[]byte{codeSnapshotChunk, byte('/'), byte('/')}, uint64(index))
+}
+
 // lookupKey is the encoded format of the storage key for looking up register value
 type lookupKey struct {
 	encoded []byte
@@ -61,6 +79,27 @@ func newLookupKey(height uint64, reg flow.RegisterID) *lookupKey {
 	return &key
 }
 
+// newOwnerPrefix returns the prefix of the lookup keys of all registers of the given owner.
+func newOwnerPrefix(owner string) []byte {
+	prefix := make([]byte, 0, len(owner)+2)
+	prefix = append(prefix, codeRegister)
+	prefix = append(prefix, owner...)
+	return append(prefix, '/')
+}
+
+// prefixUpperBound returns the smallest key greater than all keys with the given prefix,
+// or nil if there is no such key.
+func prefixUpperBound(prefix []byte) []byte {
+	upperBound := append([]byte{}, prefix...)
+	for i := len(upperBound) - 1; i >= 0; i-- {
+		upperBound[i]++
+		if upperBound[i] != 0 {
+			return upperBound[:i+1]
+		}
+	}
+	return nil
+}
+
 // lookupKeyToRegisterID takes a lookup key and decode it into height and RegisterID
 func lookupKeyToRegisterID(lookupKey []byte) (uint64, flow.RegisterID, error) {
 	if len(lookupKey) < MinLookupKeyLen {
@@ -1,8 +1,10 @@
 package pebble
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pkg/errors"
@@ -10,14 +12,18 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/pebble/registers"
 )
 
 // Registers library that implements pebble storage for registers
 // given a pebble instance with root block and root height populated
 type Registers struct {
 	db           *pebble.DB
-	firstHeight  uint64
+	firstHeight  *atomic.Uint64
 	latestHeight *atomic.Uint64
+	// prunedHeight is the height up to which register values have been pruned. It is lower than
+	// firstHeight while pruning is in progress, or if pruning was interrupted.
+	prunedHeight *atomic.Uint64
 }
 
 var _ storage.RegisterIndex = (*Registers)(nil)
@@ -33,11 +39,20 @@ func NewRegisters(db *pebble.DB) (*Registers, error) {
 		return nil, fmt.Errorf("unable to initialize register storage, latest height unavailable in db: %w", err)
 	}
 
+	// the pruned height is only stored once the db has been pruned
+	prunedHeight, err := heightLookup(db, prunedHeightKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		prunedHeight = firstHeight
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to initialize register storage, pruned height unavailable in db: %w", err)
+	}
+
 	// All registers between firstHeight and lastHeight have been indexed
 	return &Registers{
 		db:           db,
-		firstHeight:  firstHeight,
+		firstHeight:  atomic.NewUint64(firstHeight),
 		latestHeight: atomic.NewUint64(latestHeight),
+		prunedHeight: atomic.NewUint64(prunedHeight),
 	}, nil
 }
 
@@ -53,15 +68,142 @@ func (s *Registers) Get(
 	reg flow.RegisterID,
 	height uint64,
 ) (flow.RegisterValue, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+	key := newLookupKey(height, reg)
+	return s.lookupRegister(key.Bytes())
+}
+
+// ByOwner returns a page of the registers of the given owner whose keys start with the given
+// key prefix, with their most recent values up to the given height. Registers without a value
+// up to the given height, or with an empty value, are omitted.
+//
+// At most limit registers are returned, starting at the given cursor, or at the first register
+// if the cursor is nil. The returned cursor is the position of the next page, or nil if there
+// are no more registers.
+//
+// The owner must not be empty: the lookup keys of registers without owner share their prefix with
+// the lookup keys of owners starting with the separator, so they cannot be read by owner.
+//
+// - storage.ErrHeightNotIndexed if the requested height is out of the range of stored heights
+func (s *Registers) ByOwner(
+	owner string,
+	keyPrefix string,
+	height uint64,
+	cursor storage.RegisterCursor,
+	limit int,
+) (flow.RegisterEntries, storage.RegisterCursor, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, nil, err
+	}
+	if limit <= 0 {
+		return nil, nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	if owner == "" {
+		return nil, nil, fmt.Errorf("owner must not be empty")
+	}
+
+	ownerPrefix := newOwnerPrefix(owner)
+	prefix := append(append([]byte{}, ownerPrefix...), keyPrefix...)
+	lowerBound := prefix
+	if cursor != nil {
+		start := append(append([]byte{}, ownerPrefix...), cursor...)
+		if bytes.Compare(start, lowerBound) > 0 {
+			lowerBound = start
+		}
+	}
+
+	upperBound := prefixUpperBound(prefix)
+	if upperBound != nil && bytes.Compare(lowerBound, upperBound) >= 0 {
+		// the cursor is past all registers with the key prefix
+		return flow.RegisterEntries{}, nil, nil
+	}
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: lowerBound,
+		UpperBound: upperBound,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	entries := make(flow.RegisterEntries, 0)
+
+	// lookup keys for a register are sorted from the highest to the lowest height, so the first
+	// value found at or below the height is the most recent one.
+	var current storage.RegisterCursor
+	found := false
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := iter.Key()
+		if len(key) < len(ownerPrefix)+registers.HeightSuffixLen+1 || key[len(key)-registers.HeightSuffixLen-1] != '/' {
+			return nil, nil, fmt.Errorf("invalid lookup key format: %x", key)
+		}
+
+		// the register key followed by the separator is the cursor of the register
+		registerCursor := key[len(ownerPrefix) : len(key)-registers.HeightSuffixLen]
+		if !bytes.Equal(registerCursor, current) {
+			if len(entries) == limit {
+				return entries, append(storage.RegisterCursor{}, registerCursor...), nil
+			}
+			current = append(current[:0], registerCursor...)
+			found = false
+		}
+
+		if found {
+			continue
+		}
+
+		registerHeight := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+		if registerHeight > height {
+			continue
+		}
+		found = true
+
+		registerKey := string(registerCursor[:len(registerCursor)-1])
+		if !strings.HasPrefix(registerKey, keyPrefix) {
+			// the key prefix matched the separator of a shorter register key
+			continue
+		}
+
+		binaryValue, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		if len(binaryValue) == 0 {
+			// the register was removed
+			continue
+		}
+
+		// preventing caller from modifying the iterator's value slices
+		valueCopy := make([]byte, len(binaryValue))
+		copy(valueCopy, binaryValue)
+
+		entries = append(entries, flow.RegisterEntry{
+			Key:   flow.RegisterID{Owner: owner, Key: registerKey},
+			Value: valueCopy,
+		})
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	return entries, nil, nil
+}
+
+// checkHeight returns storage.ErrHeightNotIndexed if the given height is outside the indexed range.
+func (s *Registers) checkHeight(height uint64) error {
+	firstHeight := s.firstHeight.Load()
 	latestHeight := s.latestHeight.Load()
-	if height > latestHeight || height < s.firstHeight {
-		return nil, errors.Wrap(
+	if height > latestHeight || height < firstHeight {
+		return errors.Wrap(
 			storage.ErrHeightNotIndexed,
-			fmt.Sprintf("height %d not indexed, indexed range is [%d-%d]", height, s.firstHeight, latestHeight),
+			fmt.Sprintf("height %d not indexed, indexed range is [%d-%d]", height, firstHeight, latestHeight),
 		)
 	}
-	key := newLookupKey(height, reg)
-	return s.lookupRegister(key.Bytes())
+	return nil
 }
 
 func (s *Registers) lookupRegister(key []byte) (flow.RegisterValue, error) {
@@ -142,9 +284,144 @@ func (s *Registers) LatestHeight() uint64 {
 	return s.latestHeight.Load()
 }
 
-// FirstHeight first indexed height found in the store, typically root block for the spork
+// FirstHeight first indexed height found in the store, typically root block for the spork.
+// The first height is advanced when the store is pruned.
 func (s *Registers) FirstHeight() uint64 {
-	return s.firstHeight
+	return s.firstHeight.Load()
+}
+
+// PrunedHeight returns the height up to which register values have been pruned. It is lower than
+// FirstHeight while pruning is in progress, or if pruning was interrupted.
+func (s *Registers) PrunedHeight() uint64 {
+	return s.prunedHeight.Load()
+}
+
+// PruneUpToHeight removes register values indexed below the given height.
+// For each register, the most recent value at or below pruneHeight is kept, so that all heights
+// within the new indexed range [pruneHeight, LatestHeight] can still be served. Once complete,
+// FirstHeight and PrunedHeight return pruneHeight.
+//
+// The first height is advanced before any values are removed, so concurrent readers never observe
+// a partially pruned height. The pruned height is only advanced once all values are removed, so if
+// the process is interrupted, PrunedHeight stays below FirstHeight, and pruning to FirstHeight
+// completes the interrupted pruning.
+//
+// CAUTION: This function is not safe for concurrent use with itself.
+//
+// No errors are expected during normal operation.
+func (s *Registers) PruneUpToHeight(pruneHeight uint64) error {
+	if pruneHeight <= s.prunedHeight.Load() {
+		// already pruned
+		return nil
+	}
+
+	latestHeight := s.latestHeight.Load()
+	if pruneHeight > latestHeight {
+		return fmt.Errorf("cannot prune above the latest height %d, got %d", latestHeight, pruneHeight)
+	}
+
+	if pruneHeight > s.firstHeight.Load() {
+		err := s.db.Set(firstHeightKey, encodedUint64(pruneHeight), pebble.Sync)
+		if err != nil {
+			return fmt.Errorf("failed to update first height %d: %w", pruneHeight, err)
+		}
+		s.firstHeight.Store(pruneHeight)
+	}
+
+	err := s.pruneRegisterValues(pruneHeight)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Set(prunedHeightKey, encodedUint64(pruneHeight), pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to update pruned height %d: %w", pruneHeight, err)
+	}
+	s.prunedHeight.Store(pruneHeight)
+
+	return nil
+}
+
+// pruneRegisterValues deletes all register values that are shadowed by a more recent value at or
+// below pruneHeight.
+//
+// Lookup keys for a register are sorted from the highest to the lowest height. Values above
+// pruneHeight are skipped by seeking to the first value at or below pruneHeight, which is the one
+// that must be kept. All following values for the same register are removed. Since values shadowed
+// at a previous pruned height were already removed, the scan only visits one key per register in
+// addition to the removed values, instead of every value stored since the previous pruning.
+func (s *Registers) pruneRegisterValues(pruneHeight uint64) error {
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeRegister},
+		UpperBound: []byte{codeRegister + 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	var currentRegister []byte
+	keptLatest := false
+	valid := iter.First()
+	for valid {
+		key := iter.Key()
+		if len(key) < MinLookupKeyLen {
+			return fmt.Errorf("invalid lookup key format: expected >= %d bytes, got %d bytes",
+				MinLookupKeyLen, len(key))
+		}
+
+		registerPrefix := key[:len(key)-registers.HeightSuffixLen]
+		if !bytes.Equal(registerPrefix, currentRegister) {
+			currentRegister = append(currentRegister[:0], registerPrefix...)
+			keptLatest = false
+		}
+
+		height := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+		if height > pruneHeight {
+			// skip to the most recent value of the register at or below pruneHeight, or to the next
+			// register if there is none. The seek key is always past the current key.
+			seekKey := binary.BigEndian.AppendUint64(append([]byte{}, currentRegister...), ^pruneHeight)
+			valid = iter.SeekGE(seekKey)
+			continue
+		}
+
+		if !keptLatest {
+			keptLatest = true
+			valid = iter.Next()
+			continue
+		}
+
+		err = batch.Delete(key, nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete key: %w", err)
+		}
+
+		if batch.Count() >= pruneBatchLen {
+			err = batch.Commit(pebble.Sync)
+			if err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			_ = batch.Close()
+			batch = s.db.NewBatch()
+		}
+		valid = iter.Next()
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
 }
 
 func firstStoredHeight(db *pebble.DB) (uint64, error) {
@@ -58,5 +58,8 @@ func (c *RegistersCache) Get(
 	reg flow.RegisterID,
 	height uint64,
 ) (flow.RegisterValue, error) {
+	if err := c.checkHeight(height); err != nil {
+		return nil, err
+	}
 	return c.cache.Get(newLookupKey(height, reg).String())
 }
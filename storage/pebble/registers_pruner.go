@@ -0,0 +1,181 @@
+package pebble
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+const (
+	// DefaultRegistersPruningHeightRangeTarget is the default number of most recent heights to keep
+	// in the register db. A value of 0 disables pruning.
+	DefaultRegistersPruningHeightRangeTarget = uint64(0)
+
+	// DefaultRegistersPruningThreshold is the default number of heights the indexed range may exceed
+	// the height range target by before pruning is triggered.
+	DefaultRegistersPruningThreshold = uint64(100_000)
+
+	// DefaultRegistersPruningInterval is the default interval at which the pruner checks whether
+	// pruning is needed.
+	DefaultRegistersPruningInterval = 10 * time.Minute
+)
+
+// RegistersPruner is a component responsible for pruning register values from the register db.
+// It is configured with the following parameters:
+//   - Height range target: The target number of most recent heights to keep register values for.
+//     This controls the total amount of data stored on disk. A value of 0 disables pruning.
+//   - Threshold: The number of heights that the indexed range can exceed the height range target
+//     by before pruning is triggered. This controls the frequency of pruning.
+//
+// The RegistersPruner periodically checks the indexed height range of the register db and prunes
+// it down to the height range target once it reaches height range target + threshold.
+// Pruning keeps the most recent value of each register, so any height within the remaining indexed
+// range can still be queried.
+type RegistersPruner struct {
+	component.Component
+
+	log       zerolog.Logger
+	metrics   module.ExecutionDataPrunerMetrics
+	registers *Registers
+
+	// heightRangeTarget and threshold may be updated at runtime through the admin tool
+	heightRangeTarget *atomic.Uint64
+	threshold         *atomic.Uint64
+
+	pruneInterval time.Duration
+}
+
+var _ component.Component = (*RegistersPruner)(nil)
+
+type RegistersPrunerOption func(*RegistersPruner)
+
+// WithPruningHeightRangeTarget is used to configure the pruner with a custom height range target.
+func WithPruningHeightRangeTarget(heightRangeTarget uint64) RegistersPrunerOption {
+	return func(p *RegistersPruner) {
+		p.heightRangeTarget.Store(heightRangeTarget)
+	}
+}
+
+// WithPruningThreshold is used to configure the pruner with a custom threshold.
+func WithPruningThreshold(threshold uint64) RegistersPrunerOption {
+	return func(p *RegistersPruner) {
+		p.threshold.Store(threshold)
+	}
+}
+
+// WithPruneInterval is used to configure how often the pruner checks whether pruning is needed.
+func WithPruneInterval(interval time.Duration) RegistersPrunerOption {
+	return func(p *RegistersPruner) {
+		p.pruneInterval = interval
+	}
+}
+
+// NewRegistersPruner creates a new RegistersPruner for the given register storage.
+func NewRegistersPruner(
+	log zerolog.Logger,
+	metrics module.ExecutionDataPrunerMetrics,
+	registers *Registers,
+	opts ...RegistersPrunerOption,
+) *RegistersPruner {
+	p := &RegistersPruner{
+		log:               log.With().Str("component", "registers_pruner").Logger(),
+		metrics:           metrics,
+		registers:         registers,
+		heightRangeTarget: atomic.NewUint64(DefaultRegistersPruningHeightRangeTarget),
+		threshold:         atomic.NewUint64(DefaultRegistersPruningThreshold),
+		pruneInterval:     DefaultRegistersPruningInterval,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.Component = component.NewComponentManagerBuilder().
+		AddWorker(p.loop).
+		Build()
+
+	return p
+}
+
+// HeightRangeTarget returns the current height range target.
+func (p *RegistersPruner) HeightRangeTarget() uint64 {
+	return p.heightRangeTarget.Load()
+}
+
+// SetHeightRangeTarget updates the height range target. Setting it to 0 disables pruning.
+// The new value is used starting with the next pruning check.
+func (p *RegistersPruner) SetHeightRangeTarget(heightRangeTarget uint64) {
+	p.heightRangeTarget.Store(heightRangeTarget)
+}
+
+// Threshold returns the current threshold.
+func (p *RegistersPruner) Threshold() uint64 {
+	return p.threshold.Load()
+}
+
+// SetThreshold updates the threshold.
+// The new value is used starting with the next pruning check.
+func (p *RegistersPruner) SetThreshold(threshold uint64) {
+	p.threshold.Store(threshold)
+}
+
+func (p *RegistersPruner) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(p.pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		// check immediately on startup, then once per interval
+		p.checkPrune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *RegistersPruner) checkPrune(ctx irrecoverable.SignalerContext) {
+	heightRangeTarget := p.heightRangeTarget.Load()
+	if heightRangeTarget == 0 {
+		// pruning disabled
+		return
+	}
+
+	firstHeight := p.registers.FirstHeight()
+	latestHeight := p.registers.LatestHeight()
+
+	// if a previous pruning was interrupted, the values below the first height are not all removed
+	// yet, so pruning resumes from there even if the threshold is not reached.
+	pruneHeight := firstHeight
+	if latestHeight > heightRangeTarget+p.threshold.Load()+firstHeight {
+		pruneHeight = latestHeight - heightRangeTarget
+	}
+	if pruneHeight <= p.registers.PrunedHeight() {
+		return
+	}
+
+	p.log.Info().
+		Uint64("first_height", firstHeight).
+		Uint64("prune_height", pruneHeight).
+		Msg("pruning registers")
+	start := time.Now()
+
+	if err := p.registers.PruneUpToHeight(pruneHeight); err != nil {
+		ctx.Throw(fmt.Errorf("failed to prune registers: %w", err))
+		return
+	}
+
+	duration := time.Since(start)
+	p.log.Info().Dur("duration", duration).Msg("pruned registers")
+
+	p.metrics.Pruned(pruneHeight, duration)
+}
@@ -0,0 +1,139 @@
+package pebble
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRegistersPruner_Prune tests that the pruner prunes the registers db once the indexed
+// height range exceeds the height range target + threshold
+func TestRegistersPruner_Prune(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key := flow.RegisterID{Owner: "owner", Key: "key"}
+		for height := uint64(2); height <= 20; height++ {
+			require.NoError(t, r.Store(flow.RegisterEntries{{Key: key, Value: []byte{byte(height)}}}, height))
+		}
+
+		pruner := NewRegistersPruner(
+			zerolog.Nop(),
+			metrics.NewNoopCollector(),
+			r,
+			WithPruningHeightRangeTarget(10),
+			WithPruningThreshold(5),
+			WithPruneInterval(10*time.Millisecond),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "timed out waiting for pruner to be ready")
+
+		require.Eventually(t, func() bool {
+			return r.FirstHeight() == 10
+		}, time.Second, 10*time.Millisecond)
+
+		// within the threshold, no pruning is done
+		for height := uint64(21); height <= 25; height++ {
+			require.NoError(t, r.Store(flow.RegisterEntries{{Key: key, Value: []byte{byte(height)}}}, height))
+		}
+		require.Never(t, func() bool {
+			return r.FirstHeight() != 10
+		}, 100*time.Millisecond, 10*time.Millisecond)
+
+		// updating the height range target at runtime triggers pruning on the next check
+		pruner.SetHeightRangeTarget(5)
+		require.Eventually(t, func() bool {
+			return r.FirstHeight() == 20
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "timed out waiting for pruner to stop")
+	})
+}
+
+// TestRegistersPruner_Resume tests that the pruner completes an interrupted pruning, even if the
+// indexed height range does not exceed the height range target + threshold
+func TestRegistersPruner_Resume(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key := flow.RegisterID{Owner: "owner", Key: "key"}
+		for height := uint64(2); height <= 20; height++ {
+			require.NoError(t, r.Store(flow.RegisterEntries{{Key: key, Value: []byte{byte(height)}}}, height))
+		}
+
+		// simulate pruning interrupted after advancing the first height
+		require.NoError(t, r.db.Set(firstHeightKey, encodedUint64(10), nil))
+		restarted, err := NewRegisters(r.db)
+		require.NoError(t, err)
+		restarted.prunedHeight.Store(1)
+
+		pruner := NewRegistersPruner(
+			zerolog.Nop(),
+			metrics.NewNoopCollector(),
+			restarted,
+			WithPruningHeightRangeTarget(100),
+			WithPruneInterval(10*time.Millisecond),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "timed out waiting for pruner to be ready")
+
+		require.Eventually(t, func() bool {
+			return restarted.PrunedHeight() == 10
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, uint64(10), restarted.FirstHeight())
+
+		_, err = restarted.lookupRegister(newLookupKey(9, key).Bytes())
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "timed out waiting for pruner to stop")
+	})
+}
+
+// TestRegistersPruner_Disabled tests that the pruner does not prune when the height range target is 0
+func TestRegistersPruner_Disabled(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key := flow.RegisterID{Owner: "owner", Key: "key"}
+		for height := uint64(2); height <= 20; height++ {
+			require.NoError(t, r.Store(flow.RegisterEntries{{Key: key, Value: []byte{byte(height)}}}, height))
+		}
+
+		pruner := NewRegistersPruner(
+			zerolog.Nop(),
+			metrics.NewNoopCollector(),
+			r,
+			WithPruningThreshold(0),
+			WithPruneInterval(10*time.Millisecond),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "timed out waiting for pruner to be ready")
+
+		require.Never(t, func() bool {
+			return r.FirstHeight() != 1
+		}, 100*time.Millisecond, 10*time.Millisecond)
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "timed out waiting for pruner to stop")
+	})
+}
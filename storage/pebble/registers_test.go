@@ -374,6 +374,210 @@ func Benchmark_PayloadStorage(b *testing.B) {
 	}
 }
 
+// TestRegisters_PruneUpToHeight tests that pruning removes shadowed register values, while keeping
+// the most recent value of each register at or below the prune height
+func TestRegisters_PruneUpToHeight(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key2"}
+		key3 := flow.RegisterID{Owner: "owner", Key: "key3"}
+
+		// key1 is updated at every height, key2 only at height 2 and key3 only at height 5
+		for height := uint64(2); height <= 6; height++ {
+			entries := flow.RegisterEntries{
+				{Key: key1, Value: []byte(fmt.Sprintf("value1-%d", height))},
+			}
+			if height == 2 {
+				entries = append(entries, flow.RegisterEntry{Key: key2, Value: []byte("value2")})
+			}
+			if height == 5 {
+				entries = append(entries, flow.RegisterEntry{Key: key3, Value: []byte("value3")})
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		// cannot prune above the latest height
+		err := r.PruneUpToHeight(7)
+		require.Error(t, err)
+
+		err = r.PruneUpToHeight(4)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), r.FirstHeight())
+		require.Equal(t, uint64(6), r.LatestHeight())
+
+		// heights below the prune height are no longer indexed
+		_, err = r.Get(key1, 3)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		// values at and above the prune height are unchanged
+		for height := uint64(4); height <= 6; height++ {
+			value, err := r.Get(key1, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value1-%d", height)), value)
+
+			// the latest value of key2 was stored below the prune height, and must still be available
+			value, err = r.Get(key2, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte("value2"), value)
+		}
+
+		_, err = r.Get(key3, 4)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		value, err := r.Get(key3, 5)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value3"), value)
+
+		// values shadowed at the prune height are removed from the db
+		_, err = r.lookupRegister(newLookupKey(3, key1).Bytes())
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// pruning to a lower height is a no-op
+		err = r.PruneUpToHeight(2)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), r.FirstHeight())
+
+		// the first and pruned heights are persisted
+		firstHeight, latestHeight, err := ReadHeightsFromBootstrappedDB(r.db)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), firstHeight)
+		require.Equal(t, uint64(6), latestHeight)
+		prunedHeight, err := heightLookup(r.db, prunedHeightKey)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), prunedHeight)
+		require.Equal(t, uint64(4), r.PrunedHeight())
+	})
+}
+
+// TestRegisters_PruneUpToHeight_Resume tests that pruning interrupted after advancing the first
+// height is completed by pruning to the first height again
+func TestRegisters_PruneUpToHeight_Resume(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		// the key of the second register starts with the key of the first register and the separator
+		key1 := flow.RegisterID{Owner: "owner", Key: "key"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key/nested"}
+		for height := uint64(2); height <= 6; height++ {
+			require.NoError(t, r.Store(flow.RegisterEntries{
+				{Key: key1, Value: []byte(fmt.Sprintf("value1-%d", height))},
+				{Key: key2, Value: []byte(fmt.Sprintf("value2-%d", height))},
+			}, height))
+		}
+
+		// simulate pruning interrupted after advancing the first height
+		require.NoError(t, r.db.Set(firstHeightKey, encodedUint64(4), nil))
+		require.NoError(t, r.db.Set(prunedHeightKey, encodedUint64(1), nil))
+
+		restarted, err := NewRegisters(r.db)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), restarted.FirstHeight())
+		require.Equal(t, uint64(1), restarted.PrunedHeight())
+
+		_, err = restarted.lookupRegister(newLookupKey(3, key1).Bytes())
+		require.NoError(t, err)
+
+		err = restarted.PruneUpToHeight(restarted.FirstHeight())
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), restarted.FirstHeight())
+		require.Equal(t, uint64(4), restarted.PrunedHeight())
+
+		for _, key := range []flow.RegisterID{key1, key2} {
+			for height := uint64(2); height <= 3; height++ {
+				_, err = restarted.lookupRegister(newLookupKey(height, key).Bytes())
+				require.ErrorIs(t, err, storage.ErrNotFound)
+			}
+		}
+		for height := uint64(4); height <= 6; height++ {
+			value, err := restarted.Get(key1, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value1-%d", height)), value)
+
+			value, err = restarted.Get(key2, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value2-%d", height)), value)
+		}
+	})
+}
+
+// TestRegisters_ByOwner tests reading the registers of an owner by key prefix, in pages.
+func TestRegisters_ByOwner(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		reg := func(owner string, key string, value string) flow.RegisterEntry {
+			return flow.RegisterEntry{Key: flow.RegisterID{Owner: owner, Key: key}, Value: []byte(value)}
+		}
+
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			reg("owner1", "a", "a-2"),
+			reg("owner1", "ab", "ab-2"),
+			reg("owner1", "b", "b-2"),
+			reg("owner1", "c", "c-2"),
+			reg("owner2", "a", "owner2-a-2"),
+		}, 2))
+
+		// ab is updated, c is removed and d is added at height 3
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			reg("owner1", "ab", "ab-3"),
+			reg("owner1", "c", ""),
+			reg("owner1", "d", "d-3"),
+		}, 3))
+
+		// readAll reads all pages of registers with the given page size
+		readAll := func(keyPrefix string, height uint64, limit int) flow.RegisterEntries {
+			all := flow.RegisterEntries{}
+			var cursor storage.RegisterCursor
+			for {
+				entries, next, err := r.ByOwner("owner1", keyPrefix, height, cursor, limit)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(entries), limit)
+				all = append(all, entries...)
+				if next == nil {
+					return all
+				}
+				cursor = next
+			}
+		}
+
+		atHeight2 := flow.RegisterEntries{
+			reg("owner1", "a", "a-2"),
+			reg("owner1", "ab", "ab-2"),
+			reg("owner1", "b", "b-2"),
+			reg("owner1", "c", "c-2"),
+		}
+		atHeight3 := flow.RegisterEntries{
+			reg("owner1", "a", "a-2"),
+			reg("owner1", "ab", "ab-3"),
+			reg("owner1", "b", "b-2"),
+			reg("owner1", "d", "d-3"),
+		}
+
+		for _, limit := range []int{1, 2, 3, 10} {
+			require.Equal(t, atHeight2, readAll("", 2, limit))
+			require.Equal(t, atHeight3, readAll("", 3, limit))
+		}
+
+		require.Equal(t, atHeight3[:2], readAll("a", 3, 1))
+		require.Equal(t, atHeight3[1:2], readAll("ab", 3, 10))
+
+		// the key prefix doesn't match the separator following shorter keys
+		require.Empty(t, readAll("a/", 3, 10))
+		require.Empty(t, readAll("e", 3, 10))
+
+		// no registers before the first stored height
+		require.Empty(t, readAll("", 1, 10))
+
+		_, _, err := r.ByOwner("owner1", "", 4, nil, 10)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		_, _, err = r.ByOwner("owner1", "", 3, nil, 0)
+		require.Error(t, err)
+
+		// registers without owner cannot be read by owner
+		_, _, err = r.ByOwner("", "", 3, nil, 10)
+		require.Error(t, err)
+	})
+}
+
 func RunWithRegistersStorageAtHeight1(tb testing.TB, f func(r *Registers)) {
 	defaultHeight := uint64(1)
 	RunWithRegistersStorageAtInitialHeights(tb, defaultHeight, defaultHeight, f)
@@ -15,6 +15,17 @@ type RegisterIndex interface {
 	// - storage.ErrNotFound if the given height is indexed, but the register does not exist.
 	Get(ID flow.RegisterID, height uint64) (flow.RegisterValue, error)
 
+	// ByOwner returns a page of the registers of the given owner whose keys start with the given
+	// key prefix, with their values at the given block height. Registers without a value at the
+	// given height, or with an empty value, are omitted.
+	//
+	// At most limit registers are returned, starting at the given cursor, or at the first register
+	// if the cursor is nil. The returned cursor is the position of the next page, or nil if there
+	// are no more registers. The owner must not be empty.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the given height was not indexed yet or lower than the first indexed height.
+	ByOwner(owner string, keyPrefix string, height uint64, cursor RegisterCursor, limit int) (flow.RegisterEntries, RegisterCursor, error)
+
 	// LatestHeight returns the latest indexed height.
 	LatestHeight() uint64
 
@@ -30,3 +41,16 @@ type RegisterIndex interface {
 	// No errors are expected during normal operation.
 	Store(entries flow.RegisterEntries, height uint64) error
 }
+
+// RegisterCursor is the position of a register among the registers of an owner, used to page
+// through the registers returned by RegisterIndex.ByOwner. Registers are ordered by their cursors.
+type RegisterCursor []byte
+
+// NewRegisterCursor returns the cursor of the register with the given key.
+func NewRegisterCursor(key string) RegisterCursor {
+	// registers are indexed by their key followed by a separator, so the cursors follow the
+	// order of the index.
+	cursor := make(RegisterCursor, 0, len(key)+1)
+	cursor = append(cursor, key...)
+	return append(cursor, '/')
+}